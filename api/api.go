@@ -27,6 +27,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	types "github.com/dell/gopowermax/v2/types/v100"
@@ -39,6 +40,9 @@ const (
 	HeaderKeyContentType                  = "Content-Type"
 	HeaderValContentTypeJSON              = "application/json"
 	headerValContentTypeBinaryOctetStream = "binary/octet-stream"
+
+	// maxErrorBodySize is the maximum number of bytes of an error response body kept in types.Error
+	maxErrorBodySize = 64 * 1024
 )
 
 var (
@@ -46,6 +50,10 @@ var (
 	errSysCerts  = errors.New("Unable to initialize cert pool from system")
 )
 
+// RequestIDHeaders are the response headers checked, in order, for a request or
+// correlation ID to be recorded in types.Error
+var RequestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "Request-Id", "Correlation-Id"}
+
 // Client is an API client.
 type Client interface {
 	GetHTTPClient() *http.Client
@@ -63,6 +71,14 @@ type Client interface {
 		headers map[string]string,
 		body, resp interface{}) error
 
+	// DoWithResult sends an HTTP request to the API, decodes the response body into resp
+	// and returns the status code and headers of the response with it
+	DoWithResult(
+		ctx context.Context,
+		method, path string,
+		headers map[string]string,
+		body, resp interface{}) (*Result, error)
+
 	// DoandGetREsponseBody sends an HTTP reqeust to the API and returns
 	// the raw response body
 	DoAndGetResponseBody(
@@ -107,14 +123,47 @@ type Client interface {
 
 	// ParseJSONError parses the JSON in r into an error object
 	ParseJSONError(r *http.Response) error
+
+	// SetRequestSigner sets the callback used to sign every request, nil disables signing
+	SetRequestSigner(signer RequestSigner)
+
+	// SetDryRun enables or disables the dry-run mode, in which mutating requests are not sent
+	SetDryRun(dryRun bool)
+
+	// SetJournal sets the journal in which mutating requests are recorded, nil disables recording
+	SetJournal(journal Journal)
+
+	// SetSafeMode enables or disables the safe mode, in which destructive operations are refused
+	SetSafeMode(safeMode bool)
+
+	// CheckRequest returns the error a request with the method and path would get with ctx, without sending it:
+	// a DryRunError in dry-run mode, or a DestructiveOperationError in safe mode, e.g. to check every step of an
+	// operation made of several requests before sending the first one
+	CheckRequest(ctx context.Context, method, path string) error
 }
 
 type client struct {
-	http     *http.Client
-	host     string
-	token    string
-	showHTTP bool
-	debug    bool
+	http       *http.Client
+	host       string
+	token      string
+	showHTTP   bool
+	debug      bool
+	retry      *RetryPolicy
+	limiter    *rateLimiter
+	throttle   *AdaptiveThrottle
+	validation *ResponseValidation
+	limits     *ResponseLimits
+
+	slowRequestThreshold time.Duration
+	userAgent            string
+	requestIDHeader      string
+
+	// modesMu guards the settings which can be changed while requests are in flight
+	modesMu  sync.RWMutex
+	signer   RequestSigner
+	dryRun   bool
+	safeMode bool
+	journal  Journal
 }
 
 // ClientOptions are options for the API client.
@@ -134,6 +183,108 @@ type ClientOptions struct {
 
 	// CertFile is the path to the reverseproxy tls certificate file
 	CertFile string
+
+	// CertDir is the path to a directory of tls certificate files, which are
+	// reloaded every CertReloadInterval so that rotated certificates are picked up
+	CertDir string
+
+	// CertReloadInterval is the interval at which CertDir is checked for changes,
+	// DefaultCertReloadInterval is used if not set
+	CertReloadInterval time.Duration
+
+	// RequestSigner, if set, is called to sign every request before it is sent
+	RequestSigner RequestSigner
+
+	// DryRun is a flag that indicates whether mutating requests are only logged and returned as
+	// a DryRunError instead of being sent. It can be overridden per request with WithDryRun.
+	DryRun bool
+
+	// SafeMode is a flag that indicates whether destructive operations, the DELETE requests and the
+	// requests marked with WithDestructiveOperation, are refused with a DestructiveOperationError.
+	// It can be overridden per request with WithAllowDestructive.
+	SafeMode bool
+
+	// Retry is how failed requests are retried, they are not retried if nil
+	Retry *RetryPolicy
+
+	// MaxIdleConns is the maximum number of idle connections kept open,
+	// DefaultMaxIdleConns is used if not set
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost is the maximum number of idle connections kept open to Unisphere,
+	// DefaultMaxIdleConnsPerHost is used if not set
+	MaxIdleConnsPerHost int
+
+	// MaxConnsPerHost limits the number of connections to Unisphere, there is no limit if not set
+	MaxConnsPerHost int
+
+	// IdleConnTimeout is how long an idle connection is kept open,
+	// DefaultIdleConnTimeout is used if not set
+	IdleConnTimeout time.Duration
+
+	// ForceAttemptHTTP2 is a flag that indicates whether HTTP/2 is negotiated with Unisphere
+	ForceAttemptHTTP2 bool
+
+	// Journal, if set, records every mutating request sent and its outcome
+	Journal Journal
+
+	// RateLimits, if set, delays the requests exceeding the read or write budget
+	RateLimits *RateLimits
+
+	// Throttle, if set, delays the requests when Unisphere reports a high load
+	Throttle *AdaptiveThrottle
+
+	// ResponseValidation, if set, retries the GET requests getting an empty or truncated JSON response
+	ResponseValidation *ResponseValidation
+
+	// ResponseLimits, if set, bounds the size of the response bodies and the time taken to read and decode them
+	ResponseLimits *ResponseLimits
+
+	// SlowRequestThreshold, if set, is how long a request can be in flight before a warning
+	// is logged with its method, path and duration, and then again every SlowRequestThreshold
+	SlowRequestThreshold time.Duration
+
+	// PinnedCertificates, if set, are the SHA-256 fingerprints of the server certificates to accept, in hexadecimal
+	// with or without colons. They replace the validation against certificate authorities, e.g. for a reverse
+	// proxy with a self-signed certificate, and take precedence over Insecure, CertFile and CertDir.
+	PinnedCertificates []string
+
+	// UserAgent, if set, is sent as the User-Agent of every request instead of the default of Go
+	UserAgent string
+
+	// RequestIDHeader, if set, is the header in which a new UUID is sent with every request, e.g. DefaultRequestIDHeader,
+	// unless the caller already set it. The ID is logged, journaled and returned in the errors of the request.
+	RequestIDHeader string
+}
+
+// Connection pool defaults. Go only keeps 2 idle connections per host by default, so that bulk
+// provisioning opens and closes a connection for most requests, leaving many in TIME_WAIT.
+const (
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 32
+	DefaultIdleConnTimeout     = 90 * time.Second
+)
+
+// newTransport returns a transport using the TLS configuration and the connection pool settings of the options
+func (opts ClientOptions) newTransport(tlsConfig *tls.Config) *http.Transport {
+	transport := &http.Transport{
+		TLSClientConfig:     tlsConfig,
+		MaxIdleConns:        DefaultMaxIdleConns,
+		MaxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+		MaxConnsPerHost:     opts.MaxConnsPerHost,
+		IdleConnTimeout:     DefaultIdleConnTimeout,
+		ForceAttemptHTTP2:   opts.ForceAttemptHTTP2,
+	}
+	if opts.MaxIdleConns > 0 {
+		transport.MaxIdleConns = opts.MaxIdleConns
+	}
+	if opts.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	}
+	if opts.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = opts.IdleConnTimeout
+	}
+	return transport
 }
 
 // New returns a new API client.
@@ -157,36 +308,44 @@ func New(
 		c.http.Timeout = opts.Timeout
 	}
 
-	if opts.Insecure {
-		c.http.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true, // #nosec G402
-			},
+	if len(opts.PinnedCertificates) > 0 {
+		tlsConfig, err := newPinnedTLSConfig(opts.PinnedCertificates)
+		if err != nil {
+			c.doLog(log.WithError(err).Error, "Unable to pin certificates")
+			return nil, err
 		}
+		c.http.Transport = opts.newTransport(tlsConfig)
+	} else if opts.Insecure {
+		c.http.Transport = opts.newTransport(&tls.Config{
+			InsecureSkipVerify: true, // #nosec G402
+		})
 	} else {
 		// Loading system certs by default if insecure is set to false
 		// TODO: Check if we need to remove references to UseCerts from the code
-		pool, err := x509.SystemCertPool()
-		if err != nil {
-			return nil, errSysCerts
-		}
-		if opts.CertFile != "" {
-			revProxyCert, err := os.ReadFile(opts.CertFile)
+		if opts.CertDir != "" {
+			transport, err := newReloadingTransport(opts)
 			if err != nil {
-				c.doLog(log.WithError(err).Error, "Unable to read certificate file")
+				c.doLog(log.WithError(err).Error, "Unable to load certificates")
 				return nil, err
 			}
-			if ok := pool.AppendCertsFromPEM(revProxyCert); !ok {
-				c.doLog(log.Error, "Failed to append reverse proxy certificate to pool")
-				return nil, errors.New("failed to append reverse proxy certificate to pool")
+			c.http.Transport = transport
+		} else {
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				return nil, errSysCerts
+			}
+			if opts.CertFile != "" {
+				revProxyCert, err := os.ReadFile(opts.CertFile)
+				if err != nil {
+					c.doLog(log.WithError(err).Error, "Unable to read certificate file")
+					return nil, err
+				}
+				if ok := pool.AppendCertsFromPEM(revProxyCert); !ok {
+					c.doLog(log.Error, "Failed to append reverse proxy certificate to pool")
+					return nil, errors.New("failed to append reverse proxy certificate to pool")
+				}
 			}
-		}
-		c.http.Transport = &http.Transport{
-			// #nosec G402
-			TLSClientConfig: &tls.Config{
-				RootCAs:            pool,
-				InsecureSkipVerify: false,
-			},
+			c.http.Transport = newTLSTransport(pool, opts)
 		}
 	}
 
@@ -195,6 +354,18 @@ func New(
 	}
 
 	c.debug = debug
+	c.signer = opts.RequestSigner
+	c.dryRun = opts.DryRun
+	c.safeMode = opts.SafeMode
+	c.retry = opts.Retry
+	c.journal = opts.Journal
+	c.limiter = newRateLimiter(opts.RateLimits)
+	c.throttle = opts.Throttle
+	c.validation = opts.ResponseValidation
+	c.limits = opts.ResponseLimits
+	c.slowRequestThreshold = opts.SlowRequestThreshold
+	c.userAgent = opts.UserAgent
+	c.requestIDHeader = opts.RequestIDHeader
 
 	return c, nil
 }
@@ -265,33 +436,65 @@ func (c *client) DoWithHeaders(
 	headers map[string]string,
 	body, resp interface{},
 ) error {
+	_, err := c.DoWithResult(ctx, method, uri, headers, body, resp)
+	return err
+}
+
+// Result is the status code and headers of a response, with its body decoded
+type Result struct {
+	StatusCode int
+	Header     http.Header
+	// Body is the resp the body was decoded into, nil for an error response
+	Body interface{}
+}
+
+// Location returns the Location header of the response, e.g. the URI of a created resource or job
+func (r *Result) Location() string {
+	return r.Header.Get("Location")
+}
+
+// ETag returns the ETag header of the response
+func (r *Result) ETag() string {
+	return r.Header.Get("ETag")
+}
+
+// DoWithResult sends the request, decodes a successful response into resp and returns the status code and
+// headers of the response. An error response is returned as a *types.Error, with the Result of the response.
+func (c *client) DoWithResult(
+	ctx context.Context,
+	method, uri string,
+	headers map[string]string,
+	body, resp interface{},
+) (*Result, error) {
 	res, err := c.DoAndGetResponseBody(
 		ctx, method, uri, headers, body)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if res == nil {
+		return nil, nil
 	}
 	defer res.Body.Close() // #nosec G307
+	result := &Result{StatusCode: res.StatusCode, Header: res.Header}
 
 	// parse the response
 	switch {
-	case res == nil:
-		return nil
 	case res.StatusCode >= 200 && res.StatusCode <= 299:
 		if resp == nil {
-			return nil
+			return result, nil
 		}
-		dec := json.NewDecoder(res.Body)
-		if err = dec.Decode(resp); err != nil && err != io.EOF {
+		if err = decodeResponse(res.Body, resp); err != nil {
 			c.doLog(log.WithError(err).Error,
 				fmt.Sprintf("Unable to decode response into %+v",
 					resp))
-			return err
+			return result, err
 		}
+		result.Body = resp
 	default:
-		return c.ParseJSONError(res)
+		return result, c.ParseJSONError(res)
 	}
 
-	return nil
+	return result, nil
 }
 
 func (c *client) DoAndGetResponseBody(
@@ -329,12 +532,25 @@ func (c *client) DoAndGetResponseBody(
 		return nil, err
 	}
 
-	var isContentTypeSet bool
+	var (
+		isContentTypeSet bool
+		bodyBytes        []byte
+		dryRun           = c.isDryRun(ctx, method)
+		signer           = c.requestSigner()
+	)
 
 	// marshal the message body (assumes json format)
 	if r, ok := body.(io.ReadCloser); ok {
-		req, err = http.NewRequest(method, u.String(), r)
 		defer r.Close() // #nosec G307
+		if signer != nil || dryRun {
+			// the body has to be read to be signed or returned
+			if bodyBytes, err = io.ReadAll(r); err != nil {
+				return nil, err
+			}
+			req, err = http.NewRequest(method, u.String(), bytes.NewReader(bodyBytes))
+		} else {
+			req, err = http.NewRequest(method, u.String(), r)
+		}
 		if v, ok := headers[HeaderKeyContentType]; ok {
 			req.Header.Set(HeaderKeyContentType, v)
 		} else {
@@ -348,6 +564,7 @@ func (c *client) DoAndGetResponseBody(
 		if err = enc.Encode(body); err != nil {
 			return nil, err
 		}
+		bodyBytes = buf.Bytes()
 		req, err = http.NewRequest(method, u.String(), buf)
 		if v, ok := headers[HeaderKeyContentType]; ok {
 			req.Header.Set(HeaderKeyContentType, v)
@@ -375,19 +592,63 @@ func (c *client) DoAndGetResponseBody(
 		}
 		req.Header.Add(header, value)
 	}
+	metadata, hasMetadata := RequestMetadataFromContext(ctx)
+	if hasMetadata {
+		metadata.setHeaders(req.Header)
+	}
+	if c.userAgent != "" {
+		req.Header.Set(HeaderKeyUserAgent, c.userAgent)
+	}
+	requestID, err := c.setRequestID(req)
+	if err != nil {
+		return nil, err
+	}
+	logFields := metadata.fields()
+	if requestID != "" {
+		logFields["requestID"] = requestID
+	}
 
 	// set the auth token
 	if c.token != "" {
 		req.SetBasicAuth("", c.token)
 	}
 
+	if signer != nil {
+		if err = signer(req, bodyBytes); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = c.checkDestructive(ctx, req); err != nil {
+		log.WithFields(logFields).Warn(err.Error())
+		return nil, err
+	}
+
+	if dryRun {
+		dryRunErr := &DryRunError{Method: method, Path: req.URL.RequestURI(), Payload: json.RawMessage(bodyBytes)}
+		log.WithFields(logFields).WithFields(log.Fields{"method": method, "path": dryRunErr.Path, "payload": string(bodyBytes)}).Info("Dry run, request not sent")
+		return nil, dryRunErr
+	}
+
+	if len(logFields) > 0 {
+		log.WithFields(logFields).Debug("Sending " + method + " " + req.URL.RequestURI())
+	}
 	if c.showHTTP {
 		logRequest(ctx, req, c.doLog)
 	}
 
 	// send the request
 	req = req.WithContext(ctx)
-	if res, err = c.http.Do(req); err != nil {
+	start := time.Now()
+	stopWatch := c.watchRequest(req)
+	res, err = c.doWithRetry(ctx, req)
+	stopWatch()
+	c.recordInJournal(start, req, bodyBytes, res, err)
+	if err != nil {
+		if requestID != "" {
+			log.WithFields(logFields).WithError(err).Debug(method + " " + req.URL.RequestURI() + " failed")
+			return nil, &RequestError{RequestID: requestID, Err: err}
+		}
 		return nil, err
 	}
 
@@ -402,19 +663,66 @@ func (c *client) SetToken(token string) {
 	c.token = token
 }
 
+func (c *client) SetRequestSigner(signer RequestSigner) {
+	c.modesMu.Lock()
+	defer c.modesMu.Unlock()
+	c.signer = signer
+}
+
+func (c *client) SetJournal(journal Journal) {
+	c.modesMu.Lock()
+	defer c.modesMu.Unlock()
+	c.journal = journal
+}
+
+func (c *client) SetDryRun(dryRun bool) {
+	c.modesMu.Lock()
+	defer c.modesMu.Unlock()
+	c.dryRun = dryRun
+}
+
+func (c *client) SetSafeMode(safeMode bool) {
+	c.modesMu.Lock()
+	defer c.modesMu.Unlock()
+	c.safeMode = safeMode
+}
+
+// requestSigner returns the callback signing the requests, or nil
+func (c *client) requestSigner() RequestSigner {
+	c.modesMu.RLock()
+	defer c.modesMu.RUnlock()
+	return c.signer
+}
+
+// modes returns the journal, dry-run mode and safe mode of the client
+func (c *client) modes() (journal Journal, dryRun, safeMode bool) {
+	c.modesMu.RLock()
+	defer c.modesMu.RUnlock()
+	return c.journal, c.dryRun, c.safeMode
+}
+
 func (c *client) GetToken() string {
 	return c.token
 }
 
 func (c *client) ParseJSONError(r *http.Response) error {
 	jsonError := &types.Error{}
-	if err := json.NewDecoder(r.Body).Decode(jsonError); err != nil {
-		if err != nil {
-			jsonError.HTTPStatusCode = r.StatusCode
-			jsonError.Message = http.StatusText(r.StatusCode)
-			return jsonError
+	for _, h := range RequestIDHeaders {
+		if id := r.Header.Get(h); id != "" {
+			jsonError.RequestID = id
+			break
 		}
 	}
+	if jsonError.RequestID == "" && c.requestIDHeader != "" && r.Request != nil {
+		jsonError.RequestID = r.Request.Header.Get(c.requestIDHeader)
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxErrorBodySize))
+	jsonError.RawBody = string(body)
+	if err != nil || json.Unmarshal(body, jsonError) != nil {
+		jsonError.HTTPStatusCode = r.StatusCode
+		jsonError.Message = http.StatusText(r.StatusCode)
+		return jsonError
+	}
 
 	jsonError.HTTPStatusCode = r.StatusCode
 	if jsonError.Message == "" {
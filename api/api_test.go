@@ -29,6 +29,7 @@ import (
 	"time"
 
 	types "github.com/dell/gopowermax/v2/types/v100"
+	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -591,11 +592,147 @@ func TestSetToken(t *testing.T) {
 	}
 }
 
+func TestRequestSigner(t *testing.T) {
+	key := []byte("shared-key")
+	var signatures []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(HeaderKeySignature) != SignRequest(key, r.Method, r.URL.RequestURI(), body) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		signatures = append(signatures, r.Header.Get(HeaderKeySignature))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL, ClientOptions{RequestSigner: NewHMACSigner(key, "")}, false)
+	assert.NoError(t, err)
+	assert.NoError(t, c.Post(context.Background(), "/path?a=b", nil, map[string]string{"name": "sg"}, nil))
+	assert.NoError(t, c.Put(context.Background(), "/path", map[string]string{HeaderKeyContentType: "text/plain"},
+		io.NopCloser(strings.NewReader("raw body")), nil))
+	assert.NoError(t, c.Get(context.Background(), "/path", nil, nil))
+	assert.Len(t, signatures, 3)
+
+	c.SetRequestSigner(NewHMACSigner([]byte("other-key"), ""))
+	assert.Error(t, c.Get(context.Background(), "/path", nil, nil))
+
+	c.SetRequestSigner(func(_ *http.Request, _ []byte) error { return errors.New("signing failed") })
+	assert.EqualError(t, c.Get(context.Background(), "/path", nil, nil), "signing failed")
+}
+
+func TestDryRun(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL, ClientOptions{DryRun: true}, false)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	err = c.Put(ctx, "/path?a=b", nil, map[string]string{"name": "sg"}, nil)
+	var dryRunErr *DryRunError
+	assert.True(t, errors.As(err, &dryRunErr))
+	assert.True(t, IsDryRunError(err))
+	assert.Equal(t, http.MethodPut, dryRunErr.Method)
+	assert.Equal(t, "/path?a=b", dryRunErr.Path)
+	assert.JSONEq(t, `{"name":"sg"}`, string(dryRunErr.Payload))
+
+	err = c.Post(ctx, "/path", nil, io.NopCloser(strings.NewReader("raw body")), nil)
+	assert.True(t, errors.As(err, &dryRunErr))
+	assert.Equal(t, "raw body", string(dryRunErr.Payload))
+	assert.True(t, IsDryRunError(c.Delete(ctx, "/path", nil, nil)))
+	assert.Empty(t, methods)
+
+	assert.NoError(t, c.Get(ctx, "/path", nil, nil))
+	assert.NoError(t, c.Post(WithDryRun(ctx, false), "/path", nil, nil, nil))
+	assert.Equal(t, []string{http.MethodGet, http.MethodPost}, methods)
+
+	c.SetDryRun(false)
+	assert.NoError(t, c.Delete(ctx, "/path", nil, nil))
+	assert.True(t, IsDryRunError(c.Delete(WithDryRun(ctx, true), "/path", nil, nil)))
+	assert.Equal(t, []string{http.MethodGet, http.MethodPost, http.MethodDelete}, methods)
+}
+
+func TestDoWithResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.Header().Set("Location", "/job/123")
+			w.Header().Set("ETag", `"v1"`)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"name":"sg"}`))
+		default:
+			w.Header().Set("ETag", `"v2"`)
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"message":"conflict","httpStatusCode":409}`))
+		}
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL, ClientOptions{}, false)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	resp := map[string]string{}
+	result, err := c.DoWithResult(ctx, http.MethodPost, "/path", nil, map[string]string{"name": "sg"}, &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, result.StatusCode)
+	assert.Equal(t, "/job/123", result.Location())
+	assert.Equal(t, `"v1"`, result.ETag())
+	assert.Equal(t, &resp, result.Body)
+	assert.Equal(t, "sg", resp["name"])
+
+	result, err = c.DoWithResult(ctx, http.MethodPut, "/path", nil, nil, &resp)
+	var jsonErr *types.Error
+	assert.True(t, errors.As(err, &jsonErr))
+	assert.Equal(t, http.StatusConflict, jsonErr.HTTPStatusCode)
+	assert.Equal(t, http.StatusConflict, result.StatusCode)
+	assert.Equal(t, `"v2"`, result.ETag())
+	assert.Nil(t, result.Body)
+}
+
+func TestSafeMode(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL, ClientOptions{SafeMode: true}, false)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	err = c.Delete(ctx, "/path?a=b", nil, nil)
+	var destructiveErr *DestructiveOperationError
+	assert.True(t, errors.As(err, &destructiveErr))
+	assert.Equal(t, "Delete", destructiveErr.Operation)
+	assert.Equal(t, http.MethodDelete, destructiveErr.Method)
+	assert.Equal(t, "/path?a=b", destructiveErr.Path)
+	assert.True(t, IsDestructiveOperationError(c.Put(WithDestructiveOperation(ctx, "Failover"), "/path", nil, nil, nil)))
+	assert.Empty(t, methods)
+
+	assert.NoError(t, c.Put(ctx, "/path", nil, nil, nil))
+	assert.NoError(t, c.Delete(WithAllowDestructive(ctx, true), "/path", nil, nil))
+	assert.Equal(t, []string{http.MethodPut, http.MethodDelete}, methods)
+
+	c.SetSafeMode(false)
+	assert.NoError(t, c.Post(WithDestructiveOperation(ctx, "Restore"), "/path", nil, nil, nil))
+	assert.True(t, IsDestructiveOperationError(c.Delete(WithAllowDestructive(ctx, false), "/path", nil, nil)))
+	assert.False(t, IsDestructiveOperationError(errors.New("other")))
+	assert.Equal(t, []string{http.MethodPut, http.MethodDelete, http.MethodPost}, methods)
+}
+
 func TestParseJSONError(t *testing.T) {
 	tests := []struct {
 		name          string
 		responseBody  string
 		statusCode    int
+		headers       http.Header
 		expectedError *types.Error
 	}{
 		{
@@ -605,6 +742,7 @@ func TestParseJSONError(t *testing.T) {
 			expectedError: &types.Error{
 				HTTPStatusCode: http.StatusBadRequest,
 				Message:        "error occurred",
+				RawBody:        `{"Message": "error occurred"}`,
 			},
 		},
 		{
@@ -614,6 +752,20 @@ func TestParseJSONError(t *testing.T) {
 			expectedError: &types.Error{
 				HTTPStatusCode: http.StatusInternalServerError,
 				Message:        http.StatusText(http.StatusInternalServerError),
+				RawBody:        `invalid json`,
+			},
+		},
+		{
+			name:         "Error code and request ID",
+			responseBody: `{"message": "not found", "errorCode": 1234}`,
+			statusCode:   http.StatusNotFound,
+			headers:      http.Header{"X-Correlation-Id": []string{"abc-123"}},
+			expectedError: &types.Error{
+				HTTPStatusCode: http.StatusNotFound,
+				Message:        "not found",
+				ErrorCode:      1234,
+				RequestID:      "abc-123",
+				RawBody:        `{"message": "not found", "errorCode": 1234}`,
 			},
 		},
 	}
@@ -622,6 +774,7 @@ func TestParseJSONError(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &http.Response{
 				StatusCode: tt.statusCode,
+				Header:     tt.headers,
 				Body:       io.NopCloser(bytes.NewBufferString(tt.responseBody)),
 			}
 
@@ -629,10 +782,9 @@ func TestParseJSONError(t *testing.T) {
 			err := c.ParseJSONError(r)
 
 			assert.Error(t, err)
-			if e, ok := err.(*types.Error); ok {
-				assert.Equal(t, tt.expectedError.HTTPStatusCode, e.HTTPStatusCode)
-				assert.Equal(t, tt.expectedError.Message, e.Message)
-			}
+			e, ok := err.(*types.Error)
+			assert.True(t, ok)
+			assert.Equal(t, tt.expectedError, e)
 		})
 	}
 }
@@ -1011,3 +1163,578 @@ func TestDoMethod(t *testing.T) {
 		})
 	}
 }
+
+func TestRetry(t *testing.T) {
+	var calls int
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	policy := &RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond}
+	c, err := New(server.URL, ClientOptions{Retry: policy}, false)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	assert.NoError(t, c.Get(ctx, "/path", nil, nil))
+	assert.Equal(t, 3, calls)
+
+	// PUT, DELETE and POST requests are not retried without verification
+	calls = 0
+	assert.Error(t, c.Put(ctx, "/path", nil, map[string]string{"name": "sg"}, nil))
+	assert.Equal(t, 1, calls)
+	calls = 0
+	assert.Error(t, c.Delete(ctx, "/path", nil, nil))
+	assert.Equal(t, 1, calls)
+	calls = 0
+	assert.Error(t, c.Post(ctx, "/path", nil, map[string]string{"name": "sg"}, nil))
+	assert.Equal(t, 1, calls)
+
+	// or when the verification fails
+	var verified []string
+	policy.VerifyRetry = func(_ context.Context, req *http.Request, body []byte) bool {
+		verified = append(verified, req.Method+" "+string(body))
+		return calls < 2
+	}
+	calls = 0
+	assert.Error(t, c.Post(ctx, "/path", nil, map[string]string{"name": "sg"}, nil))
+	assert.Equal(t, 2, calls)
+	assert.Len(t, verified, 2)
+	assert.Contains(t, verified[0], `POST {"name":"sg"}`)
+
+	policy.VerifyRetry = func(context.Context, *http.Request, []byte) bool { return true }
+	calls = 0
+	assert.NoError(t, c.Post(ctx, "/path", nil, map[string]string{"name": "sg"}, nil))
+	assert.Equal(t, 3, calls)
+
+	// verified requests are retried with their body
+	calls, bodies = 0, nil
+	assert.NoError(t, c.Put(ctx, "/path", nil, map[string]string{"name": "sg"}, nil))
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, bodies[0], bodies[2])
+	assert.JSONEq(t, `{"name":"sg"}`, bodies[2])
+
+	// streamed bodies cannot be sent again
+	calls = 0
+	assert.Error(t, c.Put(ctx, "/path", nil, io.NopCloser(strings.NewReader("raw body")), nil))
+	assert.Equal(t, 1, calls)
+
+	// retries are bounded by MaxRetries
+	calls = -10
+	assert.Error(t, c.Get(ctx, "/path", nil, nil))
+	assert.Equal(t, -7, calls)
+}
+
+func TestConnectionPoolOptions(t *testing.T) {
+	c, err := New("https://127.0.0.1", ClientOptions{Insecure: true}, false)
+	assert.NoError(t, err)
+	transport := c.GetHTTPClient().Transport.(*http.Transport)
+	assert.Equal(t, DefaultMaxIdleConns, transport.MaxIdleConns)
+	assert.Equal(t, DefaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, DefaultIdleConnTimeout, transport.IdleConnTimeout)
+	assert.Equal(t, 0, transport.MaxConnsPerHost)
+	assert.False(t, transport.ForceAttemptHTTP2)
+
+	c, err = New("https://127.0.0.1", ClientOptions{
+		MaxIdleConns:        10,
+		MaxIdleConnsPerHost: 5,
+		MaxConnsPerHost:     20,
+		IdleConnTimeout:     time.Minute,
+		ForceAttemptHTTP2:   true,
+	}, false)
+	assert.NoError(t, err)
+	transport = c.GetHTTPClient().Transport.(*http.Transport)
+	assert.Equal(t, 10, transport.MaxIdleConns)
+	assert.Equal(t, 5, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 20, transport.MaxConnsPerHost)
+	assert.Equal(t, time.Minute, transport.IdleConnTimeout)
+	assert.True(t, transport.ForceAttemptHTTP2)
+	assert.NotNil(t, transport.TLSClientConfig.RootCAs)
+}
+
+func TestJournal(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"volume is in use","httpStatusCode":400,"errorCode":0}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	journal := NewMemoryJournal(2)
+	c, err := New(server.URL, ClientOptions{Journal: journal}, false)
+	assert.NoError(t, err)
+	ctx := context.Background()
+	start := time.Now()
+
+	assert.NoError(t, c.Get(ctx, "/volume/00001", nil, nil))
+	assert.NoError(t, c.Post(ctx, "/volume", nil, map[string]string{"name": "vol1"}, nil))
+	assert.NoError(t, c.Put(ctx, "/volume/00001", nil, map[string]string{"name": "vol2"}, nil))
+	err = c.Delete(ctx, "/volume/00001", nil, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "volume is in use")
+
+	// the GET is not recorded and the POST was dropped from the full journal
+	entries, err := journal.Query(JournalFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, http.MethodPut, entries[0].Method)
+	assert.Equal(t, "/volume/00001", entries[0].Path)
+	assert.JSONEq(t, `{"name":"vol2"}`, string(entries[0].Payload))
+	assert.Equal(t, http.StatusOK, entries[0].StatusCode)
+	assert.False(t, entries[0].Time.Before(start))
+	assert.Equal(t, "volume is in use", entries[1].Error)
+
+	entries, err = journal.Query(JournalFilter{FailedOnly: true})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	entries, err = journal.Query(JournalFilter{Since: time.Now()})
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, ExportJournal(buf, journal, JournalFilter{Method: http.MethodDelete}))
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+	assert.Contains(t, buf.String(), `"method":"DELETE"`)
+
+	// dry run requests are not sent, so not recorded
+	c.SetDryRun(true)
+	assert.Error(t, c.Put(ctx, "/volume/00002", nil, nil, nil))
+	entries, _ = journal.Query(JournalFilter{PathContains: "00002"})
+	assert.Empty(t, entries)
+}
+
+func TestCheckRequest(t *testing.T) {
+	c, err := New("http://localhost", ClientOptions{}, false)
+	assert.NoError(t, err)
+	ctx := context.Background()
+	assert.NoError(t, c.CheckRequest(ctx, http.MethodDelete, "/volume/00001"))
+
+	c.SetSafeMode(true)
+	assert.True(t, IsDestructiveOperationError(c.CheckRequest(ctx, http.MethodDelete, "/volume/00001")))
+	assert.NoError(t, c.CheckRequest(ctx, http.MethodPut, "/volume/00001"))
+	assert.True(t, IsDestructiveOperationError(c.CheckRequest(WithDestructiveOperation(ctx, "Failover"), http.MethodPut, "/rdf")))
+	assert.NoError(t, c.CheckRequest(WithAllowDestructive(ctx, true), http.MethodDelete, "/volume/00001"))
+
+	c.SetSafeMode(false)
+	c.SetDryRun(true)
+	assert.True(t, IsDryRunError(c.CheckRequest(ctx, http.MethodPut, "/volume/00001")))
+	assert.NoError(t, c.CheckRequest(ctx, http.MethodGet, "/volume/00001"))
+}
+
+func TestModesChangedDuringRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL, ClientOptions{Journal: NewMemoryJournal(0)}, false)
+	assert.NoError(t, err)
+	ctx := WithAllowDestructive(WithDryRun(context.Background(), false), true)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_ = c.Put(ctx, "/volume/00001", nil, map[string]string{"name": "vol"}, nil)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		c.SetJournal(nil)
+		c.SetJournal(NewMemoryJournal(0))
+		c.SetSafeMode(i%2 == 0)
+		c.SetDryRun(i%2 == 0)
+		c.SetRequestSigner(nil)
+	}
+	<-done
+}
+
+func TestRequestMetadata(t *testing.T) {
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	journal := NewMemoryJournal(0)
+	c, err := New(server.URL, ClientOptions{Journal: journal}, false)
+	assert.NoError(t, err)
+
+	// no metadata, no headers
+	assert.NoError(t, c.Get(context.Background(), "/volume", nil, nil))
+	assert.Empty(t, headers.Get(HeaderKeyTraceID))
+
+	ctx := WithTraceID(context.Background(), "trace-1")
+	ctx = WithRequestMetadata(ctx, RequestMetadata{Actor: "admin", Reason: "expand\nvolume"})
+	metadata, ok := RequestMetadataFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, RequestMetadata{TraceID: "trace-1", Actor: "admin", Reason: "expand\nvolume"}, metadata)
+
+	assert.NoError(t, c.Put(ctx, "/volume/00001", nil, map[string]string{"size": "10"}, nil))
+	assert.Equal(t, "trace-1", headers.Get(HeaderKeyTraceID))
+	assert.Equal(t, "admin", headers.Get(HeaderKeyActor))
+	assert.Equal(t, "expand volume", headers.Get(HeaderKeyReason))
+
+	// a later value replaces the earlier one only for the derived context
+	assert.NoError(t, c.Get(WithActor(ctx, "operator"), "/volume", nil, nil))
+	assert.Equal(t, "operator", headers.Get(HeaderKeyActor))
+	assert.Equal(t, "trace-1", headers.Get(HeaderKeyTraceID))
+
+	entries, err := journal.Query(JournalFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "trace-1", entries[0].TraceID)
+	assert.Equal(t, "admin", entries[0].Actor)
+	assert.Equal(t, "expand\nvolume", entries[0].Reason)
+}
+
+func TestUserAgentAndRequestID(t *testing.T) {
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"not found"}`))
+			return
+		}
+		if r.Method == http.MethodPost {
+			time.Sleep(100 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	// disabled by default
+	c, err := New(server.URL, ClientOptions{}, false)
+	assert.NoError(t, err)
+	assert.NoError(t, c.Get(context.Background(), "/volume", nil, nil))
+	assert.Empty(t, headers.Get(DefaultRequestIDHeader))
+	assert.True(t, strings.HasPrefix(headers.Get(HeaderKeyUserAgent), "Go-http-client"))
+
+	journal := NewMemoryJournal(0)
+	c, err = New(server.URL, ClientOptions{UserAgent: "my-app/1.0", RequestIDHeader: "X-My-Request", Journal: journal}, false)
+	assert.NoError(t, err)
+	assert.NoError(t, c.Put(context.Background(), "/volume/00001", nil, map[string]string{"size": "10"}, nil))
+	assert.Equal(t, "my-app/1.0", headers.Get(HeaderKeyUserAgent))
+	first := headers.Get("X-My-Request")
+	assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, first)
+
+	// every request gets a new ID, unless the caller sets one
+	assert.NoError(t, c.Get(context.Background(), "/volume", nil, nil))
+	assert.NotEqual(t, first, headers.Get("X-My-Request"))
+	assert.NoError(t, c.Get(context.Background(), "/volume", map[string]string{"X-My-Request": "mine"}, nil))
+	assert.Equal(t, "mine", headers.Get("X-My-Request"))
+
+	// the ID is returned in the error of a failed request
+	err = c.Delete(context.Background(), "/volume/00001", nil, nil)
+	var apiErr *types.Error
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, headers.Get("X-My-Request"), apiErr.RequestID)
+
+	// and of a request without a response
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = c.Post(ctx, "/volume", nil, map[string]string{}, nil)
+	var reqErr *RequestError
+	assert.True(t, errors.As(err, &reqErr))
+	assert.NotEmpty(t, reqErr.RequestID)
+	assert.Contains(t, err.Error(), reqErr.RequestID)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	entries, err := journal.Query(JournalFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+	assert.Equal(t, first, entries[0].RequestID)
+	assert.Equal(t, reqErr.RequestID, entries[2].RequestID)
+}
+
+func TestAdaptiveThrottle(t *testing.T) {
+	throttle := &AdaptiveThrottle{Threshold: 50, MaxDelay: 100 * time.Millisecond}
+	assert.Zero(t, throttle.Delay())
+	throttle.Update(75)
+	assert.Equal(t, 50*time.Millisecond, throttle.Delay())
+	throttle.Update(120)
+	assert.Equal(t, 100*time.Millisecond, throttle.Delay())
+	assert.Equal(t, DefaultThrottleMaxDelay, (&AdaptiveThrottle{load: 100}).Delay())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	probed := make(chan struct{}, 1)
+	throttle = &AdaptiveThrottle{
+		Threshold:     50,
+		MaxDelay:      100 * time.Millisecond,
+		ProbeInterval: time.Hour,
+		Probe: func(_ context.Context) (float64, error) {
+			probed <- struct{}{}
+			return 100, nil
+		},
+	}
+	c, err := New(server.URL, ClientOptions{Throttle: throttle}, false)
+	assert.NoError(t, err)
+
+	// the first request triggers a probe without waiting for it
+	start := time.Now()
+	assert.NoError(t, c.Get(context.Background(), "/volume", nil, nil))
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+	<-probed
+	assert.Eventually(t, func() bool { return throttle.Delay() == 100*time.Millisecond }, time.Second, time.Millisecond)
+
+	// the next ones are delayed, and the load is not probed again before ProbeInterval
+	start = time.Now()
+	assert.NoError(t, c.Get(context.Background(), "/volume", nil, nil))
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	assert.Len(t, probed, 0)
+
+	// a cancelled context stops the wait
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, c.Get(ctx, "/volume", nil, nil), context.DeadlineExceeded)
+}
+
+func TestAdaptiveThrottleWithoutLoad(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	probed := make(chan struct{}, 2)
+	throttle := &AdaptiveThrottle{
+		ProbeInterval: time.Millisecond,
+		Probe: func(_ context.Context) (float64, error) {
+			probed <- struct{}{}
+			return 0, &types.Error{Message: "not found", HTTPStatusCode: http.StatusNotFound}
+		},
+	}
+	c, err := New(server.URL, ClientOptions{Throttle: throttle}, false)
+	assert.NoError(t, err)
+
+	// the first probe is not found, the next requests do not probe again
+	assert.NoError(t, c.Get(context.Background(), "/volume", nil, nil))
+	<-probed
+	assert.Eventually(t, func() bool {
+		throttle.mu.Lock()
+		defer throttle.mu.Unlock()
+		return !throttle.probing
+	}, time.Second, time.Millisecond)
+	for i := 0; i < 5; i++ {
+		time.Sleep(2 * time.Millisecond)
+		assert.NoError(t, c.Get(context.Background(), "/volume", nil, nil))
+	}
+	assert.Len(t, probed, 0)
+	assert.Zero(t, throttle.Delay())
+}
+
+func TestRateLimits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL, ClientOptions{RateLimits: &RateLimits{Write: RateLimit{PerSecond: 50, Burst: 2}}}, false)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	// reads are not limited
+	start := time.Now()
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, c.Get(ctx, "/volume", nil, nil))
+	}
+	assert.Less(t, time.Since(start), 200*time.Millisecond)
+
+	// the first 2 writes are sent at once, the 3 next ones every 20ms
+	start = time.Now()
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, c.Put(ctx, "/volume/00001", nil, nil, nil))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 55*time.Millisecond)
+
+	// a write waiting for its budget stops with its context
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	for i := 0; i < 3; i++ {
+		err = c.Put(ctx, "/volume/00001", nil, nil, nil)
+	}
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestSlowRequestWarning(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(120 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	hook := logtest.NewGlobal()
+	defer hook.Reset()
+	c, err := New(server.URL, ClientOptions{SlowRequestThreshold: 50 * time.Millisecond}, false)
+	assert.NoError(t, err)
+
+	assert.NoError(t, c.Get(context.Background(), "/fast", nil, nil))
+	assert.Empty(t, hook.AllEntries())
+
+	assert.NoError(t, c.Get(context.Background(), "/slow", nil, nil))
+	var messages []string
+	for _, entry := range hook.AllEntries() {
+		assert.Equal(t, "warning", entry.Level.String())
+		assert.Equal(t, http.MethodGet, entry.Data["method"])
+		assert.Equal(t, "/slow", entry.Data["path"])
+		messages = append(messages, entry.Message)
+	}
+	// warned every 50ms while in flight, then once completed
+	assert.GreaterOrEqual(t, len(messages), 2)
+	assert.Equal(t, "Request to Unisphere still in flight", messages[0])
+	assert.Equal(t, "Slow request to Unisphere completed", messages[len(messages)-1])
+
+	// no warning after the request completed
+	hook.Reset()
+	time.Sleep(60 * time.Millisecond)
+	assert.Empty(t, hook.AllEntries())
+}
+
+func TestDecodeResponse(t *testing.T) {
+	iter := &types.VolumeIterator{}
+	err := decodeResponse(strings.NewReader(`{"id":"it","count":2,"resultList":{"from":1,"to":2,"result":[{"volumeId":"00001"},{"volumeId":"00002"}]}}`), iter)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.VolumeIDList{{VolumeIDs: "00001"}, {VolumeIDs: "00002"}}, iter.ResultList.VolumeList)
+	assert.Equal(t, 2, iter.Count)
+
+	for i := 0; i < 2; i++ {
+		vol := &types.Volume{}
+		assert.NoError(t, decodeResponse(strings.NewReader(`{"volumeId":"00003","cap_gb":"1.5"}`), vol))
+		assert.Equal(t, types.Volume{VolumeID: "00003", CapacityGB: 1.5}, *vol)
+	}
+
+	for _, body := range []string{"", " \n"} {
+		vol := &types.Volume{VolumeID: "unchanged"}
+		assert.NoError(t, decodeResponse(strings.NewReader(body), vol))
+		assert.Equal(t, "unchanged", vol.VolumeID)
+	}
+	assert.NoError(t, decodeResponse(strings.NewReader(""), &types.VolumeIterator{}))
+	assert.Error(t, decodeResponse(strings.NewReader(`{"id":`), &types.VolumeIterator{}))
+	assert.Error(t, decodeResponse(strings.NewReader(`{"volumeId":`), &types.Volume{}))
+}
+
+func TestResponseValidation(t *testing.T) {
+	var calls int
+	responses := []string{"", `{"id":`, `{"id":"sg1"}`}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body := responses[min(calls, len(responses))-1]
+		if r.URL.Path == "/truncated" {
+			w.Header().Set("Content-Length", "100")
+			body = `{"id":"sg1"`
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	var invalid []int
+	validation := &ResponseValidation{
+		MaxRetries: 2,
+		Backoff:    time.Millisecond,
+		OnInvalidResponse: func(_ *http.Request, attempt int, err error) {
+			assert.ErrorIs(t, err, ErrInvalidResponse)
+			invalid = append(invalid, attempt)
+		},
+	}
+	c, err := New(server.URL, ClientOptions{ResponseValidation: validation}, false)
+	assert.NoError(t, err)
+	ctx := context.Background()
+	headers := map[string]string{HeaderKeyAccept: HeaderValContentTypeJSON}
+
+	// the empty and the partial bodies are retried
+	resp := map[string]string{}
+	assert.NoError(t, c.Get(ctx, "/sg", headers, &resp))
+	assert.Equal(t, "sg1", resp["id"])
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []int{1, 2}, invalid)
+
+	// a body shorter than its length fails once the retries are exhausted
+	calls, invalid = 0, nil
+	err = c.Get(ctx, "/truncated", headers, &resp)
+	assert.ErrorIs(t, err, ErrInvalidResponse)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []int{1, 2, 3}, invalid)
+
+	// the responses which are not JSON are not validated
+	calls, invalid = 0, nil
+	res, err := c.DoAndGetResponseBody(ctx, http.MethodGet, "/download", map[string]string{HeaderKeyAccept: "application/octet-stream"}, nil)
+	assert.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, invalid)
+}
+
+func TestResponseLimits(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/large":
+			_, _ = w.Write([]byte(`{"id":"` + strings.Repeat("a", 100) + `"}`))
+		case "/chunked":
+			// flushed without a length, so that the size is only known once read
+			_, _ = w.Write([]byte(`{"id":"`))
+			w.(http.Flusher).Flush()
+			_, _ = w.Write([]byte(strings.Repeat("a", 100) + `"}`))
+		case "/download":
+			w.Header().Set(HeaderKeyContentType, "application/octet-stream")
+			_, _ = w.Write([]byte(strings.Repeat("a", 100)))
+		case "/slow":
+			_, _ = w.Write([]byte(`{"id":`))
+			w.(http.Flusher).Flush()
+			<-release
+		default:
+			_, _ = w.Write([]byte(`{"id":"sg1"}`))
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	limits := &ResponseLimits{MaxBodySize: 64, MaxDecodeTime: 100 * time.Millisecond}
+	c, err := New(server.URL, ClientOptions{ResponseLimits: limits, ResponseValidation: &ResponseValidation{MaxRetries: 2}}, false)
+	assert.NoError(t, err)
+	ctx := context.Background()
+	headers := map[string]string{HeaderKeyAccept: HeaderValContentTypeJSON}
+
+	resp := map[string]string{}
+	assert.NoError(t, c.Get(ctx, "/sg", headers, &resp))
+	assert.Equal(t, "sg1", resp["id"])
+
+	// a body announced or read above the limit is refused, without retrying
+	for _, path := range []string{"/large", "/chunked"} {
+		err = c.Get(ctx, path, headers, &resp)
+		assert.True(t, IsResponseTooLargeError(err), "%s: %v", path, err)
+		assert.False(t, errors.Is(err, ErrInvalidResponse))
+		err = c.Get(ctx, path, nil, &resp)
+		assert.True(t, IsResponseTooLargeError(err), "%s: %v", path, err)
+	}
+
+	// binary downloads are streamed by the caller, and not limited
+	res, err := c.DoAndGetResponseBody(ctx, http.MethodGet, "/download", map[string]string{HeaderKeyAccept: "application/octet-stream"}, nil)
+	assert.NoError(t, err)
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Len(t, body, 100)
+	res.Body.Close()
+
+	// a body still incomplete after the decode time is closed
+	start := time.Now()
+	err = c.Get(ctx, "/slow", nil, &resp)
+	assert.True(t, IsDecodeTimeoutError(err), err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
@@ -0,0 +1,173 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultCertReloadInterval is the interval at which CertDir is checked for changes
+// when ClientOptions.CertReloadInterval is not set
+const DefaultCertReloadInterval = 5 * time.Minute
+
+// loadCertPool returns the system cert pool with the certificates of certFile and of
+// all the files in certDir appended, along with a digest of the appended certificates.
+// The files of certDir without any certificate, e.g. the tls.key of a mounted Kubernetes TLS secret,
+// are skipped, but at least one certificate has to be found.
+func loadCertPool(certFile, certDir string) (*x509.CertPool, []byte, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		return nil, nil, errSysCerts
+	}
+	files := make([]string, 0)
+	if certFile != "" {
+		files = append(files, certFile)
+	}
+	if certDir != "" {
+		entries, err := os.ReadDir(certDir)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, e := range entries {
+			// skip the hidden entries Kubernetes uses for atomic updates of mounted secrets
+			if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+				continue
+			}
+			files = append(files, filepath.Join(certDir, e.Name()))
+		}
+	}
+	digest := sha256.New()
+	appended := 0
+	for _, f := range files {
+		cert, err := os.ReadFile(f) // #nosec G304
+		if err != nil {
+			return nil, nil, err
+		}
+		if f != certFile && !hasCertificate(cert) {
+			log.Debugf("Skipping %s, it holds no certificate", f)
+			continue
+		}
+		if ok := pool.AppendCertsFromPEM(cert); !ok {
+			return nil, nil, fmt.Errorf("failed to append reverse proxy certificate %s to pool", f)
+		}
+		digest.Write(cert)
+		appended++
+	}
+	if appended == 0 && len(files) > 0 {
+		return nil, nil, fmt.Errorf("no reverse proxy certificate found in %s", certDir)
+	}
+	return pool, digest.Sum(nil), nil
+}
+
+// hasCertificate returns true if data holds a PEM encoded certificate
+func hasCertificate(data []byte) bool {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return false
+		}
+		if block.Type == "CERTIFICATE" {
+			return true
+		}
+	}
+}
+
+// newTLSTransport returns a transport verifying servers against the given pool
+func newTLSTransport(pool *x509.CertPool, opts ClientOptions) *http.Transport {
+	// #nosec G402
+	return opts.newTransport(&tls.Config{
+		RootCAs:            pool,
+		InsecureSkipVerify: false,
+	})
+}
+
+// reloadingTransport is a RoundTripper which periodically reloads the certificates of
+// a directory and swaps in a new transport using them whenever they have changed
+type reloadingTransport struct {
+	certFile  string
+	certDir   string
+	interval  time.Duration
+	opts      ClientOptions
+	mu        sync.Mutex
+	transport *http.Transport
+	digest    []byte
+	lastCheck time.Time
+}
+
+func newReloadingTransport(opts ClientOptions) (*reloadingTransport, error) {
+	interval := opts.CertReloadInterval
+	if interval <= 0 {
+		interval = DefaultCertReloadInterval
+	}
+	pool, digest, err := loadCertPool(opts.CertFile, opts.CertDir)
+	if err != nil {
+		return nil, err
+	}
+	return &reloadingTransport{
+		certFile:  opts.CertFile,
+		certDir:   opts.CertDir,
+		interval:  interval,
+		opts:      opts,
+		transport: newTLSTransport(pool, opts),
+		digest:    digest,
+		lastCheck: time.Now(),
+	}, nil
+}
+
+// current returns the transport to be used, reloading the certificates if the reload interval has elapsed.
+// A failed reload is logged and the previous certificates stay in use.
+func (t *reloadingTransport) current() *http.Transport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if time.Since(t.lastCheck) < t.interval {
+		return t.transport
+	}
+	t.lastCheck = time.Now()
+	pool, digest, err := loadCertPool(t.certFile, t.certDir)
+	if err != nil {
+		log.WithError(err).Error("Unable to reload certificates from " + t.certDir)
+		return t.transport
+	}
+	if !bytes.Equal(digest, t.digest) {
+		log.Info("Certificates in " + t.certDir + " have changed, reloading")
+		old := t.transport
+		t.transport = newTLSTransport(pool, t.opts)
+		t.digest = digest
+		old.CloseIdleConnections()
+	}
+	return t.transport
+}
+
+func (t *reloadingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.current().RoundTrip(req)
+}
+
+func (t *reloadingTransport) CloseIdleConnections() {
+	t.current().CloseIdleConnections()
+}
@@ -0,0 +1,109 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertDirReload(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "other.pem"), selfSignedCert(t), 0o600))
+	// hidden entries, as used by Kubernetes for mounted secrets, are ignored
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden"), []byte("not a cert"), 0o600))
+
+	c, err := New(server.URL, ClientOptions{CertDir: dir, CertReloadInterval: 10 * time.Millisecond}, false)
+	assert.NoError(t, err)
+	httpClient := c.GetHTTPClient()
+
+	// the server certificate is not trusted yet
+	_, err = httpClient.Get(server.URL)
+	assert.Error(t, err)
+
+	serverCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "server.pem"), serverCert, 0o600))
+	time.Sleep(20 * time.Millisecond)
+
+	resp, err := httpClient.Get(server.URL)
+	assert.NoError(t, err)
+	if resp != nil {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	// an invalid certificate keeps the previous pool in use
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "bad.pem"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("not a cert")}), 0o600))
+	time.Sleep(20 * time.Millisecond)
+	resp, err = httpClient.Get(server.URL)
+	assert.NoError(t, err)
+	if resp != nil {
+		resp.Body.Close()
+	}
+}
+
+func selfSignedCert(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "other"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestCertDirMissing(t *testing.T) {
+	_, err := New("https://127.0.0.1", ClientOptions{CertDir: filepath.Join(t.TempDir(), "missing")}, false)
+	assert.Error(t, err)
+}
+
+func TestCertDirWithKey(t *testing.T) {
+	dir := t.TempDir()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	// a mounted Kubernetes TLS secret holds the key next to the certificate
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "tls.key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600))
+	_, err = New("https://127.0.0.1", ClientOptions{CertDir: dir}, false)
+	assert.Error(t, err)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "tls.crt"), selfSignedCert(t), 0o600))
+	_, err = New("https://127.0.0.1", ClientOptions{CertDir: dir}, false)
+	assert.NoError(t, err)
+}
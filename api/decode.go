@@ -0,0 +1,65 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// maxPooledBufferSize is the capacity above which a response buffer is not kept for reuse,
+// so that a single large response does not stay allocated
+const maxPooledBufferSize = 1 << 20
+
+// bufferPool holds the buffers the responses are read into before being decoded
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// StreamDecoder is implemented by the responses decoded from the JSON token stream, such as the large listings,
+// so that they are decoded one element at a time instead of being buffered whole
+type StreamDecoder interface {
+	DecodeStream(dec *json.Decoder) error
+}
+
+// decodeResponse decodes the JSON body r into resp. A StreamDecoder decodes the body as it is read, other
+// responses are read into a pooled buffer first, to avoid growing a new buffer for every response.
+// An empty body leaves resp unchanged.
+func decodeResponse(r io.Reader, resp interface{}) error {
+	if decoder, ok := resp.(StreamDecoder); ok {
+		if err := decoder.DecodeStream(json.NewDecoder(r)); err != nil && err != io.EOF {
+			return err
+		}
+		return nil
+	}
+	buf := bufferPool.Get().(*bytes.Buffer)
+	defer func() {
+		if buf.Cap() <= maxPooledBufferSize {
+			buf.Reset()
+			bufferPool.Put(buf)
+		}
+	}()
+	if _, err := buf.ReadFrom(r); err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(buf.Bytes())) == 0 {
+		return nil
+	}
+	return json.Unmarshal(buf.Bytes(), resp)
+}
@@ -0,0 +1,61 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+type dryRunKey struct{}
+
+// WithDryRun returns a context in which mutating requests are, or are not, dry run,
+// whatever the dry-run mode of the client
+func WithDryRun(ctx context.Context, dryRun bool) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, dryRun)
+}
+
+// DryRunError is returned, instead of sending the request, for every POST, PUT, PATCH or
+// DELETE made in dry-run mode. It holds the request which would have been sent.
+type DryRunError struct {
+	Method  string
+	Path    string
+	Payload json.RawMessage
+}
+
+func (e *DryRunError) Error() string {
+	return fmt.Sprintf("dry run: %s %s not sent", e.Method, e.Path)
+}
+
+// IsDryRunError returns true if the error is, or wraps, a DryRunError
+func IsDryRunError(err error) bool {
+	var dryRun *DryRunError
+	return errors.As(err, &dryRun)
+}
+
+// isDryRun returns true if a request with the given method must not be sent
+func (c *client) isDryRun(ctx context.Context, method string) bool {
+	if method == http.MethodGet || method == http.MethodHead {
+		return false
+	}
+	if dryRun, ok := ctx.Value(dryRunKey{}).(bool); ok {
+		return dryRun
+	}
+	_, dryRun, _ := c.modes()
+	return dryRun
+}
@@ -0,0 +1,177 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// maxJournalErrorSize is the maximum size of an error response kept in a JournalEntry
+const maxJournalErrorSize = 64 * 1024
+
+// JournalEntry is a mutating request sent by the client, and its outcome
+type JournalEntry struct {
+	Time       time.Time       `json:"time"`
+	Method     string          `json:"method"`
+	Path       string          `json:"path"`
+	Payload    json.RawMessage `json:"payload,omitempty"`
+	StatusCode int             `json:"statusCode,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	Duration   time.Duration   `json:"duration"`
+	// TraceID, Actor and Reason are the RequestMetadata of the request, if any
+	TraceID string `json:"traceId,omitempty"`
+	Actor   string `json:"actor,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	// RequestID is the ID sent with the request, if request IDs are enabled
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// JournalFilter selects journal entries, zero fields select all the entries
+type JournalFilter struct {
+	// Since and Until select the entries sent in [Since, Until)
+	Since time.Time
+	Until time.Time
+	// Method selects the entries with this method, e.g. http.MethodDelete
+	Method string
+	// PathContains selects the entries whose path contains it, e.g. a volume id
+	PathContains string
+	// FailedOnly selects the failed entries
+	FailedOnly bool
+}
+
+// Match returns true if the entry is selected by the filter
+func (f JournalFilter) Match(entry JournalEntry) bool {
+	return (f.Since.IsZero() || !entry.Time.Before(f.Since)) &&
+		(f.Until.IsZero() || entry.Time.Before(f.Until)) &&
+		(f.Method == "" || strings.EqualFold(f.Method, entry.Method)) &&
+		(f.PathContains == "" || strings.Contains(entry.Path, f.PathContains)) &&
+		(!f.FailedOnly || entry.Error != "")
+}
+
+// Journal stores the mutating requests sent by a client, so that the changes made to an array can be
+// reconstructed. Implementations have to be safe for concurrent use.
+type Journal interface {
+	// Record stores an entry. An error is logged and does not fail the request.
+	Record(entry JournalEntry) error
+	// Query returns the entries selected by the filter, oldest first
+	Query(filter JournalFilter) ([]JournalEntry, error)
+}
+
+// MemoryJournal is a Journal keeping the most recent entries in memory
+type MemoryJournal struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    []JournalEntry
+}
+
+// NewMemoryJournal returns a MemoryJournal keeping up to maxEntries entries, or all of them if maxEntries is 0
+func NewMemoryJournal(maxEntries int) *MemoryJournal {
+	return &MemoryJournal{maxEntries: maxEntries}
+}
+
+// Record stores an entry, dropping the oldest one if the journal is full
+func (j *MemoryJournal) Record(entry JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.entries = append(j.entries, entry)
+	if j.maxEntries > 0 && len(j.entries) > j.maxEntries {
+		j.entries = append([]JournalEntry(nil), j.entries[len(j.entries)-j.maxEntries:]...)
+	}
+	return nil
+}
+
+// Query returns the entries selected by the filter, oldest first
+func (j *MemoryJournal) Query(filter JournalFilter) ([]JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	entries := []JournalEntry{}
+	for _, entry := range j.entries {
+		if filter.Match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// ExportJournal writes the entries of the journal selected by the filter to w, as one JSON object per line
+func ExportJournal(w io.Writer, journal Journal, filter JournalFilter) error {
+	entries, err := journal.Query(filter)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	for _, entry := range entries {
+		if err = enc.Encode(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordInJournal records a request sent by the client in its journal, if the request is mutating.
+// The body of an error response is read to keep its message, and replaced so that it can still be parsed.
+func (c *client) recordInJournal(start time.Time, req *http.Request, payload []byte, res *http.Response, err error) {
+	journal, _, _ := c.modes()
+	if journal == nil || req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return
+	}
+	entry := JournalEntry{
+		Time:     start,
+		Method:   req.Method,
+		Path:     req.URL.RequestURI(),
+		Duration: time.Since(start),
+	}
+	if metadata, ok := RequestMetadataFromContext(req.Context()); ok {
+		entry.TraceID = metadata.TraceID
+		entry.Actor = metadata.Actor
+		entry.Reason = metadata.Reason
+	}
+	if c.requestIDHeader != "" {
+		entry.RequestID = req.Header.Get(c.requestIDHeader)
+	}
+	if json.Valid(payload) {
+		entry.Payload = json.RawMessage(payload)
+	}
+	switch {
+	case err != nil:
+		entry.Error = err.Error()
+	case res != nil:
+		entry.StatusCode = res.StatusCode
+		if res.StatusCode >= 300 {
+			body, _ := io.ReadAll(io.LimitReader(res.Body, maxJournalErrorSize))
+			res.Body.Close() // #nosec G104
+			res.Body = io.NopCloser(bytes.NewReader(body))
+			message := struct {
+				Message string `json:"message"`
+			}{}
+			if json.Unmarshal(body, &message) == nil && message.Message != "" {
+				entry.Error = message.Message
+			} else {
+				entry.Error = http.StatusText(res.StatusCode)
+			}
+		}
+	}
+	if err := journal.Record(entry); err != nil {
+		log.WithError(err).Warn("Unable to record " + entry.Method + " " + entry.Path + " in the journal")
+	}
+}
@@ -0,0 +1,120 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Headers carrying the RequestMetadata of a request
+const (
+	HeaderKeyTraceID = "X-Trace-Id"
+	HeaderKeyActor   = "X-Request-Actor"
+	HeaderKeyReason  = "X-Request-Reason"
+)
+
+type requestMetadataKey struct{}
+
+// RequestMetadata describes why a request is sent, so that it can be followed from the caller
+// to Unisphere. It is sent as HTTP headers, and logged and journaled with the request.
+type RequestMetadata struct {
+	// TraceID identifies the operation the request is part of
+	TraceID string
+	// Actor is the user or the component initiating the request
+	Actor string
+	// Reason is a free text explaining the request
+	Reason string
+}
+
+// WithRequestMetadata returns a context carrying the metadata. The non-empty fields of the
+// metadata replace the ones already in the context.
+func WithRequestMetadata(ctx context.Context, metadata RequestMetadata) context.Context {
+	current, _ := RequestMetadataFromContext(ctx)
+	if metadata.TraceID != "" {
+		current.TraceID = metadata.TraceID
+	}
+	if metadata.Actor != "" {
+		current.Actor = metadata.Actor
+	}
+	if metadata.Reason != "" {
+		current.Reason = metadata.Reason
+	}
+	return context.WithValue(ctx, requestMetadataKey{}, current)
+}
+
+// WithTraceID returns a context carrying the trace ID
+func WithTraceID(ctx context.Context, traceID string) context.Context {
+	return WithRequestMetadata(ctx, RequestMetadata{TraceID: traceID})
+}
+
+// WithActor returns a context carrying the user or component initiating the requests
+func WithActor(ctx context.Context, actor string) context.Context {
+	return WithRequestMetadata(ctx, RequestMetadata{Actor: actor})
+}
+
+// WithReason returns a context carrying the reason of the requests
+func WithReason(ctx context.Context, reason string) context.Context {
+	return WithRequestMetadata(ctx, RequestMetadata{Reason: reason})
+}
+
+// RequestMetadataFromContext returns the metadata carried by the context, if any
+func RequestMetadataFromContext(ctx context.Context) (RequestMetadata, bool) {
+	metadata, ok := ctx.Value(requestMetadataKey{}).(RequestMetadata)
+	return metadata, ok
+}
+
+// HasRequestOverrides returns true if the context overrides the dry-run or safe mode of the client, marks a
+// destructive operation, or carries request metadata, i.e. if its requests cannot be sent with another context
+func HasRequestOverrides(ctx context.Context) bool {
+	for _, key := range []interface{}{dryRunKey{}, allowDestructiveKey{}, destructiveOperationKey{}, requestMetadataKey{}} {
+		if ctx.Value(key) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// setHeaders sets the headers of the non-empty fields of the metadata on the request
+func (m RequestMetadata) setHeaders(header http.Header) {
+	for key, value := range map[string]string{
+		HeaderKeyTraceID: m.TraceID,
+		HeaderKeyActor:   m.Actor,
+		HeaderKeyReason:  m.Reason,
+	} {
+		// line breaks would make the request invalid
+		if value = strings.Join(strings.Fields(value), " "); value != "" {
+			header.Set(key, value)
+		}
+	}
+}
+
+// fields returns the non-empty fields of the metadata as log fields
+func (m RequestMetadata) fields() log.Fields {
+	fields := log.Fields{}
+	if m.TraceID != "" {
+		fields["traceID"] = m.TraceID
+	}
+	if m.Actor != "" {
+		fields["actor"] = m.Actor
+	}
+	if m.Reason != "" {
+		fields["reason"] = m.Reason
+	}
+	return fields
+}
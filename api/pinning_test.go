@@ -0,0 +1,56 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPinnedCertificates(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	fingerprint := CertificateFingerprint(server.Certificate())
+	assert.Len(t, fingerprint, 95)
+
+	// the fingerprint is accepted with or without colons, in any case
+	for _, pinned := range []string{fingerprint, strings.ToLower(strings.ReplaceAll(fingerprint, ":", ""))} {
+		c, err := New(server.URL, ClientOptions{PinnedCertificates: []string{strings.Repeat("00", 32), pinned}}, false)
+		assert.NoError(t, err)
+		resp, err := c.GetHTTPClient().Get(server.URL)
+		assert.NoError(t, err)
+		if resp != nil {
+			resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		}
+	}
+
+	// pinning takes precedence over Insecure
+	c, err := New(server.URL, ClientOptions{Insecure: true, PinnedCertificates: []string{strings.Repeat("00", 32)}}, false)
+	assert.NoError(t, err)
+	_, err = c.GetHTTPClient().Get(server.URL)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "does not match the pinned fingerprints")
+	}
+
+	_, err = New(server.URL, ClientOptions{PinnedCertificates: []string{"AB:CD"}}, false)
+	assert.Error(t, err)
+}
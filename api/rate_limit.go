@@ -0,0 +1,119 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimit is a budget of requests per second, allowing bursts of up to Burst requests.
+// There is no limit if PerSecond is not set.
+type RateLimit struct {
+	PerSecond float64
+	// Burst is the number of requests which can be sent at once after a pause, 1 if not set
+	Burst int
+}
+
+// RateLimits are the budgets of the requests reading from Unisphere (GET and HEAD) and of the
+// requests changing the arrays, so that a flood of writes does not starve the reads, e.g.
+//
+//	&RateLimits{Read: RateLimit{PerSecond: 50}, Write: RateLimit{PerSecond: 10}}
+type RateLimits struct {
+	Read  RateLimit
+	Write RateLimit
+}
+
+// rateLimiter delays the requests exceeding the budget of their category
+type rateLimiter struct {
+	read  *tokenBucket
+	write *tokenBucket
+}
+
+func newRateLimiter(limits *RateLimits) *rateLimiter {
+	if limits == nil {
+		return nil
+	}
+	return &rateLimiter{
+		read:  newTokenBucket(limits.Read),
+		write: newTokenBucket(limits.Write),
+	}
+}
+
+// wait blocks until a request with the given method fits in its budget, or ctx is done
+func (l *rateLimiter) wait(ctx context.Context, method string) error {
+	if l == nil {
+		return nil
+	}
+	if method == http.MethodGet || method == http.MethodHead {
+		return l.read.wait(ctx)
+	}
+	return l.write.wait(ctx)
+}
+
+// tokenBucket is refilled at rate tokens per second, up to burst tokens, and every request takes one token
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a full bucket for the limit, or nil if there is no limit
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	if limit.PerSecond <= 0 {
+		return nil
+	}
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rate: limit.PerSecond, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// wait takes a token, waiting for it to be refilled if needed. The tokens can go negative, so that
+// the waiting requests are queued in the order they arrived; the token is given back if ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	b.tokens--
+	delay := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// HeaderKeyUserAgent is the header carrying the ClientOptions UserAgent
+	HeaderKeyUserAgent = "User-Agent"
+	// DefaultRequestIDHeader is the header commonly used to carry the ID of a request
+	DefaultRequestIDHeader = "X-Request-Id"
+)
+
+// RequestError is returned when a request carrying a request ID fails before a response is received,
+// e.g. on a timeout, so that the failure can be matched with the Unisphere logs
+type RequestError struct {
+	RequestID string
+	Err       error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("%s (requestID: %s)", e.Err.Error(), e.RequestID)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// newRequestID returns a random (version 4) UUID
+func newRequestID() (string, error) {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", err
+	}
+	id[6] = (id[6] & 0x0f) | 0x40
+	id[8] = (id[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:]), nil
+}
+
+// setRequestID sets a new request ID on the request, unless the caller already set one, and returns it.
+// Nothing is set, and an empty ID is returned, if request IDs are not enabled.
+func (c *client) setRequestID(req *http.Request) (string, error) {
+	if c.requestIDHeader == "" {
+		return "", nil
+	}
+	if id := req.Header.Get(c.requestIDHeader); id != "" {
+		return id, nil
+	}
+	id, err := newRequestID()
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set(c.requestIDHeader, id)
+	return id, nil
+}
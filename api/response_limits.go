@@ -0,0 +1,161 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// ResponseLimits bounds the responses read from Unisphere, so that a pathological response, e.g. a huge listing or
+// a body trickling in, cannot exhaust the memory of the process or block it. Both limits apply to every response
+// decoded in memory; the binary downloads, e.g. of a log bundle, are streamed by the caller and are not limited.
+type ResponseLimits struct {
+	// MaxBodySize is the maximum size of a response body in bytes, there is no limit if not set.
+	// A larger body fails with a ResponseTooLargeError.
+	MaxBodySize int64
+	// MaxDecodeTime is how long reading and decoding a response body can take once its headers are received,
+	// there is no limit if not set. A slower body is closed and fails with a DecodeTimeoutError.
+	MaxDecodeTime time.Duration
+}
+
+// ResponseTooLargeError is returned when a response body exceeds ResponseLimits.MaxBodySize
+type ResponseTooLargeError struct {
+	Method string
+	Path   string
+	Limit  int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response to %s %s exceeds the limit of %d bytes", e.Method, e.Path, e.Limit)
+}
+
+// IsResponseTooLargeError returns true if the error is, or wraps, a ResponseTooLargeError
+func IsResponseTooLargeError(err error) bool {
+	var tooLarge *ResponseTooLargeError
+	return errors.As(err, &tooLarge)
+}
+
+// DecodeTimeoutError is returned when a response body is not read and decoded within ResponseLimits.MaxDecodeTime
+type DecodeTimeoutError struct {
+	Method string
+	Path   string
+	Limit  time.Duration
+}
+
+func (e *DecodeTimeoutError) Error() string {
+	return fmt.Sprintf("response to %s %s not decoded within %s", e.Method, e.Path, e.Limit)
+}
+
+// IsDecodeTimeoutError returns true if the error is, or wraps, a DecodeTimeoutError
+func IsDecodeTimeoutError(err error) bool {
+	var timeout *DecodeTimeoutError
+	return errors.As(err, &timeout)
+}
+
+// isResponseLimitError returns true if the error is due to the ResponseLimits, so that the request is not retried
+func isResponseLimitError(err error) bool {
+	return IsResponseTooLargeError(err) || IsDecodeTimeoutError(err)
+}
+
+// apply replaces the body of the response with one enforcing the limits. A response announcing a length
+// above MaxBodySize is refused without reading its body.
+func (l *ResponseLimits) apply(req *http.Request, res *http.Response) error {
+	if l == nil || (l.MaxBodySize <= 0 && l.MaxDecodeTime <= 0) || isBinaryDownload(req, res) {
+		return nil
+	}
+	if l.MaxBodySize > 0 && res.ContentLength > l.MaxBodySize {
+		res.Body.Close() // #nosec G104
+		return &ResponseTooLargeError{Method: req.Method, Path: req.URL.Path, Limit: l.MaxBodySize}
+	}
+	body := &limitedBody{body: res.Body, method: req.Method, path: req.URL.Path, limits: *l, remaining: l.MaxBodySize}
+	if l.MaxDecodeTime > 0 {
+		// closing the body unblocks a read in progress
+		body.timer = time.AfterFunc(l.MaxDecodeTime, func() {
+			body.expired.Store(true)
+			body.body.Close() // #nosec G104
+		})
+	}
+	res.Body = body
+	return nil
+}
+
+// isBinaryDownload returns true if the request asks for, or the response is, a binary stream
+func isBinaryDownload(req *http.Request, res *http.Response) bool {
+	for _, contentType := range []string{req.Header.Get(HeaderKeyAccept), res.Header.Get(HeaderKeyContentType)} {
+		if mediaType, _, err := mime.ParseMediaType(contentType); err == nil &&
+			(mediaType == "application/octet-stream" || mediaType == headerValContentTypeBinaryOctetStream) {
+			return true
+		}
+	}
+	return false
+}
+
+// limitedBody is a response body failing once more than MaxBodySize bytes are read or after MaxDecodeTime
+type limitedBody struct {
+	body      io.ReadCloser
+	method    string
+	path      string
+	limits    ResponseLimits
+	remaining int64
+	timer     *time.Timer
+	expired   atomic.Bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.expired.Load() {
+		return 0, b.timeoutError()
+	}
+	if b.limits.MaxBodySize > 0 {
+		if b.remaining < 0 {
+			return 0, b.tooLargeError()
+		}
+		// read one byte more than allowed to tell a body of exactly MaxBodySize from a larger one
+		if int64(len(p)) > b.remaining+1 {
+			p = p[:b.remaining+1]
+		}
+	}
+	n, err := b.body.Read(p)
+	if b.expired.Load() {
+		return 0, b.timeoutError()
+	}
+	if b.limits.MaxBodySize > 0 {
+		b.remaining -= int64(n)
+		if b.remaining < 0 {
+			return 0, b.tooLargeError()
+		}
+	}
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	if b.timer != nil {
+		b.timer.Stop()
+	}
+	return b.body.Close()
+}
+
+func (b *limitedBody) tooLargeError() error {
+	return &ResponseTooLargeError{Method: b.method, Path: b.path, Limit: b.limits.MaxBodySize}
+}
+
+func (b *limitedBody) timeoutError() error {
+	return &DecodeTimeoutError{Method: b.method, Path: b.path, Limit: b.limits.MaxDecodeTime}
+}
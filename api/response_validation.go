@@ -0,0 +1,79 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrInvalidResponse is wrapped by the error returned when a GET request still gets an empty or truncated
+// response after the retries of the ResponseValidation
+var ErrInvalidResponse = errors.New("empty or truncated response")
+
+// DefaultResponseValidationBackoff is the wait before retrying a GET with an invalid response when
+// ResponseValidation.Backoff is not set
+const DefaultResponseValidationBackoff = 500 * time.Millisecond
+
+// ResponseValidation checks the JSON responses of the GET requests, which Unisphere sometimes returns with a 200
+// status but an empty or truncated body, and retries the requests getting such responses. These retries do not
+// count against the RetryPolicy, as the request succeeded.
+type ResponseValidation struct {
+	// MaxRetries is how many times a GET with an invalid response is retried before failing with ErrInvalidResponse
+	MaxRetries int
+	// Backoff is the wait before every retry, DefaultResponseValidationBackoff is used if not set
+	Backoff time.Duration
+	// OnInvalidResponse, if set, is called with the request, the number of the attempt starting at 1, and the
+	// error, for every invalid response, e.g. to count them
+	OnInvalidResponse func(req *http.Request, attempt int, err error)
+}
+
+// validate reads the body of a successful JSON response to a GET request, and returns an error wrapping
+// ErrInvalidResponse if it is empty or truncated. The body is replaced so that it can still be decoded.
+// The other responses, e.g. downloads, are not read.
+func (v *ResponseValidation) validate(req *http.Request, res *http.Response) error {
+	if v == nil || req.Method != http.MethodGet || res.StatusCode != http.StatusOK ||
+		!strings.Contains(req.Header.Get(HeaderKeyAccept), "json") {
+		return nil
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close() // #nosec G104
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	switch {
+	case isResponseLimitError(err):
+		return err
+	case err != nil:
+		return fmt.Errorf("%w: %s", ErrInvalidResponse, err.Error())
+	case len(bytes.TrimSpace(body)) == 0:
+		return fmt.Errorf("%w: the body is empty", ErrInvalidResponse)
+	case !json.Valid(body):
+		return fmt.Errorf("%w: the body is not valid JSON (%d bytes)", ErrInvalidResponse, len(body))
+	}
+	return nil
+}
+
+// backoff returns the wait before retrying a request with an invalid response
+func (v *ResponseValidation) backoff() time.Duration {
+	if v.Backoff > 0 {
+		return v.Backoff
+	}
+	return DefaultResponseValidationBackoff
+}
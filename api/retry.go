@@ -0,0 +1,158 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultRetryBackoff is the wait before the first retry when RetryPolicy.Backoff is not set
+const DefaultRetryBackoff = time.Second
+
+// RetryPolicy is how failed requests are retried. The wait doubles after every retry.
+//
+// GET and HEAD requests are retried when they fail with a transport error or with a 502, 503 or 504 status.
+// The other requests may have been applied by the array before failing, e.g. a storage group edit answered by a
+// gateway timeout, so they are only retried if VerifyRetry is set and returns true, to prevent applying them twice.
+// Requests whose body cannot be read again are never retried.
+type RetryPolicy struct {
+	MaxRetries  int
+	Backoff     time.Duration
+	VerifyRetry IdempotencyCheck
+}
+
+// IdempotencyCheck is called before a failed POST, PUT, PATCH or DELETE request is retried, with the request and its body.
+// It returns true if the request can be sent again, e.g. after checking that the resource it creates does not exist.
+type IdempotencyCheck func(ctx context.Context, req *http.Request, body []byte) bool
+
+// isIdempotent returns true for the methods which can be retried without changing their outcome
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	}
+	return false
+}
+
+// isRetryableStatus returns true for the statuses returned by proxies and load balancers
+// when Unisphere is temporarily unavailable
+func isRetryableStatus(status int) bool {
+	return status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+}
+
+// canRetry returns true if the failed request can be sent again according to the retry policy
+func (p *RetryPolicy) canRetry(ctx context.Context, req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if isIdempotent(req.Method) {
+		return true
+	}
+	if p.VerifyRetry == nil {
+		return false
+	}
+	var body []byte
+	if req.GetBody != nil {
+		r, err := req.GetBody()
+		if err != nil {
+			return false
+		}
+		defer r.Close() // #nosec G307
+		if body, err = io.ReadAll(r); err != nil {
+			return false
+		}
+	}
+	return p.VerifyRetry(ctx, req, body)
+}
+
+// doWithRetry sends the request, retrying it according to the retry policy of the client
+func (c *client) doWithRetry(ctx context.Context, req *http.Request) (*http.Response, error) {
+	retries := 0
+	backoff := DefaultRetryBackoff
+	if c.retry != nil {
+		retries = c.retry.MaxRetries
+		if c.retry.Backoff > 0 {
+			backoff = c.retry.Backoff
+		}
+	}
+	invalidResponses := 0
+	for attempt := 0; ; attempt++ {
+		if err := c.limiter.wait(ctx, req.Method); err != nil {
+			return nil, err
+		}
+		if err := c.throttle.wait(ctx); err != nil {
+			return nil, err
+		}
+		res, err := c.http.Do(req)
+		if err == nil {
+			if err = c.limits.apply(req, res); err != nil {
+				return nil, err
+			}
+			if err = c.validation.validate(req, res); err != nil {
+				if isResponseLimitError(err) {
+					return nil, err
+				}
+				invalidResponses++
+				if c.validation.OnInvalidResponse != nil {
+					c.validation.OnInvalidResponse(req, invalidResponses, err)
+				}
+				if invalidResponses > c.validation.MaxRetries || ctx.Err() != nil {
+					return nil, fmt.Errorf("%s %s: %w", req.Method, req.URL.Path, err)
+				}
+				log.WithError(err).Warnf("%s %s returned an invalid response, retrying in %s", req.Method, req.URL.Path, c.validation.backoff())
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-time.After(c.validation.backoff()):
+				}
+				// the request succeeded, so the retries of the retry policy are kept
+				attempt--
+				continue
+			}
+		}
+		if attempt >= retries || ctx.Err() != nil || (err == nil && !isRetryableStatus(res.StatusCode)) {
+			return res, err
+		}
+		if !c.retry.canRetry(ctx, req) {
+			return res, err
+		}
+		if err != nil {
+			log.WithError(err).Warnf("%s %s failed, retrying in %s", req.Method, req.URL.Path, backoff)
+		} else {
+			log.Warnf("%s %s returned %d, retrying in %s", req.Method, req.URL.Path, res.StatusCode, backoff)
+			res.Body.Close() // #nosec G104
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+	}
+}
@@ -0,0 +1,85 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type (
+	allowDestructiveKey     struct{}
+	destructiveOperationKey struct{}
+)
+
+// WithAllowDestructive returns a context in which destructive operations are, or are not, allowed,
+// whatever the safe mode of the client
+func WithAllowDestructive(ctx context.Context, allow bool) context.Context {
+	return context.WithValue(ctx, allowDestructiveKey{}, allow)
+}
+
+// WithDestructiveOperation returns a context marking the requests sent with it as the destructive operation,
+// e.g. "Failover", so that they are refused in safe mode. DELETE requests are always destructive.
+func WithDestructiveOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, destructiveOperationKey{}, operation)
+}
+
+// DestructiveOperationError is returned, instead of sending the request, for every destructive operation
+// made in safe mode: DELETE requests and the requests marked by WithDestructiveOperation
+type DestructiveOperationError struct {
+	Operation string
+	Method    string
+	Path      string
+}
+
+func (e *DestructiveOperationError) Error() string {
+	return fmt.Sprintf("safe mode: %s (%s %s) refused, destructive operations are not allowed", e.Operation, e.Method, e.Path)
+}
+
+// IsDestructiveOperationError returns true if the error is, or wraps, a DestructiveOperationError
+func IsDestructiveOperationError(err error) bool {
+	var destructive *DestructiveOperationError
+	return errors.As(err, &destructive)
+}
+
+func (c *client) CheckRequest(ctx context.Context, method, path string) error {
+	if c.isDryRun(ctx, method) {
+		return &DryRunError{Method: method, Path: path}
+	}
+	return c.checkDestructive(ctx, &http.Request{Method: method, URL: &url.URL{Path: path}})
+}
+
+// checkDestructive returns a DestructiveOperationError if the request is a destructive operation which is not allowed
+func (c *client) checkDestructive(ctx context.Context, req *http.Request) error {
+	_, _, safeMode := c.modes()
+	allowed := !safeMode
+	if allow, ok := ctx.Value(allowDestructiveKey{}).(bool); ok {
+		allowed = allow
+	}
+	if allowed {
+		return nil
+	}
+	operation, _ := ctx.Value(destructiveOperationKey{}).(string)
+	if operation == "" && req.Method == http.MethodDelete {
+		operation = "Delete"
+	}
+	if operation == "" {
+		return nil
+	}
+	return &DestructiveOperationError{Operation: operation, Method: req.Method, Path: req.URL.RequestURI()}
+}
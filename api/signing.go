@@ -0,0 +1,51 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+)
+
+// HeaderKeySignature is the header set by NewHMACSigner when no header is given
+const HeaderKeySignature = "X-Request-Signature"
+
+// RequestSigner is called with every request, once all its headers are set and just before
+// it is sent, along with the request body. It may add headers to the request, and an error
+// returned by it fails the request.
+type RequestSigner func(req *http.Request, body []byte) error
+
+// NewHMACSigner returns a RequestSigner which sets header, or HeaderKeySignature if empty, to the
+// hex encoded HMAC-SHA256, keyed with key, of the request method, path with query, and body,
+// separated by newlines
+func NewHMACSigner(key []byte, header string) RequestSigner {
+	if header == "" {
+		header = HeaderKeySignature
+	}
+	return func(req *http.Request, body []byte) error {
+		req.Header.Set(header, SignRequest(key, req.Method, req.URL.RequestURI(), body))
+		return nil
+	}
+}
+
+// SignRequest returns the signature set by NewHMACSigner, so that it can be checked on the receiving side
+func SignRequest(key []byte, method, requestURI string, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(method + "\n" + requestURI + "\n"))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
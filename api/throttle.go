@@ -0,0 +1,146 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+	log "github.com/sirupsen/logrus"
+)
+
+// AdaptiveThrottle defaults
+const (
+	DefaultThrottleThreshold     = 70.0
+	DefaultThrottleMaxDelay      = 2 * time.Second
+	DefaultThrottleProbeInterval = 30 * time.Second
+)
+
+// AdaptiveThrottle slows down the requests when Unisphere reports a high load, so that a busy management
+// server is not pushed further into cascading timeouts. Below Threshold the requests are not delayed;
+// above it, every request is delayed in proportion to the load, up to MaxDelay at a load of 100%.
+//
+// The load is set with Update, or probed in the background with Probe every ProbeInterval, until the probe
+// reports that Unisphere does not serve the load.
+type AdaptiveThrottle struct {
+	// Threshold is the load, in percent, above which the requests are delayed, DefaultThrottleThreshold if not set
+	Threshold float64
+	// MaxDelay is the delay of the requests at a load of 100%, DefaultThrottleMaxDelay if not set
+	MaxDelay time.Duration
+	// ProbeInterval is the age of the load after which Probe is called again, DefaultThrottleProbeInterval if not set
+	ProbeInterval time.Duration
+	// Probe, if set, returns the load of Unisphere in percent. It is called in the background, without delaying
+	// the request which triggered it, and the load is left unchanged if it fails. A types.Error with a 404 status,
+	// returned by a Unisphere which does not report its load, stops the probes.
+	Probe func(ctx context.Context) (float64, error)
+
+	mu          sync.Mutex
+	load        float64
+	probedAt    time.Time
+	probing     bool
+	unsupported bool
+}
+
+// Update sets the load of Unisphere, in percent
+func (t *AdaptiveThrottle) Update(load float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.load = load
+	t.probedAt = time.Now()
+}
+
+// Delay returns the delay of the requests at the current load
+func (t *AdaptiveThrottle) Delay() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.delayAt(t.load)
+}
+
+// delayAt returns the delay of the requests at the given load
+func (t *AdaptiveThrottle) delayAt(load float64) time.Duration {
+	threshold, maxDelay := t.Threshold, t.MaxDelay
+	if threshold <= 0 || threshold >= 100 {
+		threshold = DefaultThrottleThreshold
+	}
+	if maxDelay <= 0 {
+		maxDelay = DefaultThrottleMaxDelay
+	}
+	if load <= threshold {
+		return 0
+	}
+	excess := (load - threshold) / (100 - threshold)
+	if excess > 1 {
+		excess = 1
+	}
+	return time.Duration(excess * float64(maxDelay))
+}
+
+// wait delays a request according to the current load, or until ctx is done, and starts a probe if the load is stale
+func (t *AdaptiveThrottle) wait(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	interval := t.ProbeInterval
+	if interval <= 0 {
+		interval = DefaultThrottleProbeInterval
+	}
+	if t.Probe != nil && !t.probing && !t.unsupported && time.Since(t.probedAt) >= interval {
+		t.probing = true
+		go t.probe(interval)
+	}
+	delay := t.delayAt(t.load)
+	t.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// probe updates the load with the result of Probe, bounded by timeout
+func (t *AdaptiveThrottle) probe(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	load, err := t.Probe(ctx)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.probing = false
+	t.probedAt = time.Now()
+	var apiErr *types.Error
+	if errors.As(err, &apiErr) && apiErr.HTTPStatusCode == http.StatusNotFound {
+		t.unsupported = true
+		log.WithError(err).Warn("Unisphere does not report its load, the requests are no longer probed for it")
+		return
+	}
+	if err != nil {
+		log.WithError(err).Warn("Unable to probe the load of Unisphere, keeping the last known load")
+		return
+	}
+	if load != t.load {
+		log.Debugf("Unisphere load is %.0f%%, delaying requests by %s", load, t.delayAt(load))
+	}
+	t.load = load
+}
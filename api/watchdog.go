@@ -0,0 +1,78 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// requestWatch warns about a request still in flight every threshold, until it is stopped
+type requestWatch struct {
+	mu        sync.Mutex
+	method    string
+	path      string
+	start     time.Time
+	threshold time.Duration
+	timer     *time.Timer
+	warnings  int
+	done      bool
+}
+
+// watchRequest starts watching a request if a slow request threshold is set. The returned
+// function has to be called once the response is received.
+func (c *client) watchRequest(req *http.Request) func() {
+	if c.slowRequestThreshold <= 0 {
+		return func() {}
+	}
+	w := &requestWatch{
+		method:    req.Method,
+		path:      req.URL.Path,
+		start:     time.Now(),
+		threshold: c.slowRequestThreshold,
+	}
+	w.mu.Lock()
+	w.timer = time.AfterFunc(w.threshold, w.warn)
+	w.mu.Unlock()
+	return w.stop
+}
+
+func (w *requestWatch) fields() log.Fields {
+	return log.Fields{"method": w.method, "path": w.path, "duration": time.Since(w.start).Round(time.Millisecond).String()}
+}
+
+func (w *requestWatch) warn() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.done {
+		return
+	}
+	w.warnings++
+	log.WithFields(w.fields()).Warn("Request to Unisphere still in flight")
+	w.timer = time.AfterFunc(w.threshold, w.warn)
+}
+
+func (w *requestWatch) stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.done = true
+	w.timer.Stop()
+	if w.warnings > 0 {
+		log.WithFields(w.fields()).Warn("Slow request to Unisphere completed")
+	}
+}
@@ -0,0 +1,225 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pmax
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetOrphanedResources returns the volumes, storage groups and port groups of an array which are not used,
+// and the snapshots past their time to live, e.g. for a garbage collection report. The four scans run in
+// parallel, and any error fails the whole report, so that a resource is never reported by mistake.
+func (c *Client) GetOrphanedResources(ctx context.Context, symID string) (*types.OrphanedResources, error) {
+	defer c.TimeSpent("GetOrphanedResources", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+
+	orphans := &types.OrphanedResources{SymmetrixID: symID, ScannedAt: time.Now().UTC()}
+	scans := []func() error{
+		func() (err error) {
+			orphans.Volumes, err = c.GetOrphanedVolumes(ctx, symID)
+			return err
+		},
+		func() (err error) {
+			orphans.StorageGroups, err = c.GetOrphanedStorageGroups(ctx, symID)
+			return err
+		},
+		func() (err error) {
+			orphans.PortGroups, err = c.GetOrphanedPortGroups(ctx, symID)
+			return err
+		},
+		func() (err error) {
+			orphans.ExpiredSnapshots, err = c.GetExpiredSnapshots(ctx, symID)
+			return err
+		},
+	}
+	var wg sync.WaitGroup
+	errs := make([]error, len(scans))
+	for i, scan := range scans {
+		wg.Add(1)
+		go func(i int, scan func() error) {
+			defer wg.Done()
+			errs[i] = scan()
+		}(i, scan)
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		log.Error("GetOrphanedResources failed: " + err.Error())
+		return nil, err
+	}
+	log.Info(fmt.Sprintf("Array %s has %d orphaned volumes, %d orphaned storage groups, %d orphaned port groups and %d expired snapshots",
+		symID, len(orphans.Volumes), len(orphans.StorageGroups), len(orphans.PortGroups), len(orphans.ExpiredSnapshots)))
+	return orphans, nil
+}
+
+// GetOrphanedVolumes returns the ids of the volumes of an array which are not in any storage group, reading all the
+// pages of the volume list
+func (c *Client) GetOrphanedVolumes(ctx context.Context, symID string) ([]string, error) {
+	defer c.TimeSpent("GetOrphanedVolumes", time.Now())
+	volumeIDs, err := c.GetVolumeIDListWithParams(ctx, symID, map[string]string{"in_storage_group": "false"})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list the volumes not in a storage group: %w", err)
+	}
+	slices.Sort(volumeIDs)
+	return volumeIDs, nil
+}
+
+// GetOrphanedStorageGroups returns the ids of the storage groups of an array which are not in any masking view.
+// A child storage group is not orphaned if one of its parents is in a masking view.
+func (c *Client) GetOrphanedStorageGroups(ctx context.Context, symID string) ([]string, error) {
+	defer c.TimeSpent("GetOrphanedStorageGroups", time.Now())
+	sgs, err := c.getAllStorageGroups(ctx, symID)
+	if err != nil {
+		return nil, err
+	}
+	masked := make(map[string]bool, len(sgs))
+	for _, sg := range sgs {
+		masked[sg.StorageGroupID] = len(sg.MaskingView) > 0
+	}
+	orphans := []string{}
+	for _, sg := range sgs {
+		if masked[sg.StorageGroupID] || slices.ContainsFunc(sg.ParentStorageGroup, func(parent string) bool { return masked[parent] }) {
+			continue
+		}
+		orphans = append(orphans, sg.StorageGroupID)
+	}
+	slices.Sort(orphans)
+	return orphans, nil
+}
+
+// GetOrphanedPortGroups returns the ids of the port groups of an array which are not in any masking view
+func (c *Client) GetOrphanedPortGroups(ctx context.Context, symID string) ([]string, error) {
+	defer c.TimeSpent("GetOrphanedPortGroups", time.Now())
+	list, err := c.GetPortGroupList(ctx, symID, "")
+	if err != nil {
+		return nil, fmt.Errorf("cannot list port groups: %w", err)
+	}
+	unused := make([]bool, len(list.PortGroupIDs))
+	err = fetchInParallel(len(list.PortGroupIDs), func(i int) error {
+		pg, err := c.GetPortGroupByID(ctx, symID, list.PortGroupIDs[i])
+		if err != nil {
+			return fmt.Errorf("cannot read port group %s: %w", list.PortGroupIDs[i], err)
+		}
+		unused[i] = pg.NumberMaskingViews == 0 && len(pg.MaskingView) == 0
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	orphans := []string{}
+	for i, portGroupID := range list.PortGroupIDs {
+		if unused[i] {
+			orphans = append(orphans, portGroupID)
+		}
+	}
+	slices.Sort(orphans)
+	return orphans, nil
+}
+
+// GetExpiredSnapshots returns the generations of the storage group snapshots of an array which are past their time to live.
+// The storage groups are scanned in parallel.
+func (c *Client) GetExpiredSnapshots(ctx context.Context, symID string) ([]types.ExpiredSnapshot, error) {
+	defer c.TimeSpent("GetExpiredSnapshots", time.Now())
+	list, err := c.GetStorageGroupIDList(ctx, symID, "", false)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list storage groups: %w", err)
+	}
+	expired := make([][]types.ExpiredSnapshot, len(list.StorageGroupIDs))
+	err = fetchInParallel(len(list.StorageGroupIDs), func(i int) (err error) {
+		expired[i], err = c.getExpiredStorageGroupSnapshots(ctx, symID, list.StorageGroupIDs[i])
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	snapshots := slices.Concat(expired...)
+	if snapshots == nil {
+		snapshots = []types.ExpiredSnapshot{}
+	}
+	slices.SortFunc(snapshots, func(a, b types.ExpiredSnapshot) int {
+		if a.StorageGroupID != b.StorageGroupID {
+			return cmp.Compare(a.StorageGroupID, b.StorageGroupID)
+		}
+		if a.SnapshotName != b.SnapshotName {
+			return cmp.Compare(a.SnapshotName, b.SnapshotName)
+		}
+		return cmp.Compare(a.SnapID, b.SnapID)
+	})
+	return snapshots, nil
+}
+
+func (c *Client) getExpiredStorageGroupSnapshots(ctx context.Context, symID, storageGroupID string) ([]types.ExpiredSnapshot, error) {
+	snapshots, err := c.GetStorageGroupSnapshots(ctx, symID, storageGroupID, false, false)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list the snapshots of storage group %s: %w", storageGroupID, err)
+	}
+	var expired []types.ExpiredSnapshot
+	for _, snapshotName := range snapshots.Name {
+		snapIDs, err := c.GetStorageGroupSnapshotSnapIDs(ctx, symID, storageGroupID, snapshotName)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list the generations of snapshot %s of storage group %s: %w", snapshotName, storageGroupID, err)
+		}
+		for _, snapID := range snapIDs.SnapIDs {
+			snap, err := c.GetStorageGroupSnapshotSnap(ctx, symID, storageGroupID, snapshotName, strconv.FormatInt(snapID, 10))
+			if err != nil {
+				return nil, fmt.Errorf("cannot read snapshot %s %d of storage group %s: %w", snapshotName, snapID, storageGroupID, err)
+			}
+			if !snap.Expired {
+				continue
+			}
+			expired = append(expired, types.ExpiredSnapshot{
+				StorageGroupID:       storageGroupID,
+				SnapshotName:         snapshotName,
+				SnapID:               snap.SnapID,
+				Generation:           snap.Generation,
+				Timestamp:            snap.Timestamp,
+				TimeToLiveExpiryDate: snap.TimeToLiveExpiryDate,
+				Linked:               snap.Linked,
+			})
+		}
+	}
+	return expired, nil
+}
+
+// getAllStorageGroups reads all the storage groups of an array in parallel
+func (c *Client) getAllStorageGroups(ctx context.Context, symID string) ([]*types.StorageGroup, error) {
+	list, err := c.GetStorageGroupIDList(ctx, symID, "", false)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list storage groups: %w", err)
+	}
+	sgs := make([]*types.StorageGroup, len(list.StorageGroupIDs))
+	err = fetchInParallel(len(sgs), func(i int) (err error) {
+		sgs[i], err = c.GetStorageGroup(ctx, symID, list.StorageGroupIDs[i])
+		if err != nil {
+			return fmt.Errorf("cannot read storage group %s: %w", list.StorageGroupIDs[i], err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sgs, nil
+}
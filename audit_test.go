@@ -0,0 +1,115 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pmax
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+func TestGetOrphanedResources(t *testing.T) {
+	symURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id"
+	snapURL := urlPrefix + Replication + SymmetrixX + "mock-sym-id" + XStorageGroup
+	failSnapshots := false
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.RequestURI {
+		case symURL + XVolume + "?in_storage_group=false":
+			body = &types.VolumeIterator{ID: "it1", Count: 3, MaxPageSize: 2, ResultList: types.VolumeResultList{
+				VolumeList: []types.VolumeIDList{{VolumeIDs: "00009"}, {VolumeIDs: "00003"}}, From: 1, To: 2,
+			}}
+		case "/" + RESTPrefix + IteratorX + "it1" + XPage + "?from=3&to=3":
+			body = &types.VolumeResultList{VolumeList: []types.VolumeIDList{{VolumeIDs: "00001"}}, From: 3, To: 3}
+		case "/" + RESTPrefix + IteratorX + "it1":
+		case symURL + XStorageGroup:
+			body = &types.StorageGroupIDList{StorageGroupIDs: []string{"parent", "child", "empty", "masked"}}
+		case symURL + XStorageGroup + "/parent":
+			body = &types.StorageGroup{StorageGroupID: "parent", MaskingView: []string{"mv1"}, ChildStorageGroup: []string{"child"}}
+		case symURL + XStorageGroup + "/child":
+			body = &types.StorageGroup{StorageGroupID: "child", ParentStorageGroup: []string{"parent"}}
+		case symURL + XStorageGroup + "/empty":
+			body = &types.StorageGroup{StorageGroupID: "empty"}
+		case symURL + XStorageGroup + "/masked":
+			body = &types.StorageGroup{StorageGroupID: "masked", MaskingView: []string{"mv2"}}
+		case symURL + XPortGroup:
+			body = &types.PortGroupList{PortGroupIDs: []string{"pg2", "pg1"}}
+		case symURL + XPortGroup + "/pg1":
+			body = &types.PortGroup{PortGroupID: "pg1", NumberMaskingViews: 1, MaskingView: []string{"mv1"}}
+		case symURL + XPortGroup + "/pg2":
+			body = &types.PortGroup{PortGroupID: "pg2"}
+		case snapURL + "/empty" + XSnapshot:
+			if failSnapshots {
+				resp.WriteHeader(http.StatusInternalServerError)
+				_, _ = resp.Write([]byte(`{"message":"internal error","httpStatusCode":500,"errorCode":0}`))
+				return
+			}
+			body = &types.StorageGroupSnapshot{Name: []string{"daily"}}
+		case snapURL + "/empty" + XSnapshot + "/daily" + SnapID:
+			body = &types.SnapID{SnapIDs: []int64{11, 12}}
+		case snapURL + "/empty" + XSnapshot + "/daily" + SnapID + "/11":
+			body = &types.StorageGroupSnap{Name: "daily", SnapID: 11, Generation: 1, Expired: true, Linked: true}
+		case snapURL + "/empty" + XSnapshot + "/daily" + SnapID + "/12":
+			body = &types.StorageGroupSnap{Name: "daily", SnapID: 12}
+		case snapURL + "/parent" + XSnapshot, snapURL + "/child" + XSnapshot, snapURL + "/masked" + XSnapshot:
+			body = &types.StorageGroupSnapshot{}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		if body == nil {
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+
+	orphans, err := client.GetOrphanedResources(ctx, "mock-sym-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(orphans.Volumes, []string{"00001", "00003", "00009"}) {
+		t.Errorf("unexpected orphaned volumes %v", orphans.Volumes)
+	}
+	if !slices.Equal(orphans.StorageGroups, []string{"empty"}) {
+		t.Errorf("unexpected orphaned storage groups %v", orphans.StorageGroups)
+	}
+	if !slices.Equal(orphans.PortGroups, []string{"pg2"}) {
+		t.Errorf("unexpected orphaned port groups %v", orphans.PortGroups)
+	}
+	if len(orphans.ExpiredSnapshots) != 1 || orphans.ExpiredSnapshots[0] != (types.ExpiredSnapshot{
+		StorageGroupID: "empty", SnapshotName: "daily", SnapID: 11, Generation: 1, Linked: true,
+	}) {
+		t.Errorf("unexpected expired snapshots %+v", orphans.ExpiredSnapshots)
+	}
+
+	failSnapshots = true
+	if _, err = client.GetOrphanedResources(ctx, "mock-sym-id"); err == nil {
+		t.Error("expected an error when a scan fails")
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/dell/gopowermax/v2/api"
@@ -39,10 +40,24 @@ type Client struct {
 	contextTimeout time.Duration
 	opts           clientOpts
 	headers        clientHeaders
+	coalescer      *storageGroupCoalescer
+	discovery      *arrayDiscovery
+	thinPools      *thinPools
+	// supported holds the symID/method keys found supported by verifySupport
+	supported *sync.Map
 }
 
 type clientOpts struct {
-	logResponseTimes bool
+	logResponseTimes   bool
+	validateRDFActions bool
+	validateNames      bool
+	// filterModifiedSince is set by WithModifiedSinceFilter
+	filterModifiedSince bool
+	// verifySupport is set by WithVersionChecks
+	verifySupport bool
+	// validateInitiators and allowMixedInitiators are set by WithInitiatorValidation
+	validateInitiators   bool
+	allowMixedInitiators bool
 }
 
 type clientHeaders struct {
@@ -140,29 +155,54 @@ func NewClient() (client Pmax, err error) {
 
 // NewClientWithArgs allows the user to specify the endpoint, version, application name, insecure boolean, and useCerts boolean
 // as direct arguments rather than receiving them from the enviornment. See NewClient().
+// If certFile is a directory, all the certificates in it are trusted and reloaded periodically, so that
+// rotated certificates are picked up without creating a new client.
 func NewClientWithArgs(
 	endpoint string,
 	applicationName string,
 	insecure,
 	useCerts bool,
 	certFile string,
+) (client Pmax, err error) {
+	opts := api.ClientOptions{
+		Insecure: insecure,
+		UseCerts: useCerts,
+		ShowHTTP: debug,
+		CertFile: certFile,
+	}
+	if info, err := os.Stat(certFile); certFile != "" && err == nil && info.IsDir() {
+		opts.CertFile = ""
+		opts.CertDir = certFile
+	}
+	return newClient(endpoint, applicationName, opts, 0)
+}
+
+// newClient creates the client shared by NewClientWithArgs and New.
+// The timeout of the Unisphere calls is taken from the environment when contextTimeout is 0.
+func newClient(
+	endpoint string,
+	applicationName string,
+	opts api.ClientOptions,
+	contextTimeout time.Duration,
 ) (client Pmax, err error) {
 	setLogResponseTimes, _ := strconv.ParseBool(os.Getenv("X_CSI_POWERMAX_RESPONSE_TIMES"))
 
-	contextTimeout := defaultPmaxTimeout
-	if timeoutStr := os.Getenv("X_CSI_UNISPHERE_TIMEOUT"); timeoutStr != "" {
-		if timeout, err := time.ParseDuration(timeoutStr); err != nil {
-			doLog(log.WithError(err).Error, "Unable to parse Unisphere timout")
-		} else {
-			contextTimeout = timeout
+	if contextTimeout <= 0 {
+		contextTimeout = defaultPmaxTimeout
+		if timeoutStr := os.Getenv("X_CSI_UNISPHERE_TIMEOUT"); timeoutStr != "" {
+			if timeout, err := time.ParseDuration(timeoutStr); err != nil {
+				doLog(log.WithError(err).Error, "Unable to parse Unisphere timout")
+			} else {
+				contextTimeout = timeout
+			}
 		}
 	}
 
 	fields := map[string]interface{}{
 		"endpoint":         endpoint,
 		"applicationName":  applicationName,
-		"insecure":         insecure,
-		"useCerts":         useCerts,
+		"insecure":         opts.Insecure,
+		"useCerts":         opts.UseCerts,
 		"version":          DefaultAPIVersion,
 		"debug":            debug,
 		"logResponseTimes": setLogResponseTimes,
@@ -175,13 +215,6 @@ func NewClientWithArgs(
 		return nil, fmt.Errorf("Endpoint must be supplied, e.g. https://1.2.3.4:8443")
 	}
 
-	opts := api.ClientOptions{
-		Insecure: insecure,
-		UseCerts: useCerts,
-		ShowHTTP: debug,
-		CertFile: certFile,
-	}
-
 	ac, err := api.New(endpoint, opts, debug)
 	if err != nil {
 		doLog(log.WithError(err).Error, "Unable to create HTTP client")
@@ -196,6 +229,10 @@ func NewClientWithArgs(
 			Version: DefaultAPIVersion,
 		},
 		allowedArrays:  []string{},
+		discovery:      &arrayDiscovery{},
+		coalescer:      newStorageGroupCoalescer(),
+		supported:      &sync.Map{},
+		thinPools:      &thinPools{},
 		version:        DefaultAPIVersion,
 		contextTimeout: contextTimeout,
 		opts: clientOpts{
@@ -224,6 +261,29 @@ func (c *Client) SetContextTimeout(timeout time.Duration) Pmax {
 	return c
 }
 
+// SetRequestSigner sets the callback used to sign every request sent to Unisphere
+func (c *Client) SetRequestSigner(signer api.RequestSigner) {
+	c.api.SetRequestSigner(signer)
+}
+
+// SetDryRun enables or disables the dry-run mode, in which the calls changing the array only log the request
+// they would send and return it as an *api.DryRunError. It can be overridden per call with api.WithDryRun.
+func (c *Client) SetDryRun(dryRun bool) {
+	c.api.SetDryRun(dryRun)
+}
+
+// SetSafeMode enables or disables the safe mode, in which the destructive calls, the deletions, failovers, swaps,
+// restores, formats and deallocations, return an *api.DestructiveOperationError instead of changing the array.
+// It can be overridden per call with api.WithAllowDestructive.
+func (c *Client) SetSafeMode(safeMode bool) {
+	c.api.SetSafeMode(safeMode)
+}
+
+// SetJournal sets the journal in which the calls changing the array are recorded, with their payload and result
+func (c *Client) SetJournal(journal api.Journal) {
+	c.api.SetJournal(journal)
+}
+
 func (c *Client) getDefaultHeaders() map[string]string {
 	headers := make(map[string]string)
 	headers["Accept"] = c.headers.accept
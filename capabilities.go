@@ -0,0 +1,199 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pmax
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+	log "github.com/sirupsen/logrus"
+)
+
+// Capability is the minimum Unisphere and PowerMaxOS versions required by a client method.
+// An empty version means that any version is supported.
+type Capability struct {
+	// MinUnisphere is a Unisphere version, e.g. "10.0"
+	MinUnisphere string
+	// MinPowerMaxOS is a PowerMaxOS (ucode) version, e.g. "6079"
+	MinPowerMaxOS string
+}
+
+// Capabilities maps the client methods to the versions they require. The methods not listed are
+// supported by all the Unisphere versions the client can connect to. The methods listed check their
+// requirements with VerifySupport before sending any request if the client is created WithVersionChecks.
+var Capabilities = map[string]Capability{
+	// snapshot policies
+	"GetSnapshotPolicy":                 {MinUnisphere: "9.2", MinPowerMaxOS: "5978.669"},
+	"GetSnapshotPolicyList":             {MinUnisphere: "9.2", MinPowerMaxOS: "5978.669"},
+	"CreateSnapshotPolicy":              {MinUnisphere: "9.2", MinPowerMaxOS: "5978.669"},
+	"UpdateSnapshotPolicy":              {MinUnisphere: "9.2", MinPowerMaxOS: "5978.669"},
+	"DeleteSnapshotPolicy":              {MinUnisphere: "9.2", MinPowerMaxOS: "5978.669"},
+	"SuspendSnapshotPolicy":             {MinUnisphere: "9.2", MinPowerMaxOS: "5978.669"},
+	"ResumeSnapshotPolicy":              {MinUnisphere: "9.2", MinPowerMaxOS: "5978.669"},
+	"ModifySnapshotPolicies":            {MinUnisphere: "9.2", MinPowerMaxOS: "5978.669"},
+	"GetSnapshotPolicyStorageGroupList": {MinUnisphere: "9.2", MinPowerMaxOS: "5978.669"},
+	"GetSnapshotPolicySummary":          {MinUnisphere: "9.2", MinPowerMaxOS: "5978.669"},
+	"GetStorageGroupSnapshotPolicy":     {MinUnisphere: "9.2", MinPowerMaxOS: "5978.669"},
+	"GetStorageGroupPolicySnapshots":    {MinUnisphere: "9.2", MinPowerMaxOS: "5978.669"},
+	// file
+	"GetFileSystemList":    {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	"GetFileSystemByID":    {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	"CreateFileSystem":     {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	"ModifyFileSystem":     {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	"DeleteFileSystem":     {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	"GetNFSExportList":     {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	"GetNFSExportByID":     {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	"CreateNFSExport":      {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	"ModifyNFSExport":      {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	"DeleteNFSExport":      {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	"GetNASServerList":     {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	"GetNASServerByID":     {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	"ModifyNASServer":      {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	"DeleteNASServer":      {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	"GetFileInterfaceByID": {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	// file system snapshots
+	"GetFileSystemSnapshotList": {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	"GetFileSystemSnapshotByID": {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	"CreateFileSystemSnapshot":  {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	"RestoreFileSystemSnapshot": {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	"DeleteFileSystemSnapshot":  {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	"CreateNFSExportOnSnapshot": {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	// file system performance
+	"GetFileSystemMetricsByID": {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+	// volumes
+	"ModifyMobilityForVolume": {MinUnisphere: "10.0", MinPowerMaxOS: "6079"},
+}
+
+// UnsupportedVersionError is returned by VerifySupport when the connected Unisphere, or the array,
+// is too old for a client method
+type UnsupportedVersionError struct {
+	Method string
+	// Component is "Unisphere" or "PowerMaxOS"
+	Component   string
+	SymmetrixID string
+	Required    string
+	Actual      string
+}
+
+func (e *UnsupportedVersionError) Error() string {
+	if e.Component == "PowerMaxOS" {
+		return fmt.Sprintf("%s requires PowerMaxOS %s or later, array %s runs %s", e.Method, e.Required, e.SymmetrixID, e.Actual)
+	}
+	return fmt.Sprintf("%s requires Unisphere %s or later, connected to %s", e.Method, e.Required, e.Actual)
+}
+
+// IsUnsupportedVersionError returns true if the error is, or wraps, an UnsupportedVersionError
+func IsUnsupportedVersionError(err error) bool {
+	var unsupported *UnsupportedVersionError
+	return errors.As(err, &unsupported)
+}
+
+// GetUnisphereVersion returns the version of the connected Unisphere, e.g. V10.0.0.1
+func (c *Client) GetUnisphereVersion(ctx context.Context) (*types.Version, error) {
+	defer c.TimeSpent("GetUnisphereVersion", time.Now())
+	URL := RESTPrefix + "version"
+	version := &types.Version{}
+	if err := c.getWithTimeout(ctx, URL, version); err != nil {
+		log.Error("GetUnisphereVersion failed: " + err.Error())
+		return nil, err
+	}
+	return version, nil
+}
+
+// VerifySupport checks that the connected Unisphere and, if symID is not empty, the array support the client
+// method, according to Capabilities. It returns an UnsupportedVersionError if they do not, so that callers can
+// report a clear error instead of the 404 returned by Unisphere. Methods not in Capabilities are supported.
+func (c *Client) VerifySupport(ctx context.Context, symID, method string) error {
+	defer c.TimeSpent("VerifySupport", time.Now())
+	capability, ok := Capabilities[method]
+	if !ok {
+		return nil
+	}
+	if capability.MinUnisphere != "" {
+		version, err := c.GetUnisphereVersion(ctx)
+		if err != nil {
+			return err
+		}
+		actual := strings.TrimLeft(version.Version, "VvTt")
+		if compareVersions(actual, capability.MinUnisphere) < 0 {
+			return &UnsupportedVersionError{Method: method, Component: "Unisphere", Required: capability.MinUnisphere, Actual: version.Version}
+		}
+	}
+	if capability.MinPowerMaxOS != "" && symID != "" {
+		symmetrix, err := c.GetSymmetrixByID(ctx, symID)
+		if err != nil {
+			return err
+		}
+		if compareVersions(symmetrix.Ucode, capability.MinPowerMaxOS) < 0 {
+			return &UnsupportedVersionError{Method: method, Component: "PowerMaxOS", SymmetrixID: symID, Required: capability.MinPowerMaxOS, Actual: symmetrix.Ucode}
+		}
+	}
+	return nil
+}
+
+// verifySupport calls VerifySupport for a method listed in Capabilities if the client is created WithVersionChecks.
+// The methods found supported on an array are not checked again on it.
+func (c *Client) verifySupport(ctx context.Context, symID, method string) error {
+	if !c.opts.verifySupport {
+		return nil
+	}
+	key := symID + "/" + method
+	if _, ok := c.supported.Load(key); ok {
+		return nil
+	}
+	if err := c.VerifySupport(ctx, symID, method); err != nil {
+		log.Error(method + " failed: " + err.Error())
+		return err
+	}
+	c.supported.Store(key, true)
+	return nil
+}
+
+// compareVersions compares two dotted versions, e.g. 9.2.1 and 10.0, returning -1, 0 or 1.
+// Missing parts count as 0, and only the leading digits of each part are compared.
+func compareVersions(a, b string) int {
+	partsA, partsB := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var x, y int
+		if i < len(partsA) {
+			x = leadingNumber(partsA[i])
+		}
+		if i < len(partsB) {
+			y = leadingNumber(partsB[i])
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
+// leadingNumber returns the number at the start of s, or 0
+func leadingNumber(s string) int {
+	end := 0
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+	n, _ := strconv.Atoi(s[:end])
+	return n
+}
@@ -0,0 +1,156 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pmax
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+func TestCapabilitiesAreClientMethods(t *testing.T) {
+	pmaxType := reflect.TypeOf((*Pmax)(nil)).Elem()
+	for method := range Capabilities {
+		if _, ok := pmaxType.MethodByName(method); !ok {
+			t.Errorf("%s is not a method of Pmax", method)
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	for _, tc := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"10.0.0", "10.0", 0},
+		{"10.0.0.1", "10.0", 1},
+		{"9.2.1.6", "10.0", -1},
+		{"10.1", "9.2", 1},
+		{"5978.669.669", "5978.669", 1},
+		{"5978.479.479", "5978.669", -1},
+		{"6079.175.0", "6079", 1},
+	} {
+		if actual := compareVersions(tc.a, tc.b); actual != tc.expected {
+			t.Errorf("compareVersions(%s, %s) = %d, expected %d", tc.a, tc.b, actual, tc.expected)
+		}
+	}
+}
+
+func TestVerifySupport(t *testing.T) {
+	unisphere, ucode := "V10.1.0.0", "6079.175.0"
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.URL.Path {
+		case "/" + RESTPrefix + "version":
+			body = &types.Version{Version: unisphere}
+		case urlPrefix + "system/symmetrix/sym-id":
+			body = &types.Symmetrix{SymmetrixID: "sym-id", Ucode: ucode}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Error(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+	if err = client.VerifySupport(ctx, "sym-id", "CreateFileSystem"); err != nil {
+		t.Fatal(err)
+	}
+	// methods without requirements are supported without any call
+	if err = client.VerifySupport(ctx, "unknown-sym-id", "GetVolumeByID"); err != nil {
+		t.Fatal(err)
+	}
+
+	ucode = "5978.711.711"
+	err = client.VerifySupport(ctx, "sym-id", "CreateFileSystem")
+	if !IsUnsupportedVersionError(err) || err.Error() != "CreateFileSystem requires PowerMaxOS 6079 or later, array sym-id runs 5978.711.711" {
+		t.Fatalf("unexpected error %v", err)
+	}
+	// the array is only checked if given
+	if err = client.VerifySupport(ctx, "", "CreateFileSystem"); err != nil {
+		t.Fatal(err)
+	}
+
+	unisphere = "V9.2.1.6"
+	err = client.VerifySupport(ctx, "", "GetFileSystemList")
+	if !IsUnsupportedVersionError(err) || err.Error() != "GetFileSystemList requires Unisphere 10.0 or later, connected to V9.2.1.6" {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err = client.VerifySupport(ctx, "sym-id", "GetSnapshotPolicyList"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWithVersionChecks(t *testing.T) {
+	versionRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.URL.Path {
+		case "/" + RESTPrefix + "version":
+			versionRequests++
+			body = &types.Version{Version: "V9.2.1.6"}
+		case urlPrefix + "system/symmetrix/sym-id":
+			body = &types.Symmetrix{SymmetrixID: "sym-id", Ucode: "5978.711.711"}
+		case urlPrefix + Replication + SymmetrixX + "sym-id" + SnapshotPolicy:
+			body = &types.SnapshotPolicyList{SnapshotPolicyIDs: []string{"policy"}}
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Error(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL, WithVersionChecks())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+	// the file systems are not queried on a Unisphere too old for them
+	if _, err = client.GetFileSystemList(ctx, "sym-id", nil); !IsUnsupportedVersionError(err) {
+		t.Fatalf("expected an UnsupportedVersionError, got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err = client.GetSnapshotPolicyList(ctx, "sym-id"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the support of the method is only checked once
+	if versionRequests != 2 {
+		t.Errorf("expected 2 version requests, got %d", versionRequests)
+	}
+}
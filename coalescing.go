@@ -0,0 +1,164 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pmax
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dell/gopowermax/v2/api"
+	types "github.com/dell/gopowermax/v2/types/v100"
+	log "github.com/sirupsen/logrus"
+)
+
+// storageGroupEdit identifies the calls which can be coalesced into a single storage group edit
+type storageGroupEdit struct {
+	operation      string
+	symID          string
+	storageGroupID string
+	force          bool
+}
+
+// storageGroupCall is a call coalesced into a storage group edit, with its own result
+type storageGroupCall struct {
+	volumeIDs []string
+	withdrawn bool
+	sg        *types.StorageGroup
+	err       error
+}
+
+// storageGroupBatch is the set of calls coalesced into one storage group edit. done is closed once the edit,
+// and the retries of the calls if it failed, are done.
+type storageGroupBatch struct {
+	calls []*storageGroupCall
+	sent  bool
+	done  chan struct{}
+}
+
+// storageGroupCoalescer coalesces the calls adding or removing volumes to or from the same storage group
+// within a window into a single edit. A window of 0 disables coalescing.
+type storageGroupCoalescer struct {
+	mu      sync.Mutex
+	window  time.Duration
+	pending map[storageGroupEdit]*storageGroupBatch
+}
+
+func newStorageGroupCoalescer() *storageGroupCoalescer {
+	return &storageGroupCoalescer{pending: make(map[storageGroupEdit]*storageGroupBatch)}
+}
+
+// enabled returns true if the calls made with ctx are coalesced. The calls whose context overrides the dry-run or
+// safe mode of the client, or carries request metadata, are sent on their own, as the edit is sent with one context.
+func (co *storageGroupCoalescer) enabled(ctx context.Context) bool {
+	if co == nil || api.HasRequestOverrides(ctx) {
+		return false
+	}
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	return co.window > 0
+}
+
+// do adds the call to the batch of the edit, opening it if needed, and returns the result of the call.
+// The batch is sent with edit once the window is closed, with the values of the context of the call opening it but
+// without its cancellation. A call whose context is done before the batch is sent is withdrawn from the batch.
+// If the edit of the batch fails, the volumes of each call are sent again on their own, so that a bad volume only
+// fails its own call.
+func (co *storageGroupCoalescer) do(ctx context.Context, key storageGroupEdit, volumeIDs []string,
+	edit func(ctx context.Context, volumeIDs []string) (*types.StorageGroup, error),
+) (*types.StorageGroup, error) {
+	call := &storageGroupCall{volumeIDs: volumeIDs}
+	co.mu.Lock()
+	batch, joined := co.pending[key]
+	if !joined {
+		batch = &storageGroupBatch{done: make(chan struct{})}
+		co.pending[key] = batch
+		editCtx := context.WithoutCancel(ctx)
+		time.AfterFunc(co.window, func() { co.send(editCtx, key, batch, edit) })
+	}
+	batch.calls = append(batch.calls, call)
+	co.mu.Unlock()
+
+	select {
+	case <-batch.done:
+		return call.sg, call.err
+	case <-ctx.Done():
+	}
+	co.mu.Lock()
+	if !batch.sent {
+		call.withdrawn = true
+		co.mu.Unlock()
+		return nil, ctx.Err()
+	}
+	co.mu.Unlock()
+	// the volumes of the call are being edited, the call returns the outcome
+	<-batch.done
+	return call.sg, call.err
+}
+
+// send closes the batch and sends the volumes of the calls which are not withdrawn
+func (co *storageGroupCoalescer) send(ctx context.Context, key storageGroupEdit, batch *storageGroupBatch,
+	edit func(ctx context.Context, volumeIDs []string) (*types.StorageGroup, error),
+) {
+	defer close(batch.done)
+	co.mu.Lock()
+	delete(co.pending, key)
+	batch.sent = true
+	var calls []*storageGroupCall
+	var volumeIDs []string
+	for _, call := range batch.calls {
+		if call.withdrawn {
+			continue
+		}
+		calls = append(calls, call)
+		for _, volumeID := range call.volumeIDs {
+			if !slices.Contains(volumeIDs, volumeID) {
+				volumeIDs = append(volumeIDs, volumeID)
+			}
+		}
+	}
+	co.mu.Unlock()
+	if len(calls) == 0 {
+		return
+	}
+	if len(calls) > 1 {
+		log.Info(fmt.Sprintf("Coalesced %d %s calls on storage group %s into one edit of %d volumes",
+			len(calls), key.operation, key.storageGroupID, len(volumeIDs)))
+	}
+	sg, err := edit(ctx, volumeIDs)
+	if err != nil && len(calls) > 1 {
+		log.Warn(fmt.Sprintf("Coalesced %s on storage group %s failed, sending the %d calls on their own: %s",
+			key.operation, key.storageGroupID, len(calls), err.Error()))
+		for _, call := range calls {
+			call.sg, call.err = edit(ctx, call.volumeIDs)
+		}
+		return
+	}
+	for _, call := range calls {
+		call.sg, call.err = sg, err
+	}
+}
+
+// SetStorageGroupCoalescing makes the calls adding volumes to, or removing volumes from, the same storage group
+// within window be sent as a single edit of the storage group, their volumes being merged. Every call returns the
+// result of the edit or, if it failed, of the edit of its own volumes. The calls whose context overrides the dry-run
+// or safe mode, or carries request metadata, are not coalesced. A window of 0 disables coalescing.
+func (c *Client) SetStorageGroupCoalescing(window time.Duration) {
+	c.coalescer.mu.Lock()
+	defer c.coalescer.mu.Unlock()
+	c.coalescer.window = max(window, 0)
+}
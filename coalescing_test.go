@@ -0,0 +1,157 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pmax
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dell/gopowermax/v2/api"
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+func TestStorageGroupCoalescing(t *testing.T) {
+	sgURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id" + XStorageGroup + "/sg-1"
+	var (
+		mu      sync.Mutex
+		added   [][]string
+		removed [][]string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPut || req.URL.Path != sgURL {
+			t.Errorf("unexpected request %s %s", req.Method, req.RequestURI)
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		payload := &types.UpdateStorageGroupPayload{}
+		if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if expand := payload.EditStorageGroupActionParam.ExpandStorageGroupParam; expand != nil {
+			added = append(added, expand.AddSpecificVolumeParam.VolumeIDs)
+		}
+		if remove := payload.EditStorageGroupActionParam.RemoveVolumeParam; remove != nil {
+			removed = append(removed, remove.VolumeIDs)
+		}
+		if remove := payload.EditStorageGroupActionParam.RemoveVolumeParam; remove != nil && slices.Contains(remove.VolumeIDs, "00009") {
+			resp.WriteHeader(http.StatusBadRequest)
+			_, _ = resp.Write([]byte(`{"message":"volume 00009 is not in the storage group","httpStatusCode":400,"errorCode":0}`))
+			return
+		}
+		_, _ = resp.Write([]byte(`{"storageGroupId":"sg-1","num_of_vols":2}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetStorageGroupCoalescing(50 * time.Millisecond)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 5)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = client.AddVolumesToStorageGroupS(context.TODO(), "mock-sym-id", "sg-1", false, fmt.Sprintf("0000%d", i), "00000")
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(added) != 1 {
+		t.Fatalf("expected the calls to be coalesced into one edit, got %v", added)
+	}
+	volumeIDs := slices.Clone(added[0])
+	slices.Sort(volumeIDs)
+	if !reflect.DeepEqual([]string{"00000", "00001", "00002", "00003", "00004"}, volumeIDs) {
+		t.Errorf("unexpected volumes %v", volumeIDs)
+	}
+
+	sgs := make([]*types.StorageGroup, 2)
+	for i := range sgs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sgs[i], errs[i] = client.RemoveVolumesFromStorageGroup(context.TODO(), "mock-sym-id", "sg-1", false, fmt.Sprintf("0000%d", i))
+		}(i)
+	}
+	wg.Wait()
+	if len(removed) != 1 || len(removed[0]) != 2 || sgs[0] == nil || sgs[0] != sgs[1] || sgs[0].StorageGroupID != "sg-1" {
+		t.Fatalf("expected the removals to be coalesced, got %v and %+v", removed, sgs)
+	}
+
+	// a failed edit is sent again for each call, and a cancelled call is withdrawn
+	removed = nil
+	cancelled, cancel := context.WithCancel(context.TODO())
+	cancel()
+	volumeIDs = []string{"00001", "00009", "00002"}
+	for i := range volumeIDs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx := context.TODO()
+			if i == 2 {
+				ctx = cancelled
+			}
+			_, errs[i] = client.RemoveVolumesFromStorageGroup(ctx, "mock-sym-id", "sg-1", false, volumeIDs[i])
+		}(i)
+	}
+	wg.Wait()
+	if errs[0] != nil || errs[1] == nil || !errors.Is(errs[2], context.Canceled) {
+		t.Fatalf("expected only the bad volume to fail and the cancelled call to be withdrawn, got %v", errs[:3])
+	}
+	if len(removed) != 3 || len(removed[0]) != 2 || slices.Contains(removed[0], "00002") {
+		t.Fatalf("expected the coalesced edit then one edit per call, got %v", removed)
+	}
+
+	// a dry-run call is not coalesced with the other calls
+	removed = nil
+	for i, ctx := range []context.Context{context.TODO(), api.WithDryRun(context.TODO(), true)} {
+		wg.Add(1)
+		go func(i int, ctx context.Context) {
+			defer wg.Done()
+			_, errs[i] = client.RemoveVolumesFromStorageGroup(ctx, "mock-sym-id", "sg-1", false, fmt.Sprintf("0000%d", i))
+		}(i, ctx)
+	}
+	wg.Wait()
+	if errs[0] != nil || !api.IsDryRunError(errs[1]) || len(removed) != 1 || !reflect.DeepEqual([]string{"00000"}, removed[0]) {
+		t.Fatalf("expected the dry-run call to be sent on its own, got %v and %v", errs[:2], removed)
+	}
+
+	client.SetStorageGroupCoalescing(0)
+	if err = client.AddVolumesToStorageGroupS(context.TODO(), "mock-sym-id", "sg-1", false, "00005"); err != nil {
+		t.Fatal(err)
+	}
+	if len(added) != 2 || !reflect.DeepEqual([]string{"00005"}, added[1]) {
+		t.Errorf("unexpected edits without coalescing %v", added)
+	}
+}
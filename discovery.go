@@ -0,0 +1,159 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pmax
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultArrayDiscoveryInterval is how long the arrays found by DiscoverArrays are cached when no interval is given
+const DefaultArrayDiscoveryInterval = 10 * time.Minute
+
+// UnknownArrayError is returned, before any request is sent to Unisphere, by calls referencing an array
+// which is not one of the arrays found by DiscoverArrays, e.g. because of a typo in its ID
+type UnknownArrayError struct {
+	SymmetrixID string
+	// Known are the arrays found by the last discovery
+	Known []string
+}
+
+func (e *UnknownArrayError) Error() string {
+	return fmt.Sprintf("the requested array (%s) is not known to Unisphere, the arrays are %v", e.SymmetrixID, e.Known)
+}
+
+// IsUnknownArrayError returns true if the error is, or wraps, an UnknownArrayError
+func IsUnknownArrayError(err error) bool {
+	var unknown *UnknownArrayError
+	return errors.As(err, &unknown)
+}
+
+// arrayDiscovery caches the arrays found by DiscoverArrays. It is shared by the copies of a client.
+type arrayDiscovery struct {
+	mu       sync.Mutex
+	interval time.Duration
+	// arrays is nil until DiscoverArrays is called, the symmetrix IDs are not checked until then
+	arrays *types.DiscoveredArrays
+	// refreshing is closed when the refresh in progress, if any, completes
+	refreshing chan struct{}
+}
+
+// DiscoverArrays returns the arrays managed by Unisphere, local and remote, and caches them so that the symmetrix ID
+// of every later call is checked against them, failing with an UnknownArrayError before any request is sent.
+// The cache is refreshed in the background by the first call made refreshInterval, or DefaultArrayDiscoveryInterval
+// if 0, after the previous discovery; a call referencing an unknown array then waits for the refresh, so that a
+// newly added array is found. A failed refresh keeps the arrays previously found.
+func (c *Client) DiscoverArrays(ctx context.Context, refreshInterval time.Duration) (*types.DiscoveredArrays, error) {
+	defer c.TimeSpent("DiscoverArrays", time.Now())
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultArrayDiscoveryInterval
+	}
+	arrays, err := c.fetchArrays(ctx)
+	if err != nil {
+		log.Error("DiscoverArrays failed: " + err.Error())
+		return nil, err
+	}
+	c.discovery.mu.Lock()
+	c.discovery.interval = refreshInterval
+	c.discovery.arrays = arrays
+	c.discovery.mu.Unlock()
+	log.Info(fmt.Sprintf("Discovered %d local and %d remote arrays", len(arrays.Local), len(arrays.Remote)))
+	return arrays, nil
+}
+
+// fetchArrays lists the arrays, and reads them in parallel to tell the local arrays from the remote ones
+func (c *Client) fetchArrays(ctx context.Context) (*types.DiscoveredArrays, error) {
+	list, err := c.GetSymmetrixIDList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	symmetrixes := make([]*types.Symmetrix, len(list.SymmetrixIDs))
+	err = fetchInParallel(len(symmetrixes), func(i int) (err error) {
+		symmetrixes[i], err = c.getSymmetrixByID(ctx, list.SymmetrixIDs[i])
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	arrays := &types.DiscoveredArrays{DiscoveredAt: time.Now()}
+	for _, symmetrix := range symmetrixes {
+		if symmetrix.Local {
+			arrays.Local = append(arrays.Local, symmetrix.SymmetrixID)
+		} else {
+			arrays.Remote = append(arrays.Remote, symmetrix.SymmetrixID)
+		}
+	}
+	return arrays, nil
+}
+
+// check returns an UnknownArrayError if arrays were discovered and symID is not one of them
+func (d *arrayDiscovery) check(c *Client, symID string) error {
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	if d.arrays == nil {
+		d.mu.Unlock()
+		return nil
+	}
+	known := d.arrays.Contains(symID)
+	var refreshed chan struct{}
+	if time.Since(d.arrays.DiscoveredAt) >= d.interval {
+		refreshed = d.refresh(c)
+	}
+	d.mu.Unlock()
+	if known {
+		return nil
+	}
+	if refreshed != nil {
+		<-refreshed
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.arrays.Contains(symID) {
+		return nil
+	}
+	return &UnknownArrayError{SymmetrixID: symID, Known: d.arrays.SymmetrixIDs()}
+}
+
+// refresh starts refreshing the arrays, unless a refresh is already in progress, and returns the channel closed
+// once it completes. It is called with the mutex held.
+func (d *arrayDiscovery) refresh(c *Client) chan struct{} {
+	if d.refreshing != nil {
+		return d.refreshing
+	}
+	refreshing := make(chan struct{})
+	d.refreshing = refreshing
+	go func() {
+		arrays, err := c.fetchArrays(context.Background())
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		if err != nil {
+			// the arrays are kept, and refreshed again after the interval
+			log.WithError(err).Warn("Unable to refresh the discovered arrays")
+			arrays = &types.DiscoveredArrays{Local: d.arrays.Local, Remote: d.arrays.Remote, DiscoveredAt: time.Now()}
+		}
+		d.arrays = arrays
+		d.refreshing = nil
+		close(refreshing)
+	}()
+	return refreshing
+}
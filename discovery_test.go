@@ -0,0 +1,125 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pmax
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+func TestDiscoverArrays(t *testing.T) {
+	listURL := urlPrefix + "system/symmetrix"
+	var (
+		mu       sync.Mutex
+		arrays   = map[string]bool{"000000000001": true, "000000000002": false}
+		requests atomic.Int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		mu.Lock()
+		defer mu.Unlock()
+		var body interface{}
+		if req.URL.Path == listURL {
+			list := &types.SymmetrixIDList{}
+			for id := range arrays {
+				list.SymmetrixIDs = append(list.SymmetrixIDs, id)
+			}
+			body = list
+		} else if local, ok := arrays[strings.TrimPrefix(req.URL.Path, listURL+"/")]; ok {
+			body = &types.Symmetrix{SymmetrixID: strings.TrimPrefix(req.URL.Path, listURL+"/"), Local: local}
+		} else {
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+
+	// the IDs are not checked before the discovery
+	if _, err = client.IsAllowedArray("000000000003"); err != nil {
+		t.Fatal(err)
+	}
+	discovered, err := client.DiscoverArrays(ctx, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(discovered.Local, []string{"000000000001"}) || !reflect.DeepEqual(discovered.Remote, []string{"000000000002"}) {
+		t.Fatalf("unexpected arrays %+v", discovered)
+	}
+	if _, err = client.GetSymmetrixByID(ctx, "000000000002"); err != nil {
+		t.Fatal(err)
+	}
+	sent := requests.Load()
+	_, err = client.GetSymmetrixByID(ctx, "00000000001")
+	if !IsUnknownArrayError(err) || !strings.Contains(err.Error(), "000000000002") {
+		t.Fatalf("expected an UnknownArrayError listing the arrays, got %v", err)
+	}
+	if requests.Load() != sent {
+		t.Error("expected no request for an unknown array")
+	}
+
+	// an array added since the discovery is found once the cache is stale
+	mu.Lock()
+	arrays["000000000003"] = true
+	mu.Unlock()
+	if _, err = client.IsAllowedArray("000000000003"); !IsUnknownArrayError(err) {
+		t.Fatalf("expected the cached arrays to be used, got %v", err)
+	}
+	if _, err = client.DiscoverArrays(ctx, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	arrays["000000000004"] = false
+	mu.Unlock()
+	time.Sleep(5 * time.Millisecond)
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.IsAllowedArray("000000000004"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// the allowed arrays are still checked first
+	if err = client.SetAllowedArrays([]string{"000000000001"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.IsAllowedArray("000000000002"); !IsArrayNotAllowedError(err) {
+		t.Fatalf("expected an ArrayNotAllowedError, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pmax
+
+import (
+	"context"
+	"time"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+type eventHandlerKey struct{}
+
+// EventHandler is called with the progress of the steps of the calls made with WithEvents
+type EventHandler func(event types.WorkflowEvent)
+
+// WithEvents returns a context making the calls made of several steps, CopySnapshotToRemoteArray, ResizeRDFPair,
+// AddVolumesToMetroStorageGroup and spec.Apply, call handler when each of their steps starts, succeeds or fails,
+// e.g. to show their progress live. The handler is called from the goroutine of the call, which waits for it.
+func WithEvents(ctx context.Context, handler EventHandler) context.Context {
+	return context.WithValue(ctx, eventHandlerKey{}, handler)
+}
+
+// EventChannel returns an EventHandler sending the events to ch. The calls wait for every event to be received,
+// so ch has to be read while they run.
+func EventChannel(ch chan<- types.WorkflowEvent) EventHandler {
+	return func(event types.WorkflowEvent) {
+		ch <- event
+	}
+}
+
+// RunStep runs a step of the workflow, e.g. a call made of several steps, sending the events of the step to the
+// handler of ctx, if any: StepStarted before run, then StepSucceeded or StepFailed with the error of run.
+func RunStep(ctx context.Context, workflow, step string, run func() error) error {
+	handler, _ := ctx.Value(eventHandlerKey{}).(EventHandler)
+	if handler == nil {
+		return run()
+	}
+	start := time.Now()
+	handler(types.WorkflowEvent{Workflow: workflow, Step: step, Status: types.StepStarted, Time: start})
+	err := run()
+	event := types.WorkflowEvent{Workflow: workflow, Step: step, Status: types.StepSucceeded, Time: time.Now()}
+	event.Duration = event.Time.Sub(start)
+	if err != nil {
+		event.Status = types.StepFailed
+		event.Error = err.Error()
+	}
+	handler(event)
+	return err
+}
@@ -0,0 +1,60 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pmax
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+func TestRunStep(t *testing.T) {
+	// without a handler the step only runs
+	ran := false
+	if err := RunStep(context.TODO(), "Workflow", "step", func() error { ran = true; return nil }); err != nil || !ran {
+		t.Fatalf("expected the step to run, got %v", err)
+	}
+
+	events := make(chan types.WorkflowEvent, 4)
+	ctx := WithEvents(context.TODO(), EventChannel(events))
+	if err := RunStep(ctx, "Workflow", "first", func() error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	failure := errors.New("failure")
+	if err := RunStep(ctx, "Workflow", "second", func() error { return failure }); !errors.Is(err, failure) {
+		t.Fatalf("expected the error of the step, got %v", err)
+	}
+	close(events)
+	var received []types.WorkflowEvent
+	for event := range events {
+		received = append(received, event)
+	}
+	if len(received) != 4 {
+		t.Fatalf("expected 4 events, got %+v", received)
+	}
+	started, succeeded, failed := received[0], received[1], received[3]
+	if started.Status != types.StepStarted || started.Workflow != "Workflow" || started.Step != "first" || started.Time.IsZero() {
+		t.Errorf("unexpected event %+v", started)
+	}
+	if succeeded.Status != types.StepSucceeded || succeeded.Time.Before(started.Time) || succeeded.Error != "" {
+		t.Errorf("unexpected event %+v", succeeded)
+	}
+	if failed.Status != types.StepFailed || failed.Step != "second" || failed.Error != "failure" {
+		t.Errorf("unexpected event %+v", failed)
+	}
+}
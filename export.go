@@ -0,0 +1,317 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pmax
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+	log "github.com/sirupsen/logrus"
+)
+
+// MaxConfigurationExportWorkers is the number of objects of each kind fetched in parallel by GetArrayConfiguration
+const MaxConfigurationExportWorkers = 10
+
+// GetArrayConfiguration returns the storage groups, masking views, hosts, port groups, volumes and RDF groups of an array,
+// normalized for comparison. The objects of the different kinds are listed in parallel, and the details of up to
+// MaxConfigurationExportWorkers objects of each kind are fetched in parallel. Any error fails the whole configuration,
+// so that a partial configuration is never mistaken for a change of the array.
+func (c *Client) GetArrayConfiguration(ctx context.Context, symID string) (*types.ArrayConfiguration, error) {
+	defer c.TimeSpent("GetArrayConfiguration", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+
+	config := &types.ArrayConfiguration{SymmetrixID: symID, ExportedAt: time.Now().UTC()}
+	collectors := []func() error{
+		func() (err error) {
+			config.StorageGroups, err = c.getStorageGroupConfigurations(ctx, symID)
+			return err
+		},
+		func() (err error) {
+			config.MaskingViews, err = c.getMaskingViewConfigurations(ctx, symID)
+			return err
+		},
+		func() (err error) {
+			config.Hosts, err = c.getHostConfigurations(ctx, symID)
+			return err
+		},
+		func() (err error) {
+			config.PortGroups, err = c.getPortGroupConfigurations(ctx, symID)
+			return err
+		},
+		func() (err error) {
+			config.Volumes, err = c.getVolumeConfigurations(ctx, symID)
+			return err
+		},
+		func() (err error) {
+			config.RDFGroups, err = c.getRDFGroupConfigurations(ctx, symID)
+			return err
+		},
+	}
+	var wg sync.WaitGroup
+	errs := make([]error, len(collectors))
+	for i, collect := range collectors {
+		wg.Add(1)
+		go func(i int, collect func() error) {
+			defer wg.Done()
+			errs[i] = collect()
+		}(i, collect)
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		log.Error("GetArrayConfiguration failed: " + err.Error())
+		return nil, err
+	}
+	log.Info(fmt.Sprintf("Successfully read configuration of array %s", symID))
+	return config, nil
+}
+
+// ExportArrayConfiguration writes the configuration of an array, as returned by GetArrayConfiguration, to w in the given format.
+// Nothing is written if the configuration cannot be read.
+func (c *Client) ExportArrayConfiguration(ctx context.Context, symID string, format types.ExportFormat, w io.Writer) error {
+	defer c.TimeSpent("ExportArrayConfiguration", time.Now())
+	if format != types.ExportFormatJSON && format != types.ExportFormatCSV {
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+	config, err := c.GetArrayConfiguration(ctx, symID)
+	if err != nil {
+		return err
+	}
+	if format == types.ExportFormatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(config)
+	}
+	csvWriter := csv.NewWriter(w)
+	if err = csvWriter.Write([]string{"kind", "id", "attribute", "value"}); err != nil {
+		return err
+	}
+	for _, record := range config.Records() {
+		if err = csvWriter.Write([]string{record.Kind, record.ID, record.Attribute, record.Value}); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// fetchInParallel calls fetch for each index below count, up to MaxConfigurationExportWorkers at a time, and joins their errors
+func fetchInParallel(count int, fetch func(i int) error) error {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, MaxConfigurationExportWorkers)
+	errs := make([]error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = fetch(i)
+		}(i)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// sortedCopy returns a sorted copy of values, leaving values unchanged
+func sortedCopy(values []string) []string {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	return sorted
+}
+
+func (c *Client) getStorageGroupConfigurations(ctx context.Context, symID string) ([]types.StorageGroupConfiguration, error) {
+	list, err := c.GetStorageGroupIDList(ctx, symID, "", false)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list storage groups: %w", err)
+	}
+	sgs := make([]types.StorageGroupConfiguration, len(list.StorageGroupIDs))
+	err = fetchInParallel(len(sgs), func(i int) error {
+		sg, err := c.GetStorageGroup(ctx, symID, list.StorageGroupIDs[i])
+		if err != nil {
+			return fmt.Errorf("cannot read storage group %s: %w", list.StorageGroupIDs[i], err)
+		}
+		serviceLevel := sg.ServiceLevel
+		if serviceLevel == "" {
+			serviceLevel = sg.SLO
+		}
+		sgs[i] = types.StorageGroupConfiguration{
+			StorageGroupID:      sg.StorageGroupID,
+			ServiceLevel:        serviceLevel,
+			SRP:                 sg.SRP,
+			Workload:            sg.Workload,
+			Compression:         sg.Compression,
+			ParentStorageGroups: sortedCopy(sg.ParentStorageGroup),
+			ChildStorageGroups:  sortedCopy(sg.ChildStorageGroup),
+			SnapshotPolicies:    sortedCopy(sg.SnapshotPolicies),
+			Tags:                sg.Tags,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(sgs, func(i, j int) bool { return sgs[i].StorageGroupID < sgs[j].StorageGroupID })
+	return sgs, nil
+}
+
+func (c *Client) getMaskingViewConfigurations(ctx context.Context, symID string) ([]types.MaskingView, error) {
+	list, err := c.GetMaskingViewList(ctx, symID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list masking views: %w", err)
+	}
+	mvs := make([]types.MaskingView, len(list.MaskingViewIDs))
+	err = fetchInParallel(len(mvs), func(i int) error {
+		mv, err := c.GetMaskingViewByID(ctx, symID, list.MaskingViewIDs[i])
+		if err != nil {
+			return fmt.Errorf("cannot read masking view %s: %w", list.MaskingViewIDs[i], err)
+		}
+		mvs[i] = *mv
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(mvs, func(i, j int) bool { return mvs[i].MaskingViewID < mvs[j].MaskingViewID })
+	return mvs, nil
+}
+
+func (c *Client) getHostConfigurations(ctx context.Context, symID string) ([]types.HostConfiguration, error) {
+	list, err := c.GetHostList(ctx, symID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list hosts: %w", err)
+	}
+	hosts := make([]types.HostConfiguration, len(list.HostIDs))
+	err = fetchInParallel(len(hosts), func(i int) error {
+		host, err := c.GetHostByID(ctx, symID, list.HostIDs[i])
+		if err != nil {
+			return fmt.Errorf("cannot read host %s: %w", list.HostIDs[i], err)
+		}
+		hosts[i] = types.HostConfiguration{
+			HostID:        host.HostID,
+			HostType:      host.HostType,
+			Initiators:    sortedCopy(host.Initiators),
+			ConsistentLun: host.ConsistentLun,
+			EnabledFlags:  host.EnabledFlags,
+			DisabledFlags: host.DisabledFlags,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(hosts, func(i, j int) bool { return hosts[i].HostID < hosts[j].HostID })
+	return hosts, nil
+}
+
+func (c *Client) getPortGroupConfigurations(ctx context.Context, symID string) ([]types.PortGroupConfiguration, error) {
+	list, err := c.GetPortGroupList(ctx, symID, "")
+	if err != nil {
+		return nil, fmt.Errorf("cannot list port groups: %w", err)
+	}
+	pgs := make([]types.PortGroupConfiguration, len(list.PortGroupIDs))
+	err = fetchInParallel(len(pgs), func(i int) error {
+		pg, err := c.GetPortGroupByID(ctx, symID, list.PortGroupIDs[i])
+		if err != nil {
+			return fmt.Errorf("cannot read port group %s: %w", list.PortGroupIDs[i], err)
+		}
+		ports := make([]string, 0, len(pg.SymmetrixPortKey))
+		for _, port := range pg.SymmetrixPortKey {
+			ports = append(ports, port.DirectorID+":"+port.PortID)
+		}
+		slices.Sort(ports)
+		pgs[i] = types.PortGroupConfiguration{
+			PortGroupID: pg.PortGroupID,
+			Protocol:    pg.PortGroupProtocol,
+			Ports:       ports,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(pgs, func(i, j int) bool { return pgs[i].PortGroupID < pgs[j].PortGroupID })
+	return pgs, nil
+}
+
+func (c *Client) getVolumeConfigurations(ctx context.Context, symID string) ([]types.VolumeConfiguration, error) {
+	details, err := c.GetVolumeDetailList(ctx, symID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list volumes: %w", err)
+	}
+	volumes := make([]types.VolumeConfiguration, 0, len(details))
+	var errs []error
+	for _, vol := range details {
+		if vol.Error != "" {
+			errs = append(errs, fmt.Errorf("cannot read volume %s: %s", vol.VolumeID, vol.Error))
+			continue
+		}
+		volumes = append(volumes, types.VolumeConfiguration{
+			VolumeID:         vol.VolumeID,
+			VolumeIdentifier: vol.VolumeIdentifier,
+			Emulation:        vol.Emulation,
+			CapacityCYL:      vol.CapacityCYL,
+			WWN:              vol.WWN,
+			StorageGroups:    sortedCopy(vol.StorageGroupIDList),
+		})
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	sort.Slice(volumes, func(i, j int) bool { return volumes[i].VolumeID < volumes[j].VolumeID })
+	return volumes, nil
+}
+
+func (c *Client) getRDFGroupConfigurations(ctx context.Context, symID string) ([]types.RDFGroupConfiguration, error) {
+	list, err := c.GetRDFGroupList(ctx, symID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list RDF groups: %w", err)
+	}
+	rdfGroups := make([]types.RDFGroupConfiguration, len(list.RDFGroupIDs))
+	err = fetchInParallel(len(rdfGroups), func(i int) error {
+		rdfGroupNo := strconv.Itoa(list.RDFGroupIDs[i].RDFGNumber)
+		rdfg, err := c.GetRDFGroupByID(ctx, symID, rdfGroupNo)
+		if err != nil {
+			return fmt.Errorf("cannot read RDF group %s: %w", rdfGroupNo, err)
+		}
+		rdfGroups[i] = types.RDFGroupConfiguration{
+			RDFGroupNumber:       rdfg.RdfgNumber,
+			Label:                rdfg.Label,
+			RemoteSymmetrixID:    rdfg.RemoteSymmetrix,
+			RemoteRDFGroupNumber: rdfg.RemoteRdfgNumber,
+			Modes:                sortedCopy(rdfg.Modes),
+			Metro:                rdfg.Metro,
+			LocalPorts:           sortedCopy(rdfg.LocalPorts),
+			RemotePorts:          sortedCopy(rdfg.RemotePorts),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(rdfGroups, func(i, j int) bool { return rdfGroups[i].RDFGroupNumber < rdfGroups[j].RDFGroupNumber })
+	return rdfGroups, nil
+}
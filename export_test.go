@@ -0,0 +1,138 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pmax
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+func TestExportArrayConfiguration(t *testing.T) {
+	symURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id"
+	rdfURL := urlPrefix + ReplicationX + SymmetrixX + "mock-sym-id" + XRDFGroup
+	failHost := false
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.RequestURI {
+		case symURL + XStorageGroup:
+			body = &types.StorageGroupIDList{StorageGroupIDs: []string{"sg2", "sg1"}}
+		case symURL + XStorageGroup + "/sg1":
+			body = &types.StorageGroup{StorageGroupID: "sg1", SLO: "Diamond", SRP: "SRP_1", MaskingView: []string{"mv1"}, NumOfVolumes: 2}
+		case symURL + XStorageGroup + "/sg2":
+			body = &types.StorageGroup{StorageGroupID: "sg2", ServiceLevel: "Optimized", ChildStorageGroup: []string{"sg1"}}
+		case symURL + XMaskingView:
+			body = &types.MaskingViewList{MaskingViewIDs: []string{"mv1"}}
+		case symURL + XMaskingView + "/mv1":
+			body = &types.MaskingView{MaskingViewID: "mv1", HostID: "host1", PortGroupID: "pg1", StorageGroupID: "sg1"}
+		case symURL + XHost:
+			body = &types.HostList{HostIDs: []string{"host1"}}
+		case symURL + XHost + "/host1":
+			if failHost {
+				resp.WriteHeader(http.StatusInternalServerError)
+				_, _ = resp.Write([]byte(`{"message":"internal error","httpStatusCode":500,"errorCode":0}`))
+				return
+			}
+			body = &types.Host{HostID: "host1", HostType: "Fibre", Initiators: []string{"20000002", "10000001"}, NumberMaskingViews: 1}
+		case symURL + XPortGroup:
+			body = &types.PortGroupList{PortGroupIDs: []string{"pg1"}}
+		case symURL + XPortGroup + "/pg1":
+			body = &types.PortGroup{PortGroupID: "pg1", PortGroupProtocol: "SCSI_FC", SymmetrixPortKey: []types.PortKey{
+				{DirectorID: "FA-2D", PortID: "4"}, {DirectorID: "FA-1D", PortID: "4"},
+			}}
+		case symURL + XVolume + "?details=true":
+			body = &types.VolumeDetailIterator{Count: 2, MaxPageSize: 1000, ResultList: types.VolumeDetailResultList{From: 1, To: 2, VolumeList: []types.VolumeDetail{
+				{Volume: types.Volume{VolumeID: "00002", Emulation: "FBA", CapacityCYL: 547, StorageGroupIDList: []string{"sg1", "sg2"}}},
+				{Volume: types.Volume{VolumeID: "00001", Emulation: "FBA", CapacityCYL: 547, VolumeIdentifier: "vol1", StorageGroupIDList: []string{"sg1"}}},
+			}}}
+		case rdfURL:
+			body = &types.RDFGroupList{RDFGroupCount: 1, RDFGroupIDs: []types.RDFGroupIDL{{RDFGNumber: 5}}}
+		case rdfURL + "/5":
+			body = &types.RDFGroup{RdfgNumber: 5, Label: "dr", RemoteSymmetrix: "mock-remote-sym-id", RemoteRdfgNumber: 6, Modes: []string{"Synchronous"}}
+		default:
+			t.Errorf("unexpected request %s", req.RequestURI)
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, err := client.GetArrayConfiguration(context.TODO(), "mock-sym-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.StorageGroups) != 2 || config.StorageGroups[0].StorageGroupID != "sg1" || config.StorageGroups[0].ServiceLevel != "Diamond" {
+		t.Errorf("unexpected storage groups %+v", config.StorageGroups)
+	}
+	if len(config.Hosts) != 1 || config.Hosts[0].Initiators[0] != "10000001" {
+		t.Errorf("unexpected hosts %+v", config.Hosts)
+	}
+	if len(config.PortGroups) != 1 || strings.Join(config.PortGroups[0].Ports, ",") != "FA-1D:4,FA-2D:4" {
+		t.Errorf("unexpected port groups %+v", config.PortGroups)
+	}
+	if len(config.Volumes) != 2 || config.Volumes[0].VolumeID != "00001" || len(config.Volumes[1].StorageGroups) != 2 {
+		t.Errorf("unexpected volumes %+v", config.Volumes)
+	}
+	if len(config.MaskingViews) != 1 || len(config.RDFGroups) != 1 || config.RDFGroups[0].RemoteRDFGroupNumber != 6 {
+		t.Errorf("unexpected masking views %+v or RDF groups %+v", config.MaskingViews, config.RDFGroups)
+	}
+
+	var out bytes.Buffer
+	if err = client.ExportArrayConfiguration(context.TODO(), "mock-sym-id", types.ExportFormatCSV, &out); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if lines[0] != "kind,id,attribute,value" || len(lines) != len(config.Records())+1 {
+		t.Fatalf("unexpected CSV export %s", out.String())
+	}
+	if !strings.Contains(out.String(), "\nportGroup,pg1,ports,FA-1D:4;FA-2D:4\n") {
+		t.Errorf("expected the ports of pg1 in the CSV export %s", out.String())
+	}
+
+	out.Reset()
+	if err = client.ExportArrayConfiguration(context.TODO(), "mock-sym-id", types.ExportFormatJSON, &out); err != nil {
+		t.Fatal(err)
+	}
+	exported := &types.ArrayConfiguration{}
+	if err = json.Unmarshal(out.Bytes(), exported); err != nil {
+		t.Fatal(err)
+	}
+	if exported.SymmetrixID != "mock-sym-id" || len(exported.Volumes) != 2 {
+		t.Errorf("unexpected JSON export %+v", exported)
+	}
+
+	if err = client.ExportArrayConfiguration(context.TODO(), "mock-sym-id", "xml", &out); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+	failHost = true
+	out.Reset()
+	if err = client.ExportArrayConfiguration(context.TODO(), "mock-sym-id", types.ExportFormatCSV, &out); err == nil || out.Len() != 0 {
+		t.Errorf("expected a failed export without output, got %v and %q", err, out.String())
+	}
+}
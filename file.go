@@ -26,6 +26,9 @@ func (c *Client) GetFileSystemList(ctx context.Context, symID string, query type
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.verifySupport(ctx, symID, "GetFileSystemList"); err != nil {
+		return nil, err
+	}
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
 	URL := c.urlPrefix() + XFile + SymmetrixX + symID + XFileSystem
@@ -63,6 +66,9 @@ func (c *Client) GetFileSystemByID(ctx context.Context, symID, fsID string) (*ty
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.verifySupport(ctx, symID, "GetFileSystemByID"); err != nil {
+		return nil, err
+	}
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
 
@@ -94,6 +100,9 @@ func (c *Client) CreateFileSystem(ctx context.Context, symID, name, nasServer, s
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.verifySupport(ctx, symID, "CreateFileSystem"); err != nil {
+		return nil, err
+	}
 	createFSPayload := types.CreateFileSystem{
 		Name:         name,
 		SizeTotal:    sizeInMiB,
@@ -131,6 +140,9 @@ func (c *Client) ModifyFileSystem(ctx context.Context, symID, fsID string, paylo
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.verifySupport(ctx, symID, "ModifyFileSystem"); err != nil {
+		return nil, err
+	}
 	ifDebugLogPayload(payload)
 	URL := c.urlPrefix() + XFile + SymmetrixX + symID + XFileSystem + "/" + fsID
 	fields := map[string]interface{}{
@@ -158,6 +170,9 @@ func (c *Client) DeleteFileSystem(ctx context.Context, symID, fsID string) error
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return err
 	}
+	if err := c.verifySupport(ctx, symID, "DeleteFileSystem"); err != nil {
+		return err
+	}
 	URL := c.urlPrefix() + XFile + SymmetrixX + symID + XFileSystem + "/" + fsID
 	fields := map[string]interface{}{
 		http.MethodDelete: URL,
@@ -181,6 +196,9 @@ func (c *Client) GetNFSExportList(ctx context.Context, symID string, query types
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.verifySupport(ctx, symID, "GetNFSExportList"); err != nil {
+		return nil, err
+	}
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
 	URL := c.urlPrefix() + XFile + SymmetrixX + symID + XNFSExport
@@ -218,6 +236,9 @@ func (c *Client) GetNFSExportByID(ctx context.Context, symID, nfsExportID string
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.verifySupport(ctx, symID, "GetNFSExportByID"); err != nil {
+		return nil, err
+	}
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
 
@@ -249,6 +270,9 @@ func (c *Client) CreateNFSExport(ctx context.Context, symID string, createNFSExp
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.verifySupport(ctx, symID, "CreateNFSExport"); err != nil {
+		return nil, err
+	}
 	Debug = true
 	ifDebugLogPayload(createNFSExportPayload)
 	URL := c.urlPrefix() + XFile + SymmetrixX + symID + XNFSExport
@@ -280,6 +304,9 @@ func (c *Client) ModifyNFSExport(ctx context.Context, symID, nfsExportID string,
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.verifySupport(ctx, symID, "ModifyNFSExport"); err != nil {
+		return nil, err
+	}
 	ifDebugLogPayload(payload)
 	URL := c.urlPrefix() + XFile + SymmetrixX + symID + XNFSExport + "/" + nfsExportID
 	fields := map[string]interface{}{
@@ -307,6 +334,9 @@ func (c *Client) DeleteNFSExport(ctx context.Context, symID, nfsExportID string)
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return err
 	}
+	if err := c.verifySupport(ctx, symID, "DeleteNFSExport"); err != nil {
+		return err
+	}
 	URL := c.urlPrefix() + XFile + SymmetrixX + symID + XNFSExport + "/" + nfsExportID
 	fields := map[string]interface{}{
 		http.MethodDelete: URL,
@@ -330,6 +360,9 @@ func (c *Client) GetNASServerList(ctx context.Context, symID string, query types
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.verifySupport(ctx, symID, "GetNASServerList"); err != nil {
+		return nil, err
+	}
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
 	URL := c.urlPrefix() + XFile + SymmetrixX + symID + XNASServer
@@ -367,6 +400,9 @@ func (c *Client) GetNASServerByID(ctx context.Context, symID, nasID string) (*ty
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.verifySupport(ctx, symID, "GetNASServerByID"); err != nil {
+		return nil, err
+	}
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
 
@@ -398,6 +434,9 @@ func (c *Client) ModifyNASServer(ctx context.Context, symID, nasID string, paylo
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.verifySupport(ctx, symID, "ModifyNASServer"); err != nil {
+		return nil, err
+	}
 	ifDebugLogPayload(payload)
 	URL := c.urlPrefix() + XFile + SymmetrixX + symID + XNASServer + "/" + nasID
 	fields := map[string]interface{}{
@@ -425,6 +464,9 @@ func (c *Client) DeleteNASServer(ctx context.Context, symID, nasID string) error
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return err
 	}
+	if err := c.verifySupport(ctx, symID, "DeleteNASServer"); err != nil {
+		return err
+	}
 	URL := c.urlPrefix() + XFile + SymmetrixX + symID + XNASServer + "/" + nasID
 	fields := map[string]interface{}{
 		http.MethodDelete: URL,
@@ -448,6 +490,9 @@ func (c *Client) GetFileInterfaceByID(ctx context.Context, symID, interfaceID st
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.verifySupport(ctx, symID, "GetFileInterfaceByID"); err != nil {
+		return nil, err
+	}
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
 
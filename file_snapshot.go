@@ -0,0 +1,149 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pmax
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dell/gopowermax/v2/api"
+	types "github.com/dell/gopowermax/v2/types/v100"
+	log "github.com/sirupsen/logrus"
+)
+
+// constants to be used in the file system snapshot APIs
+const (
+	XFileSystemSnapshot = "/file_system_snapshot"
+	XRestore            = "/restore"
+)
+
+// GetFileSystemSnapshotList gets the snapshots of a file system, or of all the file systems if fsID is empty
+func (c *Client) GetFileSystemSnapshotList(ctx context.Context, symID, fsID string) (*types.FileSystemSnapshotIterator, error) {
+	defer c.TimeSpent("GetFileSystemSnapshotList", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	if err := c.verifySupport(ctx, symID, "GetFileSystemSnapshotList"); err != nil {
+		return nil, err
+	}
+	URL := c.urlPrefix() + XFile + SymmetrixX + symID + XFileSystemSnapshot
+	if fsID != "" {
+		URL += "?file_system=" + fsID
+	}
+	snapshotIter := new(types.FileSystemSnapshotIterator)
+	if err := c.getWithTimeout(ctx, URL, snapshotIter); err != nil {
+		log.Error("GetFileSystemSnapshotList failed: " + err.Error())
+		return nil, err
+	}
+	return snapshotIter, nil
+}
+
+// GetFileSystemSnapshotByID gets a file system snapshot
+func (c *Client) GetFileSystemSnapshotByID(ctx context.Context, symID, snapID string) (*types.FileSystemSnapshot, error) {
+	defer c.TimeSpent("GetFileSystemSnapshotByID", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	if err := c.verifySupport(ctx, symID, "GetFileSystemSnapshotByID"); err != nil {
+		return nil, err
+	}
+	URL := c.urlPrefix() + XFile + SymmetrixX + symID + XFileSystemSnapshot + "/" + snapID
+	snapshot := new(types.FileSystemSnapshot)
+	if err := c.getWithTimeout(ctx, URL, snapshot); err != nil {
+		log.Error("GetFileSystemSnapshotByID failed: " + err.Error())
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// CreateFileSystemSnapshot creates a snapshot of the file system of the payload
+func (c *Client) CreateFileSystemSnapshot(ctx context.Context, symID string, payload types.CreateFileSystemSnapshot) (*types.FileSystemSnapshot, error) {
+	defer c.TimeSpent("CreateFileSystemSnapshot", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	if err := c.verifySupport(ctx, symID, "CreateFileSystemSnapshot"); err != nil {
+		return nil, err
+	}
+	if payload.FileSystem == "" || payload.Name == "" {
+		return nil, fmt.Errorf("the file system and the name of the snapshot have to be specified")
+	}
+	ifDebugLogPayload(payload)
+	URL := c.urlPrefix() + XFile + SymmetrixX + symID + XFileSystemSnapshot
+	snapshot := new(types.FileSystemSnapshot)
+	if err := c.postWithTimeout(ctx, URL, payload, snapshot); err != nil {
+		log.Error("CreateFileSystemSnapshot failed: " + err.Error())
+		return nil, err
+	}
+	log.Infof("Successfully created snapshot %s of file system %s", payload.Name, payload.FileSystem)
+	return snapshot, nil
+}
+
+// RestoreFileSystemSnapshot restores the file system of a snapshot to the content of the snapshot.
+// The changes made to the file system since the snapshot are lost, unless a backup snapshot is requested in the payload.
+func (c *Client) RestoreFileSystemSnapshot(ctx context.Context, symID, snapID string, payload types.RestoreFileSystemSnapshot) error {
+	defer c.TimeSpent("RestoreFileSystemSnapshot", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return err
+	}
+	if err := c.verifySupport(ctx, symID, "RestoreFileSystemSnapshot"); err != nil {
+		return err
+	}
+	URL := c.urlPrefix() + XFile + SymmetrixX + symID + XFileSystemSnapshot + "/" + snapID + XRestore
+	ctx = api.WithDestructiveOperation(ctx, "RestoreFileSystemSnapshot")
+	if err := c.postWithTimeout(ctx, URL, payload, nil); err != nil {
+		log.Error("RestoreFileSystemSnapshot failed: " + err.Error())
+		return err
+	}
+	log.Infof("Successfully restored file system snapshot %s", snapID)
+	return nil
+}
+
+// DeleteFileSystemSnapshot deletes a file system snapshot
+func (c *Client) DeleteFileSystemSnapshot(ctx context.Context, symID, snapID string) error {
+	defer c.TimeSpent("DeleteFileSystemSnapshot", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return err
+	}
+	if err := c.verifySupport(ctx, symID, "DeleteFileSystemSnapshot"); err != nil {
+		return err
+	}
+	URL := c.urlPrefix() + XFile + SymmetrixX + symID + XFileSystemSnapshot + "/" + snapID
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	if err := c.api.Delete(ctx, URL, c.getDefaultHeaders(), nil); err != nil {
+		log.Error("DeleteFileSystemSnapshot failed: " + err.Error())
+		return err
+	}
+	log.Infof("Successfully deleted file system snapshot %s", snapID)
+	return nil
+}
+
+// CreateNFSExportOnSnapshot shares a file system snapshot with an NFS export, e.g. to recover single files
+// from it. The storage resource of the payload is set to the file system of the snapshot.
+func (c *Client) CreateNFSExportOnSnapshot(ctx context.Context, symID, snapID string, createNFSExportPayload types.CreateNFSExport) (*types.NFSExport, error) {
+	defer c.TimeSpent("CreateNFSExportOnSnapshot", time.Now())
+	if err := c.verifySupport(ctx, symID, "CreateNFSExportOnSnapshot"); err != nil {
+		return nil, err
+	}
+	snapshot, err := c.GetFileSystemSnapshotByID(ctx, symID, snapID)
+	if err != nil {
+		return nil, err
+	}
+	createNFSExportPayload.StorageResource = snapshot.FileSystem
+	createNFSExportPayload.Snap = snapshot.ID
+	return c.CreateNFSExport(ctx, symID, createNFSExportPayload)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pmax
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dell/gopowermax/v2/api"
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+func TestFileSystemSnapshots(t *testing.T) {
+	fileURL := urlPrefix + XFile + SymmetrixX + "mock-sym-id"
+	var (
+		created  types.CreateFileSystemSnapshot
+		restored types.RestoreFileSystemSnapshot
+		exported types.CreateNFSExport
+		deleted  bool
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.Method + " " + req.URL.Path {
+		case "GET " + fileURL + XFileSystemSnapshot:
+			if req.URL.Query().Get("file_system") != "fs-1" {
+				t.Errorf("unexpected query %s", req.URL.RawQuery)
+			}
+			body = &types.FileSystemSnapshotIterator{
+				ResultList: types.FileSystemSnapshotList{FileSystemSnapshotList: []types.FileSystemIDName{{ID: "snap-1", Name: "daily"}}, From: 1, To: 1},
+				Count:      1,
+			}
+		case "POST " + fileURL + XFileSystemSnapshot:
+			if err := json.NewDecoder(req.Body).Decode(&created); err != nil {
+				t.Error(err)
+			}
+			body = &types.FileSystemSnapshot{ID: "snap-1", Name: created.Name, FileSystem: created.FileSystem}
+		case "GET " + fileURL + XFileSystemSnapshot + "/snap-1":
+			body = &types.FileSystemSnapshot{ID: "snap-1", Name: "daily", FileSystem: "fs-1"}
+		case "POST " + fileURL + XFileSystemSnapshot + "/snap-1" + XRestore:
+			if err := json.NewDecoder(req.Body).Decode(&restored); err != nil {
+				t.Error(err)
+			}
+			body = struct{}{}
+		case "DELETE " + fileURL + XFileSystemSnapshot + "/snap-1":
+			deleted = true
+			resp.WriteHeader(http.StatusNoContent)
+			return
+		case "POST " + fileURL + XNFSExport:
+			if err := json.NewDecoder(req.Body).Decode(&exported); err != nil {
+				t.Error(err)
+			}
+			body = &types.NFSExport{ID: "export-1", Name: exported.Name, Filesystem: exported.StorageResource, Snap: exported.Snap}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+	snapshot, err := client.CreateFileSystemSnapshot(ctx, "mock-sym-id", types.CreateFileSystemSnapshot{FileSystem: "fs-1", Name: "daily"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.ID != "snap-1" || created.FileSystem != "fs-1" || created.Name != "daily" {
+		t.Fatalf("unexpected snapshot %+v created with %+v", snapshot, created)
+	}
+	if _, err = client.CreateFileSystemSnapshot(ctx, "mock-sym-id", types.CreateFileSystemSnapshot{Name: "daily"}); err == nil {
+		t.Fatal("expected an error without file system")
+	}
+	snapshots, err := client.GetFileSystemSnapshotList(ctx, "mock-sym-id", "fs-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshots.Count != 1 || snapshots.ResultList.FileSystemSnapshotList[0].ID != "snap-1" {
+		t.Fatalf("unexpected snapshots %+v", snapshots)
+	}
+
+	export, err := client.CreateNFSExportOnSnapshot(ctx, "mock-sym-id", "snap-1", types.CreateNFSExport{Name: "daily-share", Path: "/daily"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if export.Filesystem != "fs-1" || export.Snap != "snap-1" {
+		t.Fatalf("unexpected export %+v", export)
+	}
+
+	if err = client.RestoreFileSystemSnapshot(ctx, "mock-sym-id", "snap-1", types.RestoreFileSystemSnapshot{BackupSnapshotName: "before-restore"}); err != nil {
+		t.Fatal(err)
+	}
+	if restored.BackupSnapshotName != "before-restore" {
+		t.Fatalf("unexpected restore %+v", restored)
+	}
+	client.SetSafeMode(true)
+	if err = client.RestoreFileSystemSnapshot(ctx, "mock-sym-id", "snap-1", types.RestoreFileSystemSnapshot{}); !api.IsDestructiveOperationError(err) {
+		t.Fatalf("expected a DestructiveOperationError in safe mode, got %v", err)
+	}
+	client.SetSafeMode(false)
+
+	if err = client.DeleteFileSystemSnapshot(ctx, "mock-sym-id", "snap-1"); err != nil || !deleted {
+		t.Fatalf("expected the snapshot to be deleted, got %v", err)
+	}
+	if _, err = client.GetFileSystemSnapshotByID(ctx, "mock-sym-id", "snap-2"); err == nil {
+		t.Fatal("expected an error for an unknown snapshot")
+	}
+}
@@ -16,8 +16,11 @@ package pmax
 
 import (
 	"context"
+	"io"
 	"net/http"
+	"time"
 
+	"github.com/dell/gopowermax/v2/api"
 	types "github.com/dell/gopowermax/v2/types/v100"
 )
 
@@ -46,6 +49,16 @@ type NVMeTCPTarget struct {
 	PortalIPs []string
 }
 
+// FCTarget is a structure representing a front-end FC port WWPN along with its director port, speed and status
+type FCTarget struct {
+	WWPN            string
+	DirectorID      string
+	PortID          string
+	NegotiatedSpeed string
+	PortStatus      string
+	DirectorStatus  string
+}
+
 const (
 	// DefaultAPIVersion is the default API version you will get if not specified to NewClientWithArgs.
 	// The other supported versions are listed here.
@@ -55,6 +68,8 @@ const (
 )
 
 // Pmax interface has all the externally available functions provided by the pmax client library for the Powermax accessed through Unisphere.
+// It is composed of focused interfaces, e.g. VolumeClient or ReplicationClient, which consumers can depend on, and mock,
+// when they only use a part of the library.
 type Pmax interface {
 	GetHTTPClient() *http.Client
 
@@ -65,9 +80,51 @@ type Pmax interface {
 	// for it to be added to the request header.
 	WithSymmetrixID(symmetrixID string) Pmax
 
-	// SLO provisioning are the methods for SLO provisioning. All the methods requre a
-	// symID to identify the Symmetrix.
+	// SetRequestSigner sets a callback signing every request sent to Unisphere, e.g. api.NewHMACSigner,
+	// so that proxies can verify the integrity of the traffic. A nil signer disables signing.
+	SetRequestSigner(signer api.RequestSigner)
+
+	// SetDryRun enables or disables the dry-run mode, in which the calls changing the array return the
+	// request they would send as an *api.DryRunError instead of sending it. See also api.WithDryRun.
+	SetDryRun(dryRun bool)
+
+	// SetSafeMode enables or disables the safe mode, in which the destructive calls are refused with an
+	// *api.DestructiveOperationError unless allowed for the call with api.WithAllowDestructive.
+	SetSafeMode(safeMode bool)
 
+	// SetStorageGroupCoalescing makes the AddVolumesToStorageGroup(S) and RemoveVolumesFromStorageGroup calls on the
+	// same storage group within window be sent as a single edit. A window of 0 disables coalescing.
+	SetStorageGroupCoalescing(window time.Duration)
+
+	// SetJournal sets the journal, e.g. api.NewMemoryJournal, in which every call changing the array is
+	// recorded with its payload and result, so that the changes can be queried and exported with
+	// api.ExportJournal. A nil journal disables recording.
+	SetJournal(journal api.Journal)
+
+	// SetAllowedArrays sets the list of arrays which can be manipulated
+	// an empty list will allow all arrays to be accessed
+	SetAllowedArrays(arrays []string) error
+	// GetAllowedArrays returns a slice of arrays that can be manipulated
+	GetAllowedArrays() []string
+	// IsAllowedArray checks to see if we can manipulate the specified array
+	IsAllowedArray(array string) (bool, error)
+	// DiscoverArrays returns the local and remote arrays managed by Unisphere, and caches them, refreshing them
+	// every refreshInterval, so that the calls referencing an unknown array fail before any request is sent
+	DiscoverArrays(ctx context.Context, refreshInterval time.Duration) (*types.DiscoveredArrays, error)
+
+	VolumeClient
+	MaskingClient
+	ReplicationClient
+	SystemClient
+	PerformanceClient
+	FileClient
+}
+
+var _ Pmax = (*Client)(nil)
+
+// VolumeClient has the methods managing the volumes, storage groups, storage resource pools and service levels of a Symmetrix.
+// All the methods require a symID to identify the Symmetrix.
+type VolumeClient interface {
 	// GetVolumeIDsIterator generates a VolumeIterator containing the ids of either all or a selected set volumes.
 	// The volumeIdentifierMatch string can be used to find a specific volume, or if the like bool is set, all the
 	// volumes containing match as part of their VolumeIdentifier.
@@ -99,19 +156,42 @@ type Pmax interface {
 
 	// GetVolumeByID returns a Volume given the volumeID.
 	GetVolumeByID(ctx context.Context, symID string, volumeID string) (*types.Volume, error)
+	// GetVolumesByIDs returns the details of the volumes, looking them up in batches where Unisphere supports it
+	GetVolumesByIDs(ctx context.Context, symID string, volumeIDs []string) ([]types.VolumeDetail, error)
+	// GetVolumeDetailList returns the details of all the volumes matching the query parameters in one call
+	GetVolumeDetailList(ctx context.Context, symID string, queryParams map[string]string) ([]types.VolumeDetail, error)
+	// GetStorageGroupVolumeList returns the details of the volumes of a storage group, without gatekeepers,
+	// of an emulation or ordered by capacity according to the options
+	GetStorageGroupVolumeList(ctx context.Context, symID string, storageGroupID string, opts types.VolumeListOptions) ([]types.VolumeDetail, error)
 
 	// GetStorageGroupIDList returns a list of all the StorageGroup ids.
 	GetStorageGroupIDList(ctx context.Context, symID, storageGroupIDMatch string, like bool) (*types.StorageGroupIDList, error)
+	// ListStorageGroupsModifiedSince returns the storage groups modified after since, or all of them if they are not filtered, see WithModifiedSinceFilter
+	ListStorageGroupsModifiedSince(ctx context.Context, symID string, since time.Time) (*types.ModifiedIDList, error)
+	// ListVolumesModifiedSince returns the volumes modified after since, or all of them if they are not filtered, see WithModifiedSinceFilter
+	ListVolumesModifiedSince(ctx context.Context, symID string, since time.Time) (*types.ModifiedIDList, error)
 
 	// GetStorageGroup returns a storage group given the StorageGroup id.
-	GetStorageGroup(ctx context.Context, symID string, storageGroupID string) (*types.StorageGroup, error)
-
-	// GetStorageGroupSnapshotPolicy returns a storage group snapshot policy details.
-	GetStorageGroupSnapshotPolicy(ctx context.Context, symID, snapshotPolicyID, storageGroupID string) (*types.StorageGroupSnapshotPolicy, error)
+	GetStorageGroup(ctx context.Context, symID string, storageGroupID string, opts ...types.GetStorageGroupOptions) (*types.StorageGroup, error)
 
 	// GetStoragePool returns a storage pool given the GetStoragePoolID and SymID.
 	GetStoragePool(ctx context.Context, symID string, storagePoolID string) (*types.StoragePool, error)
 
+	// MigrateVolumesToSRP starts moving the data of volumes to another storage resource pool and returns the job IDs
+	MigrateVolumesToSRP(ctx context.Context, symID string, volumeIDs []string, targetSRP string) ([]string, error)
+
+	// GetSRPMigrationProgress returns the progress of the jobs started by MigrateVolumesToSRP
+	GetSRPMigrationProgress(ctx context.Context, symID string, targetSRP string, jobIDs []string) (*types.SRPMigrationProgress, error)
+
+	// GetServiceLevelList returns the IDs of the service levels of the array
+	GetServiceLevelList(ctx context.Context, symID string) (*types.ServiceLevelList, error)
+	// GetServiceLevel returns a service level of the array, with its expected latency band
+	GetServiceLevel(ctx context.Context, symID, serviceLevelID string) (*types.ServiceLevel, error)
+	// GetStoragePoolServiceLevels returns the service levels available in a storage pool, with their expected latency bands
+	GetStoragePoolServiceLevels(ctx context.Context, symID, storagePoolID string) ([]types.ServiceLevel, error)
+	// ModifyStoragePool changes the reserved capacity, SRDF/A DSE usage or description of a storage pool
+	ModifyStoragePool(ctx context.Context, symID string, storagePoolID string, param *types.ModifyStoragePoolParam) (*types.StoragePool, error)
+
 	// CreateStorageGroup creates a storage group given the Storage group id
 	// and returns the storage group object. The storage group can be configured for thick volumes as an option.
 	// This is a blocking call and will only return after the storage group has been created
@@ -125,6 +205,12 @@ type Pmax interface {
 	// operations (but many have not been tested).
 	// This is done synchronously and doesn't create any jobs
 	UpdateStorageGroupS(ctx context.Context, symID string, storageGroupID string, payload interface{}) error
+	// ModifyStorageGroupSLO changes the service level of a storage group
+	ModifyStorageGroupSLO(ctx context.Context, symID, storageGroupID, sloID string) error
+	// ModifyStorageGroupWorkload changes the workload of a storage group
+	ModifyStorageGroupWorkload(ctx context.Context, symID, storageGroupID, workload string) error
+	// ModifyStorageGroupSRP changes the storage resource pool of a storage group
+	ModifyStorageGroupSRP(ctx context.Context, symID, storageGroupID, srpID string) error
 
 	// CreateVolumeInStorageGroup takes simplified input arguments to create a volume of a give name and size in a particular storage group.
 	// This method creates a job and waits on the job to complete.
@@ -134,66 +220,93 @@ type Pmax interface {
 	// This is done synchronously and no jobs are created. HTTP header argument is optional
 	CreateVolumeInStorageGroupS(ctx context.Context, symID, storageGroupID string, volumeName string, volumeSize interface{}, volOpts map[string]interface{}, opts ...http.Header) (*types.Volume, error)
 
-	// CreateVolumeInProtectedStorageGroupS takes simplified input arguments to create a volume of a give name and size in a protected storage group.
-	// This will add volume in both Local and Remote Storage group
-	// This is done synchronously and no jobs are created. HTTP header argument is optional
-	CreateVolumeInProtectedStorageGroupS(ctx context.Context, symID, remoteSymID, storageGroupID string, remoteStorageGroupID string, volumeName string, volumeSize interface{}, volOpts map[string]interface{}, opts ...http.Header) (*types.Volume, error)
-
-	// GetStorageGroupSnapshots Gets All Storage Group Snapshots
-	GetStorageGroupSnapshots(ctx context.Context, symID string, storageGroupID string, excludeManualSnaps bool, excludeSlSnaps bool) (*types.StorageGroupSnapshot, error)
-
-	// GetStorageGroupSnapshotSnapIDs Gets a list of SnapIDs for a particular snapshot
-	GetStorageGroupSnapshotSnapIDs(ctx context.Context, symID string, storageGroupID string, snapshotID string) (*types.SnapID, error)
-
-	// GetStorageGroupSnapshotSnap Gets the details of a storage group snapshot snap
-	GetStorageGroupSnapshotSnap(ctx context.Context, symID string, storageGroupID string, snapshotID, snapID string) (*types.StorageGroupSnap, error)
-
-	// CreateStorageGroupSnapshot Creates a Storage Group Snapshot
-	CreateStorageGroupSnapshot(ctx context.Context, symID string, storageGroupID string, payload *types.CreateStorageGroupSnapshot) (*types.StorageGroupSnap, error)
-
-	// ModifyStorageGroupSnapshot Modify a Storage Group Snapshot snap
-	ModifyStorageGroupSnapshot(ctx context.Context, symID string, storageGroupID string, snapshotID string, snapID string, payload *types.ModifyStorageGroupSnapshot) (*types.StorageGroupSnap, error)
+	// CreateVolumesWithAppendNumber creates volumes in a storage group named with a prefix and an appended number, and returns them with their numbers
+	CreateVolumesWithAppendNumber(ctx context.Context, symID, storageGroupID, identifierPrefix string, count, startNumber int, volumeSize interface{}, capUnit string) ([]types.NumberedVolume, error)
 
-	// DeleteStorageGroupSnapshot Deletes a Storage Group Snapshot snap
-	DeleteStorageGroupSnapshot(ctx context.Context, symID string, storageGroupID string, snapshotID string, snapID string) error
+	// GetVolumesByIdentifierRange returns the volumes named with a prefix and a number in a range, optionally only those of a storage group
+	GetVolumesByIdentifierRange(ctx context.Context, symID, storageGroupID, identifierPrefix string, from, to int) ([]types.NumberedVolume, error)
 
 	// DeleteStorageGroup deletes a storage group given a storage group id
 	DeleteStorageGroup(ctx context.Context, symID string, storageGroupID string) error
-
-	// DeleteMaskingView deletes a masking view given a masking view id
-	DeleteMaskingView(ctx context.Context, symID string, maskingViewID string) error
-
-	// RenameMaskingView renames masking view given its identifier (which is the name)
-	RenameMaskingView(ctx context.Context, symID string, maskingViewID string, newName string) (*types.MaskingView, error)
+	// DeleteStorageGroupCascade deletes a storage group after optionally cleaning up its snapshots, volumes and parent storage groups
+	DeleteStorageGroupCascade(ctx context.Context, symID string, storageGroupID string, opts types.DeleteStorageGroupCascadeOptions) (*types.DeleteStorageGroupCascadeReport, error)
 
 	// GetStoragePoolList Gets the list of Storage Pools
 	GetStoragePoolList(ctx context.Context, symID string) (*types.StoragePoolList, error)
 
 	// RenameVolume Rename a Volume given the volumeID
 	RenameVolume(ctx context.Context, symID string, volumeID string, newName string) (*types.Volume, error)
+	// RelabelStorageGroupVolumes sets the identifiers of all the volumes of a storage group to a prefix followed by an index
+	RelabelStorageGroupVolumes(ctx context.Context, symID, storageGroupID, identifierPrefix string, startIndex int) ([]types.VolumeRelabel, error)
+	// SetVolumeMetadata sets metadata entries of a volume, stored in its volume identifier after its name
+	SetVolumeMetadata(ctx context.Context, symID, volumeID string, metadata map[string]string) error
+	// GetVolumeMetadata returns the metadata entries of a volume
+	GetVolumeMetadata(ctx context.Context, symID, volumeID string) (map[string]string, error)
+	// SetStorageGroupMetadata sets metadata entries of a storage group, stored as tags
+	SetStorageGroupMetadata(ctx context.Context, symID, storageGroupID string, metadata map[string]string) error
+	// GetStorageGroupMetadata returns the metadata entries of a storage group
+	GetStorageGroupMetadata(ctx context.Context, symID, storageGroupID string) (map[string]string, error)
 
 	// AddVolumesToStorageGroup Add volume(s) asynchronously to a StorageGroup
 	AddVolumesToStorageGroup(ctx context.Context, symID, storageGroupID string, force bool, volumeIDs ...string) error
 	// AddVolumesToStorageGroupS Add volume(s) synchronously to a StorageGroup
 	// This is a blocking call and will only return once the volumes have been added to storage group
 	AddVolumesToStorageGroupS(ctx context.Context, symID, storageGroupID string, force bool, volumeIDs ...string) error
-	// AddVolumesToProtectedStorageGroup Adds one or more volumes (given by their volumeIDs) to a Protected StorageGroup
-	AddVolumesToProtectedStorageGroup(ctx context.Context, symID, storageGroupID, remoteSymID, remoteStorageGroupID string, force bool, volumeIDs ...string) error
 
 	// RemoveVolumesFromStorageGroup Removes volume(s) synchronously from a StorageGroup
 	RemoveVolumesFromStorageGroup(ctx context.Context, symID string, storageGroupID string, force bool, volumeIDs ...string) (*types.StorageGroup, error)
 
-	// RemoveVolumesFromProtectedStorageGroup removes one or more volumes (given by their volumeIDs) from a Protected StorageGroup.
-	RemoveVolumesFromProtectedStorageGroup(ctx context.Context, symID string, storageGroupID, remoteSymID, remoteStorageGroupID string, force bool, volumeIDs ...string) (*types.StorageGroup, error)
-
 	// InitiateDeallocationOfTracksFromVolume Initiate a job to remove storage space from the volume.
 	InitiateDeallocationOfTracksFromVolume(ctx context.Context, symID string, volumeID string) (*types.Job, error)
+	// FormatVolume starts a job formatting a volume, which erases all its data
+	FormatVolume(ctx context.Context, symID string, volumeID string, symForce bool) (*types.Job, error)
+
+	// DeleteVolume Deletes a volume, optionally checking for and removing the snapshots, RDF pairs, masking views
+	// and storage groups using it first
+	DeleteVolume(ctx context.Context, symID string, volumeID string, opts ...types.DeleteVolumeOptions) error
+	// GetVolumePaths returns the masking views exposing a volume, through the storage groups holding it or their parents
+	GetVolumePaths(ctx context.Context, symID string, volumeID string) ([]types.VolumePath, error)
+	// UnmapVolume removes a volume from every storage group exposing it in a masking view, or only lists the removals
+	// in a dry run, and returns the outcome of each removal
+	UnmapVolume(ctx context.Context, symID string, volumeID string, opts ...types.UnmapVolumeOptions) ([]types.VolumeUnmap, error)
+	// DeleteVolumeWithDeallocate frees the tracks of a volume, waits for the deallocation to complete, reporting its progress,
+	// and deletes the volume
+	DeleteVolumeWithDeallocate(ctx context.Context, symID string, volumeID string, pollInterval time.Duration, progress func(types.DeallocationProgress)) error
+	// GetPrivVolumeByID returns a Volume structure given the symmetrix and volume ID (volume ID is in WWN format)
+	GetPrivVolumeByID(ctx context.Context, symID string, volumeID string) (*types.VolumeResultPrivate, error)
 
-	// DeleteVolume Deletes a volume
-	DeleteVolume(ctx context.Context, symID string, volumeID string) error
+	// ModifyMobilityForVolume allows enabling/disabling mobility id for the volume
+	ModifyMobilityForVolume(ctx context.Context, symID string, volumeID string, mobility bool) (*types.Volume, error)
+	// SetVolumeReadyState sets the device ready state (ready, not ready or user not ready) on the given volumes
+	SetVolumeReadyState(ctx context.Context, symID string, volumeIDs []string, readyState string, symForce bool) ([]types.VolumeActionOutcome, error)
+	// SetPortACLX enables or disables the access control (ACLX) deciding which initiators see the volumes of a port
+	SetPortACLX(ctx context.Context, symID, directorID, portID string, enabled bool) (*types.Port, error)
+	// ExpandVolume expands the size of an existing volume
+	ExpandVolume(ctx context.Context, symID string, volumeID string, rdfGNo int, volumeSize interface{}, capUnits ...string) (*types.Volume, error)
+	// GetCreateVolInSGPayload returns a payload to create a volume in a storage group
+	GetCreateVolInSGPayload(volumeSize interface{}, capUnit string, volumeName string, isSync, enableMobility bool, remoteSymID, storageGroupID string, opts ...http.Header) (payload interface{})
+}
+
+// MaskingClient has the methods managing the masking views, port groups, hosts, host groups, initiators and
+// front-end ports of a Symmetrix
+type MaskingClient interface {
+	// ListMaskingViewsModifiedSince returns the masking views modified after since, or all of them if they are not filtered, see WithModifiedSinceFilter
+	ListMaskingViewsModifiedSince(ctx context.Context, symID string, since time.Time) (*types.ModifiedIDList, error)
+
+	// DeleteMaskingView deletes a masking view given a masking view id
+	DeleteMaskingView(ctx context.Context, symID string, maskingViewID string) error
+
+	// RenameMaskingView renames masking view given its identifier (which is the name)
+	RenameMaskingView(ctx context.Context, symID string, maskingViewID string, newName string) (*types.MaskingView, error)
+
+	// SwapMaskingViewPortGroup deletes and recreates a masking view with a new port group, keeping the volumes mapped
+	SwapMaskingViewPortGroup(ctx context.Context, symID string, maskingViewID string, portGroupID string) (*types.MaskingView, error)
 
-	// GetMaskingViewList  returns a list of the MaskingView names.
-	GetMaskingViewList(ctx context.Context, symID string) (*types.MaskingViewList, error)
+	// SwapMaskingViewHost deletes and recreates a masking view with a new host or host group, keeping the volumes mapped
+	SwapMaskingViewHost(ctx context.Context, symID string, maskingViewID string, hostOrhostGroupID string, isHost bool) (*types.MaskingView, error)
+
+	// GetMaskingViewList  returns a list of the MaskingView names, optionally filtered by host, port group or storage group.
+	GetMaskingViewList(ctx context.Context, symID string, filters ...types.MaskingViewFilter) (*types.MaskingViewList, error)
 
 	// GetMaskingViewByID returns a masking view given its identifier (which is the name)
 	GetMaskingViewByID(ctx context.Context, symID string, maskingViewID string) (*types.MaskingView, error)
@@ -212,43 +325,50 @@ type Pmax interface {
 	// RenamePortGroup renames port group given it is identifier (which is the name)
 	RenamePortGroup(ctx context.Context, symID string, portGroupID string, newName string) (*types.PortGroup, error)
 
-	// GetSymmetrixIDList gets symmetrix list
-	GetSymmetrixIDList(ctx context.Context) (*types.SymmetrixIDList, error)
-	// GetSymmetrixByID gets symmetrix by given ID
-	GetSymmetrixByID(ctx context.Context, id string) (*types.Symmetrix, error)
-
-	// GetJobIDList retrieves the list of jobs on a given Symmetrix.
-	// If optional parameter statusQuery is a types.JobStatusRunning or similar string, will search for jobs
-	// with a particular status.
-	GetJobIDList(ctx context.Context, symID string, statusQuery string) ([]string, error)
-	GetJobByID(ctx context.Context, symID string, jobID string) (*types.Job, error)
-	WaitOnJobCompletion(ctx context.Context, symID string, jobID string) (*types.Job, error)
-	JobToString(job *types.Job) string
-
 	// GetPortGroupList returns a list of all the Port Group ids.
 	GetPortGroupList(ctx context.Context, symID string, portGroupType string) (*types.PortGroupList, error)
 	// GetPortGroupByID returns a port group given the PortGroup id.
 	GetPortGroupByID(ctx context.Context, symID string, portGroupID string) (*types.PortGroup, error)
+	// GetDirectorPortMembership returns the port groups and masking views referencing a director port
+	GetDirectorPortMembership(ctx context.Context, symID string, directorID string, portID string) (*types.DirectorPortMembership, error)
+	// RecommendPortGroup suggests the least loaded front-end ports of a transport for a new port group, spread over the directors
+	RecommendPortGroup(ctx context.Context, symID string, transport string, count int) (*types.PortGroupRecommendation, error)
 
 	// GetInitiatorList returns a list of all the Initiator ids based on filters supplied
 	GetInitiatorList(ctx context.Context, symID string, initiatorHBA string, isISCSI bool, inHost bool) (*types.InitiatorList, error)
 	// GetInitiatorByID returns an Initiator given the Initiator id.
 	GetInitiatorByID(ctx context.Context, symID string, initID string) (*types.Initiator, error)
+	// GetHostIDListByInitiator returns the hosts and masking views of an initiator WWN, IQN or NQN
+	GetHostIDListByInitiator(ctx context.Context, symID string, initiator string) (*types.InitiatorHosts, error)
 
 	// GetHostList returns a list of all the Host ids.
 	GetHostList(ctx context.Context, symID string) (*types.HostList, error)
 	// GetHostByID returns a Host given the Host id.
 	GetHostByID(ctx context.Context, symID string, hostID string) (*types.Host, error)
+	// AuditHostFlags compares the flags of all the hosts of the array against a policy and reports the hosts which drifted
+	AuditHostFlags(ctx context.Context, symID string, selectPolicy HostFlagPolicySelector) (*types.HostFlagDriftReport, error)
 	// CreateHost creates a host from a list of InitiatorIDs (and optional HostFlags) return returns a types.Host.
 	// Initiator IDs do not contain the storage port designations, just the IQN string or FC WWN.
 	// Initiator IDs cannot be a member of more than one host.
 	CreateHost(ctx context.Context, symID string, hostID string, initiatorIDs []string, hostFlags *types.HostFlags) (*types.Host, error)
-	// DeleteHost deletes a host given the hostID.
-	DeleteHost(ctx context.Context, symID string, hostID string) error
+
+	// CreateNVMeHost creates a host from a list of host NQNs, after validating them
+	CreateNVMeHost(ctx context.Context, symID string, hostID string, nqns []string, hostFlags *types.HostFlags) (*types.Host, error)
+	// GetNVMeInitiatorList returns the IDs of the NVMe initiators, optionally only those in a host
+	GetNVMeInitiatorList(ctx context.Context, symID string, inHost bool) ([]string, error)
+	// DeleteHost deletes a host given the hostID, optionally checking first that no masking view references it.
+	DeleteHost(ctx context.Context, symID string, hostID string, opts ...types.DeleteHostOptions) error
 	// UpdateHostInitiators will update the inititators
 	UpdateHostInitiators(ctx context.Context, symID string, host *types.Host, initiatorIDs []string) (*types.Host, error)
+	// UpdateHostInitiatorsInChunks updates the initiators of a host by chunks, continuing on errors, and reports the result of every initiator
+	UpdateHostInitiatorsInChunks(ctx context.Context, symID string, host *types.Host, initiatorIDs []string, chunkSize int) (*types.HostInitiatorsUpdate, error)
+	// UpdateHostName renames a host and returns the renamed types.Host.
 	UpdateHostName(ctx context.Context, symID, oldHostID, newHostID string) (*types.Host, error)
 	UpdateHostFlags(ctx context.Context, symID string, hostID string, hostFlags *types.HostFlags) (*types.Host, error)
+	// SetInitiatorPortFlags overrides the flags of the host of an initiator on the port of the initiator
+	SetInitiatorPortFlags(ctx context.Context, symID string, initID string, hostFlags *types.HostFlags) (*types.Initiator, error)
+	// GetInitiatorFlags returns the port flags set on an initiator and the flags in effect for it
+	GetInitiatorFlags(ctx context.Context, symID string, initID string) (*types.InitiatorFlags, error)
 	// GetDirectorIDList returns a list of directors
 	GetDirectorIDList(ctx context.Context, symID string) (*types.DirectorIDList, error)
 	// GetPortList returns a list of all the ports on a specified director/array.
@@ -261,12 +381,17 @@ type Pmax interface {
 	GetNVMeTCPTargets(ctx context.Context, symID string) ([]NVMeTCPTarget, error)
 	// GetISCSITargets returns a list of ISCSI Targets for a given sym id
 	GetISCSITargets(ctx context.Context, symID string) ([]ISCSITarget, error)
+	// GetFCTargets returns a list of front-end FC port WWPNs for a given sym id
+	GetFCTargets(ctx context.Context, symID string) ([]FCTarget, error)
 	// CreateHostGroup creates a hostGroup from a list of hostIDs (and optional HostFlags) and  returns a types.HostGroup.
 	CreateHostGroup(ctx context.Context, symID string, hostGroupID string, hostIDs []string, hostFlags *types.HostFlags) (*types.HostGroup, error)
 	// GetHostGroupList returns a list of all the HostGroup ids.
 	GetHostGroupList(ctx context.Context, symID string) (*types.HostGroupList, error)
 	// GetHostGroupByID returns a HostGroup given the HostGroup id.
 	GetHostGroupByID(ctx context.Context, symID string, hostGroupID string) (*types.HostGroup, error)
+	// GetHostGroupInheritance returns the initiators and flags in effect for a host group including its child hosts,
+	// and the flags on which they conflict
+	GetHostGroupInheritance(ctx context.Context, symID string, hostGroupID string) (*types.HostGroupInheritance, error)
 	// DeleteHostGroup deletes a hostGroup given the hostGroupID.
 	DeleteHostGroup(ctx context.Context, symID string, hostGroupID string) error
 	// UpdateHostGroupName updates a hostGroup with new hostGroup ID and returns a types.HostGroup.
@@ -276,13 +401,62 @@ type Pmax interface {
 	// UpdateHostGroupHosts will add/remove the hosts for a host group
 	UpdateHostGroupHosts(ctx context.Context, symID string, hostGroupID string, hostIDs []string) (*types.HostGroup, error)
 
-	// SetAllowedArrays sets the list of arrays which can be manipulated
-	// an empty list will allow all arrays to be accessed
-	SetAllowedArrays(arrays []string) error
-	// GetAllowedArrays returns a slice of arrays that can be manipulated
-	GetAllowedArrays() []string
-	// IsAllowedArray checks to see if we can manipulate the specified array
-	IsAllowedArray(array string) (bool, error)
+	// DeletePortGroup deletes a port group
+	DeletePortGroup(ctx context.Context, symID string, portGroupID string) error
+	// UpdatePortGroup updates a port group
+	UpdatePortGroup(ctx context.Context, symID string, portGroupID string, ports []types.PortKey) (*types.PortGroup, error)
+	// ModifyPortGroup adds ports to, and removes ports from, a port group, refusing to leave a port group in use without ports
+	ModifyPortGroup(ctx context.Context, symID string, portGroupID string, add, remove []types.PortKey) (*types.PortGroup, error)
+}
+
+// ReplicationClient has the methods managing the snapshots, snapshot policies, SRDF groups and pairs,
+// and storage group migrations of a Symmetrix
+type ReplicationClient interface {
+	// GetStorageGroupSnapshotPolicy returns a storage group snapshot policy details.
+	GetStorageGroupSnapshotPolicy(ctx context.Context, symID, snapshotPolicyID, storageGroupID string) (*types.StorageGroupSnapshotPolicy, error)
+
+	// CreateVolumeInProtectedStorageGroupS takes simplified input arguments to create a volume of a give name and size in a protected storage group.
+	// This will add volume in both Local and Remote Storage group
+	// This is done synchronously and no jobs are created. HTTP header argument is optional
+	CreateVolumeInProtectedStorageGroupS(ctx context.Context, symID, remoteSymID, storageGroupID string, remoteStorageGroupID string, volumeName string, volumeSize interface{}, volOpts map[string]interface{}, opts ...http.Header) (*types.Volume, error)
+
+	// GetStorageGroupSnapshots Gets All Storage Group Snapshots
+	GetStorageGroupSnapshots(ctx context.Context, symID string, storageGroupID string, excludeManualSnaps bool, excludeSlSnaps bool) (*types.StorageGroupSnapshot, error)
+
+	// GetStorageGroupSnapshotSnapIDs Gets a list of SnapIDs for a particular snapshot
+	GetStorageGroupSnapshotSnapIDs(ctx context.Context, symID string, storageGroupID string, snapshotID string) (*types.SnapID, error)
+
+	// GetStorageGroupSnapshotSnap Gets the details of a storage group snapshot snap
+	GetStorageGroupSnapshotSnap(ctx context.Context, symID string, storageGroupID string, snapshotID, snapID string) (*types.StorageGroupSnap, error)
+
+	// GetSnapshotCapacityUsage sums the modified and non-shared tracks of the snapshots of a storage group, per snapshot name and in total
+	GetSnapshotCapacityUsage(ctx context.Context, symID string, storageGroupID string) (*types.SnapshotCapacityUsage, error)
+	// GetSnapshotDelta returns the number of tracks of the source volumes changed since a generation of a storage group snapshot was taken
+	GetSnapshotDelta(ctx context.Context, symID, storageGroupID, snapshotName string, snapID int64) (*types.SnapshotDelta, error)
+	// GetSnapshotGenerationDelta returns the number of tracks changed since the older of two generations of a storage group snapshot
+	GetSnapshotGenerationDelta(ctx context.Context, symID, storageGroupID, snapshotName string, snapID, compareSnapID int64) (*types.SnapshotDelta, error)
+	// GetStorageGroupPolicySnapshots returns the snapshots of a storage group created by snapshot policies, separately from the manual ones
+	GetStorageGroupPolicySnapshots(ctx context.Context, symID string, storageGroupID string) (*types.StorageGroupPolicySnapshots, error)
+
+	// CreateStorageGroupSnapshot Creates a Storage Group Snapshot
+	CreateStorageGroupSnapshot(ctx context.Context, symID string, storageGroupID string, payload *types.CreateStorageGroupSnapshot) (*types.StorageGroupSnap, error)
+
+	// ModifyStorageGroupSnapshot Modify a Storage Group Snapshot snap
+	ModifyStorageGroupSnapshot(ctx context.Context, symID string, storageGroupID string, snapshotID string, snapID string, payload *types.ModifyStorageGroupSnapshot) (*types.StorageGroupSnap, error)
+
+	// LinkStorageGroupSnapshot links a Storage Group Snapshot snap to a target Storage Group, in copy or nocopy mode
+	LinkStorageGroupSnapshot(ctx context.Context, symID string, storageGroupID string, snapshotID string, snapID string, targetStorageGroupID string, copyMode bool) (*types.StorageGroupSnap, error)
+
+	// SetStorageGroupSnapshotLinkMode switches a linked target Storage Group between copy and nocopy mode
+	SetStorageGroupSnapshotLinkMode(ctx context.Context, symID string, storageGroupID string, snapshotID string, snapID string, targetStorageGroupID string, copyMode bool) (*types.StorageGroupSnap, error)
+
+	// DeleteStorageGroupSnapshot Deletes a Storage Group Snapshot snap
+	DeleteStorageGroupSnapshot(ctx context.Context, symID string, storageGroupID string, snapshotID string, snapID string) error
+	// AddVolumesToProtectedStorageGroup Adds one or more volumes (given by their volumeIDs) to a Protected StorageGroup
+	AddVolumesToProtectedStorageGroup(ctx context.Context, symID, storageGroupID, remoteSymID, remoteStorageGroupID string, force bool, volumeIDs ...string) error
+
+	// RemoveVolumesFromProtectedStorageGroup removes one or more volumes (given by their volumeIDs) from a Protected StorageGroup.
+	RemoveVolumesFromProtectedStorageGroup(ctx context.Context, symID string, storageGroupID, remoteSymID, remoteStorageGroupID string, force bool, volumeIDs ...string) (*types.StorageGroup, error)
 
 	// GetSnapVolumeList returns a list of all snapshot volumes on the array.
 	GetSnapVolumeList(ctx context.Context, symID string, queryParams types.QueryParams) (*types.SymVolumeList, error)
@@ -290,19 +464,21 @@ type Pmax interface {
 	GetVolumeSnapInfo(ctx context.Context, symID string, volume string) (*types.SnapshotVolumeGeneration, error)
 	// GetSnapshotInfo returns snapVx information of the specified volume
 	GetSnapshotInfo(ctx context.Context, symID, volume, SnapID string) (*types.VolumeSnapshot, error)
-	// CreateSnapshot creates a snapVx snapshot of a volume using the input parameters
-	CreateSnapshot(ctx context.Context, symID string, SnapID string, sourceVolumeList []types.VolumeList, ttl int64) error
+	// CreateSnapshot creates a snapVx snapshot of a volume using the input parameters, terminated automatically
+	// after ttl days, or hours with the TimeInHours option, if ttl is not 0
+	CreateSnapshot(ctx context.Context, symID string, SnapID string, sourceVolumeList []types.VolumeList, ttl int64, opts ...types.CreateSnapshotOptions) error
 
 	// ModifySnapshot executes actions on a snapshot asynchronously
 	// This creates a job and waits on its completion
+	// The options of the Link and Relink actions can make a Relink copy all the tracks instead of the changed ones
 	ModifySnapshot(ctx context.Context, symID string, sourceVol []types.VolumeList,
 		targetVol []types.VolumeList, SnapID string, action string,
-		newSnapID string, generation int64, isCopy bool) error
+		newSnapID string, generation int64, isCopy bool, opts ...types.LinkSnapshotOptions) error
 
 	// ModifySnapshotS executes actions on a snapshot synchronously
 	ModifySnapshotS(ctx context.Context, symID string, sourceVol []types.VolumeList,
 		targetVol []types.VolumeList, SnapID string, action string,
-		newSnapID string, generation int64, isCopy bool) error
+		newSnapID string, generation int64, isCopy bool, opts ...types.LinkSnapshotOptions) error
 	// DeleteSnapshot deletes a snapshot from a volume
 	// This is an asynchronous call and waits for the job to complete
 	DeleteSnapshot(ctx context.Context, symID, SnapID string, sourceVolumes []types.VolumeList, generation int64) error
@@ -311,32 +487,25 @@ type Pmax interface {
 	// This is a synchronous call and doesn't create a job
 	DeleteSnapshotS(ctx context.Context, symID, SnapID string, sourceVolumes []types.VolumeList, generation int64) error
 
-	// GetSnapshotGenerations returns a list of all the snapshot generation on a specific snapshot
-	GetSnapshotGenerations(ctx context.Context, symID, volume, SnapID string) (*types.VolumeSnapshotGenerations, error)
+	// GetSnapshotGenerations returns a list of all the snapshot generation on a specific snapshot, optionally filtered and sorted
+	GetSnapshotGenerations(ctx context.Context, symID, volume, SnapID string, filter ...types.SnapshotGenerationFilter) (*types.VolumeSnapshotGenerations, error)
 	// GetSnapshotGenerationInfo returns the specific generation info related to a snapshot
 	GetSnapshotGenerationInfo(ctx context.Context, symID, volume, SnapID string, generation int64) (*types.VolumeSnapshotGeneration, error)
+	// GetSnapshotLinkProgress returns the progress of the copy of a snapshot generation to each of its linked targets
+	GetSnapshotLinkProgress(ctx context.Context, symID, volumeID, snapID string, generation int64) ([]types.SnapshotLinkProgress, error)
 	// GetReplicationCapabilities returns details about SnapVX and SRDF execution capabilities on the Symmetrix array
 	GetReplicationCapabilities(ctx context.Context) (*types.SymReplicationCapabilities, error)
-	// GetPrivVolumeByID returns a Volume structure given the symmetrix and volume ID (volume ID is in WWN format)
-	GetPrivVolumeByID(ctx context.Context, symID string, volumeID string) (*types.VolumeResultPrivate, error)
-
-	// DeletePortGroup deletes a port group
-	DeletePortGroup(ctx context.Context, symID string, portGroupID string) error
-	// UpdatePortGroup updates a port group
-	UpdatePortGroup(ctx context.Context, symID string, portGroupID string, ports []types.PortKey) (*types.PortGroup, error)
-
-	// ModifyMobilityForVolume allows enabling/disabling mobility id for the volume
-	ModifyMobilityForVolume(ctx context.Context, symID string, volumeID string, mobility bool) (*types.Volume, error)
-	// ExpandVolume expands the size of an existing volume
-	ExpandVolume(ctx context.Context, symID string, volumeID string, rdfGNo int, volumeSize interface{}, capUnits ...string) (*types.Volume, error)
-	// GetCreateVolInSGPayload returns a payload to create a volume in a storage group
-	GetCreateVolInSGPayload(volumeSize interface{}, capUnit string, volumeName string, isSync, enableMobility bool, remoteSymID, storageGroupID string, opts ...http.Header) (payload interface{})
-	// GetCreateVolInSGPayloadWithMetaDataHeaders(sizeInCylinders int, volumeName string, isSync bool, remoteSymID, remoteStorageGroupID string, metadata http.Header) (payload interface{})
 
 	// GetRDFGroupList GetRDFGroupList fetches all RDF group
 	GetRDFGroupList(ctx context.Context, symID string, queryParams types.QueryParams) (*types.RDFGroupList, error)
+	// GetFilteredRDFGroupList returns the RDF groups selected by remote array, group type and volume count range
+	GetFilteredRDFGroupList(ctx context.Context, symID string, filter types.RDFGroupFilter) (*types.RDFGroupList, error)
 	// GetRDFGroupByID fetches RDF group information
 	GetRDFGroupByID(ctx context.Context, symID, rdfGroup string) (*types.RDFGroup, error)
+	// GetSRDFAAttributes returns the SRDF/A session attributes of an RDF group
+	GetSRDFAAttributes(ctx context.Context, symID, rdfGroupNo string) (*types.SRDFAAttributes, error)
+	// SetSRDFAAttributes changes the SRDF/A session attributes of an RDF group
+	SetSRDFAAttributes(ctx context.Context, symID, rdfGroupNo string, param types.SetSRDFAAttributesParam) (*types.SRDFAAttributes, error)
 
 	// GetProtectedStorageGroup returns protected storage group given the storage group ID
 	GetProtectedStorageGroup(ctx context.Context, symID, storageGroup string) (*types.RDFStorageGroup, error)
@@ -344,11 +513,42 @@ type Pmax interface {
 	CreateSGReplica(ctx context.Context, symID, remoteSymID, rdfMode, rdfGroupNo, sourceSG, remoteSGName, remoteServiceLevel string, bias bool) (*types.SGRDFInfo, error)
 	// ExecuteReplicationActionOnSG executes supported replication based actions on the protected SG
 	ExecuteReplicationActionOnSG(ctx context.Context, symID, action, storageGroup, rdfGroup string, force, exemptConsistency, bias bool) error
-
+	// ValidateReplicationActionOnSG checks that an SRDF action is valid in the current state of the pairs of the protected SG
+	ValidateReplicationActionOnSG(ctx context.Context, symID, action, storageGroup, rdfGroup string) error
+	// VerifyReplicationStateOnSG verifies, optionally waiting, that the pairs of the protected SG are in one of the expected states
+	VerifyReplicationStateOnSG(ctx context.Context, symID, storageGroup, rdfGroup string, expected []string, wait, pollInterval time.Duration) (*types.StorageGroupRDFG, error)
+	// CopySnapshotToRemoteArray links a storage group snapshot to a staging storage group, protects it with SRDF and splits the pairs once synchronized
+	CopySnapshotToRemoteArray(ctx context.Context, symID string, param types.RemoteSnapshotCopyParam, pollInterval time.Duration) (*types.StorageGroupRDFG, error)
+
+	// VerifyRemoteArrayConnectivity checks the RDF links and RDF groups between the local and remote arrays before replication setup
+	VerifyRemoteArrayConnectivity(ctx context.Context, localSymID, remoteSymID string) (*types.RemoteArrayConnectivity, error)
+	// SuggestRDFPairs proposes remote devices matching the local devices by size and emulation and returns the CreateRDFPair payloads
+	SuggestRDFPairs(ctx context.Context, localSymID, remoteSymID string, localVolumeIDs, remoteCandidateIDs []string, rdfMode, rdfType string, establish, exemptConsistency bool) (*types.RDFPairSuggestion, error)
 	// CreateRDFPair creates a volume replication pair
 	CreateRDFPair(ctx context.Context, symID, rdfGroupNo, deviceID, rdfMode, rdfType string, establish, exemptConsistency bool) (*types.RDFDevicePairList, error)
 	// GetRDFDevicePairInfo returns RDF volume information
 	GetRDFDevicePairInfo(ctx context.Context, symID, rdfGroup, volumeID string) (*types.RDFDevicePair, error)
+	// GetRemoteRDFGroup returns the remote array's view of one of its RDF groups, through the remote_symmetrix endpoints
+	GetRemoteRDFGroup(ctx context.Context, symID, remoteSymID, remoteRDFGroupNo string) (*types.RDFGroup, error)
+	// GetRemoteRDFDevicePairInfo returns the remote array's view of the RDF pair of one of its volumes, through the remote_symmetrix endpoints
+	GetRemoteRDFDevicePairInfo(ctx context.Context, symID, remoteSymID, remoteRDFGroupNo, remoteVolumeID string) (*types.RDFDevicePair, error)
+	// GetRDFInfoForVolume returns the RDF pairs of a volume in all the RDF groups it is in
+	GetRDFInfoForVolume(ctx context.Context, symID, volumeID string) (*types.VolumeRDFInfo, error)
+	// GetRDFGroupSides returns an RDF group as seen from the local array and, if reachable, from the remote array
+	GetRDFGroupSides(ctx context.Context, symID, rdfGroupNo string) (*types.RDFGroupSides, error)
+	// GetRDFDevicePairSides returns an RDF pair as seen from the local array and, if reachable, from the remote array
+	GetRDFDevicePairSides(ctx context.Context, symID, rdfGroupNo, volumeID string) (*types.RDFDevicePairSides, error)
+	// ResizeRDFPair expands both sides of an SRDF/S or SRDF/A pair by suspending it with consistency exempt,
+	// expanding the R2 and R1 volumes and resuming it
+	ResizeRDFPair(ctx context.Context, symID, storageGroup, rdfGroup, volumeID string, volumeSize int, capUnit string) (*types.RDFDevicePair, error)
+	// AddExistingVolumesToProtectedStorageGroup adds unpaired volumes to an SRDF/S or SRDF/A protected storage group,
+	// creating their pairs, adding the R2 volumes to the remote storage group and resuming the storage group
+	AddExistingVolumesToProtectedStorageGroup(ctx context.Context, symID, storageGroupID, rdfGroupNo, remoteStorageGroupID string, volumeIDs ...string) ([]types.RDFDevicePair, error)
+	// AddVolumesToMetroStorageGroup adds volumes to a storage group protected by SRDF/Metro, keeping the remote storage group consistent,
+	// and waits for the pairs to be ActiveActive
+	AddVolumesToMetroStorageGroup(ctx context.Context, symID, storageGroupID, rdfGroupNo, remoteStorageGroupID string, pollInterval time.Duration, volumeIDs ...string) (*types.MetroStorageGroupReport, error)
+	// SetR2ReadOnly write disables the R2 devices of the RDF pairs of the volumes, or read/write enables them if readOnly is false
+	SetR2ReadOnly(ctx context.Context, symID, rdfGroupNo string, readOnly bool, volumeIDs ...string) error
 	// GetStorageGroupRDFInfo returns the of RDF info of protected storage group
 	GetStorageGroupRDFInfo(ctx context.Context, symID, sgName, rdfGroupNo string) (*types.StorageGroupRDFG, error)
 	// GetFreeLocalAndRemoteRDFg returns list of Local and Remote Free RDFg in the array
@@ -363,19 +563,19 @@ type Pmax interface {
 	GetRemoteRDFPortOnSAN(ctx context.Context, localSymID string, rdfDir string, rdfPort string) (*types.RemoteRDFPortDetails, error)
 	// GetLocalRDFPortDetails returns details about the local RDFDir:port
 	GetLocalRDFPortDetails(ctx context.Context, localSymID string, rdfDir string, rdfPort int) (*types.RDFPortDetails, error)
-
-	// GetStorageGroupMetrics returns the list of required metrics
-	GetStorageGroupMetrics(ctx context.Context, symID string, storageGroupID string, metricsQuery []string, firstAvailableDate int64, lastAvailableTime int64) (*types.StorageGroupMetricsIterator, error)
-	// GetVolumesMetrics returns the list of volume metrics for specific storage groups
-	GetVolumesMetrics(ctx context.Context, symID string, storageGroups string, metricsQuery []string, firstAvailableDate int64, lastAvailableTime int64) (*types.VolumeMetricsIterator, error)
-	// GetStorageGroupPerfKeys returns the performance keys of storage group
-	GetStorageGroupPerfKeys(ctx context.Context, symID string) (*types.StorageGroupKeysResult, error)
-	// GetArrayPerfKeys returns the performance keys of array
-	GetArrayPerfKeys(ctx context.Context) (*types.ArrayKeysResult, error)
-	// GetVolumesMetricsByID returns a given Volume performance metrics
-	GetVolumesMetricsByID(ctx context.Context, symID string, volID string, metricsQuery []string, firstAvailableTime, lastAvailableTime int64) (*types.VolumeMetricsIterator, error)
-	// GetFileSystemMetricsByID returns a given FileSystem performance metrics
-	GetFileSystemMetricsByID(ctx context.Context, symID string, fsID string, metricsQuery []string, firstAvailableTime, lastAvailableTime int64) (*types.FileSystemMetricsIterator, error)
+	// GetRDFDirList returns all the RDF directors of the array, online or not
+	GetRDFDirList(ctx context.Context, symID string) (*types.RDFDirList, error)
+	// GetRDFDirDetails returns the details of an RDF director
+	GetRDFDirDetails(ctx context.Context, symID, rdfDir string) (*types.RDFDirDetails, error)
+	// GetRDFPortList returns all the ports of an RDF director, online or not
+	GetRDFPortList(ctx context.Context, symID, rdfDir string) (*types.RDFPortList, error)
+	// GetRDFPort returns the details of an RDF port, including the IP addresses of the GigE ports
+	GetRDFPort(ctx context.Context, symID, rdfDir string, rdfPort int) (*types.RDFPort, error)
+	// GetRDFPortInventory returns all the RDF directors of the array with their FC and GigE ports
+	GetRDFPortInventory(ctx context.Context, symID string) (*types.RDFPortInventory, error)
+	// GetTargetRDFPorts returns the online RDF ports of the array with their negotiated speed and protocol,
+	// and the remote arrays reachable through each of them
+	GetTargetRDFPorts(ctx context.Context, symID string) (*types.RDFConnections, error)
 
 	// CreateMigrationEnvironment creates a migration environment
 	CreateMigrationEnvironment(ctx context.Context, sourceSymID, remoteSymID string) (*types.MigrationEnv, error)
@@ -395,6 +595,10 @@ type Pmax interface {
 	GetStorageGroupMigration(ctx context.Context, localSymID string) (*types.MigrationStorageGroups, error)
 	// GetStorageGroupMigrationByID returns migration details for a storage group
 	GetStorageGroupMigrationByID(ctx context.Context, localSymID, storageGroupID string) (*types.MigrationSession, error)
+	// GetStorageGroupMigrationDevicePairs returns the state and progress of each device pair of a storage group migration
+	GetStorageGroupMigrationDevicePairs(ctx context.Context, localSymID, storageGroupID string) (*types.MigrationDevicePairStates, error)
+	// GetStorageGroupMigrationDevicePair returns the state and progress of the device pair of a volume in a storage group migration
+	GetStorageGroupMigrationDevicePair(ctx context.Context, localSymID, storageGroupID, volumeID string) (*types.MigrationDevicePairState, error)
 
 	// GetSnapshotPolicy returns a SnapshotPolicy given the Symmetrix ID and SnapshotPolicy ID (which is really a name).
 	GetSnapshotPolicy(ctx context.Context, symID string, snapshotPolicyID string) (*types.SnapshotPolicy, error)
@@ -407,7 +611,147 @@ type Pmax interface {
 		complianceCountCritical int64, optionalPayload map[string]interface{}) (*types.SnapshotPolicy, error)
 	// UpdateSnapshotPolicy is a general method to update a SnapshotPolicy (PUT operation) based on the action using a UpdateSnapshotPolicyPayload.
 	UpdateSnapshotPolicy(ctx context.Context, symID string, action string, snapshotPolicyID string, optionalPayload map[string]interface{}) error
+	// SuspendSnapshotPolicy stops a SnapshotPolicy from taking snapshots, and returns the policy.
+	SuspendSnapshotPolicy(ctx context.Context, symID string, snapshotPolicyID string) (*types.SnapshotPolicy, error)
+	// ResumeSnapshotPolicy restarts a suspended SnapshotPolicy, and returns the policy.
+	ResumeSnapshotPolicy(ctx context.Context, symID string, snapshotPolicyID string) (*types.SnapshotPolicy, error)
+	// GetSnapshotPolicyStorageGroupList returns the names of the storage groups associated with a SnapshotPolicy
+	GetSnapshotPolicyStorageGroupList(ctx context.Context, symID string, snapshotPolicyID string) (*types.SnapshotPolicyStorageGroupList, error)
+	// ModifySnapshotPolicies applies the same modification to several SnapshotPolicies, and returns the outcome
+	// of each modification with the storage groups affected by it.
+	ModifySnapshotPolicies(ctx context.Context, symID string, modify *types.ModifySnapshotPolicyParam, snapshotPolicyIDs ...string) ([]types.SnapshotPolicyModification, error)
+	// GetSnapshotPolicySummary returns all the SnapshotPolicies of an array with their next run and the compliance
+	// of their storage groups, reading them in parallel, for reporting.
+	GetSnapshotPolicySummary(ctx context.Context, symID string) (*types.SnapshotPolicySummaryReport, error)
+}
+
+// SystemClient has the methods reading and configuring a Symmetrix as a whole: arrays, jobs, alerts, thresholds and health
+type SystemClient interface {
+	// GetSymmetrixIDList gets symmetrix list
+	GetSymmetrixIDList(ctx context.Context) (*types.SymmetrixIDList, error)
+	// GetSymmetrixByID gets symmetrix by given ID
+	GetSymmetrixByID(ctx context.Context, id string) (*types.Symmetrix, error)
+	// GetUnisphereVersion returns the version of the connected Unisphere
+	GetUnisphereVersion(ctx context.Context) (*types.Version, error)
+	// VerifySupport returns an UnsupportedVersionError if Unisphere or the array are too old for the client method
+	VerifySupport(ctx context.Context, symID, method string) error
+	// RequiresThinPools returns true if the array is older than PowerMaxOS 5977 and provisions from thin pools
+	RequiresThinPools(ctx context.Context, symID string) (bool, error)
+
+	// GetArraySummary returns the model, ucode, service tag, connectivity and storage pool capacity of an array in one call
+	GetArraySummary(ctx context.Context, symID string) (*types.ArraySummary, error)
+
+	// GetArrayConfiguration returns the storage groups, masking views, hosts, port groups, volumes and RDF groups of an array,
+	// normalized so that two configurations of an unchanged array are equal
+	GetArrayConfiguration(ctx context.Context, symID string) (*types.ArrayConfiguration, error)
+	// ExportArrayConfiguration writes the configuration of an array to w as JSON or CSV
+	ExportArrayConfiguration(ctx context.Context, symID string, format types.ExportFormat, w io.Writer) error
+	// GetOrphanedResources returns the unused volumes, storage groups and port groups of an array, and its expired snapshots
+	GetOrphanedResources(ctx context.Context, symID string) (*types.OrphanedResources, error)
+	// GetOrphanedVolumes returns the volumes not in any storage group
+	GetOrphanedVolumes(ctx context.Context, symID string) ([]string, error)
+	// GetOrphanedStorageGroups returns the storage groups not in any masking view, directly or through a parent
+	GetOrphanedStorageGroups(ctx context.Context, symID string) ([]string, error)
+	// GetOrphanedPortGroups returns the port groups not in any masking view
+	GetOrphanedPortGroups(ctx context.Context, symID string) ([]string, error)
+	// GetExpiredSnapshots returns the storage group snapshots past their time to live
+	GetExpiredSnapshots(ctx context.Context, symID string) ([]types.ExpiredSnapshot, error)
+
+	// GetUserList returns the ids of the Unisphere users
+	GetUserList(ctx context.Context) (*types.UserList, error)
+	// GetUser returns a Unisphere user and its roles
+	GetUser(ctx context.Context, userID string) (*types.User, error)
+	// GetArrayUsers returns the users having a role on an array
+	GetArrayUsers(ctx context.Context, symID string) (*types.ArrayUserList, error)
+	// AssignRole gives a role to a user on an array, or on all the arrays if symID is empty
+	AssignRole(ctx context.Context, userID, symID, role string) error
+	// RevokeRole removes a role from a user on an array, or on all the arrays if symID is empty
+	RevokeRole(ctx context.Context, userID, symID, role string) error
+
+	// CreateLogBundle starts the collection of a support log bundle on an array
+	CreateLogBundle(ctx context.Context, symID string, param *types.CreateLogBundleParam) (*types.LogBundle, error)
+	// GetLogBundleList returns the ids of the log bundles of an array
+	GetLogBundleList(ctx context.Context, symID string) (*types.LogBundleList, error)
+	// GetLogBundle returns a log bundle, with the progress of its collection
+	GetLogBundle(ctx context.Context, symID, logBundleID string) (*types.LogBundle, error)
+	// WaitForLogBundle waits until the collection of a log bundle is over
+	WaitForLogBundle(ctx context.Context, symID, logBundleID string, pollInterval time.Duration) (*types.LogBundle, error)
+	// DownloadLogBundle streams a collected log bundle to w
+	DownloadLogBundle(ctx context.Context, symID, logBundleID string, w io.Writer) (int64, error)
+	// DeleteLogBundle deletes a log bundle from an array
+	DeleteLogBundle(ctx context.Context, symID, logBundleID string) error
+	// CollectLogBundle collects a support log bundle of an array, waits for the collection and streams the bundle to w
+	CollectLogBundle(ctx context.Context, symID string, param *types.CreateLogBundleParam, pollInterval time.Duration, w io.Writer) (*types.LogBundle, error)
 
+	// GetJobIDList retrieves the list of jobs on a given Symmetrix.
+	// If optional parameter statusQuery is a types.JobStatusRunning or similar string, will search for jobs
+	// with a particular status.
+	GetJobIDList(ctx context.Context, symID string, statusQuery string) ([]string, error)
+	GetJobByID(ctx context.Context, symID string, jobID string) (*types.Job, error)
+	WaitOnJobCompletion(ctx context.Context, symID string, jobID string) (*types.Job, error)
+	JobToString(job *types.Job) string
+
+	// ListJobsSince, ListAlertsSince and ListAuditLogRecordsSince return the jobs, alerts and audit log records
+	// since the given time, querying a chunk of history at a time and splitting the chunks whose result is capped by Unisphere
+	ListJobsSince(ctx context.Context, symID string, since time.Time, chunk time.Duration) ([]string, error)
+	ListAlertsSince(ctx context.Context, symID string, since time.Time, chunk time.Duration) ([]string, error)
+	ListAuditLogRecordsSince(ctx context.Context, symID string, since time.Time, chunk time.Duration) ([]types.AuditLogRecord, error)
+
+	// GetCapacityThresholds returns the capacity alert thresholds of the array, or of a storage pool if storagePoolID is set
+	GetCapacityThresholds(ctx context.Context, symID string, storagePoolID string) (*types.CapacityThresholds, error)
+	// SetCapacityThresholds sets the capacity alert thresholds of the array, or of a storage pool if storagePoolID is set
+	SetCapacityThresholds(ctx context.Context, symID string, storagePoolID string, thresholds *types.CapacityThresholds) error
+	// GetAlertNotificationPolicies returns the alert notification policies of the array
+	GetAlertNotificationPolicies(ctx context.Context, symID string) (*types.AlertNotificationPolicyList, error)
+	// SetAlertNotificationPolicy enables or disables an alert notification policy and sets how it is notified
+	SetAlertNotificationPolicy(ctx context.Context, symID string, policy *types.AlertNotificationPolicy) error
+	// GetEncryptionStatus returns the data at rest encryption (D@RE) capability and status of the array
+	GetEncryptionStatus(ctx context.Context, symID string) (*types.EncryptionStatus, error)
+	// GetKeyManagerConfig returns the key manager of the array and its external KMIP servers
+	GetKeyManagerConfig(ctx context.Context, symID string) (*types.KeyManagerConfig, error)
+	// GetSystemHealth returns the health scores and the number of failed disks of the array
+	GetSystemHealth(ctx context.Context, symID string) (*types.SystemHealth, error)
+	// GetServerLoad returns the CPU and memory utilization and the request counts of the Unisphere management server
+	GetServerLoad(ctx context.Context) (*types.ServerLoad, error)
+	// GetHealthCheckList returns the ids of the health checks run on the array
+	GetHealthCheckList(ctx context.Context, symID string) (*types.HealthCheckList, error)
+	// GetHealthCheck returns the test results of a health check run on the array
+	GetHealthCheck(ctx context.Context, symID, healthCheckID string) (*types.HealthCheck, error)
+	// GetUpgradeReadiness checks whether the array is healthy enough to be upgraded to a PowerMaxOS version
+	GetUpgradeReadiness(ctx context.Context, symID, targetUcode string) (*types.UpgradeReadiness, error)
+
+	// RefreshSymmetrix refreshes cache on the symID
+	RefreshSymmetrix(ctx context.Context, symID string) error
+}
+
+// PerformanceClient has the methods reading the performance metrics of a Symmetrix
+type PerformanceClient interface {
+	// GetStorageGroupMetrics returns the list of required metrics
+	GetStorageGroupMetrics(ctx context.Context, symID string, storageGroupID string, metricsQuery []string, firstAvailableDate int64, lastAvailableTime int64) (*types.StorageGroupMetricsIterator, error)
+	// GetVolumesMetrics returns the list of volume metrics for specific storage groups
+	GetVolumesMetrics(ctx context.Context, symID string, storageGroups string, metricsQuery []string, firstAvailableDate int64, lastAvailableTime int64) (*types.VolumeMetricsIterator, error)
+	// GetStorageGroupPerfKeys returns the performance keys of storage group
+	GetStorageGroupPerfKeys(ctx context.Context, symID string) (*types.StorageGroupKeysResult, error)
+	// GetArrayPerfKeys returns the performance keys of array
+	GetArrayPerfKeys(ctx context.Context) (*types.ArrayKeysResult, error)
+	// RegisterArrayForPerformance registers an array for performance metrics collection
+	RegisterArrayForPerformance(ctx context.Context, symID string, realTime bool) error
+	// UnregisterArrayForPerformance stops the performance metrics collection of an array
+	UnregisterArrayForPerformance(ctx context.Context, symID string) error
+	// GetPerformanceRegistration returns the performance metrics collection registration and backlog of an array
+	GetPerformanceRegistration(ctx context.Context, symID string) (*types.PerformanceRegistrationStatus, error)
+	// GetVolumesMetricsByID returns a given Volume performance metrics
+	GetVolumesMetricsByID(ctx context.Context, symID string, volID string, metricsQuery []string, firstAvailableTime, lastAvailableTime int64) (*types.VolumeMetricsIterator, error)
+	// GetFEPortMetrics returns the performance metrics of a front-end director port
+	GetFEPortMetrics(ctx context.Context, symID string, directorID string, portID string, metricsQuery []string, firstAvailableTime, lastAvailableTime int64) (*types.FEPortMetricsIterator, error)
+	// GetVolumesMetricsByIDs returns the performance metrics of many volumes, querying consecutive volumes as one range
+	GetVolumesMetricsByIDs(ctx context.Context, symID string, volIDs []string, metricsQuery []string, firstAvailableTime, lastAvailableTime int64) (*types.VolumeMetricsIterator, error)
+	// GetFileSystemMetricsByID returns a given FileSystem performance metrics
+	GetFileSystemMetricsByID(ctx context.Context, symID string, fsID string, metricsQuery []string, firstAvailableTime, lastAvailableTime int64) (*types.FileSystemMetricsIterator, error)
+}
+
+// FileClient has the methods managing the file systems, NFS exports, NAS servers and file interfaces of a Symmetrix
+type FileClient interface {
 	// GetFileSystemList get file system list on a symID
 	GetFileSystemList(ctx context.Context, symID string, query types.QueryParams) (*types.FileSystemIterator, error)
 	// GetFileSystemByID get file system  on a symID
@@ -438,7 +782,16 @@ type Pmax interface {
 	DeleteNASServer(ctx context.Context, symID, nasID string) error
 	// GetFileInterfaceByID gets a FileInterface
 	GetFileInterfaceByID(ctx context.Context, symID, interfaceID string) (*types.FileInterface, error)
-
-	// RefreshSymmetrix refreshes cache on the symID
-	RefreshSymmetrix(ctx context.Context, symID string) error
+	// GetFileSystemSnapshotList gets the snapshots of a file system, or of all the file systems if fsID is empty
+	GetFileSystemSnapshotList(ctx context.Context, symID, fsID string) (*types.FileSystemSnapshotIterator, error)
+	// GetFileSystemSnapshotByID gets a file system snapshot
+	GetFileSystemSnapshotByID(ctx context.Context, symID, snapID string) (*types.FileSystemSnapshot, error)
+	// CreateFileSystemSnapshot creates a snapshot of a file system
+	CreateFileSystemSnapshot(ctx context.Context, symID string, payload types.CreateFileSystemSnapshot) (*types.FileSystemSnapshot, error)
+	// RestoreFileSystemSnapshot restores a file system to the content of one of its snapshots
+	RestoreFileSystemSnapshot(ctx context.Context, symID, snapID string, payload types.RestoreFileSystemSnapshot) error
+	// DeleteFileSystemSnapshot deletes a file system snapshot
+	DeleteFileSystemSnapshot(ctx context.Context, symID, snapID string) error
+	// CreateNFSExportOnSnapshot shares a file system snapshot with an NFS export
+	CreateNFSExportOnSnapshot(ctx context.Context, symID, snapID string, createNFSExportPayload types.CreateNFSExport) (*types.NFSExport, error)
 }
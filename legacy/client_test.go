@@ -0,0 +1,81 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package legacy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	pmax "github.com/dell/gopowermax/v2"
+)
+
+// TestClientInSync checks that client_gen.go has a method without context for every method of pmax.Pmax taking one,
+// i.e. that go generate was run after the interface changed
+func TestClientInSync(t *testing.T) {
+	contextType := reflect.TypeOf((*context.Context)(nil)).Elem()
+	pmaxType := reflect.TypeOf((*pmax.Pmax)(nil)).Elem()
+	clientType := reflect.TypeOf(&Client{})
+	for i := 0; i < pmaxType.NumMethod(); i++ {
+		method := pmaxType.Method(i)
+		if method.Type.NumIn() == 0 || method.Type.In(0) != contextType {
+			continue
+		}
+		wrapper, ok := clientType.MethodByName(method.Name)
+		if !ok {
+			t.Errorf("%s is not wrapped, run go generate", method.Name)
+			continue
+		}
+		expected := make([]reflect.Type, 0, method.Type.NumIn())
+		expected = append(expected, clientType)
+		for j := 1; j < method.Type.NumIn(); j++ {
+			expected = append(expected, method.Type.In(j))
+		}
+		out := make([]reflect.Type, 0, method.Type.NumOut())
+		for j := 0; j < method.Type.NumOut(); j++ {
+			out = append(out, method.Type.Out(j))
+		}
+		if want := reflect.FuncOf(expected, out, method.Type.IsVariadic()); wrapper.Type != want {
+			t.Errorf("%s is %s, expected %s, run go generate", method.Name, wrapper.Type, want)
+		}
+	}
+}
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/univmax/restapi/100/system/symmetrix" {
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		_, _ = resp.Write([]byte(`{"symmetrixId":["000000000001"]}`))
+	}))
+	defer server.Close()
+
+	client, err := pmax.NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	symmetrixIDs, err := New(client).GetSymmetrixIDList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(symmetrixIDs.SymmetrixIDs) != 1 || symmetrixIDs.SymmetrixIDs[0] != "000000000001" {
+		t.Fatalf("unexpected symmetrix IDs %+v", symmetrixIDs)
+	}
+}
@@ -0,0 +1,27 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+// Package legacy wraps a pmax client with methods without a context, for the callers migrating from
+// the SDKs whose methods did not take one:
+//
+//	client, err := pmax.New("https://1.2.3.4:8443", pmax.WithTimeout(2*time.Minute))
+//	...
+//	vol, err := legacy.New(client).GetVolumeByID(symID, volumeID)
+//
+// The methods call the client with context.Background, so each call is bounded by the default timeout
+// of the client. They are generated from the interfaces of the pmax package by gen.go; run go generate
+// after changing them.
+package legacy
+
+//go:generate go run gen.go
@@ -0,0 +1,271 @@
+//go:build ignore
+
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+// gen.go generates client_gen.go, the methods of Client without a context, from the Pmax interface of
+// ../interface.go. It is run by go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	source     = "../interface.go"
+	output     = "client_gen.go"
+	pmaxImport = "github.com/dell/gopowermax/v2"
+)
+
+// generator collects the wrappers of the methods of an interface and the imports they need
+type generator struct {
+	fset       *token.FileSet
+	interfaces map[string]*ast.InterfaceType
+	imports    map[string]string
+	used       map[string]bool
+	seen       map[string]bool
+	body       bytes.Buffer
+}
+
+func main() {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, source, nil, parser.ParseComments)
+	if err != nil {
+		log.Fatal(err)
+	}
+	g := &generator{
+		fset:       fset,
+		interfaces: make(map[string]*ast.InterfaceType),
+		imports:    make(map[string]string),
+		used:       map[string]bool{"context": true, "pmax": true},
+		seen:       make(map[string]bool),
+	}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		g.imports[name] = path
+	}
+	g.imports["pmax"] = pmaxImport
+	ast.Inspect(file, func(n ast.Node) bool {
+		if spec, ok := n.(*ast.TypeSpec); ok {
+			if iface, ok := spec.Type.(*ast.InterfaceType); ok {
+				g.interfaces[spec.Name.Name] = iface
+			}
+		}
+		return true
+	})
+	if err = g.wrapInterface("Pmax"); err != nil {
+		log.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	header, err := os.ReadFile("client.go")
+	if err != nil {
+		log.Fatal(err)
+	}
+	// reuse the license header of client.go
+	out.Write(header[:bytes.Index(header, []byte("package "))])
+	out.WriteString("// Code generated by gen.go from interface.go. DO NOT EDIT.\n\npackage legacy\n\n")
+	out.WriteString(g.importBlock())
+	out.Write(g.body.Bytes())
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		log.Fatalf("formatting the generated code: %s\n%s", err, out.String())
+	}
+	if err = os.WriteFile(output, formatted, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// wrapInterface writes the wrappers of the methods of the interface and of the interfaces it embeds
+func (g *generator) wrapInterface(name string) error {
+	iface, ok := g.interfaces[name]
+	if !ok {
+		return fmt.Errorf("interface %s not found in %s", name, source)
+	}
+	for _, field := range iface.Methods.List {
+		if len(field.Names) == 0 {
+			embedded, ok := field.Type.(*ast.Ident)
+			if !ok {
+				return fmt.Errorf("unsupported embedded interface in %s", name)
+			}
+			if err := g.wrapInterface(embedded.Name); err != nil {
+				return err
+			}
+			continue
+		}
+		method := field.Names[0].Name
+		funcType := field.Type.(*ast.FuncType)
+		if g.seen[method] || !takesContext(funcType) {
+			continue
+		}
+		g.seen[method] = true
+		g.wrapMethod(method, field.Doc, funcType)
+	}
+	return nil
+}
+
+// takesContext returns true if the first parameter of the method is a context.Context
+func takesContext(funcType *ast.FuncType) bool {
+	if len(funcType.Params.List) == 0 {
+		return false
+	}
+	selector, ok := funcType.Params.List[0].Type.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := selector.X.(*ast.Ident)
+	return ok && pkg.Name == "context" && selector.Sel.Name == "Context"
+}
+
+// wrapMethod writes the wrapper of a method, calling it with a background context
+func (g *generator) wrapMethod(method string, doc *ast.CommentGroup, funcType *ast.FuncType) {
+	var params, args []string
+	first := funcType.Params.List[0]
+	if len(first.Names) > 1 {
+		// the context shares its declaration with other parameters, which cannot happen as their types differ
+		log.Fatalf("unexpected parameters of %s", method)
+	}
+	for i, field := range funcType.Params.List[1:] {
+		names := make([]string, 0, len(field.Names))
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+		if len(names) == 0 {
+			names = append(names, fmt.Sprintf("arg%d", i))
+		}
+		params = append(params, strings.Join(names, ", ")+" "+g.typeString(field.Type))
+		for _, name := range names {
+			if _, variadic := field.Type.(*ast.Ellipsis); variadic {
+				name += "..."
+			}
+			args = append(args, name)
+		}
+	}
+	results := ""
+	if funcType.Results != nil && len(funcType.Results.List) > 0 {
+		var types []string
+		for _, field := range funcType.Results.List {
+			for range max(1, len(field.Names)) {
+				types = append(types, g.typeString(field.Type))
+			}
+		}
+		results = " " + strings.Join(types, ", ")
+		if len(types) > 1 {
+			results = " (" + strings.Join(types, ", ") + ")"
+		}
+	}
+
+	if doc != nil {
+		for _, comment := range doc.List {
+			g.body.WriteString(comment.Text + "\n")
+		}
+	} else {
+		fmt.Fprintf(&g.body, "// %s calls %s of the wrapped client with a background context\n", method, method)
+	}
+	fmt.Fprintf(&g.body, "func (c *Client) %s(%s)%s {\n\t", method, strings.Join(params, ", "), results)
+	if results != "" {
+		g.body.WriteString("return ")
+	}
+	fmt.Fprintf(&g.body, "c.Pmax.%s(%s)\n}\n\n", method, strings.Join(append([]string{"context.Background()"}, args...), ", "))
+}
+
+// typeString prints the type, qualifying the types of the pmax package with their package name and
+// recording the packages used
+func (g *generator) typeString(expr ast.Expr) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, g.fset, g.qualify(expr)); err != nil {
+		log.Fatal(err)
+	}
+	return buf.String()
+}
+
+// qualify returns the type with the exported identifiers of the pmax package qualified by pmax
+func (g *generator) qualify(expr ast.Expr) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if ast.IsExported(e.Name) {
+			return &ast.SelectorExpr{X: ast.NewIdent("pmax"), Sel: ast.NewIdent(e.Name)}
+		}
+		return e
+	case *ast.SelectorExpr:
+		if pkg, ok := e.X.(*ast.Ident); ok {
+			g.used[pkg.Name] = true
+		}
+		return e
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: g.qualify(e.X)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: e.Len, Elt: g.qualify(e.Elt)}
+	case *ast.MapType:
+		return &ast.MapType{Key: g.qualify(e.Key), Value: g.qualify(e.Value)}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: g.qualify(e.Elt)}
+	case *ast.ChanType:
+		return &ast.ChanType{Dir: e.Dir, Value: g.qualify(e.Value)}
+	case *ast.FuncType:
+		return &ast.FuncType{Params: g.qualifyFields(e.Params), Results: g.qualifyFields(e.Results)}
+	default:
+		return expr
+	}
+}
+
+// qualifyFields returns the fields with their types qualified
+func (g *generator) qualifyFields(fields *ast.FieldList) *ast.FieldList {
+	if fields == nil {
+		return nil
+	}
+	qualified := &ast.FieldList{}
+	for _, field := range fields.List {
+		qualified.List = append(qualified.List, &ast.Field{Names: field.Names, Type: g.qualify(field.Type)})
+	}
+	return qualified
+}
+
+// importBlock returns the imports of the packages used, the standard library first
+func (g *generator) importBlock() string {
+	var std, other []string
+	for name := range g.used {
+		path, ok := g.imports[name]
+		if !ok {
+			log.Fatalf("import of package %s not found in %s", name, source)
+		}
+		spec := strconv.Quote(path)
+		if path[strings.LastIndex(path, "/")+1:] != name {
+			spec = name + " " + spec
+		}
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			other = append(other, spec)
+		} else {
+			std = append(std, spec)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+	return "import (\n\t" + strings.Join(std, "\n\t") + "\n\n\t" + strings.Join(other, "\n\t") + "\n)\n\n"
+}
@@ -0,0 +1,268 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pmax
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+	log "github.com/sirupsen/logrus"
+)
+
+// Metadata is stored on the array as text restricted to the characters accepted in tags and volume identifiers.
+// An entry key=value is written as the escaped key, an underscore and the escaped value. Letters and digits are
+// kept and any other byte is escaped as a hyphen followed by its two hexadecimal digits, e.g. "cluster-1" is
+// written "cluster-2D1". On a storage group, every entry is a tag prefixed by StorageGroupMetadataTagPrefix.
+// On a volume, the entries follow the name in the volume identifier, after VolumeMetadataSeparator and separated
+// by dots, e.g. "pvc-1.md.owner_csi.cluster_c1".
+const (
+	// StorageGroupMetadataTagPrefix prefixes the storage group tags holding metadata
+	StorageGroupMetadataTagPrefix = "md_"
+	// VolumeMetadataSeparator separates the name of a volume from its metadata in the volume identifier
+	VolumeMetadataSeparator = ".md."
+	// MaxStorageGroupTagLength is the maximum length of a storage group tag, so of a metadata entry once escaped
+	MaxStorageGroupTagLength = 64
+	// MaxVolumeIdentifierLength is the maximum length of a volume identifier, so of the name and all the metadata
+	// entries of a volume once escaped
+	MaxVolumeIdentifierLength = 64
+)
+
+// SetStorageGroupMetadata sets the metadata entries of a storage group, stored as tags, e.g. to mark the storage groups
+// owned by a cluster. The other entries are kept, and an entry with an empty value is removed. Nothing is changed
+// if an entry exceeds MaxStorageGroupTagLength once escaped.
+func (c *Client) SetStorageGroupMetadata(ctx context.Context, symID, storageGroupID string, metadata map[string]string) error {
+	defer c.TimeSpent("SetStorageGroupMetadata", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return err
+	}
+	sg, err := c.GetStorageGroup(ctx, symID, storageGroupID)
+	if err != nil {
+		return err
+	}
+	current := storageGroupMetadataTags(sg.Tags)
+	add, remove := []string{}, []string{}
+	for _, key := range slices.Sorted(maps.Keys(metadata)) {
+		if key == "" {
+			return errors.New("metadata keys cannot be empty")
+		}
+		tag := ""
+		if value := metadata[key]; value != "" {
+			tag = StorageGroupMetadataTagPrefix + encodeMetadataEntry(key, value)
+			if len(tag) > MaxStorageGroupTagLength {
+				return fmt.Errorf("the metadata entry %s of storage group %s is %d characters long once escaped, the maximum is %d",
+					key, storageGroupID, len(tag), MaxStorageGroupTagLength)
+			}
+		}
+		if tag == current[key] {
+			continue
+		}
+		if current[key] != "" {
+			remove = append(remove, current[key])
+		}
+		if tag != "" {
+			add = append(add, tag)
+		}
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return nil
+	}
+	tags := &types.TagManagementParam{}
+	if len(add) > 0 {
+		tags.AddTagsParam = &types.AddTagsParam{TagName: add}
+	}
+	if len(remove) > 0 {
+		tags.RemoveTagsParam = &types.RemoveTagsParam{TagName: remove}
+	}
+	payload := &types.UpdateStorageGroupPayload{
+		EditStorageGroupActionParam: types.EditStorageGroupActionParam{TagManagementParam: tags},
+		ExecutionOption:             types.ExecutionOptionSynchronous,
+	}
+	ifDebugLogPayload(payload)
+	if err = c.UpdateStorageGroupS(ctx, symID, storageGroupID, payload); err != nil {
+		log.Error("SetStorageGroupMetadata failed: " + err.Error())
+		return err
+	}
+	log.Info(fmt.Sprintf("Successfully set the metadata of storage group: %s", storageGroupID))
+	return nil
+}
+
+// GetStorageGroupMetadata returns the metadata entries of a storage group, set by SetStorageGroupMetadata.
+// The other tags of the storage group are ignored.
+func (c *Client) GetStorageGroupMetadata(ctx context.Context, symID, storageGroupID string) (map[string]string, error) {
+	defer c.TimeSpent("GetStorageGroupMetadata", time.Now())
+	sg, err := c.GetStorageGroup(ctx, symID, storageGroupID)
+	if err != nil {
+		return nil, err
+	}
+	metadata := map[string]string{}
+	for key, tag := range storageGroupMetadataTags(sg.Tags) {
+		_, metadata[key], _ = decodeMetadataEntry(strings.TrimPrefix(tag, StorageGroupMetadataTagPrefix))
+	}
+	return metadata, nil
+}
+
+// SetVolumeMetadata sets the metadata entries of a volume, stored in its volume identifier after its name.
+// The name and the other entries are kept, and an entry with an empty value is removed. Nothing is changed
+// if the identifier would exceed MaxVolumeIdentifierLength.
+func (c *Client) SetVolumeMetadata(ctx context.Context, symID, volumeID string, metadata map[string]string) error {
+	defer c.TimeSpent("SetVolumeMetadata", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return err
+	}
+	vol, err := c.GetVolumeByID(ctx, symID, volumeID)
+	if err != nil {
+		return err
+	}
+	name, current := splitVolumeIdentifier(vol.VolumeIdentifier)
+	for key, value := range metadata {
+		if key == "" {
+			return errors.New("metadata keys cannot be empty")
+		}
+		if value == "" {
+			delete(current, key)
+		} else {
+			current[key] = value
+		}
+	}
+	identifier := name
+	if len(current) > 0 {
+		entries := make([]string, 0, len(current))
+		for _, key := range slices.Sorted(maps.Keys(current)) {
+			entries = append(entries, encodeMetadataEntry(key, current[key]))
+		}
+		identifier += VolumeMetadataSeparator + strings.Join(entries, ".")
+	}
+	if identifier == vol.VolumeIdentifier {
+		return nil
+	}
+	if len(identifier) > MaxVolumeIdentifierLength {
+		return fmt.Errorf("the identifier of volume %s with its metadata is %d characters long, the maximum is %d",
+			volumeID, len(identifier), MaxVolumeIdentifierLength)
+	}
+	if _, err = c.RenameVolume(ctx, symID, volumeID, identifier); err != nil {
+		log.Error("SetVolumeMetadata failed: " + err.Error())
+		return err
+	}
+	return nil
+}
+
+// GetVolumeMetadata returns the metadata entries of a volume, set by SetVolumeMetadata
+func (c *Client) GetVolumeMetadata(ctx context.Context, symID, volumeID string) (map[string]string, error) {
+	defer c.TimeSpent("GetVolumeMetadata", time.Now())
+	vol, err := c.GetVolumeByID(ctx, symID, volumeID)
+	if err != nil {
+		return nil, err
+	}
+	_, metadata := splitVolumeIdentifier(vol.VolumeIdentifier)
+	return metadata, nil
+}
+
+// VolumeNameFromIdentifier returns the name of a volume, without the metadata set by SetVolumeMetadata
+func VolumeNameFromIdentifier(identifier string) string {
+	name, _ := splitVolumeIdentifier(identifier)
+	return name
+}
+
+// storageGroupMetadataTags returns the metadata tags of a storage group by key, from its comma-separated tags
+func storageGroupMetadataTags(tags string) map[string]string {
+	metadataTags := map[string]string{}
+	for _, tag := range strings.Split(tags, ",") {
+		tag = strings.TrimSpace(tag)
+		if !strings.HasPrefix(tag, StorageGroupMetadataTagPrefix) {
+			continue
+		}
+		if key, _, ok := decodeMetadataEntry(strings.TrimPrefix(tag, StorageGroupMetadataTagPrefix)); ok {
+			metadataTags[key] = tag
+		}
+	}
+	return metadataTags
+}
+
+// splitVolumeIdentifier returns the name and the metadata entries of a volume identifier.
+// The identifier is returned as the name if its metadata cannot be decoded.
+func splitVolumeIdentifier(identifier string) (string, map[string]string) {
+	metadata := map[string]string{}
+	i := strings.LastIndex(identifier, VolumeMetadataSeparator)
+	if i < 0 {
+		return identifier, metadata
+	}
+	for _, entry := range strings.Split(identifier[i+len(VolumeMetadataSeparator):], ".") {
+		key, value, ok := decodeMetadataEntry(entry)
+		if !ok {
+			return identifier, map[string]string{}
+		}
+		metadata[key] = value
+	}
+	return identifier[:i], metadata
+}
+
+// encodeMetadataEntry returns the escaped key, an underscore and the escaped value
+func encodeMetadataEntry(key, value string) string {
+	return escapeMetadata(key) + "_" + escapeMetadata(value)
+}
+
+// decodeMetadataEntry returns the key and the value of an entry written by encodeMetadataEntry
+func decodeMetadataEntry(entry string) (string, string, bool) {
+	escapedKey, escapedValue, found := strings.Cut(entry, "_")
+	if !found {
+		return "", "", false
+	}
+	key, ok := unescapeMetadata(escapedKey)
+	if !ok || key == "" {
+		return "", "", false
+	}
+	value, ok := unescapeMetadata(escapedValue)
+	return key, value, ok
+}
+
+func escapeMetadata(text string) string {
+	var escaped strings.Builder
+	for i := 0; i < len(text); i++ {
+		if b := text[i]; b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' {
+			escaped.WriteByte(b)
+		} else {
+			fmt.Fprintf(&escaped, "-%02X", b)
+		}
+	}
+	return escaped.String()
+}
+
+func unescapeMetadata(escaped string) (string, bool) {
+	var text strings.Builder
+	for i := 0; i < len(escaped); i++ {
+		b := escaped[i]
+		switch {
+		case b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9':
+			text.WriteByte(b)
+		case b == '-' && i+2 < len(escaped):
+			value, err := strconv.ParseUint(escaped[i+1:i+3], 16, 8)
+			if err != nil {
+				return "", false
+			}
+			text.WriteByte(byte(value))
+			i += 2
+		default:
+			return "", false
+		}
+	}
+	return text.String(), true
+}
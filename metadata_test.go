@@ -0,0 +1,132 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pmax
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+func TestMetadata(t *testing.T) {
+	symURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id"
+	tags := []string{"gold"}
+	identifier := "pvc-1"
+	puts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch {
+		case req.URL.Path == symURL+XStorageGroup+"/sg" && req.Method == http.MethodGet:
+			body = &types.StorageGroup{StorageGroupID: "sg", Tags: strings.Join(tags, ", ")}
+		case req.URL.Path == symURL+XStorageGroup+"/sg" && req.Method == http.MethodPut:
+			puts++
+			payload := &types.UpdateStorageGroupPayload{}
+			if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+				t.Error(err)
+			}
+			param := payload.EditStorageGroupActionParam.TagManagementParam
+			if param.RemoveTagsParam != nil {
+				tags = slices.DeleteFunc(tags, func(tag string) bool { return slices.Contains(param.RemoveTagsParam.TagName, tag) })
+			}
+			if param.AddTagsParam != nil {
+				tags = append(tags, param.AddTagsParam.TagName...)
+			}
+		case req.URL.Path == symURL+XVolume+"/00001" && req.Method == http.MethodGet:
+			body = &types.Volume{VolumeID: "00001", VolumeIdentifier: identifier}
+		case req.URL.Path == symURL+XVolume+"/00001" && req.Method == http.MethodPut:
+			puts++
+			payload := &types.EditVolumeParam{}
+			if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+				t.Error(err)
+			}
+			identifier = payload.EditVolumeActionParam.ModifyVolumeIdentifierParam.VolumeIdentifier.IdentifierName
+			body = &types.Volume{VolumeID: "00001", VolumeIdentifier: identifier}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		if body == nil {
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+	metadata := map[string]string{"owner": "csi-powermax", "cluster id": "c_1,2"}
+
+	// storage group
+	if err = client.SetStorageGroupMetadata(ctx, "mock-sym-id", "sg", metadata); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(tags, "gold") || !slices.Contains(tags, "md_cluster-20id_c-5F1-2C2") {
+		t.Errorf("unexpected tags %v", tags)
+	}
+	got, err := client.GetStorageGroupMetadata(ctx, "mock-sym-id", "sg")
+	if err != nil || !maps.Equal(got, metadata) {
+		t.Errorf("expected %v, got %v, %v", metadata, got, err)
+	}
+	// an unchanged entry is not sent again, and an empty value removes the entry
+	puts = 0
+	if err = client.SetStorageGroupMetadata(ctx, "mock-sym-id", "sg", map[string]string{"owner": "csi-powermax"}); err != nil || puts != 0 {
+		t.Errorf("expected no update, got %d, %v", puts, err)
+	}
+	if err = client.SetStorageGroupMetadata(ctx, "mock-sym-id", "sg", map[string]string{"owner": "", "cluster id": "c2"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ = client.GetStorageGroupMetadata(ctx, "mock-sym-id", "sg"); !maps.Equal(got, map[string]string{"cluster id": "c2"}) || len(tags) != 2 {
+		t.Errorf("unexpected metadata %v, tags %v", got, tags)
+	}
+	if err = client.SetStorageGroupMetadata(ctx, "mock-sym-id", "sg", map[string]string{"owner": strings.Repeat("x", 60)}); err == nil {
+		t.Error("expected an error for an entry too long")
+	}
+
+	// volume
+	if err = client.SetVolumeMetadata(ctx, "mock-sym-id", "00001", metadata); err != nil {
+		t.Fatal(err)
+	}
+	if identifier != "pvc-1.md.cluster-20id_c-5F1-2C2.owner_csi-2Dpowermax" || VolumeNameFromIdentifier(identifier) != "pvc-1" {
+		t.Errorf("unexpected identifier %s", identifier)
+	}
+	got, err = client.GetVolumeMetadata(ctx, "mock-sym-id", "00001")
+	if err != nil || !maps.Equal(got, metadata) {
+		t.Errorf("expected %v, got %v, %v", metadata, got, err)
+	}
+	if err = client.SetVolumeMetadata(ctx, "mock-sym-id", "00001", map[string]string{"owner": "", "cluster id": ""}); err != nil || identifier != "pvc-1" {
+		t.Errorf("expected the metadata to be removed, got %s, %v", identifier, err)
+	}
+	puts = 0
+	if err = client.SetVolumeMetadata(ctx, "mock-sym-id", "00001", map[string]string{"owner": strings.Repeat("x", 60)}); err == nil || puts != 0 {
+		t.Errorf("expected an error for an identifier too long, got %d updates, %v", puts, err)
+	}
+
+	// an identifier which only looks like it holds metadata is kept as the name
+	if name := VolumeNameFromIdentifier("db.md.backup"); name != "db.md.backup" {
+		t.Errorf("unexpected name %s", name)
+	}
+}
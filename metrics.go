@@ -17,22 +17,30 @@ package pmax
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
 	"time"
 
+	"github.com/dell/gopowermax/v2/api"
 	types "github.com/dell/gopowermax/v2/types/v100"
+	log "github.com/sirupsen/logrus"
 )
 
 // The following constants are for the query of performance metrics for pmax
 const (
-	Average      = "Average"
-	Performance  = "performance"
-	StorageGroup = "/StorageGroup"
-	Volume       = "/Volume"
-	FileSystem   = "/file/filesystem"
-	Metrics      = "/metrics"
-	Keys         = "/keys"
-	Array        = "/Array"
+	Average             = "Average"
+	Performance         = "performance"
+	StorageGroup        = "/StorageGroup"
+	Volume              = "/Volume"
+	FileSystem          = "/file/filesystem"
+	Metrics             = "/metrics"
+	Keys                = "/keys"
+	Array               = "/Array"
+	Register            = "/register"
+	RegistrationDetails = "/registrationdetails/"
+	FEPort              = "/FEPort"
 )
 
 // GetStorageGroupPerfKeys returns the available timestamp for the storage group performance
@@ -47,7 +55,8 @@ func (c *Client) GetStorageGroupPerfKeys(ctx context.Context, symID string) (*ty
 	params := types.StorageGroupKeysParam{
 		SymmetrixID: symID,
 	}
-	resp, err := c.api.DoAndGetResponseBody(ctx, http.MethodPost, URL, c.getDefaultHeaders(), params)
+	// performance queries are POSTs which do not change anything, they are never dry run
+	resp, err := c.api.DoAndGetResponseBody(api.WithDryRun(ctx, false), http.MethodPost, URL, c.getDefaultHeaders(), params)
 	if err = c.checkResponse(resp); err != nil {
 		return nil, err
 	}
@@ -102,7 +111,7 @@ func (c *Client) GetStorageGroupMetrics(ctx context.Context, symID string, stora
 		StorageGroupID: storageGroupID,
 		Metrics:        metricsQuery,
 	}
-	resp, err := c.api.DoAndGetResponseBody(ctx, http.MethodPost, URL, c.getDefaultHeaders(), params)
+	resp, err := c.api.DoAndGetResponseBody(api.WithDryRun(ctx, false), http.MethodPost, URL, c.getDefaultHeaders(), params)
 	if err = c.checkResponse(resp); err != nil {
 		return nil, err
 	}
@@ -135,7 +144,7 @@ func (c *Client) GetVolumesMetrics(ctx context.Context, symID string, storageGro
 		CommaSeparatedStorageGroupList: storageGroups,
 		Metrics:                        metricsQuery,
 	}
-	resp, err := c.api.DoAndGetResponseBody(ctx, http.MethodPost, URL, c.getDefaultHeaders(), params)
+	resp, err := c.api.DoAndGetResponseBody(api.WithDryRun(ctx, false), http.MethodPost, URL, c.getDefaultHeaders(), params)
 	if err = c.checkResponse(resp); err != nil {
 		return nil, err
 	}
@@ -169,7 +178,7 @@ func (c *Client) GetVolumesMetricsByID(ctx context.Context, symID string, volID
 		DataFormat:       Average,
 		Metrics:          metricsQuery,
 	}
-	resp, err := c.api.DoAndGetResponseBody(ctx, http.MethodPost, URL, c.getDefaultHeaders(), params)
+	resp, err := c.api.DoAndGetResponseBody(api.WithDryRun(ctx, false), http.MethodPost, URL, c.getDefaultHeaders(), params)
 	if err = c.checkResponse(resp); err != nil {
 		return nil, err
 	}
@@ -185,12 +194,124 @@ func (c *Client) GetVolumesMetricsByID(ctx context.Context, symID string, volID
 	return metricsList, nil
 }
 
+// GetVolumesMetricsByIDs returns the performance metrics of many volumes. The volume IDs are grouped into
+// runs of consecutive IDs and each run is queried with a single volume range, so that contiguous volumes
+// cost one request instead of one per volume. The pages of the result of each run are all read, and the results
+// of all the runs are merged into one iterator holding every result in its first page.
+func (c *Client) GetVolumesMetricsByIDs(ctx context.Context, symID string, volIDs []string, metricsQuery []string, firstAvailableTime, lastAvailableTime int64) (*types.VolumeMetricsIterator, error) {
+	defer c.TimeSpent("GetVolumesMetricsByIDs", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	ranges, err := volumeIDRanges(volIDs)
+	if err != nil {
+		return nil, err
+	}
+	URL := RESTPrefix + Performance + Volume + Metrics
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	metricsList := &types.VolumeMetricsIterator{}
+	for _, r := range ranges {
+		params := types.VolumeMetricsParam{
+			SystemID:         symID,
+			StartDate:        firstAvailableTime,
+			EndDate:          lastAvailableTime,
+			VolumeStartRange: r[0],
+			VolumeEndRange:   r[1],
+			DataFormat:       Average,
+			Metrics:          metricsQuery,
+		}
+		page := &types.VolumeMetricsIterator{}
+		err = c.api.Post(api.WithDryRun(ctx, false), URL, c.getDefaultHeaders(), params, page)
+		if err != nil {
+			log.Error("GetVolumesMetricsByIDs failed: " + err.Error())
+			return nil, err
+		}
+		results, err := c.getVolumeMetricsPages(ctx, page)
+		if err != nil {
+			log.Error("GetVolumesMetricsByIDs failed: " + err.Error())
+			return nil, err
+		}
+		metricsList.ResultList.Result = append(metricsList.ResultList.Result, results...)
+	}
+	metricsList.Count = len(metricsList.ResultList.Result)
+	metricsList.MaxPageSize = metricsList.Count
+	metricsList.ResultList.To = metricsList.Count
+	if metricsList.ResultList.To > 0 {
+		metricsList.ResultList.From = 1
+	}
+	return metricsList, nil
+}
+
+// getVolumeMetricsPages returns the results of all the pages of a volume metrics iterator, deleting the iterator
+// once read
+func (c *Client) getVolumeMetricsPages(ctx context.Context, iter *types.VolumeMetricsIterator) ([]types.VolumeResult, error) {
+	results := iter.ResultList.Result
+	if iter.ResultList.To >= iter.Count {
+		return results, nil
+	}
+	defer func() {
+		_ = c.DeleteVolumeIDsIterator(ctx, &types.VolumeIterator{ID: iter.ID})
+	}()
+	pageSize := iter.MaxPageSize
+	if pageSize <= 0 {
+		pageSize = iter.Count
+	}
+	for from := iter.ResultList.To + 1; from <= iter.Count; {
+		to := min(from+pageSize-1, iter.Count)
+		page := &types.VolumeMetricsResultList{}
+		URL := RESTPrefix + IteratorX + iter.ID + XPage + fmt.Sprintf("?from=%d&to=%d", from, to)
+		if err := c.api.Get(ctx, URL, c.getDefaultHeaders(), page); err != nil {
+			return nil, err
+		}
+		if len(page.Result) == 0 {
+			return nil, fmt.Errorf("page %d to %d of volume metrics iterator %s is empty", from, to, iter.ID)
+		}
+		results = append(results, page.Result...)
+		from += len(page.Result)
+	}
+	return results, nil
+}
+
+// volumeIDRanges sorts the hexadecimal volume IDs and groups consecutive IDs into [start, end] ranges,
+// keeping the IDs as given so that their zero padding is preserved. Duplicated IDs are ignored.
+func volumeIDRanges(volIDs []string) ([][2]string, error) {
+	type volumeNumber struct {
+		id  string
+		num uint64
+	}
+	numbers := make([]volumeNumber, 0, len(volIDs))
+	for _, id := range volIDs {
+		num, err := strconv.ParseUint(id, 16, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid volume ID %q: %s", id, err.Error())
+		}
+		numbers = append(numbers, volumeNumber{id: id, num: num})
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i].num < numbers[j].num })
+	var ranges [][2]string
+	for i, n := range numbers {
+		switch {
+		case i > 0 && n.num == numbers[i-1].num:
+			continue
+		case i > 0 && n.num == numbers[i-1].num+1:
+			ranges[len(ranges)-1][1] = n.id
+		default:
+			ranges = append(ranges, [2]string{n.id, n.id})
+		}
+	}
+	return ranges, nil
+}
+
 // GetFileSystemMetricsByID returns a given FileSystem performance metrics
 func (c *Client) GetFileSystemMetricsByID(ctx context.Context, symID string, fsID string, metricsQuery []string, firstAvailableTime, lastAvailableTime int64) (*types.FileSystemMetricsIterator, error) {
 	defer c.TimeSpent("GetFileSystemMetricsByID", time.Now())
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.verifySupport(ctx, symID, "GetFileSystemMetricsByID"); err != nil {
+		return nil, err
+	}
 	URL := RESTPrefix + Performance + FileSystem + Metrics
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
@@ -202,7 +323,7 @@ func (c *Client) GetFileSystemMetricsByID(ctx context.Context, symID string, fsI
 		FileSystemID: fsID,
 		Metrics:      metricsQuery,
 	}
-	resp, err := c.api.DoAndGetResponseBody(ctx, http.MethodPost, URL, c.getDefaultHeaders(), params)
+	resp, err := c.api.DoAndGetResponseBody(api.WithDryRun(ctx, false), http.MethodPost, URL, c.getDefaultHeaders(), params)
 	if err = c.checkResponse(resp); err != nil {
 		return nil, err
 	}
@@ -217,3 +338,114 @@ func (c *Client) GetFileSystemMetricsByID(ctx context.Context, symID string, fsI
 	}
 	return metricsList, nil
 }
+
+// GetFEPortMetrics returns the performance metrics of a front-end director port, e.g. PercentBusy
+func (c *Client) GetFEPortMetrics(ctx context.Context, symID string, directorID string, portID string, metricsQuery []string, firstAvailableTime, lastAvailableTime int64) (*types.FEPortMetricsIterator, error) {
+	defer c.TimeSpent("GetFEPortMetrics", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	URL := RESTPrefix + Performance + FEPort + Metrics
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	params := types.FEPortMetricsParam{
+		SymmetrixID: symID,
+		StartDate:   firstAvailableTime,
+		EndDate:     lastAvailableTime,
+		DataFormat:  Average,
+		DirectorID:  directorID,
+		PortID:      portID,
+		Metrics:     metricsQuery,
+	}
+	resp, err := c.api.DoAndGetResponseBody(api.WithDryRun(ctx, false), http.MethodPost, URL, c.getDefaultHeaders(), params)
+	if err = c.checkResponse(resp); err != nil {
+		return nil, err
+	}
+	metricsList := &types.FEPortMetricsIterator{}
+	decoder := json.NewDecoder(resp.Body)
+	if err = decoder.Decode(metricsList); err != nil {
+		return nil, err
+	}
+	err = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	return metricsList, nil
+}
+
+// RegisterArrayForPerformance registers an array for diagnostic and, optionally, real time performance metrics collection
+func (c *Client) RegisterArrayForPerformance(ctx context.Context, symID string, realTime bool) error {
+	defer c.TimeSpent("RegisterArrayForPerformance", time.Now())
+	return c.setPerformanceRegistration(ctx, symID, true, realTime)
+}
+
+// UnregisterArrayForPerformance stops the performance metrics collection of an array
+func (c *Client) UnregisterArrayForPerformance(ctx context.Context, symID string) error {
+	defer c.TimeSpent("UnregisterArrayForPerformance", time.Now())
+	return c.setPerformanceRegistration(ctx, symID, false, false)
+}
+
+func (c *Client) setPerformanceRegistration(ctx context.Context, symID string, diagnostic, realTime bool) error {
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return err
+	}
+	URL := RESTPrefix + Performance + Array + Register
+	params := types.PerformanceRegistrationParam{
+		SymmetrixID: symID,
+		Diagnostic:  diagnostic,
+		RealTime:    realTime,
+	}
+	ifDebugLogPayload(params)
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	err := c.api.Post(ctx, URL, c.getDefaultHeaders(), params, nil)
+	if err != nil {
+		log.Error("setPerformanceRegistration failed: " + err.Error())
+		return err
+	}
+	log.Info(fmt.Sprintf("Successfully set performance registration of %s to diagnostic: %t, realtime: %t", symID, diagnostic, realTime))
+	return nil
+}
+
+// GetPerformanceRegistration returns the performance metrics collection registration of an array along with
+// the dates of the available performance data and how far behind the collection is
+func (c *Client) GetPerformanceRegistration(ctx context.Context, symID string) (*types.PerformanceRegistrationStatus, error) {
+	defer c.TimeSpent("GetPerformanceRegistration", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	URL := RESTPrefix + Performance + Array + RegistrationDetails + symID
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	result := &types.PerformanceRegistrationDetailsResult{}
+	err := c.api.Get(ctx, URL, c.getDefaultHeaders(), result)
+	if err != nil {
+		log.Error("GetPerformanceRegistration failed: " + err.Error())
+		return nil, err
+	}
+	status := &types.PerformanceRegistrationStatus{}
+	for _, details := range result.RegistrationDetails {
+		if details.SymmetrixID == symID {
+			status.PerformanceRegistrationDetails = details
+			status.Registered = details.Diagnostic || details.RealTime
+		}
+	}
+	if !status.Registered {
+		status.SymmetrixID = symID
+		return status, nil
+	}
+	keys, err := c.GetArrayPerfKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range keys.ArrayInfos {
+		if info.SymmetrixID == symID {
+			status.FirstAvailableDate = info.FirstAvailableDate
+			status.LastAvailableDate = info.LastAvailableDate
+			if info.LastAvailableDate > 0 {
+				status.BacklogMillis = time.Now().UnixMilli() - info.LastAvailableDate
+			}
+		}
+	}
+	return status, nil
+}
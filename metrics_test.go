@@ -0,0 +1,186 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pmax
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dell/gopowermax/v2/api"
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+func TestPerformanceRegistration(t *testing.T) {
+	registered := map[string]types.PerformanceRegistrationParam{}
+	lastAvailable := time.Now().Add(-10 * time.Minute).UnixMilli()
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.URL.Path {
+		case "/" + RESTPrefix + Performance + Array + Register:
+			param := types.PerformanceRegistrationParam{}
+			if err := json.NewDecoder(req.Body).Decode(&param); err != nil {
+				t.Fatal(err)
+			}
+			registered[param.SymmetrixID] = param
+			resp.WriteHeader(http.StatusOK)
+			return
+		case "/" + RESTPrefix + Performance + Array + RegistrationDetails + "000000000001":
+			param := registered["000000000001"]
+			body = &types.PerformanceRegistrationDetailsResult{
+				RegistrationDetails: []types.PerformanceRegistrationDetails{{
+					SymmetrixID:            "000000000001",
+					Diagnostic:             param.Diagnostic,
+					RealTime:               param.RealTime,
+					CollectionIntervalMins: 5,
+				}},
+			}
+		case "/" + RESTPrefix + Performance + Array + Keys:
+			body = &types.ArrayKeysResult{ArrayInfos: []types.ArrayInfo{{SymmetrixID: "000000000001", LastAvailableDate: lastAvailable}}}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = client.RegisterArrayForPerformance(context.TODO(), "000000000001", true); err != nil {
+		t.Fatal(err)
+	}
+	status, err := client.GetPerformanceRegistration(context.TODO(), "000000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Registered || !status.RealTime || status.LastAvailableDate != lastAvailable || status.BacklogMillis < (10*time.Minute).Milliseconds() {
+		t.Fatalf("unexpected registration status %+v", status)
+	}
+
+	if err = client.UnregisterArrayForPerformance(context.TODO(), "000000000001"); err != nil {
+		t.Fatal(err)
+	}
+	status, err = client.GetPerformanceRegistration(context.TODO(), "000000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Registered || status.BacklogMillis != 0 {
+		t.Fatalf("unexpected registration status %+v", status)
+	}
+}
+
+func TestPerformanceQueriesDryRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/"+RESTPrefix+Performance+StorageGroup+Keys {
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content, err := json.Marshal(&types.StorageGroupKeysResult{StorageGroupInfos: []types.StorageGroupInfo{{StorageGroupID: "sg-1"}}})
+		if err != nil {
+			t.Error(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetDryRun(true)
+	keys, err := client.GetStorageGroupPerfKeys(context.TODO(), "000000000001")
+	if err != nil || len(keys.StorageGroupInfos) != 1 {
+		t.Fatalf("expected performance query to be sent in dry-run mode, got %+v, %v", keys, err)
+	}
+	err = client.RegisterArrayForPerformance(context.TODO(), "000000000001", false)
+	if !api.IsDryRunError(err) {
+		t.Fatalf("expected dry run error, got %v", err)
+	}
+}
+
+func TestGetVolumesMetricsByIDs(t *testing.T) {
+	var queried [][2]string
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch {
+		case req.Method == http.MethodPost && req.URL.Path == "/"+RESTPrefix+Performance+Volume+Metrics:
+			param := types.VolumeMetricsParam{}
+			if err := json.NewDecoder(req.Body).Decode(&param); err != nil {
+				t.Fatal(err)
+			}
+			queried = append(queried, [2]string{param.VolumeStartRange, param.VolumeEndRange})
+			body = &types.VolumeMetricsIterator{
+				ResultList: types.VolumeMetricsResultList{Result: []types.VolumeResult{{VolumeID: param.VolumeStartRange}}, From: 1, To: 1},
+				Count:      1,
+			}
+			if param.VolumeStartRange == "00001" {
+				// the run of 3 volumes is returned in pages of 1 volume
+				body = &types.VolumeMetricsIterator{
+					ResultList: types.VolumeMetricsResultList{Result: []types.VolumeResult{{VolumeID: "00001"}}, From: 1, To: 1},
+					ID:         "it1", Count: 3, MaxPageSize: 1,
+				}
+			}
+		case req.Method == http.MethodGet && req.URL.Path == "/"+RESTPrefix+IteratorX+"it1"+XPage:
+			from := req.URL.Query().Get("from")
+			body = &types.VolumeMetricsResultList{Result: []types.VolumeResult{{VolumeID: "0000" + from}}}
+		case req.Method == http.MethodDelete:
+			deleted = append(deleted, req.URL.Path)
+			return
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	metrics, err := client.GetVolumesMetricsByIDs(context.TODO(), "000000000001", []string{"0000A", "00002", "00009", "00001", "00003", "00002"}, []string{"Reads"}, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queried) != 2 || queried[0] != [2]string{"00001", "00003"} || queried[1] != [2]string{"00009", "0000A"} {
+		t.Fatalf("unexpected volume ranges %v", queried)
+	}
+	if metrics.Count != 4 || len(metrics.ResultList.Result) != 4 || metrics.ResultList.To != 4 || metrics.ResultList.Result[2].VolumeID != "00003" {
+		t.Fatalf("unexpected metrics %+v", metrics)
+	}
+	if len(deleted) != 1 {
+		t.Errorf("expected the iterator to be deleted, got %v", deleted)
+	}
+
+	if _, err = client.GetVolumesMetricsByIDs(context.TODO(), "000000000001", []string{"not-hex"}, []string{"Reads"}, 0, 0); err == nil {
+		t.Fatal("expected an error for an invalid volume ID")
+	}
+}
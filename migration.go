@@ -31,6 +31,8 @@ const (
 	XEnvironment = "/environment/"
 	// IncludeMigrations constant for internal use in URL
 	IncludeMigrations = "?includeMigrations"
+	// XDevicePair constant for internal use in URL
+	XDevicePair = "/devicepair"
 )
 
 // ModifyMigrationSession does modification to storage group migration session
@@ -270,3 +272,66 @@ func (c *Client) GetMigrationEnvironment(ctx context.Context, localSymID, remote
 	}
 	return migEnv, nil
 }
+
+// GetStorageGroupMigrationDevicePairs returns the state and copy progress of each device pair of the migration session
+// of a storage group, along with the aggregate state of the session
+func (c *Client) GetStorageGroupMigrationDevicePairs(ctx context.Context, localSymID, storageGroupID string) (*types.MigrationDevicePairStates, error) {
+	defer c.TimeSpent("GetStorageGroupMigrationDevicePairs", time.Now())
+	if _, err := c.IsAllowedArray(localSymID); err != nil {
+		return nil, err
+	}
+	URL := c.urlPrefix() + XMigration + SymmetrixX + localSymID + XStorageGroup + "/" + storageGroupID + XDevicePair
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	pairStates := new(types.MigrationDevicePairStates)
+	if err := c.api.Get(ctx, URL, c.getDefaultHeaders(), pairStates); err != nil {
+		log.Error("GetStorageGroupMigrationDevicePairs failed: " + err.Error())
+		return nil, err
+	}
+	if pairStates.StorageGroup == "" || pairStates.State == "" {
+		session, err := c.GetStorageGroupMigrationByID(ctx, localSymID, storageGroupID)
+		if err != nil {
+			return nil, err
+		}
+		pairStates.StorageGroup = session.StorageGroup
+		pairStates.State = session.State
+	}
+	var total, remaining float64
+	for i := range pairStates.DevicePairs {
+		pair := &pairStates.DevicePairs[i]
+		setMigrationPercentComplete(pair)
+		total += pair.TotalCapacity
+		remaining += pair.RemainingCapacity
+	}
+	if pairStates.PercentComplete == 0 && total > 0 {
+		pairStates.PercentComplete = 100 * (total - remaining) / total
+	}
+	log.Info("GetStorageGroupMigrationDevicePairs is successfully done")
+	return pairStates, nil
+}
+
+// GetStorageGroupMigrationDevicePair returns the state and copy progress of the device pair of a source volume in the
+// migration session of a storage group
+func (c *Client) GetStorageGroupMigrationDevicePair(ctx context.Context, localSymID, storageGroupID, volumeID string) (*types.MigrationDevicePairState, error) {
+	defer c.TimeSpent("GetStorageGroupMigrationDevicePair", time.Now())
+	if _, err := c.IsAllowedArray(localSymID); err != nil {
+		return nil, err
+	}
+	URL := c.urlPrefix() + XMigration + SymmetrixX + localSymID + XStorageGroup + "/" + storageGroupID + XDevicePair + "/" + volumeID
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	pair := new(types.MigrationDevicePairState)
+	if err := c.api.Get(ctx, URL, c.getDefaultHeaders(), pair); err != nil {
+		log.Error("GetStorageGroupMigrationDevicePair failed: " + err.Error())
+		return nil, err
+	}
+	setMigrationPercentComplete(pair)
+	return pair, nil
+}
+
+// setMigrationPercentComplete derives the progress of a device pair from its capacities when it is not reported
+func setMigrationPercentComplete(pair *types.MigrationDevicePairState) {
+	if pair.PercentComplete == 0 && pair.TotalCapacity > 0 {
+		pair.PercentComplete = 100 * (pair.TotalCapacity - pair.RemainingCapacity) / pair.TotalCapacity
+	}
+}
@@ -603,3 +603,58 @@ func TestGetMigrationEnvironment(t *testing.T) {
 		tc.server.Close()
 	}
 }
+
+func TestGetStorageGroupMigrationDevicePairs(t *testing.T) {
+	sgURL := urlPrefix + XMigration + SymmetrixX + "mock-local-sym-id" + XStorageGroup + "/mock-storage-group-id"
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.URL.Path {
+		case sgURL:
+			body = &types.MigrationSession{StorageGroup: "mock-storage-group-id", State: "Migrating"}
+		case sgURL + XDevicePair:
+			body = &types.MigrationDevicePairStates{DevicePairs: []types.MigrationDevicePairState{
+				{SrcVolumeName: "00001", TgtVolumeName: "00011", State: "CutoverReady", TotalCapacity: 10, PercentComplete: 100},
+				{SrcVolumeName: "00002", TgtVolumeName: "00012", State: "Migrating", TotalCapacity: 30, RemainingCapacity: 15},
+			}}
+		case sgURL + XDevicePair + "/00002":
+			body = &types.MigrationDevicePairState{SrcVolumeName: "00002", TgtVolumeName: "00012", State: "Migrating", TotalCapacity: 30, RemainingCapacity: 15}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pairStates, err := client.GetStorageGroupMigrationDevicePairs(context.TODO(), "mock-local-sym-id", "mock-storage-group-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pairStates.StorageGroup != "mock-storage-group-id" || pairStates.State != "Migrating" {
+		t.Fatalf("expected the aggregate state of the session, got %+v", pairStates)
+	}
+	if pairStates.DevicePairs[1].PercentComplete != 50 || pairStates.PercentComplete != 62.5 {
+		t.Fatalf("unexpected progress %+v", pairStates)
+	}
+	pending := pairStates.NotInState("CutoverReady")
+	if len(pending) != 1 || pending[0].SrcVolumeName != "00002" {
+		t.Fatalf("expected device pair 00002 to hold up the cutover, got %+v", pending)
+	}
+
+	pair, err := client.GetStorageGroupMigrationDevicePair(context.TODO(), "mock-local-sym-id", "mock-storage-group-id", "00002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pair.State != "Migrating" || pair.PercentComplete != 50 {
+		t.Fatalf("unexpected device pair %+v", pair)
+	}
+	if _, err = client.GetStorageGroupMigrationDevicePair(context.TODO(), "mock-local-sym-id", "mock-storage-group-id", "00003"); err == nil {
+		t.Fatal("expected an error for an unknown device pair")
+	}
+}
@@ -1664,6 +1664,10 @@ func handleVolume(w http.ResponseWriter, r *http.Request) {
 			expandVolume(w, updateVolumePayload.EditVolumeActionParam.ExpandVolumeParam, volID, executionOption)
 			return
 		}
+		if updateVolumePayload.EditVolumeActionParam.SetVolumeReadyStateParam != nil {
+			setVolumeReadyState(w, updateVolumePayload.EditVolumeActionParam.SetVolumeReadyStateParam, volID)
+			return
+		}
 	case http.MethodDelete:
 		if InducedErrors.DeleteVolumeError {
 			writeError(w, "Error deleting Volume: induced error", http.StatusRequestTimeout)
@@ -1796,6 +1800,24 @@ func modifyMobility(w http.ResponseWriter, param *types.EnableMobilityIDParam, v
 	returnVolume(w, volID, false)
 }
 
+// SetVolumeReadyState - Sets the ready state of a volume in cache
+func SetVolumeReadyState(w http.ResponseWriter, param *types.SetVolumeReadyStateParam, volID string) {
+	mockCacheMutex.Lock()
+	defer mockCacheMutex.Unlock()
+	setVolumeReadyState(w, param, volID)
+}
+
+// This sets the status of the volume to the requested ready state
+func setVolumeReadyState(w http.ResponseWriter, param *types.SetVolumeReadyStateParam, volID string) {
+	vol, ok := Data.VolumeIDToVolume[volID]
+	if !ok {
+		writeError(w, "Could not find volume", http.StatusNotFound)
+		return
+	}
+	vol.Status = param.ReadyState
+	returnVolume(w, volID, false)
+}
+
 // ExpandVolume - Expands volume size in cache
 func ExpandVolume(w http.ResponseWriter, param *types.ExpandVolumeParam, volID string, executionOption string) {
 	mockCacheMutex.Lock()
@@ -0,0 +1,197 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pmax
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+// MultiClient holds a client for each of several arrays, which may be managed by
+// different Unisphere endpoints, and fans out calls across all of them.
+type MultiClient struct {
+	mu      sync.RWMutex
+	clients map[string]Pmax
+}
+
+// ArrayError is the error returned by one array during a fan-out call
+type ArrayError struct {
+	SymmetrixID string
+	Err         error
+}
+
+// MultiArrayError holds the errors of the arrays which failed during a fan-out call.
+// The results of the other arrays are still returned along with it.
+type MultiArrayError struct {
+	Errors []ArrayError
+}
+
+func (e *MultiArrayError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, ae := range e.Errors {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", ae.SymmetrixID, ae.Err.Error()))
+	}
+	return "errors from arrays: " + strings.Join(msgs, "; ")
+}
+
+// VolumeLocation is a volume along with the array it was found on
+type VolumeLocation struct {
+	SymmetrixID string
+	Volume      *types.Volume
+}
+
+// ArrayCapacity is the capacity, summed over all its storage resource pools, of an array
+type ArrayCapacity struct {
+	SymmetrixID       string
+	StoragePools      []string
+	UsableTotalTB     float64
+	UsableUsedTB      float64
+	SubscribedTotalTB float64
+}
+
+// CapacityReport is the capacity of each array along with the totals across all of them
+type CapacityReport struct {
+	Arrays            []ArrayCapacity
+	UsableTotalTB     float64
+	UsableUsedTB      float64
+	SubscribedTotalTB float64
+}
+
+// NewMultiClient returns a MultiClient for the given clients, keyed by symmetrix ID
+func NewMultiClient(clients map[string]Pmax) *MultiClient {
+	m := &MultiClient{clients: make(map[string]Pmax)}
+	for symID, client := range clients {
+		m.clients[symID] = client
+	}
+	return m
+}
+
+// AddArray adds, or replaces, the client used for an array
+func (m *MultiClient) AddArray(symID string, client Pmax) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clients[symID] = client
+}
+
+// Arrays returns the sorted symmetrix IDs of the arrays of the MultiClient
+func (m *MultiClient) Arrays() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	arrays := make([]string, 0, len(m.clients))
+	for symID := range m.clients {
+		arrays = append(arrays, symID)
+	}
+	sort.Strings(arrays)
+	return arrays
+}
+
+// forEachArray calls fn for every array in parallel, and returns a MultiArrayError with the
+// errors returned by fn, or nil if none failed
+func (m *MultiClient) forEachArray(ctx context.Context, fn func(ctx context.Context, symID string, client Pmax) error) error {
+	m.mu.RLock()
+	clients := make(map[string]Pmax, len(m.clients))
+	for symID, client := range m.clients {
+		clients[symID] = client
+	}
+	m.mu.RUnlock()
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		errors []ArrayError
+	)
+	for symID, client := range clients {
+		wg.Add(1)
+		go func(symID string, client Pmax) {
+			defer wg.Done()
+			if err := fn(ctx, symID, client); err != nil {
+				mu.Lock()
+				errors = append(errors, ArrayError{SymmetrixID: symID, Err: err})
+				mu.Unlock()
+			}
+		}(symID, client)
+	}
+	wg.Wait()
+	if len(errors) == 0 {
+		return nil
+	}
+	sort.Slice(errors, func(i, j int) bool { return errors[i].SymmetrixID < errors[j].SymmetrixID })
+	return &MultiArrayError{Errors: errors}
+}
+
+// FindVolumeByWWN looks for volumes with the given WWN on all the arrays
+func (m *MultiClient) FindVolumeByWWN(ctx context.Context, wwn string) ([]VolumeLocation, error) {
+	var (
+		mu        sync.Mutex
+		locations []VolumeLocation
+	)
+	err := m.forEachArray(ctx, func(ctx context.Context, symID string, client Pmax) error {
+		volumeIDs, err := client.GetVolumeIDListWithParams(ctx, symID, map[string]string{"wwn": wwn})
+		if err != nil {
+			return err
+		}
+		for _, volumeID := range volumeIDs {
+			vol, err := client.GetVolumeByID(ctx, symID, volumeID)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			locations = append(locations, VolumeLocation{SymmetrixID: symID, Volume: vol})
+			mu.Unlock()
+		}
+		return nil
+	})
+	sort.Slice(locations, func(i, j int) bool { return locations[i].SymmetrixID < locations[j].SymmetrixID })
+	return locations, err
+}
+
+// GetCapacityReport returns the capacity of each array, summed over its storage resource pools, and the totals across all arrays
+func (m *MultiClient) GetCapacityReport(ctx context.Context) (*CapacityReport, error) {
+	var mu sync.Mutex
+	report := &CapacityReport{}
+	err := m.forEachArray(ctx, func(ctx context.Context, symID string, client Pmax) error {
+		pools, err := client.GetStoragePoolList(ctx, symID)
+		if err != nil {
+			return err
+		}
+		capacity := ArrayCapacity{SymmetrixID: symID}
+		for _, poolID := range pools.StoragePoolIDs {
+			pool, err := client.GetStoragePool(ctx, symID, poolID)
+			if err != nil {
+				return err
+			}
+			capacity.StoragePools = append(capacity.StoragePools, poolID)
+			if pool.SrpCap != nil {
+				capacity.UsableTotalTB += pool.SrpCap.UsableTotInTB
+				capacity.UsableUsedTB += pool.SrpCap.UsableUsedInTB
+				capacity.SubscribedTotalTB += pool.SrpCap.SubTotInTB
+			}
+		}
+		mu.Lock()
+		report.Arrays = append(report.Arrays, capacity)
+		report.UsableTotalTB += capacity.UsableTotalTB
+		report.UsableUsedTB += capacity.UsableUsedTB
+		report.SubscribedTotalTB += capacity.SubscribedTotalTB
+		mu.Unlock()
+		return nil
+	})
+	sort.Slice(report.Arrays, func(i, j int) bool { return report.Arrays[i].SymmetrixID < report.Arrays[j].SymmetrixID })
+	return report, err
+}
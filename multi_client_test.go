@@ -0,0 +1,119 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pmax
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+func newMultiClientTestServer(t *testing.T, symID string, volumes map[string]string, srpCap *types.SrpCap) *httptest.Server {
+	symURL := urlPrefix + "sloprovisioning/symmetrix/" + symID
+	return httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch {
+		case req.URL.Path == symURL+"/volume":
+			var ids []types.VolumeIDList
+			for id, wwn := range volumes {
+				if req.URL.Query().Get("wwn") == wwn {
+					ids = append(ids, types.VolumeIDList{VolumeIDs: id})
+				}
+			}
+			body = &types.VolumeIterator{
+				ResultList: types.VolumeResultList{VolumeList: ids, From: 1, To: len(ids)},
+				Count:      len(ids),
+			}
+		case req.URL.Path == symURL+"/srp" && srpCap != nil:
+			body = &types.StoragePoolList{StoragePoolIDs: []string{"SRP_1"}}
+		case req.URL.Path == symURL+"/srp/SRP_1" && srpCap != nil:
+			body = &types.StoragePool{StoragePoolID: "SRP_1", SrpCap: srpCap}
+		default:
+			for id, wwn := range volumes {
+				if req.URL.Path == symURL+"/volume/"+id {
+					body = &types.Volume{VolumeID: id, WWN: wwn}
+				}
+			}
+		}
+		if body == nil {
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Error(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+}
+
+func TestMultiClient(t *testing.T) {
+	wwn := "60000970000197900046533030300501"
+	server1 := newMultiClientTestServer(t, "000000000001", map[string]string{"00501": wwn, "00502": "other"},
+		&types.SrpCap{UsableTotInTB: 10, UsableUsedInTB: 4, SubTotInTB: 12})
+	defer server1.Close()
+	server2 := newMultiClientTestServer(t, "000000000002", map[string]string{"00601": "other"},
+		&types.SrpCap{UsableTotInTB: 20, UsableUsedInTB: 5, SubTotInTB: 8})
+	defer server2.Close()
+	server3 := newMultiClientTestServer(t, "000000000003", nil, nil)
+	defer server3.Close()
+
+	clients := make(map[string]Pmax)
+	for symID, server := range map[string]*httptest.Server{"000000000001": server1, "000000000002": server2} {
+		client, err := NewClientWithArgs(server.URL, "", true, true, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		clients[symID] = client
+	}
+	multi := NewMultiClient(clients)
+
+	locations, err := multi.FindVolumeByWWN(context.TODO(), wwn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locations) != 1 || locations[0].SymmetrixID != "000000000001" || locations[0].Volume.VolumeID != "00501" {
+		t.Fatalf("unexpected locations %+v", locations)
+	}
+
+	client3, err := NewClientWithArgs(server3.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	multi.AddArray("000000000003", client3)
+	if arrays := multi.Arrays(); !reflect.DeepEqual(arrays, []string{"000000000001", "000000000002", "000000000003"}) {
+		t.Fatalf("unexpected arrays %v", arrays)
+	}
+
+	report, err := multi.GetCapacityReport(context.TODO())
+	var multiErr *MultiArrayError
+	if !errors.As(err, &multiErr) || len(multiErr.Errors) != 1 || multiErr.Errors[0].SymmetrixID != "000000000003" {
+		t.Fatalf("expected an error for array 000000000003 only, got %v", err)
+	}
+	if len(report.Arrays) != 2 || report.UsableTotalTB != 30 || report.UsableUsedTB != 9 || report.SubscribedTotalTB != 20 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if report.Arrays[0].SymmetrixID != "000000000001" || !reflect.DeepEqual(report.Arrays[0].StoragePools, []string{"SRP_1"}) {
+		t.Fatalf("unexpected array capacity %+v", report.Arrays[0])
+	}
+}
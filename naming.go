@@ -0,0 +1,109 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pmax
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dell/gopowermax/v2/payload"
+	log "github.com/sirupsen/logrus"
+)
+
+// nameHashLength is the number of hexadecimal characters of the hash appended by ObjectName to the names it changes
+const nameHashLength = 8
+
+// The kinds of objects whose names are validated
+const (
+	StorageGroupKind = "storage group"
+	MaskingViewKind  = "masking view"
+	HostKind         = "host"
+	HostGroupKind    = "host group"
+	PortGroupKind    = "port group"
+)
+
+// InvalidNameError is returned, before any request is sent to Unisphere, for a name which Unisphere would refuse
+type InvalidNameError struct {
+	Kind   string
+	Name   string
+	Reason string
+}
+
+func (e *InvalidNameError) Error() string {
+	return fmt.Sprintf("invalid %s name %q: %s", e.Kind, e.Name, e.Reason)
+}
+
+// IsInvalidNameError returns true if the error is, or wraps, an InvalidNameError
+func IsInvalidNameError(err error) bool {
+	var invalid *InvalidNameError
+	return errors.As(err, &invalid)
+}
+
+// ValidateObjectName returns an InvalidNameError if name is not a valid name for an object of the kind, e.g.
+// StorageGroupKind: it must be 1 to payload.MaxNameLength characters long, made of letters, digits, '-' and '_'
+func ValidateObjectName(kind, name string) error {
+	switch {
+	case name == "":
+		return &InvalidNameError{Kind: kind, Name: name, Reason: "the name is empty"}
+	case len(name) > payload.MaxNameLength:
+		return &InvalidNameError{Kind: kind, Name: name, Reason: fmt.Sprintf("the name is longer than %d characters", payload.MaxNameLength)}
+	}
+	if i := strings.IndexFunc(name, func(r rune) bool { return !payload.IsValidNameChar(r) }); i >= 0 {
+		return &InvalidNameError{Kind: kind, Name: name, Reason: fmt.Sprintf("the character %q is not allowed, only letters, digits, '-' and '_' are", []rune(name[i:])[0])}
+	}
+	return nil
+}
+
+// ObjectName returns a valid object name made of the parts joined with '-', e.g. a prefix and the name of a
+// Kubernetes object. The characters which are not allowed are replaced with '_'. A name which had to be changed
+// or truncated to payload.MaxNameLength ends with a hash of the joined parts, so that the same parts always give
+// the same name. Parts which join to the same string give the same name, e.g. ObjectName("a-b") and
+// ObjectName("a", "b"); a valid name is kept as it is, so the parts should not be ambiguous once joined.
+func ObjectName(parts ...string) string {
+	joined := strings.Join(parts, "-")
+	name := strings.Map(func(r rune) rune {
+		if payload.IsValidNameChar(r) {
+			return r
+		}
+		return '_'
+	}, joined)
+	if name == joined && name != "" && len(name) <= payload.MaxNameLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(joined))
+	hash := hex.EncodeToString(sum[:])[:nameHashLength]
+	if len(name) > payload.MaxNameLength-nameHashLength-1 {
+		name = name[:payload.MaxNameLength-nameHashLength-1]
+	}
+	if name == "" {
+		return hash
+	}
+	return name + "-" + hash
+}
+
+// validateName returns an InvalidNameError for an invalid name if the names are validated, see WithNameValidation
+func (c *Client) validateName(kind, name string) error {
+	if !c.opts.validateNames {
+		return nil
+	}
+	if err := ValidateObjectName(kind, name); err != nil {
+		log.Error(err.Error())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pmax
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dell/gopowermax/v2/payload"
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+func TestValidateObjectName(t *testing.T) {
+	valid := []string{"sg1", "csi-k8s-Bronze-SRP_1-SG", strings.Repeat("a", payload.MaxNameLength)}
+	for _, name := range valid {
+		if err := ValidateObjectName(StorageGroupKind, name); err != nil {
+			t.Errorf("expected %q to be valid, got %v", name, err)
+		}
+	}
+	invalid := []string{"", strings.Repeat("a", payload.MaxNameLength+1), "pvc.default", "my host", "sg/1"}
+	for _, name := range invalid {
+		if err := ValidateObjectName(HostKind, name); !IsInvalidNameError(err) {
+			t.Errorf("expected %q to be invalid, got %v", name, err)
+		}
+	}
+}
+
+func TestObjectName(t *testing.T) {
+	if name := ObjectName("csi", "node1"); name != "csi-node1" {
+		t.Errorf("expected a valid name to be kept, got %s", name)
+	}
+	long := ObjectName("csi", "pvc-"+strings.Repeat("0123456789", 10))
+	if len(long) != payload.MaxNameLength || long != ObjectName("csi", "pvc-"+strings.Repeat("0123456789", 10)) {
+		t.Errorf("expected a deterministic name of %d characters, got %s", payload.MaxNameLength, long)
+	}
+	if long == ObjectName("csi", "pvc-"+strings.Repeat("0123456789", 10)+"x") {
+		t.Error("expected different long names to give different names")
+	}
+	if ObjectName("a-b") != ObjectName("a", "b") {
+		t.Error("expected parts which join to the same string to give the same name")
+	}
+	replaced, other := ObjectName("node.example.com"), ObjectName("node_example_com")
+	if replaced == other || !strings.HasPrefix(replaced, "node_example_com-") {
+		t.Errorf("expected the replaced characters to be hashed, got %s and %s", replaced, other)
+	}
+	for _, name := range []string{long, replaced, ObjectName(""), ObjectName("..")} {
+		if err := ValidateObjectName(MaskingViewKind, name); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCreateWithNameValidation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		resp.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL, WithNameValidation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+	if _, err = client.CreateStorageGroup(ctx, "000000000001", "pvc.default", "SRP_1", "Bronze", false, nil); !IsInvalidNameError(err) {
+		t.Errorf("expected an InvalidNameError, got %v", err)
+	}
+	if _, err = client.CreateMaskingView(ctx, "000000000001", strings.Repeat("m", 65), "sg", "host", true, "pg"); !IsInvalidNameError(err) {
+		t.Errorf("expected an InvalidNameError, got %v", err)
+	}
+	if _, err = client.CreateHost(ctx, "000000000001", "host 1", nil, nil); !IsInvalidNameError(err) {
+		t.Errorf("expected an InvalidNameError, got %v", err)
+	}
+	if _, err = client.CreatePortGroup(ctx, "000000000001", "pg:1", []types.PortKey{{DirectorID: "FA-1D", PortID: "4"}}, ""); !IsInvalidNameError(err) {
+		t.Errorf("expected an InvalidNameError, got %v", err)
+	}
+	if _, err = client.CreateHostGroup(ctx, "000000000001", "", nil, nil); !IsInvalidNameError(err) {
+		t.Errorf("expected an InvalidNameError, got %v", err)
+	}
+}
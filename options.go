@@ -0,0 +1,333 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pmax
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/dell/gopowermax/v2/api"
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+// Option configures the client created by New
+type Option func(*clientConfig)
+
+// clientConfig holds the settings collected from the options passed to New
+type clientConfig struct {
+	applicationName string
+	contextTimeout  time.Duration
+	apiOptions      api.ClientOptions
+	// validateRDFActions is set by WithRDFStateValidation
+	validateRDFActions bool
+	// validateNames is set by WithNameValidation
+	validateNames bool
+	// coalescingWindow is set by WithStorageGroupCoalescing
+	coalescingWindow time.Duration
+	// thinPools is set by WithThinPool
+	thinPools map[string]types.ThinPoolSettings
+	// filterModifiedSince is set by WithModifiedSinceFilter
+	filterModifiedSince bool
+	// verifySupport is set by WithVersionChecks
+	verifySupport bool
+	// validateInitiators and allowMixedInitiators are set by WithInitiatorValidation
+	validateInitiators   bool
+	allowMixedInitiators bool
+}
+
+// New returns a new client for the Unisphere endpoint, e.g. https://1.2.3.4:8443,
+// configured by the options. Certificates are validated by default.
+//
+//	client, err := pmax.New("https://1.2.3.4:8443",
+//		pmax.WithApplicationName("my-app"),
+//		pmax.WithTimeout(2*time.Minute),
+//		pmax.WithCert("/etc/certs"),
+//		pmax.WithRetry(3, time.Second))
+func New(endpoint string, opts ...Option) (Pmax, error) {
+	cfg := &clientConfig{
+		apiOptions: api.ClientOptions{
+			ShowHTTP: debug,
+		},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	client, err := newClient(endpoint, cfg.applicationName, cfg.apiOptions, cfg.contextTimeout)
+	if err != nil {
+		return nil, err
+	}
+	client.(*Client).opts.validateRDFActions = cfg.validateRDFActions
+	client.(*Client).opts.validateNames = cfg.validateNames
+	client.(*Client).opts.filterModifiedSince = cfg.filterModifiedSince
+	client.(*Client).opts.verifySupport = cfg.verifySupport
+	client.(*Client).opts.validateInitiators = cfg.validateInitiators
+	client.(*Client).opts.allowMixedInitiators = cfg.allowMixedInitiators
+	client.(*Client).thinPools.settings = cfg.thinPools
+	client.SetStorageGroupCoalescing(cfg.coalescingWindow)
+	if throttle := cfg.apiOptions.Throttle; throttle != nil && throttle.Probe == nil {
+		throttle.Probe = func(ctx context.Context) (float64, error) {
+			load, err := client.GetServerLoad(ctx)
+			if err != nil {
+				return 0, err
+			}
+			return load.Utilization(), nil
+		}
+	}
+	return client, nil
+}
+
+// WithClientOptions replaces the HTTP settings of the client with opts.
+// It is applied in order, so options passed after it amend opts.
+func WithClientOptions(opts api.ClientOptions) Option {
+	return func(cfg *clientConfig) {
+		cfg.apiOptions = opts
+	}
+}
+
+// WithApplicationName sets the application name registered with Unisphere
+func WithApplicationName(applicationName string) Option {
+	return func(cfg *clientConfig) {
+		cfg.applicationName = applicationName
+	}
+}
+
+// WithTimeout sets the time limit of every Unisphere call made by the client.
+// X_CSI_UNISPHERE_TIMEOUT, or the default timeout, is used if not set.
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *clientConfig) {
+		cfg.contextTimeout = timeout
+	}
+}
+
+// WithInsecure disables the validation of the Unisphere certificate
+func WithInsecure() Option {
+	return func(cfg *clientConfig) {
+		cfg.apiOptions.Insecure = true
+	}
+}
+
+// WithCert trusts the certificate in certFile to validate the Unisphere certificate.
+// If certFile is a directory, all the certificates in it are trusted and reloaded periodically.
+func WithCert(certFile string) Option {
+	return func(cfg *clientConfig) {
+		cfg.apiOptions.UseCerts = true
+		cfg.apiOptions.CertFile = certFile
+		cfg.apiOptions.CertDir = ""
+		if info, err := os.Stat(certFile); err == nil && info.IsDir() {
+			cfg.apiOptions.CertFile = ""
+			cfg.apiOptions.CertDir = certFile
+		}
+	}
+}
+
+// WithRetry retries the GET and HEAD requests failing with a transport error or a 502, 503 or 504 status up to
+// maxRetries times, waiting backoff before the first retry and doubling it after every retry. The POST, PUT, PATCH
+// and DELETE requests are only retried if WithRetryVerification is used too.
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(cfg *clientConfig) {
+		policy := &api.RetryPolicy{}
+		if cfg.apiOptions.Retry != nil {
+			*policy = *cfg.apiOptions.Retry
+		}
+		policy.MaxRetries = maxRetries
+		policy.Backoff = backoff
+		cfg.apiOptions.Retry = policy
+	}
+}
+
+// WithRetryVerification retries the failed POST, PUT, PATCH and DELETE requests, according to WithRetry, when check
+// returns true, e.g. after verifying that the resource the request creates does not exist
+func WithRetryVerification(check api.IdempotencyCheck) Option {
+	return func(cfg *clientConfig) {
+		policy := &api.RetryPolicy{}
+		if cfg.apiOptions.Retry != nil {
+			*policy = *cfg.apiOptions.Retry
+		}
+		policy.VerifyRetry = check
+		cfg.apiOptions.Retry = policy
+	}
+}
+
+// WithRequestSigner signs every request sent by the client with signer
+func WithRequestSigner(signer api.RequestSigner) Option {
+	return func(cfg *clientConfig) {
+		cfg.apiOptions.RequestSigner = signer
+	}
+}
+
+// WithDryRun makes the client return the mutating requests as a DryRunError instead of sending them
+func WithDryRun() Option {
+	return func(cfg *clientConfig) {
+		cfg.apiOptions.DryRun = true
+	}
+}
+
+// WithSafeMode makes the client refuse the destructive operations with a DestructiveOperationError
+// unless they are allowed with api.WithAllowDestructive
+func WithSafeMode() Option {
+	return func(cfg *clientConfig) {
+		cfg.apiOptions.SafeMode = true
+	}
+}
+
+// WithStorageGroupCoalescing coalesces the calls adding volumes to, or removing volumes from, the same storage group
+// within window into a single edit, see SetStorageGroupCoalescing
+func WithStorageGroupCoalescing(window time.Duration) Option {
+	return func(cfg *clientConfig) {
+		cfg.coalescingWindow = window
+	}
+}
+
+// WithJournal records every call changing the array, with its payload and result, in journal
+func WithJournal(journal api.Journal) Option {
+	return func(cfg *clientConfig) {
+		cfg.apiOptions.Journal = journal
+	}
+}
+
+// WithRateLimits limits the requests sent to Unisphere per second, with separate budgets for reads and writes.
+// A zero rate leaves the category unlimited.
+func WithRateLimits(readsPerSecond, writesPerSecond float64) Option {
+	return func(cfg *clientConfig) {
+		cfg.apiOptions.RateLimits = &api.RateLimits{
+			Read:  api.RateLimit{PerSecond: readsPerSecond},
+			Write: api.RateLimit{PerSecond: writesPerSecond},
+		}
+	}
+}
+
+// WithSlowRequestWarning logs a warning, with the method, path and duration of the request, when a request
+// is still in flight after threshold, to help diagnosing Unisphere hangs before the timeout
+func WithSlowRequestWarning(threshold time.Duration) Option {
+	return func(cfg *clientConfig) {
+		cfg.apiOptions.SlowRequestThreshold = threshold
+	}
+}
+
+// WithRDFStateValidation makes ExecuteReplicationActionOnSG check the action against the current state of the pairs,
+// as ValidateReplicationActionOnSG does, and return an InvalidTransitionError instead of sending an invalid action
+func WithRDFStateValidation() Option {
+	return func(cfg *clientConfig) {
+		cfg.validateRDFActions = true
+	}
+}
+
+// WithPinnedCertificates accepts only the Unisphere certificates with one of the SHA-256 fingerprints,
+// e.g. "AB:CD:...", instead of validating them against certificate authorities
+func WithPinnedCertificates(fingerprints ...string) Option {
+	return func(cfg *clientConfig) {
+		cfg.apiOptions.PinnedCertificates = fingerprints
+	}
+}
+
+// WithUserAgent sends userAgent as the User-Agent of every request, e.g. "my-app/1.2", to identify the client in the Unisphere logs
+func WithUserAgent(userAgent string) Option {
+	return func(cfg *clientConfig) {
+		cfg.apiOptions.UserAgent = userAgent
+	}
+}
+
+// WithRequestID sends a new UUID with every request in header, or in api.DefaultRequestIDHeader if header is empty.
+// The ID is logged with the request and returned in its errors, in types.Error or api.RequestError, so that a failed
+// call can be matched with the Unisphere logs.
+func WithRequestID(header string) Option {
+	return func(cfg *clientConfig) {
+		if header == "" {
+			header = api.DefaultRequestIDHeader
+		}
+		cfg.apiOptions.RequestIDHeader = header
+	}
+}
+
+// WithAdaptiveThrottle delays the requests when the load of the Unisphere management server, as returned by
+// GetServerLoad every api.DefaultThrottleProbeInterval, exceeds threshold percent. The delay grows with the load,
+// up to maxDelay at 100%. The api defaults are used for a zero threshold or maxDelay. The load is the CPU utilization,
+// see types.ServerLoad.Utilization. On a Unisphere without the server_load resource, the first probe fails with
+// a 404 and logs a warning, the load is not probed again and the requests are never delayed.
+func WithAdaptiveThrottle(threshold float64, maxDelay time.Duration) Option {
+	return func(cfg *clientConfig) {
+		cfg.apiOptions.Throttle = &api.AdaptiveThrottle{Threshold: threshold, MaxDelay: maxDelay}
+	}
+}
+
+// WithResponseValidation retries, up to maxRetries times, the GET requests getting a 200 response with an empty or truncated
+// JSON body, before failing with api.ErrInvalidResponse. onInvalid, if not nil, is called for every invalid response.
+func WithResponseValidation(maxRetries int, onInvalid func(req *http.Request, attempt int, err error)) Option {
+	return func(cfg *clientConfig) {
+		cfg.apiOptions.ResponseValidation = &api.ResponseValidation{MaxRetries: maxRetries, OnInvalidResponse: onInvalid}
+	}
+}
+
+// WithResponseLimits fails the requests getting a response body larger than maxBodySize bytes, with an
+// api.ResponseTooLargeError, or not read and decoded within maxDecodeTime, with an api.DecodeTimeoutError,
+// e.g. to bound the memory of a CSI node plugin. A zero value leaves the limit unset. Binary downloads, such as
+// DownloadLogBundle, are not limited.
+func WithResponseLimits(maxBodySize int64, maxDecodeTime time.Duration) Option {
+	return func(cfg *clientConfig) {
+		cfg.apiOptions.ResponseLimits = &api.ResponseLimits{MaxBodySize: maxBodySize, MaxDecodeTime: maxDecodeTime}
+	}
+}
+
+// WithNameValidation makes the calls creating a storage group, masking view, host, host group or port group check
+// its name with ValidateObjectName, and return an InvalidNameError instead of sending a name Unisphere would refuse
+func WithNameValidation() Option {
+	return func(cfg *clientConfig) {
+		cfg.validateNames = true
+	}
+}
+
+// WithModifiedSinceFilter makes ListStorageGroupsModifiedSince, ListVolumesModifiedSince and ListMaskingViewsModifiedSince
+// send the ModifiedSinceParam query parameter, for a Unisphere known to honour it. Without it, all the objects are
+// listed and the results are marked as full, since a Unisphere ignoring the parameter would return all the objects
+// as if they had been modified.
+func WithModifiedSinceFilter() Option {
+	return func(cfg *clientConfig) {
+		cfg.filterModifiedSince = true
+	}
+}
+
+// WithInitiatorValidation makes CreateHost check the initiators with ValidateHostInitiators, and return its error
+// instead of sending initiators Unisphere would refuse. allowMixed accepts a host with initiators of different
+// transports, for the arrays supporting it.
+func WithInitiatorValidation(allowMixed bool) Option {
+	return func(cfg *clientConfig) {
+		cfg.validateInitiators = true
+		cfg.allowMixedInitiators = allowMixed
+	}
+}
+
+// WithVersionChecks makes the methods listed in Capabilities call VerifySupport, and return an
+// UnsupportedVersionError instead of the 404 of a Unisphere, or array, too old for them. The versions are
+// looked up on the first call of each method on each array.
+func WithVersionChecks() Option {
+	return func(cfg *clientConfig) {
+		cfg.verifySupport = true
+	}
+}
+
+// WithThinPool sets the thin pool, and optionally the FAST policy, of the storage groups created on the array symID
+// if it runs a version older than MinSRPPowerMaxOS and so has no storage resource pool. The storage groups created
+// on such an array, and the storage group replicas created on it, are then sent with the thin pool instead of the
+// SRP and service level. Without it, the generation of the arrays is not looked up.
+func WithThinPool(symID, thinPool, fastPolicy string) Option {
+	return func(cfg *clientConfig) {
+		if cfg.thinPools == nil {
+			cfg.thinPools = make(map[string]types.ThinPoolSettings)
+		}
+		cfg.thinPools[symID] = types.ThinPoolSettings{ThinPool: thinPool, FastPolicy: fastPolicy}
+	}
+}
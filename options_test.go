@@ -0,0 +1,233 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pmax
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dell/gopowermax/v2/api"
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	if _, err := New(""); err == nil {
+		t.Error("expected an error for a missing endpoint")
+	}
+
+	var calls int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"symmetrixId":["000000000001"]}`))
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL,
+		WithApplicationName("app"),
+		WithTimeout(time.Minute),
+		WithInsecure(),
+		WithRetry(1, time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := client.(*Client)
+	if c.contextTimeout != time.Minute {
+		t.Errorf("expected a timeout of 1m, got %s", c.contextTimeout)
+	}
+	if c.headers.applicationType != "app" {
+		t.Errorf("expected application name app, got %s", c.headers.applicationType)
+	}
+	ids, err := client.GetSymmetrixIDList(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids.SymmetrixIDs) != 1 || calls != 2 {
+		t.Errorf("expected one array after one retry, got %v after %d calls", ids.SymmetrixIDs, calls)
+	}
+
+	// certificates are validated unless WithInsecure is used
+	client, err = New(server.URL, WithClientOptions(api.ClientOptions{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.GetSymmetrixIDList(context.Background()); err == nil {
+		t.Error("expected a certificate error")
+	}
+}
+
+func TestWithCert(t *testing.T) {
+	cfg := &clientConfig{}
+	dir := t.TempDir()
+	WithCert(dir)(cfg)
+	if !cfg.apiOptions.UseCerts || cfg.apiOptions.CertDir != dir || cfg.apiOptions.CertFile != "" {
+		t.Errorf("expected the directory to be used as CertDir, got %+v", cfg.apiOptions)
+	}
+	WithCert("/path/to/cert.pem")(cfg)
+	if cfg.apiOptions.CertFile != "/path/to/cert.pem" || cfg.apiOptions.CertDir != "" {
+		t.Errorf("expected the file to be used as CertFile, got %+v", cfg.apiOptions)
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	cfg := &clientConfig{}
+	WithRetryVerification(func(context.Context, *http.Request, []byte) bool { return true })(cfg)
+	WithRetry(3, time.Second)(cfg)
+	if cfg.apiOptions.Retry.MaxRetries != 3 || cfg.apiOptions.Retry.Backoff != time.Second || cfg.apiOptions.Retry.VerifyRetry == nil {
+		t.Errorf("expected the retry options to be combined, got %+v", cfg.apiOptions.Retry)
+	}
+}
+
+func TestWithRateLimits(t *testing.T) {
+	cfg := &clientConfig{}
+	WithRateLimits(50, 10)(cfg)
+	expected := &api.RateLimits{Read: api.RateLimit{PerSecond: 50}, Write: api.RateLimit{PerSecond: 10}}
+	if *cfg.apiOptions.RateLimits != *expected {
+		t.Errorf("unexpected rate limits %+v", cfg.apiOptions.RateLimits)
+	}
+}
+
+func TestWithPinnedCertificates(t *testing.T) {
+	cfg := &clientConfig{}
+	WithPinnedCertificates("AB:CD", "EF")(cfg)
+	if !reflect.DeepEqual([]string{"AB:CD", "EF"}, cfg.apiOptions.PinnedCertificates) {
+		t.Errorf("unexpected pinned certificates %v", cfg.apiOptions.PinnedCertificates)
+	}
+}
+
+func TestWithUserAgentAndRequestID(t *testing.T) {
+	cfg := &clientConfig{}
+	WithUserAgent("my-app/1.0")(cfg)
+	WithRequestID("")(cfg)
+	if cfg.apiOptions.UserAgent != "my-app/1.0" || cfg.apiOptions.RequestIDHeader != api.DefaultRequestIDHeader {
+		t.Errorf("unexpected options %+v", cfg.apiOptions)
+	}
+	WithRequestID("X-Correlation-Id")(cfg)
+	if cfg.apiOptions.RequestIDHeader != "X-Correlation-Id" {
+		t.Errorf("unexpected request ID header %s", cfg.apiOptions.RequestIDHeader)
+	}
+}
+
+func TestWithResponseValidation(t *testing.T) {
+	cfg := &clientConfig{}
+	invalid := 0
+	WithResponseValidation(3, func(_ *http.Request, _ int, _ error) { invalid++ })(cfg)
+	validation := cfg.apiOptions.ResponseValidation
+	if validation == nil || validation.MaxRetries != 3 || validation.OnInvalidResponse == nil {
+		t.Fatalf("unexpected response validation %+v", validation)
+	}
+	validation.OnInvalidResponse(nil, 1, api.ErrInvalidResponse)
+	if invalid != 1 {
+		t.Error("expected the hook to be set")
+	}
+}
+
+func TestWithResponseLimits(t *testing.T) {
+	cfg := &clientConfig{}
+	WithResponseLimits(1<<20, 5*time.Second)(cfg)
+	limits := cfg.apiOptions.ResponseLimits
+	if limits == nil || limits.MaxBodySize != 1<<20 || limits.MaxDecodeTime != 5*time.Second {
+		t.Fatalf("unexpected response limits %+v", limits)
+	}
+}
+
+func TestWithAdaptiveThrottle(t *testing.T) {
+	probed := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/"+RESTPrefix+DefaultAPIVersion+"/system/server_load" {
+			_, _ = w.Write([]byte(`{"cpu_utilization_percent":40,"memory_utilization_percent":95,"active_requests":12}`))
+			select {
+			case probed <- struct{}{}:
+			default:
+			}
+			return
+		}
+		_, _ = w.Write([]byte(`{"symmetrixId":["000000000001"]}`))
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL, WithAdaptiveThrottle(50, 10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the first request probes the load in the background
+	if _, err = client.GetSymmetrixIDList(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-probed:
+	case <-time.After(time.Second):
+		t.Fatal("expected the load to be probed")
+	}
+	load, err := client.GetServerLoad(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if load.Utilization() != 40 || load.ActiveRequests != 12 {
+		t.Errorf("unexpected load %+v", load)
+	}
+}
+
+func TestWithAdaptiveThrottleWithoutServerLoad(t *testing.T) {
+	probed := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/"+RESTPrefix+DefaultAPIVersion+"/system/server_load" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			select {
+			case probed <- struct{}{}:
+			default:
+			}
+			return
+		}
+		_, _ = w.Write([]byte(`{"symmetrixId":["000000000001"]}`))
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL, WithAdaptiveThrottle(50, time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.GetSymmetrixIDList(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-probed:
+	case <-time.After(time.Second):
+		t.Fatal("expected the load to be probed")
+	}
+	// the failed probe leaves the requests undelayed
+	start := time.Now()
+	if _, err = client.GetSymmetrixIDList(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("expected the request not to be delayed, took %s", elapsed)
+	}
+	var apiErr *types.Error
+	if _, err = client.GetServerLoad(context.Background()); !errors.As(err, &apiErr) || apiErr.HTTPStatusCode != http.StatusNotFound {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
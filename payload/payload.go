@@ -0,0 +1,221 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+// Package payload builds and validates the request payloads sent to Unisphere, without a client or any HTTP
+// call, so that they can be rendered and reviewed before being executed. The payload builders of the pmax
+// client use the functions of this package.
+package payload
+
+import (
+	"strconv"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+// The RDF modes accepted by the RDF payload builders, the same as the ASYNC, SYNC and METRO constants of the pmax package
+const (
+	RDFModeAsync = "ASYNC"
+	RDFModeSync  = "SYNC"
+	RDFModeMetro = "METRO"
+)
+
+// DefaultEmulation is the emulation of the storage groups and volumes created by the payloads
+const DefaultEmulation = "FBA"
+
+// CreateStorageGroup returns the payload to create a storage group. If srpID is "None" then serviceLevel and
+// thickVolumes are ignored. The optional payload can hold "hostLimits", a *types.SetHostIOLimitsParam,
+// and "snapshotPolicies", a []string.
+func CreateStorageGroup(storageGroupID, srpID, serviceLevel string, thickVolumes bool, optionalPayload map[string]interface{}) *types.CreateStorageGroupParam {
+	workload := "None"
+	sloParams := []types.SLOBasedStorageGroupParam{}
+	var snapshotPolicies []string
+	if srpID != "None" {
+		sloParams = []types.SLOBasedStorageGroupParam{
+			{
+				SLOID:             serviceLevel,
+				WorkloadSelection: workload,
+				VolumeAttributes: []types.VolumeAttributeType{
+					{
+						VolumeSize:      "0",
+						CapacityUnit:    "CYL",
+						NumberOfVolumes: 0,
+					},
+				},
+				AllocateCapacityForEachVol: thickVolumes,
+				// compression not allowed with thick volumes
+				NoCompression: thickVolumes,
+			},
+		}
+
+		if len(optionalPayload) > 0 {
+			hostLimit, ok := optionalPayload["hostLimits"]
+			if ok {
+				sloParams[0].SetHostIOLimitsParam = hostLimit.(*types.SetHostIOLimitsParam)
+			}
+			snapshotPolicies, _ = optionalPayload["snapshotPolicies"].([]string)
+		}
+	}
+	return &types.CreateStorageGroupParam{
+		StorageGroupID:            storageGroupID,
+		SRPID:                     srpID,
+		Emulation:                 DefaultEmulation,
+		ExecutionOption:           types.ExecutionOptionSynchronous,
+		SLOBasedStorageGroupParam: sloParams,
+		SnapshotPolicies:          snapshotPolicies,
+	}
+}
+
+// CreateVolumeInStorageGroup returns the payload to create a volume in a storage group. volumeSize is an int or a string.
+// If remoteSymID is set, the payload includes the remote storage group of a protected storage group.
+func CreateVolumeInStorageGroup(volumeSize interface{}, capUnit string, volumeName string, isSync, enableMobility bool, remoteSymID, remoteStorageGroupID string) *types.UpdateStorageGroupPayload {
+	var size string
+	if val, isInt := volumeSize.(int); isInt {
+		size = strconv.Itoa(val)
+	} else if val, isString := volumeSize.(string); isString {
+		size = val
+	}
+	addVolumeParam := &types.AddVolumeParam{
+		CreateNewVolumes: true,
+		Emulation:        DefaultEmulation,
+		EnableMobilityID: enableMobility,
+		VolumeAttributes: []types.VolumeAttributeType{
+			{
+				NumberOfVolumes: 1,
+				VolumeIdentifier: &types.VolumeIdentifierType{
+					VolumeIdentifierChoice: "identifier_name",
+					IdentifierName:         volumeName,
+				},
+				CapacityUnit: capUnit,
+				VolumeSize:   size,
+			},
+		},
+		RemoteSymmetrixSGInfo: types.RemoteSymmSGInfoParam{
+			Force: true,
+		},
+	}
+	if remoteSymID != "" {
+		addVolumeParam.RemoteSymmetrixSGInfo.RemoteSymmetrix1ID = remoteSymID
+		addVolumeParam.RemoteSymmetrixSGInfo.RemoteSymmetrix1SGs = []string{remoteStorageGroupID}
+	}
+	return &types.UpdateStorageGroupPayload{
+		EditStorageGroupActionParam: types.EditStorageGroupActionParam{
+			ExpandStorageGroupParam: &types.ExpandStorageGroupParam{
+				AddVolumeParam: addVolumeParam,
+			},
+		},
+		ExecutionOption: executionOption(isSync),
+	}
+}
+
+// AddVolumesToStorageGroup returns the payload to add existing volumes to a storage group.
+// If remoteSymID is set, the payload includes the remote storage group of a protected storage group.
+func AddVolumesToStorageGroup(isSync, force bool, remoteSymID, remoteStorageGroupID string, volumeIDs ...string) *types.UpdateStorageGroupPayload {
+	addSpecificVolumeParam := &types.AddSpecificVolumeParam{
+		VolumeIDs: volumeIDs,
+		RemoteSymmetrixSGInfo: types.RemoteSymmSGInfoParam{
+			Force: force,
+		},
+	}
+	if remoteSymID != "" {
+		addSpecificVolumeParam.RemoteSymmetrixSGInfo.RemoteSymmetrix1ID = remoteSymID
+		addSpecificVolumeParam.RemoteSymmetrixSGInfo.RemoteSymmetrix1SGs = []string{remoteStorageGroupID}
+	}
+	return &types.UpdateStorageGroupPayload{
+		EditStorageGroupActionParam: types.EditStorageGroupActionParam{
+			ExpandStorageGroupParam: &types.ExpandStorageGroupParam{
+				AddSpecificVolumeParam: addSpecificVolumeParam,
+			},
+		},
+		ExecutionOption: executionOption(isSync),
+	}
+}
+
+// RemoveVolumesFromStorageGroup returns the payload to remove volumes from a storage group.
+// If remoteSymID is set, the payload includes the remote storage group of a protected storage group.
+func RemoveVolumesFromStorageGroup(force bool, remoteSymID, remoteStorageGroupID string, volumeIDs ...string) *types.UpdateStorageGroupPayload {
+	removeVolumeParam := &types.RemoveVolumeParam{
+		VolumeIDs: volumeIDs,
+		RemoteSymmSGInfoParam: types.RemoteSymmSGInfoParam{
+			Force: force,
+		},
+	}
+	if remoteSymID != "" {
+		removeVolumeParam.RemoteSymmSGInfoParam.RemoteSymmetrix1ID = remoteSymID
+		removeVolumeParam.RemoteSymmSGInfoParam.RemoteSymmetrix1SGs = []string{remoteStorageGroupID}
+	}
+	return &types.UpdateStorageGroupPayload{
+		EditStorageGroupActionParam: types.EditStorageGroupActionParam{
+			RemoveVolumeParam: removeVolumeParam,
+		},
+		ExecutionOption: types.ExecutionOptionSynchronous,
+	}
+}
+
+// CreateStorageGroupReplica returns the payload to create a storage group on the remote array and protect it in RDF
+// group rdfgNo. It returns nil if rdfMode is not one of the RDFMode constants.
+func CreateStorageGroupReplica(remoteSymID string, rdfMode string, rdfgNo int, remoteSGName string, remoteServiceLevel string, establish, bias bool) *types.CreateSGSRDF {
+	replicationMode, ok := replicationModes[rdfMode]
+	if !ok {
+		return nil
+	}
+	payload := &types.CreateSGSRDF{
+		ReplicationMode:        replicationMode,
+		RemoteSLO:              remoteServiceLevel,
+		RemoteSymmID:           remoteSymID,
+		RdfgNumber:             rdfgNo,
+		RemoteStorageGroupName: remoteSGName,
+		Establish:              establish,
+		ExecutionOption:        types.ExecutionOptionSynchronous,
+	}
+	if rdfMode == RDFModeMetro {
+		payload.MetroBias = bias
+	}
+	return payload
+}
+
+// CreateRDFPair returns the payload to pair the devices of devList. SRDF/Metro pairs are always of type RDF1,
+// with bias. It returns nil if rdfMode is not one of the RDFMode constants.
+func CreateRDFPair(devList types.LocalDeviceListCriteria, rdfMode, rdfType string, establish, exemptConsistency bool) *types.CreateRDFPair {
+	replicationMode, ok := replicationModes[rdfMode]
+	if !ok {
+		return nil
+	}
+	payload := &types.CreateRDFPair{
+		RdfMode:                 replicationMode,
+		RdfType:                 rdfType,
+		Establish:               establish,
+		Exempt:                  exemptConsistency,
+		LocalDeviceListCriteria: &devList,
+		ExecutionOption:         types.ExecutionOptionSynchronous,
+	}
+	if rdfMode == RDFModeMetro {
+		payload.RdfType = "RDF1"
+		payload.Bias = true
+	}
+	return payload
+}
+
+// replicationModes maps the RDF modes to the replication modes of the payloads
+var replicationModes = map[string]string{
+	RDFModeAsync: "Asynchronous",
+	RDFModeSync:  "Synchronous",
+	RDFModeMetro: "Active",
+}
+
+func executionOption(isSync bool) string {
+	if isSync {
+		return types.ExecutionOptionSynchronous
+	}
+	return types.ExecutionOptionAsynchronous
+}
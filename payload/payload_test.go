@@ -0,0 +1,99 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package payload
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+func TestValidate(t *testing.T) {
+	devices := types.LocalDeviceListCriteria{LocalDeviceList: []string{"0012A"}}
+	tests := []struct {
+		name     string
+		payload  interface{}
+		problems []string
+	}{
+		{"storage group", CreateStorageGroup("csi-sg_1", "SRP_1", "Diamond", false, nil), nil},
+		{"storage group without pool", CreateStorageGroup("csi-sg", "None", "", false, nil), nil},
+		{"storage group with invalid name", CreateStorageGroup("csi sg", "SRP_1", "", false, nil),
+			[]string{"can only contain", "service level is empty"}},
+		{"new volume", CreateVolumeInStorageGroup(10, "GB", "vol-1", true, false, "000000000002", "remote-sg"), nil},
+		{"new volume with invalid size", CreateVolumeInStorageGroup("ten", "PB", strings.Repeat("v", 65), false, false, "2", "remote-sg"),
+			[]string{"capacity unit", "volume size", "longer than 64", "symmetrix id"}},
+		{"add volumes", AddVolumesToStorageGroup(true, false, "", "", "0012A", "0012b"), nil},
+		{"add no volume", AddVolumesToStorageGroup(true, false, "000000000002", ""), []string{"no volume id", "remote storage group name is empty"}},
+		{"remove volumes", RemoveVolumesFromStorageGroup(true, "", "", "12345678"), []string{"5 hexadecimal digits"}},
+		{"no action", &types.UpdateStorageGroupPayload{ExecutionOption: types.ExecutionOptionSynchronous}, []string{"no storage group action"}},
+		{"replica", CreateStorageGroupReplica("000000000002", RDFModeMetro, 10, "remote-sg", "Diamond", true, true), nil},
+		{"replica with invalid group", CreateStorageGroupReplica("000000000002", RDFModeSync, 251, "remote-sg", "", true, false), []string{"RDF group number"}},
+		{"replica with invalid mode", CreateStorageGroupReplica("000000000002", "FAST", 10, "remote-sg", "", true, false), []string{"payload is nil"}},
+		{"RDF pair", CreateRDFPair(devices, RDFModeAsync, "RDF1", true, true), nil},
+		{"RDF pair with invalid type", CreateRDFPair(devices, RDFModeSync, "R1", true, false), []string{"RDF type"}},
+		{"RDF pair with remote devices", CreateRDFPair(types.LocalDeviceListCriteria{LocalDeviceList: []string{"0012A"}, RemoteDeviceList: []string{"0012A", "0012B"}}, RDFModeSync, "RDF1", true, false),
+			[]string{"2 remote devices are given for 1 local devices"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.payload)
+			if tt.problems == nil {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected a ValidationError, got %v", err)
+			}
+			if len(validationErr.Problems) != len(tt.problems) {
+				t.Fatalf("expected %d problems, got %v", len(tt.problems), validationErr.Problems)
+			}
+			for i, problem := range tt.problems {
+				if !strings.Contains(validationErr.Problems[i], problem) {
+					t.Errorf("expected problem %d to contain %q, got %q", i, problem, validationErr.Problems[i])
+				}
+			}
+		})
+	}
+	if err := Validate(&types.Volume{}); err == nil {
+		t.Error("expected an error for an unsupported payload")
+	}
+}
+
+func TestRender(t *testing.T) {
+	content, err := Render(AddVolumesToStorageGroup(true, true, "000000000002", "remote-sg", "0012A"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &types.UpdateStorageGroupPayload{}
+	if err = json.Unmarshal(content, decoded); err != nil {
+		t.Fatal(err)
+	}
+	addVolumes := decoded.EditStorageGroupActionParam.ExpandStorageGroupParam.AddSpecificVolumeParam
+	if addVolumes.VolumeIDs[0] != "0012A" || addVolumes.RemoteSymmetrixSGInfo.RemoteSymmetrix1SGs[0] != "remote-sg" {
+		t.Errorf("unexpected rendered payload %s", content)
+	}
+	if !strings.Contains(string(content), "\n  ") {
+		t.Errorf("expected an indented payload, got %s", content)
+	}
+	if _, err = Render(AddVolumesToStorageGroup(true, true, "", "")); err == nil {
+		t.Error("expected an invalid payload not to be rendered")
+	}
+}
@@ -0,0 +1,239 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package payload
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+// The limits checked by Validate
+const (
+	// MaxNameLength is the maximum length of the name of a storage group, masking view, host, host group or port group
+	MaxNameLength = 64
+	// MaxVolumeIdentifierLength is the maximum length of a volume identifier
+	MaxVolumeIdentifierLength = 64
+	// MaxRDFGroupNumber is the highest RDF group number
+	MaxRDFGroupNumber = 250
+)
+
+var (
+	symmetrixIDRegex = regexp.MustCompile(`^[0-9]{12}$`)
+	volumeIDRegex    = regexp.MustCompile(`^[0-9A-Fa-f]{5}$`)
+	capacityUnits    = []string{"CYL", "MB", "GB", "TB"}
+)
+
+// ValidationError lists the problems found in a payload by Validate
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid payload: " + strings.Join(e.Problems, "; ")
+}
+
+// Validate checks a payload returned by one of the builders of the package, or built by the caller,
+// before it is sent to Unisphere. It returns a *ValidationError listing all the problems found.
+func Validate(payload interface{}) error {
+	v := &validator{}
+	switch p := payload.(type) {
+	case *types.CreateStorageGroupParam:
+		if p == nil {
+			return &ValidationError{Problems: []string{"payload is nil"}}
+		}
+		v.validateCreateStorageGroup(p)
+	case *types.UpdateStorageGroupPayload:
+		if p == nil {
+			return &ValidationError{Problems: []string{"payload is nil"}}
+		}
+		v.validateUpdateStorageGroup(p)
+	case *types.CreateSGSRDF:
+		if p == nil {
+			return &ValidationError{Problems: []string{"payload is nil, the RDF mode may be invalid"}}
+		}
+		v.validateCreateStorageGroupReplica(p)
+	case *types.CreateRDFPair:
+		if p == nil {
+			return &ValidationError{Problems: []string{"payload is nil, the RDF mode may be invalid"}}
+		}
+		v.validateCreateRDFPair(p)
+	default:
+		return fmt.Errorf("payload of type %T is not supported", payload)
+	}
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+// Render validates a payload and returns it as indented JSON, as it would be sent to Unisphere
+func Render(payload interface{}) ([]byte, error) {
+	if err := Validate(payload); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(payload, "", "  ")
+}
+
+// IsValidNameChar returns true for the characters allowed in the names of storage groups, masking views, hosts,
+// host groups and port groups: letters, digits, '-' and '_'
+func IsValidNameChar(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_'
+}
+
+// validator collects the problems found in a payload
+type validator struct {
+	problems []string
+}
+
+func (v *validator) addf(format string, args ...interface{}) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+func (v *validator) validateName(kind, name string) {
+	switch {
+	case name == "":
+		v.addf("%s name is empty", kind)
+	case len(name) > MaxNameLength:
+		v.addf("%s name %s is longer than %d characters", kind, name, MaxNameLength)
+	case strings.IndexFunc(name, func(r rune) bool { return !IsValidNameChar(r) }) >= 0:
+		v.addf("%s name %s can only contain letters, digits, '_' and '-'", kind, name)
+	}
+}
+
+func (v *validator) validateSymmetrixID(symID string) {
+	if !symmetrixIDRegex.MatchString(symID) {
+		v.addf("symmetrix id %q is not made of 12 digits", symID)
+	}
+}
+
+func (v *validator) validateVolumeIDs(volumeIDs []string) {
+	if len(volumeIDs) == 0 {
+		v.addf("no volume id is given")
+	}
+	for _, volumeID := range volumeIDs {
+		if !volumeIDRegex.MatchString(volumeID) {
+			v.addf("volume id %q is not made of 5 hexadecimal digits", volumeID)
+		}
+	}
+}
+
+func (v *validator) validateRemoteStorageGroup(remote types.RemoteSymmSGInfoParam) {
+	if remote.RemoteSymmetrix1ID == "" {
+		return
+	}
+	v.validateSymmetrixID(remote.RemoteSymmetrix1ID)
+	if len(remote.RemoteSymmetrix1SGs) == 0 {
+		v.addf("no storage group is given for remote symmetrix %s", remote.RemoteSymmetrix1ID)
+	}
+	for _, sg := range remote.RemoteSymmetrix1SGs {
+		v.validateName("remote storage group", sg)
+	}
+}
+
+func (v *validator) validateCreateStorageGroup(p *types.CreateStorageGroupParam) {
+	v.validateName("storage group", p.StorageGroupID)
+	if p.SRPID == "" {
+		v.addf("storage resource pool is empty, use None for no pool")
+	}
+	for _, slo := range p.SLOBasedStorageGroupParam {
+		if slo.SLOID == "" {
+			v.addf("service level is empty")
+		}
+	}
+}
+
+func (v *validator) validateUpdateStorageGroup(p *types.UpdateStorageGroupPayload) {
+	action := p.EditStorageGroupActionParam
+	switch {
+	case action.ExpandStorageGroupParam != nil && action.ExpandStorageGroupParam.AddVolumeParam != nil:
+		addVolume := action.ExpandStorageGroupParam.AddVolumeParam
+		if len(addVolume.VolumeAttributes) == 0 {
+			v.addf("no volume attributes are given")
+		}
+		for _, attributes := range addVolume.VolumeAttributes {
+			v.validateVolumeAttributes(attributes)
+		}
+		v.validateRemoteStorageGroup(addVolume.RemoteSymmetrixSGInfo)
+	case action.ExpandStorageGroupParam != nil && action.ExpandStorageGroupParam.AddSpecificVolumeParam != nil:
+		addVolumes := action.ExpandStorageGroupParam.AddSpecificVolumeParam
+		v.validateVolumeIDs(addVolumes.VolumeIDs)
+		v.validateRemoteStorageGroup(addVolumes.RemoteSymmetrixSGInfo)
+	case action.RemoveVolumeParam != nil:
+		v.validateVolumeIDs(action.RemoveVolumeParam.VolumeIDs)
+		v.validateRemoteStorageGroup(action.RemoveVolumeParam.RemoteSymmSGInfoParam)
+	case action == types.EditStorageGroupActionParam{}:
+		v.addf("no storage group action is given")
+	}
+	if p.ExecutionOption != types.ExecutionOptionSynchronous && p.ExecutionOption != types.ExecutionOptionAsynchronous {
+		v.addf("execution option %q is neither %s nor %s", p.ExecutionOption, types.ExecutionOptionSynchronous, types.ExecutionOptionAsynchronous)
+	}
+}
+
+func (v *validator) validateVolumeAttributes(attributes types.VolumeAttributeType) {
+	if !slices.Contains(capacityUnits, attributes.CapacityUnit) {
+		v.addf("capacity unit %q is not one of %s", attributes.CapacityUnit, strings.Join(capacityUnits, ", "))
+	}
+	if size, err := strconv.ParseFloat(attributes.VolumeSize, 64); err != nil || size <= 0 {
+		v.addf("volume size %q is not a positive number", attributes.VolumeSize)
+	}
+	if attributes.NumberOfVolumes <= 0 {
+		v.addf("number of volumes %d is not positive", attributes.NumberOfVolumes)
+	}
+	if attributes.VolumeIdentifier != nil && len(attributes.VolumeIdentifier.IdentifierName) > MaxVolumeIdentifierLength {
+		v.addf("volume identifier %s is longer than %d characters", attributes.VolumeIdentifier.IdentifierName, MaxVolumeIdentifierLength)
+	}
+}
+
+func (v *validator) validateReplicationMode(mode string) {
+	for _, replicationMode := range replicationModes {
+		if mode == replicationMode {
+			return
+		}
+	}
+	v.addf("replication mode %q is not supported", mode)
+}
+
+func (v *validator) validateCreateStorageGroupReplica(p *types.CreateSGSRDF) {
+	v.validateSymmetrixID(p.RemoteSymmID)
+	v.validateReplicationMode(p.ReplicationMode)
+	if p.RdfgNumber < 1 || p.RdfgNumber > MaxRDFGroupNumber {
+		v.addf("RDF group number %d is not between 1 and %d", p.RdfgNumber, MaxRDFGroupNumber)
+	}
+	v.validateName("remote storage group", p.RemoteStorageGroupName)
+}
+
+func (v *validator) validateCreateRDFPair(p *types.CreateRDFPair) {
+	v.validateReplicationMode(p.RdfMode)
+	if p.RdfType != "RDF1" && p.RdfType != "RDF2" {
+		v.addf("RDF type %q is neither RDF1 nor RDF2", p.RdfType)
+	}
+	if p.LocalDeviceListCriteria == nil {
+		v.addf("no local device is given")
+		return
+	}
+	v.validateVolumeIDs(p.LocalDeviceListCriteria.LocalDeviceList)
+	if remote := p.LocalDeviceListCriteria.RemoteDeviceList; len(remote) > 0 {
+		if len(remote) != len(p.LocalDeviceListCriteria.LocalDeviceList) {
+			v.addf("%d remote devices are given for %d local devices", len(remote), len(p.LocalDeviceListCriteria.LocalDeviceList))
+		}
+		v.validateVolumeIDs(remote)
+	}
+}
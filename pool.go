@@ -0,0 +1,179 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pmax
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ClientPoolOptions configures a ClientPool
+type ClientPoolOptions struct {
+	// HealthCheckInterval is how long a pooled client is used before it is checked again, by authenticating,
+	// when it is taken from the pool. A client failing the check is replaced. Clients are not checked if it is 0.
+	HealthCheckInterval time.Duration
+	// IdleTimeout is how long a pooled client can be unused before it is evicted and its idle connections
+	// are closed. Clients are not evicted if it is 0.
+	IdleTimeout time.Duration
+	// ClientOptions are passed to New when the clients are created
+	ClientOptions []Option
+}
+
+// ClientPool holds a client for each Unisphere endpoint and credentials, so that the controllers managing
+// several arrays share the clients, and their connections, instead of creating one per call.
+// The clients are created and authenticated the first time they are taken from the pool.
+type ClientPool struct {
+	opts    ClientPoolOptions
+	mu      sync.Mutex
+	clients map[string]*pooledClient
+}
+
+// pooledClient is a client of a ClientPool. mu is held while the client is created or checked.
+type pooledClient struct {
+	mu          sync.Mutex
+	client      Pmax
+	lastChecked time.Time
+	// lastUsed is guarded by the mutex of the pool
+	lastUsed time.Time
+}
+
+// NewClientPool returns an empty ClientPool
+func NewClientPool(opts ClientPoolOptions) *ClientPool {
+	return &ClientPool{opts: opts, clients: make(map[string]*pooledClient)}
+}
+
+// poolKey returns the key of the clients for the endpoint and credentials, without the password in clear
+func poolKey(configConnect *ConfigConnect) string {
+	password := sha256.Sum256([]byte(configConnect.Password))
+	return configConnect.Endpoint + "|" + configConnect.Username + "|" + hex.EncodeToString(password[:])
+}
+
+// Get returns the client for the endpoint and credentials of configConnect, creating and authenticating it
+// if the pool does not hold one yet, or if the pooled client fails its health check
+func (p *ClientPool) Get(ctx context.Context, configConnect *ConfigConnect) (Pmax, error) {
+	if configConnect == nil || configConnect.Endpoint == "" {
+		return nil, fmt.Errorf("Endpoint must be supplied, e.g. https://1.2.3.4:8443")
+	}
+	connect := *configConnect
+	key := poolKey(&connect)
+
+	p.mu.Lock()
+	p.evictIdle()
+	entry, ok := p.clients[key]
+	if !ok {
+		entry = &pooledClient{}
+		p.clients[key] = entry
+	}
+	entry.lastUsed = time.Now()
+	p.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.client != nil {
+		if p.opts.HealthCheckInterval <= 0 || time.Since(entry.lastChecked) < p.opts.HealthCheckInterval {
+			return entry.client, nil
+		}
+		err := entry.client.Authenticate(ctx, &connect)
+		if err == nil {
+			entry.lastChecked = time.Now()
+			return entry.client, nil
+		}
+		log.Warn(fmt.Sprintf("Health check of the client for %s failed, replacing it: %s", connect.Endpoint, err.Error()))
+		entry.client.GetHTTPClient().CloseIdleConnections()
+		entry.client = nil
+	}
+	client, err := New(connect.Endpoint, p.opts.ClientOptions...)
+	if err == nil {
+		err = client.Authenticate(ctx, &connect)
+		if err != nil {
+			client.GetHTTPClient().CloseIdleConnections()
+		}
+	}
+	if err != nil {
+		p.remove(key, entry)
+		return nil, err
+	}
+	entry.client = client
+	entry.lastChecked = time.Now()
+	return client, nil
+}
+
+// remove removes entry from the pool, unless it was already replaced
+func (p *ClientPool) remove(key string, entry *pooledClient) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.clients[key] == entry {
+		delete(p.clients, key)
+	}
+}
+
+// Len returns the number of clients in the pool
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.clients)
+}
+
+// Evict evicts the clients unused for longer than the idle timeout, closing their idle connections,
+// and returns the number of clients evicted. It is also done every time a client is taken from the pool.
+func (p *ClientPool) Evict() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.evictIdle()
+}
+
+// Close evicts all the clients of the pool, closing their idle connections
+func (p *ClientPool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, entry := range p.clients {
+		p.evict(key, entry)
+	}
+}
+
+// evictIdle evicts the clients unused for longer than the idle timeout, skipping the clients being
+// created or checked. The mutex of the pool must be held.
+func (p *ClientPool) evictIdle() int {
+	if p.opts.IdleTimeout <= 0 {
+		return 0
+	}
+	evicted := 0
+	for key, entry := range p.clients {
+		if time.Since(entry.lastUsed) > p.opts.IdleTimeout && p.evict(key, entry) {
+			evicted++
+		}
+	}
+	return evicted
+}
+
+// evict removes the client from the pool and closes its idle connections, unless it is being created or checked.
+// The mutex of the pool must be held.
+func (p *ClientPool) evict(key string, entry *pooledClient) bool {
+	if !entry.mu.TryLock() {
+		return false
+	}
+	defer entry.mu.Unlock()
+	if entry.client != nil {
+		entry.client.GetHTTPClient().CloseIdleConnections()
+	}
+	delete(p.clients, key)
+	return true
+}
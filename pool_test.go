@@ -0,0 +1,117 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pmax
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientPool(t *testing.T) {
+	var authentications atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/univmax/restapi/version" {
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		authentications.Add(1)
+		if user, _, _ := req.BasicAuth(); user != "admin" {
+			resp.WriteHeader(http.StatusUnauthorized)
+			_, _ = resp.Write([]byte(`{"message":"unauthorized","httpStatusCode":401,"errorCode":0}`))
+			return
+		}
+		_, _ = resp.Write([]byte(`{"version":"V10.0.0.1"}`))
+	}))
+	defer server.Close()
+
+	pool := NewClientPool(ClientPoolOptions{HealthCheckInterval: time.Hour, IdleTimeout: time.Hour})
+	defer pool.Close()
+	admin := &ConfigConnect{Endpoint: server.URL, Username: "admin", Password: "secret"}
+	first, err := pool.Get(context.TODO(), admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := pool.Get(context.TODO(), admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second || authentications.Load() != 1 || pool.Len() != 1 {
+		t.Fatalf("expected the pooled client to be reused, got %d authentications and %d clients", authentications.Load(), pool.Len())
+	}
+
+	other, err := pool.Get(context.TODO(), &ConfigConnect{Endpoint: server.URL, Username: "admin", Password: "other"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other == first || pool.Len() != 2 {
+		t.Fatalf("expected a client per credentials, got %d clients", pool.Len())
+	}
+
+	if _, err = pool.Get(context.TODO(), &ConfigConnect{Endpoint: server.URL, Username: "guest"}); err == nil {
+		t.Fatal("expected the authentication to fail")
+	}
+	if pool.Len() != 2 {
+		t.Fatalf("expected the failed client not to be pooled, got %d clients", pool.Len())
+	}
+	if _, err = pool.Get(context.TODO(), &ConfigConnect{}); err == nil {
+		t.Fatal("expected an error without endpoint")
+	}
+
+	t.Run("health check", func(t *testing.T) {
+		pool := NewClientPool(ClientPoolOptions{HealthCheckInterval: time.Millisecond})
+		defer pool.Close()
+		client, err := pool.Get(context.TODO(), admin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		before := authentications.Load()
+		time.Sleep(5 * time.Millisecond)
+		checked, err := pool.Get(context.TODO(), admin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if checked != client || authentications.Load() != before+1 {
+			t.Fatalf("expected the pooled client to be checked and kept, got %d authentications", authentications.Load()-before)
+		}
+	})
+
+	t.Run("idle eviction", func(t *testing.T) {
+		pool := NewClientPool(ClientPoolOptions{IdleTimeout: 5 * time.Millisecond})
+		defer pool.Close()
+		client, err := pool.Get(context.TODO(), admin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if evicted := pool.Evict(); evicted != 0 {
+			t.Fatalf("expected no client to be evicted, got %d", evicted)
+		}
+		time.Sleep(10 * time.Millisecond)
+		if evicted := pool.Evict(); evicted != 1 || pool.Len() != 0 {
+			t.Fatalf("expected the idle client to be evicted, got %d evicted and %d clients", evicted, pool.Len())
+		}
+		recreated, err := pool.Get(context.TODO(), admin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if recreated == client {
+			t.Fatal("expected a new client after the eviction")
+		}
+	})
+}
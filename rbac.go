@@ -0,0 +1,126 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pmax
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+	log "github.com/sirupsen/logrus"
+)
+
+// The following constants are for internal use within the pmax library.
+const (
+	XUser           = "/user"
+	XAuthorizedUser = "/authorized_user"
+)
+
+// userURL returns the URL of the users, or of a user if userID is set. The ids of the directory users hold a backslash,
+// so they are escaped.
+func (c *Client) userURL(userID string) string {
+	URL := c.urlPrefix() + "system" + XUser
+	if userID != "" {
+		URL += "/" + url.PathEscape(userID)
+	}
+	return URL
+}
+
+// GetUserList returns the ids of the Unisphere users
+func (c *Client) GetUserList(ctx context.Context) (*types.UserList, error) {
+	defer c.TimeSpent("GetUserList", time.Now())
+	users := &types.UserList{}
+	if err := c.getWithTimeout(ctx, c.userURL(""), users); err != nil {
+		log.Error("GetUserList failed: " + err.Error())
+		return nil, err
+	}
+	return users, nil
+}
+
+// GetUser returns a Unisphere user and its roles on all the arrays
+func (c *Client) GetUser(ctx context.Context, userID string) (*types.User, error) {
+	defer c.TimeSpent("GetUser", time.Now())
+	user := &types.User{}
+	if err := c.getWithTimeout(ctx, c.userURL(userID), user); err != nil {
+		log.Error("GetUser failed: " + err.Error())
+		return nil, err
+	}
+	return user, nil
+}
+
+// GetArrayUsers returns the users having a role on an array, with their roles on it
+func (c *Client) GetArrayUsers(ctx context.Context, symID string) (*types.ArrayUserList, error) {
+	defer c.TimeSpent("GetArrayUsers", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	URL := c.getSymmetrixIDListURL() + "/" + symID + XAuthorizedUser
+	users := &types.ArrayUserList{}
+	if err := c.getWithTimeout(ctx, URL, users); err != nil {
+		log.Error("GetArrayUsers failed: " + err.Error())
+		return nil, err
+	}
+	users.SymmetrixID = symID
+	return users, nil
+}
+
+// AssignRole gives a role, e.g. types.RoleStorageAdmin, to a user on the array symID, or on all the arrays if symID
+// is empty. Nothing is changed if the user already has the role.
+func (c *Client) AssignRole(ctx context.Context, userID, symID, role string) error {
+	defer c.TimeSpent("AssignRole", time.Now())
+	return c.modifyUserRole(ctx, userID, symID, role, types.UserActionAssignRole)
+}
+
+// RevokeRole removes a role from a user on the array symID, or on all the arrays if symID is empty.
+// Nothing is changed if the user does not have the role.
+func (c *Client) RevokeRole(ctx context.Context, userID, symID, role string) error {
+	defer c.TimeSpent("RevokeRole", time.Now())
+	return c.modifyUserRole(ctx, userID, symID, role, types.UserActionRevokeRole)
+}
+
+func (c *Client) modifyUserRole(ctx context.Context, userID, symID, role, action string) error {
+	if symID != "" {
+		if _, err := c.IsAllowedArray(symID); err != nil {
+			return err
+		}
+	}
+	if userID == "" || role == "" {
+		return fmt.Errorf("a user and a role are required")
+	}
+	user, err := c.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.HasRole(symID, role) == (action == types.UserActionAssignRole) {
+		log.Info(fmt.Sprintf("Nothing to do, %s of role %s to user %s on array %q is already done", action, role, userID, symID))
+		return nil
+	}
+	assignment := &types.RoleAssignment{Role: role, SymmetrixID: symID}
+	payload := &types.ModifyUserParam{Action: action}
+	if action == types.UserActionAssignRole {
+		payload.AssignRole = assignment
+	} else {
+		payload.RevokeRole = assignment
+	}
+	ifDebugLogPayload(payload)
+	if err = c.putWithTimeout(ctx, c.userURL(userID), payload, nil); err != nil {
+		log.Error(action + " failed: " + err.Error())
+		return err
+	}
+	log.Info(fmt.Sprintf("Successfully completed %s of role %s to user %s on array %q", action, role, userID, symID))
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pmax
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+func TestUserRoles(t *testing.T) {
+	userURL := urlPrefix + "system" + XUser
+	user := &types.User{UserID: `ldap\jdoe`, Roles: []types.RoleAssignment{{Role: types.RoleMonitor}}}
+	puts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch {
+		case req.URL.Path == userURL:
+			body = &types.UserList{UserIDs: []string{"admin", user.UserID}}
+		case req.URL.EscapedPath() == userURL+"/ldap%5Cjdoe" && req.Method == http.MethodGet:
+			body = user
+		case req.URL.EscapedPath() == userURL+"/ldap%5Cjdoe" && req.Method == http.MethodPut:
+			puts++
+			payload := &types.ModifyUserParam{}
+			if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+				t.Error(err)
+			}
+			switch payload.Action {
+			case types.UserActionAssignRole:
+				user.Roles = append(user.Roles, *payload.AssignRole)
+			case types.UserActionRevokeRole:
+				user.Roles = slices.DeleteFunc(user.Roles, func(role types.RoleAssignment) bool { return role == *payload.RevokeRole })
+			}
+		case req.URL.Path == urlPrefix+"system/symmetrix/mock-sym-id"+XAuthorizedUser:
+			body = &types.ArrayUserList{Users: []types.User{*user}}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		if body == nil {
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+
+	users, err := client.GetUserList(ctx)
+	if err != nil || len(users.UserIDs) != 2 {
+		t.Errorf("unexpected users %+v, %v", users, err)
+	}
+	if err = client.AssignRole(ctx, `ldap\jdoe`, "mock-sym-id", types.RoleStorageAdmin); err != nil {
+		t.Fatal(err)
+	}
+	// assigning a role twice does not change the user
+	if err = client.AssignRole(ctx, `ldap\jdoe`, "mock-sym-id", types.RoleStorageAdmin); err != nil || puts != 1 {
+		t.Errorf("expected a single update, got %d, %v", puts, err)
+	}
+	arrayUsers, err := client.GetArrayUsers(ctx, "mock-sym-id")
+	if err != nil || arrayUsers.SymmetrixID != "mock-sym-id" || len(arrayUsers.Users) != 1 || !arrayUsers.Users[0].HasRole("mock-sym-id", types.RoleStorageAdmin) {
+		t.Errorf("unexpected array users %+v, %v", arrayUsers, err)
+	}
+	// the role is revoked on the array only
+	if err = client.RevokeRole(ctx, `ldap\jdoe`, "", types.RoleStorageAdmin); err != nil || puts != 1 {
+		t.Errorf("expected no update for a role not given on all the arrays, got %d, %v", puts, err)
+	}
+	if err = client.RevokeRole(ctx, `ldap\jdoe`, "mock-sym-id", types.RoleStorageAdmin); err != nil || puts != 2 {
+		t.Errorf("expected the role to be revoked, got %d, %v", puts, err)
+	}
+	if got, _ := client.GetUser(ctx, `ldap\jdoe`); len(got.Roles) != 1 || !got.HasRole("", types.RoleMonitor) {
+		t.Errorf("unexpected roles %+v", got.Roles)
+	}
+	if err = client.AssignRole(ctx, "unknown", "", types.RoleAuditor); err == nil {
+		t.Error("expected an error for an unknown user")
+	}
+}
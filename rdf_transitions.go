@@ -0,0 +1,160 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pmax
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+	log "github.com/sirupsen/logrus"
+)
+
+// RDFTransitions maps the SRDF pair states to the actions of ExecuteReplicationActionOnSG which are valid in them.
+// The states not listed are not validated, and left for the array to accept or reject the action.
+var RDFTransitions = map[string][]string{
+	"Synchronized": {"Suspend", "Split", "Failover"},
+	"Consistent":   {"Suspend", "Split", "Failover"},
+	"SyncInProg":   {"Suspend", "Split", "Failover"},
+	"TransIdle":    {"Suspend", "Failover"},
+	"ActiveActive": {"Suspend"},
+	"ActiveBias":   {"Suspend"},
+	"Suspended":    {"Establish", "Resume", "Split", "Failover", "Swap"},
+	"Split":        {"Establish", "Failover", "Swap"},
+	"Failed Over":  {"Failback", "Swap"},
+	"Partitioned":  {"Failover"},
+	"R1 Updated":   {"Failback"},
+	"R1 UpdInProg": {"Failback"},
+}
+
+// InvalidTransitionError is returned when an SRDF action is not valid in the current state of the pairs
+type InvalidTransitionError struct {
+	Action string
+	States []string
+	// Allowed are the actions valid in all the States
+	Allowed []string
+}
+
+func (e *InvalidTransitionError) Error() string {
+	allowed := "none"
+	if len(e.Allowed) > 0 {
+		allowed = strings.Join(e.Allowed, ", ")
+	}
+	return fmt.Sprintf("RDF action %s is not valid in state %s, allowed actions: %s", e.Action, strings.Join(e.States, ", "), allowed)
+}
+
+// IsInvalidTransitionError returns true if err is, or wraps, an InvalidTransitionError
+func IsInvalidTransitionError(err error) bool {
+	var transitionErr *InvalidTransitionError
+	return errors.As(err, &transitionErr)
+}
+
+// ValidateRDFTransition checks that action is valid in all the states of RDFTransitions, e.g. the states of the pairs
+// of a protected storage group. It returns an InvalidTransitionError with the actions valid in all the states otherwise.
+func ValidateRDFTransition(states []string, action string) error {
+	var allowed []string
+	known := false
+	for _, state := range states {
+		actions, ok := RDFTransitions[state]
+		if !ok {
+			continue
+		}
+		if !known {
+			allowed = slices.Clone(actions)
+			known = true
+			continue
+		}
+		allowed = slices.DeleteFunc(allowed, func(a string) bool { return !slices.Contains(actions, a) })
+	}
+	if !known || slices.Contains(allowed, action) {
+		return nil
+	}
+	return &InvalidTransitionError{Action: action, States: states, Allowed: allowed}
+}
+
+// ValidateReplicationActionOnSG checks that action is valid in the current states of the pairs of the storage group
+// in the RDF group, before it is sent with ExecuteReplicationActionOnSG
+func (c *Client) ValidateReplicationActionOnSG(ctx context.Context, symID, action, storageGroup, rdfGroup string) error {
+	rdfInfo, err := c.GetStorageGroupRDFInfo(ctx, symID, storageGroup, rdfGroup)
+	if err != nil {
+		return err
+	}
+	return ValidateRDFTransition(rdfInfo.States, action)
+}
+
+// RDFStateMismatchError is returned by VerifyReplicationStateOnSG when the pairs of a storage group are not in the expected states
+type RDFStateMismatchError struct {
+	StorageGroup string
+	RDFGroup     string
+	Expected     []string
+	States       []string
+}
+
+func (e *RDFStateMismatchError) Error() string {
+	return fmt.Sprintf("storage group %s in RDF group %s is in state %s, expected %s", e.StorageGroup, e.RDFGroup,
+		strings.Join(e.States, ", "), strings.Join(e.Expected, " or "))
+}
+
+// IsRDFStateMismatchError returns true if err is, or wraps, an RDFStateMismatchError
+func IsRDFStateMismatchError(err error) bool {
+	var mismatchErr *RDFStateMismatchError
+	return errors.As(err, &mismatchErr)
+}
+
+// VerifyReplicationStateOnSG verifies that all the pairs of the storage group in the RDF group are in one of the
+// expected states, e.g. Consistent or Synchronized, like the verify action of symrdf. If wait is set, the pairs are
+// checked every pollInterval, or DefaultRDFSyncPollInterval if it is 0, until they are in the expected states or wait
+// elapses. It returns the RDF information of the storage group, along with an RDFStateMismatchError if the pairs are not
+// in the expected states.
+func (c *Client) VerifyReplicationStateOnSG(ctx context.Context, symID, storageGroup, rdfGroup string, expected []string, wait, pollInterval time.Duration) (*types.StorageGroupRDFG, error) {
+	defer c.TimeSpent("VerifyReplicationStateOnSG", time.Now())
+	if len(expected) == 0 {
+		return nil, fmt.Errorf("at least one expected RDF state has to be specified")
+	}
+	if pollInterval <= 0 {
+		pollInterval = DefaultRDFSyncPollInterval
+	}
+	deadline := time.Now().Add(wait)
+	for {
+		rdfInfo, err := c.GetStorageGroupRDFInfo(ctx, symID, storageGroup, rdfGroup)
+		if err != nil {
+			return nil, err
+		}
+		verified := len(rdfInfo.States) > 0
+		for _, state := range rdfInfo.States {
+			if !slices.ContainsFunc(expected, func(e string) bool { return strings.EqualFold(e, state) }) {
+				verified = false
+			}
+		}
+		if verified {
+			log.Info(fmt.Sprintf("Verified storage group %s in RDF group %s is %s", storageGroup, rdfGroup, strings.Join(expected, " or ")))
+			return rdfInfo, nil
+		}
+		mismatchErr := &RDFStateMismatchError{StorageGroup: storageGroup, RDFGroup: rdfGroup, Expected: expected, States: rdfInfo.States}
+		if !time.Now().Add(pollInterval).Before(deadline) {
+			return rdfInfo, mismatchErr
+		}
+		log.Debug(fmt.Sprintf("Waiting for storage group %s to be %s, states: %v", storageGroup, strings.Join(expected, " or "), rdfInfo.States))
+		select {
+		case <-ctx.Done():
+			return rdfInfo, fmt.Errorf("%w: %w", mismatchErr, ctx.Err())
+		case <-time.After(pollInterval):
+		}
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pmax
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValidateRDFTransition(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		states  []string
+		action  string
+		allowed []string
+	}{
+		{name: "suspend synchronized", states: []string{"Synchronized"}, action: "Suspend"},
+		{name: "resume suspended", states: []string{"Suspended"}, action: "Resume"},
+		{name: "failback failed over", states: []string{"Failed Over"}, action: "Failback"},
+		{name: "unknown state", states: []string{"Invalid"}, action: "Resume"},
+		{name: "no state", action: "Resume"},
+		{name: "resume synchronized", states: []string{"Synchronized"}, action: "Resume", allowed: []string{"Suspend", "Split", "Failover"}},
+		{name: "failback active", states: []string{"ActiveActive"}, action: "Failback", allowed: []string{"Suspend"}},
+		{name: "mixed states", states: []string{"Suspended", "Split"}, action: "Resume", allowed: []string{"Establish", "Failover", "Swap"}},
+		{name: "no common action", states: []string{"Failed Over", "Synchronized"}, action: "Swap", allowed: []string{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateRDFTransition(tc.states, tc.action)
+			if tc.allowed == nil {
+				if err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+				return
+			}
+			if !IsInvalidTransitionError(err) {
+				t.Fatalf("expected an InvalidTransitionError, got %v", err)
+			}
+			if allowed := err.(*InvalidTransitionError).Allowed; len(allowed) != len(tc.allowed) || (len(allowed) > 0 && !reflect.DeepEqual(allowed, tc.allowed)) {
+				t.Errorf("expected allowed actions %v, got %v", tc.allowed, allowed)
+			}
+		})
+	}
+}
+
+func TestExecuteReplicationActionOnSGWithValidation(t *testing.T) {
+	puts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != urlPrefix+ReplicationX+SymmetrixX+"sym-id"+XStorageGroup+"/sg-1"+XRDFGroup+"/10" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL)
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch req.Method {
+		case http.MethodGet:
+			_, _ = resp.Write([]byte(`{"symmetrixId":"sym-id","storageGroupName":"sg-1","rdfGroupNumber":10,"states":["Synchronized"]}`))
+		case http.MethodPut:
+			puts++
+			_, _ = resp.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL, WithInsecure(), WithRDFStateValidation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.ExecuteReplicationActionOnSG(context.TODO(), "sym-id", "Resume", "sg-1", "10", false, false, false)
+	if !IsInvalidTransitionError(err) {
+		t.Fatalf("expected an InvalidTransitionError, got %v", err)
+	}
+	if puts != 0 {
+		t.Fatalf("the invalid action was sent")
+	}
+	if err = client.ExecuteReplicationActionOnSG(context.TODO(), "sym-id", "Suspend", "sg-1", "10", false, false, false); err != nil {
+		t.Fatal(err)
+	}
+	if puts != 1 {
+		t.Fatalf("expected the action to be sent once, got %d", puts)
+	}
+}
+
+func TestVerifyReplicationStateOnSG(t *testing.T) {
+	gets := 0
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != urlPrefix+ReplicationX+SymmetrixX+"sym-id"+XStorageGroup+"/sg-1"+XRDFGroup+"/10" || req.Method != http.MethodGet {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL)
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gets++
+		if gets < 3 {
+			_, _ = resp.Write([]byte(`{"symmetrixId":"sym-id","storageGroupName":"sg-1","rdfGroupNumber":10,"states":["SyncInProg","Synchronized"]}`))
+			return
+		}
+		_, _ = resp.Write([]byte(`{"symmetrixId":"sym-id","storageGroupName":"sg-1","rdfGroupNumber":10,"states":["Synchronized","Consistent"]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"Synchronized", "consistent"}
+	info, err := client.VerifyReplicationStateOnSG(context.TODO(), "sym-id", "sg-1", "10", expected, 0, 0)
+	if !IsRDFStateMismatchError(err) || info == nil || gets != 1 {
+		t.Fatalf("expected an RDFStateMismatchError without waiting, got %v after %d reads", err, gets)
+	}
+	if _, err = client.VerifyReplicationStateOnSG(context.TODO(), "sym-id", "sg-1", "10", expected, time.Second, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if gets != 3 {
+		t.Fatalf("expected the states to be read 3 times, got %d", gets)
+	}
+	if _, err = client.VerifyReplicationStateOnSG(context.TODO(), "sym-id", "sg-1", "10", nil, 0, 0); err == nil {
+		t.Fatal("expected an error without expected states")
+	}
+}
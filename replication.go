@@ -17,10 +17,12 @@ package pmax
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/dell/gopowermax/v2/api"
 	types "github.com/dell/gopowermax/v2/types/v100"
 	log "github.com/sirupsen/logrus"
 )
@@ -31,6 +33,9 @@ const (
 	SnapID      = "/snapid"
 )
 
+// SnapshotTrackSizeBytes is the size of the FBA tracks counted in snapshot space accounting
+const SnapshotTrackSizeBytes = 128 * 1024
+
 // SnapshotAction A list of possible Snapshot actions.
 type SnapshotAction string
 
@@ -50,6 +55,9 @@ const (
 // GetStorageGroupSnapshots Get All Storage Group Snapshots
 func (c *Client) GetStorageGroupSnapshots(ctx context.Context, symID string, storageGroupID string, excludeManualSnaps bool, excludeSlSnaps bool) (*types.StorageGroupSnapshot, error) {
 	defer c.TimeSpent("GetStorageGroupSnapshots", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
 	query := ""
 	if excludeManualSnaps && excludeSlSnaps {
 		query = "?exclude_manual_snaps=true&exclude_sl_snaps=true"
@@ -89,6 +97,9 @@ func (c *Client) GetStorageGroupSnapshots(ctx context.Context, symID string, sto
 // GetStorageGroupSnapshotSnapIDs Get a list of SnapIDs for a particular snapshot
 func (c *Client) GetStorageGroupSnapshotSnapIDs(ctx context.Context, symID string, storageGroupID string, snapshotID string) (*types.SnapID, error) {
 	defer c.TimeSpent("GetStorageGroupSnapshotSnapIDs", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
 	URL := c.urlPrefix() + Replication + SymmetrixX + symID + XStorageGroup + "/" + storageGroupID + XSnapshot + "/" + snapshotID + SnapID
 
 	ctx, cancel := c.GetTimeoutContext(ctx)
@@ -120,6 +131,9 @@ func (c *Client) GetStorageGroupSnapshotSnapIDs(ctx context.Context, symID strin
 // GetStorageGroupSnapshotSnap Get the details of a storage group snapshot snap
 func (c *Client) GetStorageGroupSnapshotSnap(ctx context.Context, symID string, storageGroupID string, snapshotID, snapID string) (*types.StorageGroupSnap, error) {
 	defer c.TimeSpent("GetStorageGroupSnapshotSnapIDs", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
 	URL := c.urlPrefix() + Replication + SymmetrixX + symID + XStorageGroup + "/" + storageGroupID + XSnapshot + "/" + snapshotID + SnapID + "/" + snapID
 
 	ctx, cancel := c.GetTimeoutContext(ctx)
@@ -148,9 +162,180 @@ func (c *Client) GetStorageGroupSnapshotSnap(ctx context.Context, symID string,
 	return snap, nil
 }
 
+// GetSnapshotCapacityUsage sums the modified and non-shared tracks of every generation of every snapshot
+// of a storage group, per snapshot name and for the whole storage group
+func (c *Client) GetSnapshotCapacityUsage(ctx context.Context, symID string, storageGroupID string) (*types.SnapshotCapacityUsage, error) {
+	defer c.TimeSpent("GetSnapshotCapacityUsage", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	snapshots, err := c.GetStorageGroupSnapshots(ctx, symID, storageGroupID, false, false)
+	if err != nil {
+		return nil, err
+	}
+	usage := &types.SnapshotCapacityUsage{StorageGroupID: storageGroupID}
+	for _, snapshotName := range snapshots.Name {
+		snapIDs, err := c.GetStorageGroupSnapshotSnapIDs(ctx, symID, storageGroupID, snapshotName)
+		if err != nil {
+			return nil, err
+		}
+		nameUsage := types.SnapshotNameUsage{SnapshotName: snapshotName}
+		for _, snapID := range snapIDs.SnapIDs {
+			snap, err := c.GetStorageGroupSnapshotSnap(ctx, symID, storageGroupID, snapshotName, fmt.Sprintf("%d", snapID))
+			if err != nil {
+				return nil, err
+			}
+			nameUsage.Generations = append(nameUsage.Generations, types.SnapshotGenerationUsage{
+				SnapID:          snap.SnapID,
+				Generation:      snap.Generation,
+				Timestamp:       snap.Timestamp,
+				ModifiedTracks:  snap.Tracks,
+				NonSharedTracks: snap.NotSharedTracks,
+				Linked:          snap.Linked,
+				Expired:         snap.Expired,
+			})
+			nameUsage.ModifiedTracks += snap.Tracks
+			nameUsage.NonSharedTracks += snap.NotSharedTracks
+		}
+		nameUsage.SnapshotCount = len(nameUsage.Generations)
+		nameUsage.NonSharedGB = tracksToGB(nameUsage.NonSharedTracks)
+		usage.Snapshots = append(usage.Snapshots, nameUsage)
+		usage.SnapshotCount += nameUsage.SnapshotCount
+		usage.ModifiedTracks += nameUsage.ModifiedTracks
+		usage.NonSharedTracks += nameUsage.NonSharedTracks
+	}
+	usage.NonSharedGB = tracksToGB(usage.NonSharedTracks)
+	log.Info(fmt.Sprintf("Storage group %s has %d snapshots holding %d non-shared tracks", storageGroupID, usage.SnapshotCount, usage.NonSharedTracks))
+	return usage, nil
+}
+
+// GetSnapshotDelta returns the number of tracks of the source volumes changed since a generation of a storage group
+// snapshot was taken, from the tracks reported for the generation
+func (c *Client) GetSnapshotDelta(ctx context.Context, symID, storageGroupID, snapshotName string, snapID int64) (*types.SnapshotDelta, error) {
+	defer c.TimeSpent("GetSnapshotDelta", time.Now())
+	snap, err := c.GetStorageGroupSnapshotSnap(ctx, symID, storageGroupID, snapshotName, fmt.Sprintf("%d", snapID))
+	if err != nil {
+		log.Error("GetSnapshotDelta failed: " + err.Error())
+		return nil, err
+	}
+	return &types.SnapshotDelta{
+		StorageGroupID: storageGroupID,
+		SnapshotName:   snapshotName,
+		SnapID:         snapID,
+		CompareSnapID:  types.CompareWithSource,
+		ChangedTracks:  snap.Tracks,
+		TrackSize:      SnapshotTrackSizeBytes,
+	}, nil
+}
+
+// GetSnapshotGenerationDelta returns the number of tracks changed between two generations of a storage group snapshot,
+// e.g. to size the transfer of an incremental backup from compareSnapID to snapID. Only the tracks changed since each
+// generation was taken are reported, so the delta is those of the older generation: it covers every track changed
+// between the two generations, and the tracks changed since the newer one, which an incremental backup reads anyway.
+func (c *Client) GetSnapshotGenerationDelta(ctx context.Context, symID, storageGroupID, snapshotName string, snapID, compareSnapID int64) (*types.SnapshotDelta, error) {
+	defer c.TimeSpent("GetSnapshotGenerationDelta", time.Now())
+	if compareSnapID < 0 || compareSnapID == snapID {
+		return nil, fmt.Errorf("snapshot %d cannot be compared with snapshot %d", snapID, compareSnapID)
+	}
+	snap, err := c.GetStorageGroupSnapshotSnap(ctx, symID, storageGroupID, snapshotName, fmt.Sprintf("%d", snapID))
+	if err != nil {
+		log.Error("GetSnapshotGenerationDelta failed: " + err.Error())
+		return nil, err
+	}
+	compareSnap, err := c.GetStorageGroupSnapshotSnap(ctx, symID, storageGroupID, snapshotName, fmt.Sprintf("%d", compareSnapID))
+	if err != nil {
+		log.Error("GetSnapshotGenerationDelta failed: " + err.Error())
+		return nil, err
+	}
+	// generation 0 is the newest
+	older := snap
+	if compareSnap.Generation > snap.Generation {
+		older = compareSnap
+	}
+	return &types.SnapshotDelta{
+		StorageGroupID: storageGroupID,
+		SnapshotName:   snapshotName,
+		SnapID:         snapID,
+		CompareSnapID:  compareSnapID,
+		ChangedTracks:  older.Tracks,
+		TrackSize:      SnapshotTrackSizeBytes,
+	}, nil
+}
+
+func tracksToGB(tracks int64) float64 {
+	return float64(tracks*SnapshotTrackSizeBytes) / (1024 * 1024 * 1024)
+}
+
+// GetStorageGroupPolicySnapshots returns every generation of the snapshots of a storage group created by a
+// snapshot policy, with its policy, expiry and secure flags, separately from the names of the manual snapshots.
+// Policy snapshots are named after their policy, so the snapshots named after a snapshot policy of the array
+// are the policy snapshots, even if the policy is no longer associated with the storage group.
+func (c *Client) GetStorageGroupPolicySnapshots(ctx context.Context, symID string, storageGroupID string) (*types.StorageGroupPolicySnapshots, error) {
+	defer c.TimeSpent("GetStorageGroupPolicySnapshots", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	if err := c.verifySupport(ctx, symID, "GetStorageGroupPolicySnapshots"); err != nil {
+		return nil, err
+	}
+	policies, err := c.GetSnapshotPolicyList(ctx, symID)
+	if err != nil {
+		return nil, err
+	}
+	isPolicy := make(map[string]bool, len(policies.SnapshotPolicyIDs))
+	for _, policy := range policies.SnapshotPolicyIDs {
+		isPolicy[policy] = true
+	}
+	snapshots, err := c.GetStorageGroupSnapshots(ctx, symID, storageGroupID, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.StorageGroupPolicySnapshots{
+		StorageGroupID:      storageGroupID,
+		PolicySnapshots:     []types.PolicySnapshot{},
+		ManualSnapshotNames: []string{},
+	}
+	for _, snapshotName := range snapshots.Name {
+		if !isPolicy[snapshotName] {
+			result.ManualSnapshotNames = append(result.ManualSnapshotNames, snapshotName)
+			continue
+		}
+		snapIDs, err := c.GetStorageGroupSnapshotSnapIDs(ctx, symID, storageGroupID, snapshotName)
+		if err != nil {
+			return nil, err
+		}
+		for _, snapID := range snapIDs.SnapIDs {
+			snap, err := c.GetStorageGroupSnapshotSnap(ctx, symID, storageGroupID, snapshotName, fmt.Sprintf("%d", snapID))
+			if err != nil {
+				return nil, err
+			}
+			result.PolicySnapshots = append(result.PolicySnapshots, types.PolicySnapshot{
+				SnapshotPolicyName:   snapshotName,
+				SnapshotName:         snap.Name,
+				SnapID:               snap.SnapID,
+				Generation:           snap.Generation,
+				Timestamp:            snap.Timestamp,
+				TimestampUtc:         snap.TimestampUtc,
+				TimeToLiveExpiryDate: snap.TimeToLiveExpiryDate,
+				SecureExpiryDate:     snap.SecureExpiryDate,
+				Secure:               snap.SecureExpiryDate != "" && snap.SecureExpiryDate != "N/A",
+				Expired:              snap.Expired,
+				Linked:               snap.Linked,
+				Persistent:           snap.Persistent,
+			})
+		}
+	}
+	log.Info(fmt.Sprintf("Storage group %s has %d policy snapshots and %d manual snapshots", storageGroupID, len(result.PolicySnapshots), len(result.ManualSnapshotNames)))
+	return result, nil
+}
+
 // CreateStorageGroupSnapshot Create a Storage Group Snapshot
 func (c *Client) CreateStorageGroupSnapshot(ctx context.Context, symID string, storageGroupID string, payload *types.CreateStorageGroupSnapshot) (*types.StorageGroupSnap, error) {
 	defer c.TimeSpent("CreateStorageGroupSnapshot", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
 	ifDebugLogPayload(payload)
 	URL := c.urlPrefix() + Replication + SymmetrixX + symID + XStorageGroup + "/" + storageGroupID + XSnapshot
 	ctx, cancel := c.GetTimeoutContext(ctx)
@@ -168,6 +353,9 @@ func (c *Client) CreateStorageGroupSnapshot(ctx context.Context, symID string, s
 // ModifyStorageGroupSnapshot Modify a Storage Group Snapshot snap
 func (c *Client) ModifyStorageGroupSnapshot(ctx context.Context, symID string, storageGroupID string, snapshotID string, snapID string, payload *types.ModifyStorageGroupSnapshot) (*types.StorageGroupSnap, error) {
 	defer c.TimeSpent("ModifyStorageGroupSnapshot", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
 	URL := c.urlPrefix() + Replication + SymmetrixX + symID + XStorageGroup + "/" + storageGroupID + XSnapshot + "/" + snapshotID + SnapID + "/" + snapID
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
@@ -177,6 +365,7 @@ func (c *Client) ModifyStorageGroupSnapshot(ctx context.Context, symID string, s
 	var putPayload interface{}
 	switch payload.Action {
 	case string(Restore):
+		ctx = api.WithDestructiveOperation(ctx, payload.Action)
 		putPayload = &types.RestoreStorageGroupSnapshot{
 			Action:          payload.Action,
 			ExecutionOption: payload.ExecutionOption,
@@ -241,9 +430,38 @@ func (c *Client) ModifyStorageGroupSnapshot(ctx context.Context, symID string, s
 	return snap, nil
 }
 
+// LinkStorageGroupSnapshot links a storage group snapshot to the target storage group, which is created if it does not
+// exist. In copy mode, all the tracks of the snapshot are copied to the target in the background, making it a full
+// clone which stays usable once unlinked. In nocopy mode, the target only shares the tracks of the snapshot.
+func (c *Client) LinkStorageGroupSnapshot(ctx context.Context, symID string, storageGroupID string, snapshotID string, snapID string, targetStorageGroupID string, copyMode bool) (*types.StorageGroupSnap, error) {
+	defer c.TimeSpent("LinkStorageGroupSnapshot", time.Now())
+	payload := &types.ModifyStorageGroupSnapshot{
+		ExecutionOption: types.ExecutionOptionSynchronous,
+		Action:          string(Link),
+		Link:            types.LinkSnapshotAction{StorageGroupName: targetStorageGroupID, Copy: copyMode},
+	}
+	return c.ModifyStorageGroupSnapshot(ctx, symID, storageGroupID, snapshotID, snapID, payload)
+}
+
+// SetStorageGroupSnapshotLinkMode changes the mode of the link between a storage group snapshot and a linked target
+// storage group, e.g. to convert a nocopy target into a full copy. The copy progress is reported in the
+// PercentageCopied of the linked storage groups of the snapshot.
+func (c *Client) SetStorageGroupSnapshotLinkMode(ctx context.Context, symID string, storageGroupID string, snapshotID string, snapID string, targetStorageGroupID string, copyMode bool) (*types.StorageGroupSnap, error) {
+	defer c.TimeSpent("SetStorageGroupSnapshotLinkMode", time.Now())
+	payload := &types.ModifyStorageGroupSnapshot{
+		ExecutionOption: types.ExecutionOptionSynchronous,
+		Action:          string(SetMode),
+		SetMode:         types.SetModeSnapshotAction{StorageGroupName: targetStorageGroupID, Copy: copyMode},
+	}
+	return c.ModifyStorageGroupSnapshot(ctx, symID, storageGroupID, snapshotID, snapID, payload)
+}
+
 // DeleteStorageGroupSnapshot Delete a Storage Group Snapshot snap
 func (c *Client) DeleteStorageGroupSnapshot(ctx context.Context, symID string, storageGroupID string, snapshotID string, snapID string) error {
 	defer c.TimeSpent("DeleteStorageGroupSnapshot", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return err
+	}
 	URL := c.urlPrefix() + Replication + SymmetrixX + symID + XStorageGroup + "/" + storageGroupID + XSnapshot + "/" + snapshotID + SnapID + "/" + snapID
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
@@ -262,6 +480,9 @@ func (c *Client) GetSnapshotPolicy(ctx context.Context, symID string, snapshotPo
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.verifySupport(ctx, symID, "GetSnapshotPolicy"); err != nil {
+		return nil, err
+	}
 	URL := c.urlPrefix() + Replication + SymmetrixX + symID + SnapshotPolicy + "/" + snapshotPolicyID
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
@@ -294,6 +515,9 @@ func (c *Client) DeleteSnapshotPolicy(ctx context.Context, symID string, snapsho
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return err
 	}
+	if err := c.verifySupport(ctx, symID, "DeleteSnapshotPolicy"); err != nil {
+		return err
+	}
 	URL := c.urlPrefix() + Replication + SymmetrixX + symID + SnapshotPolicy + "/" + snapshotPolicyID
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
@@ -311,6 +535,9 @@ func (c *Client) CreateSnapshotPolicy(ctx context.Context, symID string, snapsho
 	complianceCountCritical int64, optionalPayload map[string]interface{},
 ) (*types.SnapshotPolicy, error) {
 	defer c.TimeSpent("CreateSnapshotPolicy", time.Now())
+	if err := c.verifySupport(ctx, symID, "CreateSnapshotPolicy"); err != nil {
+		return nil, err
+	}
 
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
@@ -357,6 +584,9 @@ func (c *Client) UpdateSnapshotPolicy(ctx context.Context, symID string, action
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return err
 	}
+	if err := c.verifySupport(ctx, symID, "UpdateSnapshotPolicy"); err != nil {
+		return err
+	}
 
 	updateSnapshotPolicyParam := &types.UpdateSnapshotPolicyParam{
 		Action:          action,
@@ -394,12 +624,216 @@ func (c *Client) UpdateSnapshotPolicy(ctx context.Context, symID string, action
 	return nil
 }
 
+// SuspendSnapshotPolicy stops a SnapshotPolicy from taking snapshots, and returns the policy.
+// Nothing is sent if the policy is already suspended.
+func (c *Client) SuspendSnapshotPolicy(ctx context.Context, symID string, snapshotPolicyID string) (*types.SnapshotPolicy, error) {
+	defer c.TimeSpent("SuspendSnapshotPolicy", time.Now())
+	if err := c.verifySupport(ctx, symID, "SuspendSnapshotPolicy"); err != nil {
+		return nil, err
+	}
+	return c.setSnapshotPolicySuspended(ctx, symID, snapshotPolicyID, true)
+}
+
+// ResumeSnapshotPolicy restarts a suspended SnapshotPolicy, and returns the policy.
+// Nothing is sent if the policy is not suspended.
+func (c *Client) ResumeSnapshotPolicy(ctx context.Context, symID string, snapshotPolicyID string) (*types.SnapshotPolicy, error) {
+	defer c.TimeSpent("ResumeSnapshotPolicy", time.Now())
+	if err := c.verifySupport(ctx, symID, "ResumeSnapshotPolicy"); err != nil {
+		return nil, err
+	}
+	return c.setSnapshotPolicySuspended(ctx, symID, snapshotPolicyID, false)
+}
+
+func (c *Client) setSnapshotPolicySuspended(ctx context.Context, symID string, snapshotPolicyID string, suspended bool) (*types.SnapshotPolicy, error) {
+	policy, err := c.GetSnapshotPolicy(ctx, symID, snapshotPolicyID)
+	if err != nil {
+		return nil, err
+	}
+	if policy.Suspended == suspended {
+		return policy, nil
+	}
+	action := "Resume"
+	if suspended {
+		action = "Suspend"
+	}
+	if err = c.UpdateSnapshotPolicy(ctx, symID, action, snapshotPolicyID, nil); err != nil {
+		return nil, err
+	}
+	log.Info(fmt.Sprintf("Successfully executed action %s on SnapshotPolicy: %s", action, snapshotPolicyID))
+	return c.GetSnapshotPolicy(ctx, symID, snapshotPolicyID)
+}
+
+// GetSnapshotPolicyStorageGroupList returns the names of the storage groups associated with a SnapshotPolicy
+func (c *Client) GetSnapshotPolicyStorageGroupList(ctx context.Context, symID string, snapshotPolicyID string) (*types.SnapshotPolicyStorageGroupList, error) {
+	defer c.TimeSpent("GetSnapshotPolicyStorageGroupList", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	if err := c.verifySupport(ctx, symID, "GetSnapshotPolicyStorageGroupList"); err != nil {
+		return nil, err
+	}
+	URL := c.urlPrefix() + Replication + SymmetrixX + symID + SnapshotPolicy + "/" + snapshotPolicyID + XStorageGroup
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	storageGroups := &types.SnapshotPolicyStorageGroupList{}
+	if err := c.api.Get(ctx, URL, c.getDefaultHeaders(), storageGroups); err != nil {
+		log.Error("GetSnapshotPolicyStorageGroupList failed: " + err.Error())
+		return nil, err
+	}
+	return storageGroups, nil
+}
+
+// ModifySnapshotPolicies applies the same interval, offset, snapshot count or compliance thresholds to several
+// SnapshotPolicies. Every policy is modified even if the modification of another one fails; the outcome of each
+// modification, with the storage groups affected by it, is returned in the order of snapshotPolicyIDs.
+// An error is only returned if the modification itself is invalid.
+func (c *Client) ModifySnapshotPolicies(ctx context.Context, symID string, modify *types.ModifySnapshotPolicyParam, snapshotPolicyIDs ...string) ([]types.SnapshotPolicyModification, error) {
+	defer c.TimeSpent("ModifySnapshotPolicies", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	if err := c.verifySupport(ctx, symID, "ModifySnapshotPolicies"); err != nil {
+		return nil, err
+	}
+	if modify == nil || *modify == (types.ModifySnapshotPolicyParam{}) {
+		return nil, fmt.Errorf("no modification given for the snapshot policies")
+	}
+	if modify.SnapshotPolicyName != "" && len(snapshotPolicyIDs) > 1 {
+		return nil, fmt.Errorf("cannot rename %d snapshot policies to %s", len(snapshotPolicyIDs), modify.SnapshotPolicyName)
+	}
+
+	modifications := make([]types.SnapshotPolicyModification, len(snapshotPolicyIDs))
+	for i, snapshotPolicyID := range snapshotPolicyIDs {
+		modifications[i].SnapshotPolicyID = snapshotPolicyID
+		err := c.UpdateSnapshotPolicy(ctx, symID, "Modify", snapshotPolicyID, map[string]interface{}{"modify": modify})
+		if err != nil {
+			modifications[i].Error = err.Error()
+			continue
+		}
+		if modify.SnapshotPolicyName != "" {
+			snapshotPolicyID = modify.SnapshotPolicyName
+		}
+		policy, err := c.GetSnapshotPolicy(ctx, symID, snapshotPolicyID)
+		if err != nil {
+			modifications[i].Error = err.Error()
+			continue
+		}
+		modifications[i].SnapshotPolicy = policy
+		if policy.StorageGroupCount == 0 {
+			continue
+		}
+		storageGroups, err := c.GetSnapshotPolicyStorageGroupList(ctx, symID, snapshotPolicyID)
+		if err != nil {
+			modifications[i].Error = err.Error()
+			continue
+		}
+		modifications[i].StorageGroupIDs = storageGroups.StorageGroupIDs
+	}
+	log.Info(fmt.Sprintf("Modified %d SnapshotPolicies on %s", len(snapshotPolicyIDs), symID))
+	return modifications, nil
+}
+
+// GetSnapshotPolicySummary returns all the SnapshotPolicies of an array, with their next run and the compliance
+// of each of their storage groups, in the order of GetSnapshotPolicyList. Only GETs are sent, in parallel.
+// A policy which cannot be read in full, e.g. because of the role of the user, is returned with what could be
+// read and its Error set; an error is only returned if the policies cannot be listed.
+func (c *Client) GetSnapshotPolicySummary(ctx context.Context, symID string) (*types.SnapshotPolicySummaryReport, error) {
+	defer c.TimeSpent("GetSnapshotPolicySummary", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	if err := c.verifySupport(ctx, symID, "GetSnapshotPolicySummary"); err != nil {
+		return nil, err
+	}
+	ctx = api.WithDryRun(ctx, false)
+	list, err := c.GetSnapshotPolicyList(ctx, symID)
+	if err != nil {
+		return nil, err
+	}
+	report := &types.SnapshotPolicySummaryReport{SymmetrixID: symID, GeneratedAt: time.Now().UTC()}
+	report.Policies = make([]types.SnapshotPolicySummary, len(list.SnapshotPolicyIDs))
+	storageGroupIDs := make([][]string, len(list.SnapshotPolicyIDs))
+	errs := make([][]error, len(list.SnapshotPolicyIDs))
+	_ = fetchInParallel(len(report.Policies), func(i int) error {
+		snapshotPolicyID := list.SnapshotPolicyIDs[i]
+		report.Policies[i].SnapshotPolicy.SnapshotPolicyName = snapshotPolicyID
+		policy, err := c.GetSnapshotPolicy(ctx, symID, snapshotPolicyID)
+		if err != nil {
+			errs[i] = append(errs[i], err)
+			return nil
+		}
+		report.Policies[i].SnapshotPolicy = *policy
+		if next := policy.NextRunTimes(report.GeneratedAt, 1); len(next) > 0 {
+			report.Policies[i].NextRun = next[0]
+		}
+		if policy.StorageGroupCount == 0 {
+			return nil
+		}
+		storageGroups, err := c.GetSnapshotPolicyStorageGroupList(ctx, symID, snapshotPolicyID)
+		if err != nil {
+			errs[i] = append(errs[i], err)
+			return nil
+		}
+		storageGroupIDs[i] = storageGroups.StorageGroupIDs
+		return nil
+	})
+
+	// the compliance of all the storage groups of all the policies is read in parallel
+	type policyStorageGroup struct{ policy, storageGroup int }
+	var pairs []policyStorageGroup
+	for i := range storageGroupIDs {
+		report.Policies[i].StorageGroups = make([]types.StorageGroupSnapshotPolicy, len(storageGroupIDs[i]))
+		for j := range storageGroupIDs[i] {
+			pairs = append(pairs, policyStorageGroup{i, j})
+		}
+	}
+	pairErrs := make([]error, len(pairs))
+	_ = fetchInParallel(len(pairs), func(k int) error {
+		i, j := pairs[k].policy, pairs[k].storageGroup
+		storageGroupID := storageGroupIDs[i][j]
+		report.Policies[i].StorageGroups[j].StorageGroupID = storageGroupID
+		compliance, err := c.GetStorageGroupSnapshotPolicy(ctx, symID, list.SnapshotPolicyIDs[i], storageGroupID)
+		if err != nil {
+			pairErrs[k] = fmt.Errorf("storage group %s: %w", storageGroupID, err)
+			return nil
+		}
+		report.Policies[i].StorageGroups[j] = *compliance
+		return nil
+	})
+	for k, err := range pairErrs {
+		if err != nil {
+			errs[pairs[k].policy] = append(errs[pairs[k].policy], err)
+		}
+	}
+
+	for i := range report.Policies {
+		summary := &report.Policies[i]
+		for _, storageGroup := range summary.StorageGroups {
+			if storageGroup.Compliance == "" {
+				continue
+			}
+			if summary.Compliance == nil {
+				summary.Compliance = make(map[string]int)
+			}
+			summary.Compliance[storageGroup.Compliance]++
+		}
+		if err := errors.Join(errs[i]...); err != nil {
+			summary.Error = err.Error()
+		}
+	}
+	log.Info(fmt.Sprintf("Successfully summarized %d SnapshotPolicies on %s", len(report.Policies), symID))
+	return report, nil
+}
+
 // GetSnapshotPolicyList returns all the SnapshotPolicy names given the Symmetrix ID
 func (c *Client) GetSnapshotPolicyList(ctx context.Context, symID string) (*types.SnapshotPolicyList, error) {
 	defer c.TimeSpent("GetSnapshotPolicyList", time.Now())
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.verifySupport(ctx, symID, "GetSnapshotPolicyList"); err != nil {
+		return nil, err
+	}
 	URL := c.urlPrefix() + Replication + SymmetrixX + symID + SnapshotPolicy
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
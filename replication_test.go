@@ -0,0 +1,556 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pmax
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+func TestGetSnapshotCapacityUsage(t *testing.T) {
+	sgURL := urlPrefix + Replication + SymmetrixX + "mock-sym-id" + XStorageGroup + "/sg1" + XSnapshot
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.RequestURI {
+		case sgURL:
+			body = &types.StorageGroupSnapshot{Name: []string{"daily", "weekly"}}
+		case sgURL + "/daily" + SnapID:
+			body = &types.SnapID{SnapIDs: []int64{1, 2}}
+		case sgURL + "/weekly" + SnapID:
+			body = &types.SnapID{SnapIDs: []int64{3}}
+		case sgURL + "/daily" + SnapID + "/1":
+			body = &types.StorageGroupSnap{Name: "daily", SnapID: 1, Generation: 1, Tracks: 100, NotSharedTracks: 8192}
+		case sgURL + "/daily" + SnapID + "/2":
+			body = &types.StorageGroupSnap{Name: "daily", SnapID: 2, Generation: 0, Tracks: 50, NotSharedTracks: 0}
+		case sgURL + "/weekly" + SnapID + "/3":
+			body = &types.StorageGroupSnap{Name: "weekly", SnapID: 3, Tracks: 400, NotSharedTracks: 8192, Linked: true}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Error(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	usage, err := client.GetSnapshotCapacityUsage(context.TODO(), "mock-sym-id", "sg1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.SnapshotCount != 3 || usage.ModifiedTracks != 550 || usage.NonSharedTracks != 16384 || usage.NonSharedGB != 2 {
+		t.Fatalf("unexpected usage %+v", usage)
+	}
+	if len(usage.Snapshots) != 2 {
+		t.Fatalf("expected 2 snapshot names, got %+v", usage.Snapshots)
+	}
+	daily := usage.Snapshots[0]
+	if daily.SnapshotName != "daily" || daily.SnapshotCount != 2 || daily.ModifiedTracks != 150 || daily.NonSharedGB != 1 {
+		t.Fatalf("unexpected usage of daily snapshots %+v", daily)
+	}
+	if !usage.Snapshots[1].Generations[0].Linked {
+		t.Fatalf("expected weekly snapshot to be linked: %+v", usage.Snapshots[1])
+	}
+
+	if _, err = client.GetSnapshotCapacityUsage(context.TODO(), "mock-sym-id", "sg2"); err == nil {
+		t.Fatal("expected error for unknown storage group")
+	}
+}
+
+func TestGetStorageGroupPolicySnapshots(t *testing.T) {
+	symURL := urlPrefix + Replication + SymmetrixX + "mock-sym-id"
+	sgURL := symURL + XStorageGroup + "/sg1" + XSnapshot
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.RequestURI {
+		case symURL + SnapshotPolicy:
+			body = &types.SnapshotPolicyList{SnapshotPolicyIDs: []string{"hourly", "detached"}}
+		case sgURL:
+			body = &types.StorageGroupSnapshot{Name: []string{"hourly", "manual", "detached"}}
+		case sgURL + "/hourly" + SnapID:
+			body = &types.SnapID{SnapIDs: []int64{1, 2}}
+		case sgURL + "/detached" + SnapID:
+			body = &types.SnapID{SnapIDs: []int64{3}}
+		case sgURL + "/hourly" + SnapID + "/1":
+			body = &types.StorageGroupSnap{Name: "hourly", SnapID: 1, Generation: 1, TimeToLiveExpiryDate: "Mon Jan 01 10:00:00 2024", SecureExpiryDate: "N/A"}
+		case sgURL + "/hourly" + SnapID + "/2":
+			body = &types.StorageGroupSnap{Name: "hourly", SnapID: 2, Generation: 0, SecureExpiryDate: "Tue Jan 02 10:00:00 2024"}
+		case sgURL + "/detached" + SnapID + "/3":
+			body = &types.StorageGroupSnap{Name: "detached", SnapID: 3, Persistent: true}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshots, err := client.GetStorageGroupPolicySnapshots(context.TODO(), "mock-sym-id", "sg1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots.ManualSnapshotNames) != 1 || snapshots.ManualSnapshotNames[0] != "manual" {
+		t.Errorf("unexpected manual snapshots %v", snapshots.ManualSnapshotNames)
+	}
+	if len(snapshots.PolicySnapshots) != 3 {
+		t.Fatalf("expected 3 policy snapshots, got %+v", snapshots.PolicySnapshots)
+	}
+	first, second, detached := snapshots.PolicySnapshots[0], snapshots.PolicySnapshots[1], snapshots.PolicySnapshots[2]
+	if first.SnapshotPolicyName != "hourly" || first.Secure || first.TimeToLiveExpiryDate == "" || !second.Secure {
+		t.Errorf("unexpected hourly snapshots %+v %+v", first, second)
+	}
+	if detached.SnapshotPolicyName != "detached" || !detached.Persistent {
+		t.Errorf("unexpected detached policy snapshot %+v", detached)
+	}
+
+	if _, err = client.GetStorageGroupPolicySnapshots(context.TODO(), "mock-sym-id", "sg2"); err == nil {
+		t.Error("expected an error for an unknown storage group")
+	}
+}
+
+func TestStorageGroupSnapshotLinkMode(t *testing.T) {
+	var payloads []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPut || req.URL.Path != urlPrefix+Replication+SymmetrixX+"mock-sym-id"+XStorageGroup+"/sg1"+XSnapshot+"/snap1"+SnapID+"/7" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL)
+		}
+		payload := map[string]interface{}{}
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			t.Error(err)
+		}
+		payloads = append(payloads, payload)
+		_, _ = resp.Write([]byte(`{"name":"snap1","snapid":7,"linked":true}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	snap, err := client.LinkStorageGroupSnapshot(context.TODO(), "mock-sym-id", "sg1", "snap1", "7", "target-sg", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !snap.Linked {
+		t.Errorf("unexpected snapshot %+v", snap)
+	}
+	if _, err = client.SetStorageGroupSnapshotLinkMode(context.TODO(), "mock-sym-id", "sg1", "snap1", "7", "target-sg", true); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(payloads) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(payloads))
+	}
+	link, ok := payloads[0]["link"].(map[string]interface{})
+	if payloads[0]["action"] != "Link" || !ok || link["storage_group_name"] != "target-sg" || link["copy"] != nil {
+		t.Errorf("unexpected link payload %v", payloads[0])
+	}
+	setMode, ok := payloads[1]["set_mode"].(map[string]interface{})
+	if payloads[1]["action"] != "SetMode" || !ok || setMode["storage_group_name"] != "target-sg" || setMode["copy"] != true {
+		t.Errorf("unexpected set mode payload %v", payloads[1])
+	}
+}
+
+func TestGetSnapshotDelta(t *testing.T) {
+	snapURL := urlPrefix + Replication + SymmetrixX + "mock-sym-id" + XStorageGroup + "/sg1" + XSnapshot + "/daily" + SnapID
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.RequestURI {
+		case snapURL + "/1":
+			body = &types.StorageGroupSnap{Name: "daily", SnapID: 1, Generation: 1, Tracks: 100}
+		case snapURL + "/2":
+			body = &types.StorageGroupSnap{Name: "daily", SnapID: 2, Generation: 0, Tracks: 40}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	delta, err := client.GetSnapshotDelta(context.TODO(), "mock-sym-id", "sg1", "daily", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delta.CompareSnapID != types.CompareWithSource || delta.ChangedBytes() != 40*SnapshotTrackSizeBytes {
+		t.Fatalf("unexpected delta %+v", delta)
+	}
+	// the delta between two generations is that of the older one, whichever is compared with
+	for _, snapIDs := range [][2]int64{{2, 1}, {1, 2}} {
+		delta, err = client.GetSnapshotGenerationDelta(context.TODO(), "mock-sym-id", "sg1", "daily", snapIDs[0], snapIDs[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if delta.SnapID != snapIDs[0] || delta.CompareSnapID != snapIDs[1] || delta.ChangedTracks != 100 {
+			t.Fatalf("unexpected delta %+v", delta)
+		}
+	}
+	if _, err = client.GetSnapshotGenerationDelta(context.TODO(), "mock-sym-id", "sg1", "daily", 2, 2); err == nil {
+		t.Fatal("expected an error when comparing a generation with itself")
+	}
+	if _, err = client.GetSnapshotGenerationDelta(context.TODO(), "mock-sym-id", "sg1", "daily", 2, 3); err == nil {
+		t.Fatal("expected an error for an unknown generation")
+	}
+}
+
+func TestRelinkSnapshotAndLinkProgress(t *testing.T) {
+	privPrefix := "/" + RESTPrefix + PrivateX + "100/" + ReplicationX + SymmetrixX + "mock-sym-id"
+	var actions []types.ModifyVolumeSnapshot
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		switch req.Method + " " + req.URL.Path {
+		case "PUT " + privPrefix + XSnapshot + "/snap1":
+			payload := types.ModifyVolumeSnapshot{}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				t.Error(err)
+			}
+			actions = append(actions, payload)
+		case "GET " + privPrefix + XVolume + "/00001" + XSnapshot + "/snap1" + XGenereation + "/0":
+			body := &types.VolumeSnapshotGeneration{
+				VolumeSnapshotLink: []types.VolumeSnapshotLink{
+					{TargetDevice: "00002", State: "CopyInProg", Tracks: 1000, TrackSize: 131072, PercentageCopied: 40, Copy: true, Defined: true},
+					{TargetDevice: "00003", State: "Linked", Tracks: 1000, TrackSize: 131072, Defined: true},
+				},
+			}
+			content, _ := json.Marshal(body)
+			_, _ = resp.Write(content)
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+			resp.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := []types.VolumeList{{Name: "00001"}}
+	target := []types.VolumeList{{Name: "00002"}}
+	if err = client.ModifySnapshotS(context.TODO(), "mock-sym-id", source, target, "snap1", "Relink", "", 0, true, types.LinkSnapshotOptions{Exact: true}); err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 1 || actions[0].Action != "Relink" || !actions[0].Copy || !actions[0].Exact {
+		t.Fatalf("expected a differential relink, got %+v", actions)
+	}
+	actions = nil
+	if err = client.ModifySnapshotS(context.TODO(), "mock-sym-id", source, target, "snap1", "Relink", "", 0, false, types.LinkSnapshotOptions{FullCopy: true}); err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 2 || actions[0].Action != "Unlink" || actions[1].Action != "Link" || !actions[1].Copy {
+		t.Fatalf("expected the target to be unlinked and linked in copy mode, got %+v", actions)
+	}
+
+	progress, err := client.GetSnapshotLinkProgress(context.TODO(), "mock-sym-id", "00001", "snap1", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(progress) != 2 || progress[0].RemainingTracks != 600 || progress[0].RemainingBytes() != 600*131072 || progress[1].RemainingTracks != 0 {
+		t.Fatalf("unexpected progress %+v", progress)
+	}
+}
+
+func TestCreateSnapshotTimeToLive(t *testing.T) {
+	snapURL := "/" + RESTPrefix + PrivateX + "100/" + ReplicationX + SymmetrixX + "mock-sym-id" + XSnapshot + "/snap1"
+	var created []types.CreateVolumesSnapshot
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		switch req.Method + " " + req.URL.Path {
+		case "POST " + snapURL:
+			payload := types.CreateVolumesSnapshot{}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				t.Error(err)
+			}
+			created = append(created, payload)
+		case "GET " + "/" + RESTPrefix + PrivateX + "100/" + ReplicationX + SymmetrixX + "mock-sym-id" + XVolume + "/00001" + XSnapshot + "/snap1":
+			body := &types.VolumeSnapshot{
+				VolumeSnapshotSource: []types.VolumeSnapshotSource{{SnapshotName: "snap1", TTL: 4, TimeToLiveExpiryDate: "Mon Jan 01 14:00:00 2024", SecureExpiryDate: "N/A"}},
+			}
+			content, _ := json.Marshal(body)
+			_, _ = resp.Write(content)
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.RequestURI)
+			resp.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sources := []types.VolumeList{{Name: "00001"}}
+	if err = client.CreateSnapshot(context.TODO(), "mock-sym-id", "snap1", sources, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.CreateSnapshot(context.TODO(), "mock-sym-id", "snap1", sources, 4, types.CreateSnapshotOptions{TimeInHours: true, SecureTTL: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 2 || created[0].TimeToLive != 2 || created[0].TimeInHours || created[0].Securettl != 0 ||
+		created[1].TimeToLive != 4 || !created[1].TimeInHours || created[1].Securettl != 1 {
+		t.Fatalf("unexpected snapshot payloads %+v", created)
+	}
+
+	snapshot, err := client.GetSnapshotInfo(context.TODO(), "mock-sym-id", "00001", "snap1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := snapshot.VolumeSnapshotSource[0]
+	if expiry, ok := source.TimeToLiveExpiry(); !ok || expiry.Hour() != 14 {
+		t.Errorf("unexpected time to live expiry %v", expiry)
+	}
+	if _, ok := source.SecureExpiry(); ok {
+		t.Error("expected no secure expiry")
+	}
+}
+
+func TestGetSnapshotGenerationsFiltered(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/"+RESTPrefix+PrivateX+"100/"+ReplicationX+SymmetrixX+"mock-sym-id"+XVolume+"/00001"+XSnapshot+"/snap1"+XGenereation {
+			t.Errorf("unexpected request %s %s", req.Method, req.RequestURI)
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body := &types.VolumeSnapshotGenerations{
+			SnapshotName: "snap1",
+			Generation:   []int64{0, 1, 2, 3},
+			VolumeSnapshotSource: []types.VolumeSnapshotSource{
+				{Generation: 0, TimeStamp: "Thu Jan 04 10:00:00 2024"},
+				{Generation: 1, TimeStamp: "Wed Jan 03 10:00:00 2024", LinkedVolumes: []types.LinkedVolumes{{TargetDevice: "00002", Linked: true}}},
+				{Generation: 2, TimeStamp: "Tue Jan 02 10:00:00 2024", Expired: true},
+				{Generation: 3, TimeStamp: "Mon Jan 01 10:00:00 2024"},
+			},
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlinked, unexpired := false, false
+	created := func(date string) time.Time {
+		created, _ := types.ParseSnapshotDate(date)
+		return created
+	}
+	tests := []struct {
+		name     string
+		filter   types.SnapshotGenerationFilter
+		expected []int64
+	}{
+		{"oldest first", types.SnapshotGenerationFilter{}, []int64{3, 2, 1, 0}},
+		{"newest first", types.SnapshotGenerationFilter{NewestFirst: true}, []int64{0, 1, 2, 3}},
+		{"deletion candidates", types.SnapshotGenerationFilter{Linked: &unlinked, Expired: &unexpired}, []int64{3, 0}},
+		{"time range", types.SnapshotGenerationFilter{CreatedAfter: created("Tue Jan 02 10:00:00 2024"), CreatedBefore: created("Thu Jan 04 10:00:00 2024")}, []int64{2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generations, err := client.GetSnapshotGenerations(context.TODO(), "mock-sym-id", "00001", "snap1", tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(generations.Generation, tt.expected) || len(generations.VolumeSnapshotSource) != len(tt.expected) {
+				t.Fatalf("expected generations %v, got %v", tt.expected, generations.Generation)
+			}
+		})
+	}
+
+	generations, err := client.GetSnapshotGenerations(context.TODO(), "mock-sym-id", "00001", "snap1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(generations.Generation, []int64{0, 1, 2, 3}) {
+		t.Fatalf("expected the generations as listed without a filter, got %v", generations.Generation)
+	}
+}
+
+func TestSnapshotPolicySuspendAndBulkModify(t *testing.T) {
+	policyURL := urlPrefix + Replication + SymmetrixX + "mock-sym-id" + SnapshotPolicy
+	policies := map[string]*types.SnapshotPolicy{
+		"hourly": {SnapshotPolicyName: "hourly", IntervalMinutes: 60, SnapshotCount: 24, StorageGroupCount: 2},
+		"daily":  {SnapshotPolicyName: "daily", IntervalMinutes: 1440, SnapshotCount: 7},
+	}
+	var actions []string
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch {
+		case req.RequestURI == policyURL+"/hourly"+XStorageGroup:
+			body = &types.SnapshotPolicyStorageGroupList{StorageGroupIDs: []string{"sg1", "sg2"}}
+		case req.Method == http.MethodGet && policies[path.Base(req.RequestURI)] != nil:
+			body = policies[path.Base(req.RequestURI)]
+		case req.Method == http.MethodPut && policies[path.Base(req.RequestURI)] != nil:
+			policy := policies[path.Base(req.RequestURI)]
+			param := &types.UpdateSnapshotPolicyParam{}
+			if err := json.NewDecoder(req.Body).Decode(param); err != nil {
+				t.Error(err)
+			}
+			actions = append(actions, param.Action+" "+policy.SnapshotPolicyName)
+			switch param.Action {
+			case "Suspend":
+				policy.Suspended = true
+			case "Resume":
+				policy.Suspended = false
+			case "Modify":
+				policy.IntervalMinutes = param.ModifySnapshotPolicyParam.IntervalMinutes
+				policy.SnapshotCount = int64(param.ModifySnapshotPolicyParam.SnapshotCount)
+			}
+			return
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy, err := client.SuspendSnapshotPolicy(context.TODO(), "mock-sym-id", "hourly")
+	if err != nil || !policy.Suspended {
+		t.Fatalf("expected a suspended policy, got %+v, %v", policy, err)
+	}
+	if _, err = client.SuspendSnapshotPolicy(context.TODO(), "mock-sym-id", "hourly"); err != nil {
+		t.Fatal(err)
+	}
+	policy, err = client.ResumeSnapshotPolicy(context.TODO(), "mock-sym-id", "hourly")
+	if err != nil || policy.Suspended {
+		t.Fatalf("expected a resumed policy, got %+v, %v", policy, err)
+	}
+	if len(actions) != 2 || actions[0] != "Suspend hourly" || actions[1] != "Resume hourly" {
+		t.Fatalf("unexpected actions %v", actions)
+	}
+
+	modify := &types.ModifySnapshotPolicyParam{IntervalMinutes: 120, SnapshotCount: 12}
+	modifications, err := client.ModifySnapshotPolicies(context.TODO(), "mock-sym-id", modify, "hourly", "missing", "daily")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(modifications) != 3 {
+		t.Fatalf("expected 3 modifications, got %+v", modifications)
+	}
+	hourly, missing, daily := modifications[0], modifications[1], modifications[2]
+	if hourly.Error != "" || hourly.SnapshotPolicy.IntervalMinutes != 120 || len(hourly.StorageGroupIDs) != 2 {
+		t.Errorf("unexpected modification of hourly %+v", hourly)
+	}
+	if missing.Error == "" || missing.SnapshotPolicy != nil {
+		t.Errorf("expected the modification of an unknown policy to fail: %+v", missing)
+	}
+	if daily.Error != "" || daily.SnapshotPolicy.SnapshotCount != 12 || len(daily.StorageGroupIDs) != 0 {
+		t.Errorf("unexpected modification of daily %+v", daily)
+	}
+
+	if _, err = client.ModifySnapshotPolicies(context.TODO(), "mock-sym-id", &types.ModifySnapshotPolicyParam{}, "hourly"); err == nil {
+		t.Error("expected an error for an empty modification")
+	}
+	if _, err = client.ModifySnapshotPolicies(context.TODO(), "mock-sym-id", &types.ModifySnapshotPolicyParam{SnapshotPolicyName: "x"}, "hourly", "daily"); err == nil {
+		t.Error("expected an error when renaming several policies")
+	}
+}
+
+func TestGetSnapshotPolicySummary(t *testing.T) {
+	policyURL := urlPrefix + Replication + SymmetrixX + "mock-sym-id" + SnapshotPolicy
+	var methods sync.Map
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		methods.Store(req.Method, true)
+		var body interface{}
+		switch req.RequestURI {
+		case policyURL:
+			body = &types.SnapshotPolicyList{SnapshotPolicyIDs: []string{"hourly", "daily", "restricted"}}
+		case policyURL + "/hourly":
+			body = &types.SnapshotPolicy{SnapshotPolicyName: "hourly", IntervalMinutes: 60, StorageGroupCount: 3}
+		case policyURL + "/daily":
+			body = &types.SnapshotPolicy{SnapshotPolicyName: "daily", IntervalMinutes: 1440, Suspended: true}
+		case policyURL + "/hourly" + XStorageGroup:
+			body = &types.SnapshotPolicyStorageGroupList{StorageGroupIDs: []string{"sg1", "sg2", "sg3"}}
+		case policyURL + "/hourly" + XStorageGroup + "/sg1", policyURL + "/hourly" + XStorageGroup + "/sg2":
+			body = &types.StorageGroupSnapshotPolicy{SnapshotPolicyID: "hourly", StorageGroupID: path.Base(req.RequestURI), Compliance: "GREEN"}
+		default:
+			resp.WriteHeader(http.StatusForbidden)
+			_, _ = resp.Write([]byte(`{"message":"forbidden","httpStatusCode":403,"errorCode":0}`))
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := client.GetSnapshotPolicySummary(context.TODO(), "mock-sym-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.SymmetrixID != "mock-sym-id" || len(report.Policies) != 3 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	hourly, daily, restricted := report.Policies[0], report.Policies[1], report.Policies[2]
+	if hourly.SnapshotPolicy.SnapshotPolicyName != "hourly" || hourly.NextRun.IsZero() || len(hourly.StorageGroups) != 3 {
+		t.Errorf("unexpected summary of hourly %+v", hourly)
+	}
+	if !reflect.DeepEqual(hourly.Compliance, map[string]int{"GREEN": 2}) || !strings.Contains(hourly.Error, "sg3") {
+		t.Errorf("expected the compliance of 2 storage groups and an error for sg3, got %v, %q", hourly.Compliance, hourly.Error)
+	}
+	if hourly.StorageGroups[2].StorageGroupID != "sg3" || hourly.StorageGroups[2].Compliance != "" {
+		t.Errorf("unexpected storage group %+v", hourly.StorageGroups[2])
+	}
+	if daily.Error != "" || !daily.NextRun.IsZero() || len(daily.StorageGroups) != 0 || daily.Compliance != nil {
+		t.Errorf("unexpected summary of daily %+v", daily)
+	}
+	if restricted.SnapshotPolicy.SnapshotPolicyName != "restricted" || restricted.Error == "" {
+		t.Errorf("expected the forbidden policy to be returned with an error, got %+v", restricted)
+	}
+	methods.Range(func(method, _ interface{}) bool {
+		if method != http.MethodGet {
+			t.Errorf("unexpected %s request", method)
+		}
+		return true
+	})
+}
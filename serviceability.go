@@ -0,0 +1,193 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pmax
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+	log "github.com/sirupsen/logrus"
+)
+
+// The following constants are for internal use within the pmax library.
+const (
+	ServiceabilityX = "serviceability/"
+	XLogBundle      = "/log_bundle"
+	XDownload       = "/download"
+)
+
+// DefaultLogBundlePollInterval is how often WaitForLogBundle checks the collection when no interval is given
+const DefaultLogBundlePollInterval = 30 * time.Second
+
+// DefaultLogBundleTimeout is how long WaitForLogBundle waits for the collection when ctx has no deadline
+const DefaultLogBundleTimeout = 2 * time.Hour
+
+func (c *Client) logBundleURL(symID string) string {
+	return c.urlPrefix() + ServiceabilityX + SymmetrixX + symID + XLogBundle
+}
+
+// CreateLogBundle starts the collection of a support log bundle on an array. Log bundles can only be collected
+// through REST on the arrays whose Unisphere exposes the serviceability endpoints, e.g. embedded management.
+func (c *Client) CreateLogBundle(ctx context.Context, symID string, param *types.CreateLogBundleParam) (*types.LogBundle, error) {
+	defer c.TimeSpent("CreateLogBundle", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	if param == nil {
+		param = &types.CreateLogBundleParam{}
+	}
+	ifDebugLogPayload(param)
+	bundle := &types.LogBundle{}
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	if err := c.api.Post(ctx, c.logBundleURL(symID), c.getDefaultHeaders(), param, bundle); err != nil {
+		log.Error("CreateLogBundle failed: " + err.Error())
+		return nil, err
+	}
+	log.Info(fmt.Sprintf("Successfully started collecting log bundle %s on array %s", bundle.LogBundleID, symID))
+	return bundle, nil
+}
+
+// GetLogBundleList returns the ids of the log bundles of an array
+func (c *Client) GetLogBundleList(ctx context.Context, symID string) (*types.LogBundleList, error) {
+	defer c.TimeSpent("GetLogBundleList", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	list := &types.LogBundleList{}
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	if err := c.api.Get(ctx, c.logBundleURL(symID), c.getDefaultHeaders(), list); err != nil {
+		log.Error("GetLogBundleList failed: " + err.Error())
+		return nil, err
+	}
+	return list, nil
+}
+
+// GetLogBundle returns a log bundle, with the progress of its collection
+func (c *Client) GetLogBundle(ctx context.Context, symID, logBundleID string) (*types.LogBundle, error) {
+	defer c.TimeSpent("GetLogBundle", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	bundle := &types.LogBundle{}
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	if err := c.api.Get(ctx, c.logBundleURL(symID)+"/"+logBundleID, c.getDefaultHeaders(), bundle); err != nil {
+		log.Error("GetLogBundle failed: " + err.Error())
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// WaitForLogBundle polls a log bundle every pollInterval, or DefaultLogBundlePollInterval if it is 0, until its collection
+// is over, and returns an error if the collection failed or is in an unknown state. It waits until ctx is done, or for
+// DefaultLogBundleTimeout if ctx has no deadline.
+func (c *Client) WaitForLogBundle(ctx context.Context, symID, logBundleID string, pollInterval time.Duration) (*types.LogBundle, error) {
+	defer c.TimeSpent("WaitForLogBundle", time.Now())
+	if pollInterval <= 0 {
+		pollInterval = DefaultLogBundlePollInterval
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultLogBundleTimeout)
+		defer cancel()
+	}
+	for {
+		bundle, err := c.GetLogBundle(ctx, symID, logBundleID)
+		if err != nil {
+			return nil, err
+		}
+		switch bundle.Status {
+		case types.LogBundleStatusSucceeded:
+			return bundle, nil
+		case types.LogBundleStatusFailed:
+			return bundle, fmt.Errorf("the collection of log bundle %s failed: %s", logBundleID, bundle.Message)
+		case types.LogBundleStatusRunning:
+		default:
+			return bundle, fmt.Errorf("log bundle %s is in the unknown state %q", logBundleID, bundle.Status)
+		}
+		log.Debug(fmt.Sprintf("Log bundle %s is %d%% collected", logBundleID, bundle.Progress))
+		select {
+		case <-ctx.Done():
+			return bundle, fmt.Errorf("log bundle %s is still being collected: %w", logBundleID, ctx.Err())
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// DownloadLogBundle streams a collected log bundle to w and returns the number of bytes written.
+// The download is not limited by the client timeout, as bundles can be large, so ctx should be used to bound it.
+func (c *Client) DownloadLogBundle(ctx context.Context, symID, logBundleID string, w io.Writer) (int64, error) {
+	defer c.TimeSpent("DownloadLogBundle", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return 0, err
+	}
+	headers := c.getDefaultHeaders()
+	headers["Accept"] = "application/octet-stream"
+	resp, err := c.api.DoAndGetResponseBody(ctx, http.MethodGet, c.logBundleURL(symID)+"/"+logBundleID+XDownload, headers, nil)
+	if err != nil {
+		log.Error("DownloadLogBundle failed: " + err.Error())
+		return 0, err
+	}
+	defer resp.Body.Close() // #nosec G307
+	if err = c.checkResponse(resp); err != nil {
+		return 0, err
+	}
+	written, err := io.Copy(w, resp.Body)
+	if err != nil {
+		log.Error("DownloadLogBundle failed: " + err.Error())
+		return written, err
+	}
+	log.Info(fmt.Sprintf("Successfully downloaded log bundle %s (%d bytes)", logBundleID, written))
+	return written, nil
+}
+
+// DeleteLogBundle deletes a log bundle from an array
+func (c *Client) DeleteLogBundle(ctx context.Context, symID, logBundleID string) error {
+	defer c.TimeSpent("DeleteLogBundle", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return err
+	}
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	if err := c.api.Delete(ctx, c.logBundleURL(symID)+"/"+logBundleID, c.getDefaultHeaders(), nil); err != nil {
+		log.Error("DeleteLogBundle failed: " + err.Error())
+		return err
+	}
+	log.Info(fmt.Sprintf("Successfully deleted log bundle %s", logBundleID))
+	return nil
+}
+
+// CollectLogBundle collects a support log bundle of an array, waits for the collection, polling every pollInterval,
+// and streams the bundle to w. The bundle is left on the array, and can be deleted with DeleteLogBundle.
+func (c *Client) CollectLogBundle(ctx context.Context, symID string, param *types.CreateLogBundleParam, pollInterval time.Duration, w io.Writer) (*types.LogBundle, error) {
+	defer c.TimeSpent("CollectLogBundle", time.Now())
+	bundle, err := c.CreateLogBundle(ctx, symID, param)
+	if err != nil {
+		return nil, err
+	}
+	if bundle, err = c.WaitForLogBundle(ctx, symID, bundle.LogBundleID, pollInterval); err != nil {
+		return bundle, err
+	}
+	if _, err = c.DownloadLogBundle(ctx, symID, bundle.LogBundleID, w); err != nil {
+		return bundle, err
+	}
+	return bundle, nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pmax
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+func TestCollectLogBundle(t *testing.T) {
+	bundleURL := urlPrefix + ServiceabilityX + SymmetrixX + "mock-sym-id" + XLogBundle
+	polls := 0
+	status := types.LogBundleStatusSucceeded
+	deleted := false
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch {
+		case req.URL.Path == bundleURL && req.Method == http.MethodPost:
+			param := &types.CreateLogBundleParam{}
+			if err := json.NewDecoder(req.Body).Decode(param); err != nil || param.Description != "case 42" {
+				t.Errorf("unexpected parameters %+v, %v", param, err)
+			}
+			body = &types.LogBundle{LogBundleID: "lb1", Status: types.LogBundleStatusRunning}
+		case req.URL.Path == bundleURL && req.Method == http.MethodGet:
+			body = &types.LogBundleList{LogBundleIDs: []string{"lb1"}}
+		case req.URL.Path == bundleURL+"/lb1" && req.Method == http.MethodGet:
+			polls++
+			bundle := &types.LogBundle{LogBundleID: "lb1", Status: types.LogBundleStatusRunning, Progress: 50}
+			if polls > 1 {
+				bundle.Status, bundle.Progress, bundle.Message = status, 100, "disk full"
+			}
+			body = bundle
+		case req.URL.Path == bundleURL+"/lb1" && req.Method == http.MethodDelete:
+			deleted = true
+			resp.WriteHeader(http.StatusNoContent)
+			return
+		case req.URL.Path == bundleURL+"/lb1"+XDownload:
+			if req.Header.Get("Accept") != "application/octet-stream" {
+				t.Errorf("unexpected Accept header %s", req.Header.Get("Accept"))
+			}
+			resp.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = resp.Write([]byte("bundle-content"))
+			return
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+
+	var buf bytes.Buffer
+	bundle, err := client.CollectLogBundle(ctx, "mock-sym-id", &types.CreateLogBundleParam{Description: "case 42"}, time.Millisecond, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bundle.Status != types.LogBundleStatusSucceeded || buf.String() != "bundle-content" || polls != 2 {
+		t.Errorf("unexpected bundle %+v, content %q after %d polls", bundle, buf.String(), polls)
+	}
+	list, err := client.GetLogBundleList(ctx, "mock-sym-id")
+	if err != nil || len(list.LogBundleIDs) != 1 {
+		t.Errorf("unexpected list %+v, %v", list, err)
+	}
+	if err = client.DeleteLogBundle(ctx, "mock-sym-id", "lb1"); err != nil || !deleted {
+		t.Errorf("expected the bundle to be deleted, got %v", err)
+	}
+
+	// a failed collection is not downloaded
+	polls, status = 0, types.LogBundleStatusFailed
+	buf.Reset()
+	if _, err = client.CollectLogBundle(ctx, "mock-sym-id", &types.CreateLogBundleParam{Description: "case 42"}, time.Millisecond, &buf); err == nil || buf.Len() != 0 {
+		t.Errorf("expected an error for a failed collection, got %v", err)
+	}
+	// neither is a collection in an unknown state, which is not waited for
+	polls, status = 0, "Paused"
+	if _, err = client.WaitForLogBundle(ctx, "mock-sym-id", "lb1", time.Millisecond); err == nil || polls != 2 {
+		t.Errorf("expected an error for an unknown state, got %v after %d polls", err, polls)
+	}
+	if _, err = client.DownloadLogBundle(ctx, "mock-sym-id", "lb2", &buf); err == nil {
+		t.Error("expected an error for a missing bundle")
+	}
+}
@@ -15,16 +15,25 @@
 package pmax
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"net/url"
 	"regexp"
 	"runtime"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/dell/gopowermax/v2/api"
+	"github.com/dell/gopowermax/v2/payload"
 	types "github.com/dell/gopowermax/v2/types/v100"
 	log "github.com/sirupsen/logrus"
 )
@@ -43,9 +52,22 @@ const (
 	XHost                  = "/host"
 	XHostGroup             = "/hostgroup"
 	XMaskingView           = "/maskingview"
+	XSLO                   = "/slo"
 	Emulation              = "FBA"
 	MaxVolIdentifierLength = 64
 	Migration              = "migration/"
+	// MaxVolumeDetailWorkers is the number of volumes fetched in parallel when a detailed volume listing is emulated
+	MaxVolumeDetailWorkers = 10
+	// MaxHostAuditWorkers is the number of hosts fetched in parallel by AuditHostFlags
+	MaxHostAuditWorkers = 10
+	// MaxVolumeRelabelWorkers is the number of volumes renamed in parallel by RelabelStorageGroupVolumes
+	MaxVolumeRelabelWorkers = 10
+	// MaxStorageGroupReadAttempts is how many times GetStorageGroup reads a storage group and its volumes until they match
+	MaxStorageGroupReadAttempts = 3
+	// DefaultHostInitiatorChunkSize is the number of initiators added to or removed from a host per request by UpdateHostInitiatorsInChunks
+	DefaultHostInitiatorChunkSize = 16
+	// MaxBulkQueryKeys is the number of objects looked up per request by the bulk queries, e.g. GetVolumesByIDs
+	MaxBulkQueryKeys = 100
 )
 
 // TimeSpent - Calculates and prints time spent for a caller function
@@ -83,6 +105,9 @@ func (c *Client) GetVolumeIDsIterator(ctx context.Context, symID string, volumeI
 
 // GetVolumesInStorageGroupIterator returns a iterator of a list of volumes associated with a StorageGroup.
 func (c *Client) GetVolumesInStorageGroupIterator(ctx context.Context, symID string, storageGroupID string) (*types.VolumeIterator, error) {
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
 	var query string
 	if storageGroupID == "" {
 		return nil, fmt.Errorf("storageGroupID is empty")
@@ -125,6 +150,9 @@ func (c *Client) GetVolumeIDsIteratorWithParams(ctx context.Context, symID strin
 
 // GetVolumeIDsIterator returns a VolumeIDs Iterator. It generally fetches the first page in the result as part of the operation.
 func (c *Client) getVolumeIDsIteratorBase(ctx context.Context, symID string, query string) (*types.VolumeIterator, error) {
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
 	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XVolume
 	if query != "" {
 		URL = URL + query
@@ -143,8 +171,7 @@ func (c *Client) getVolumeIDsIteratorBase(ctx context.Context, symID string, que
 		return nil, err
 	}
 	iter := &types.VolumeIterator{}
-	decoder := json.NewDecoder(resp.Body)
-	if err = decoder.Decode(iter); err != nil {
+	if err = iter.DecodeStream(json.NewDecoder(resp.Body)); err != nil {
 		return nil, err
 	}
 	err = resp.Body.Close()
@@ -179,8 +206,7 @@ func (c *Client) GetVolumeIDsIteratorPage(ctx context.Context, iter *types.Volum
 		return nil, err
 	}
 	result := &types.VolumeResultList{}
-	decoder := json.NewDecoder(resp.Body)
-	if err = decoder.Decode(result); err != nil {
+	if err = result.DecodeStream(json.NewDecoder(resp.Body)); err != nil {
 		return nil, err
 	}
 	err = resp.Body.Close()
@@ -195,9 +221,11 @@ func (c *Client) GetVolumeIDsIteratorPage(ctx context.Context, iter *types.Volum
 }
 
 // DeleteVolumeIDsIterator deletes a volume iterator.
+// The iterator only holds the results of a listing, so it is deleted in safe mode and in dry-run mode too.
 func (c *Client) DeleteVolumeIDsIterator(ctx context.Context, iter *types.VolumeIterator) error {
 	defer c.TimeSpent("DeleteVolumeIDsIterator", time.Now())
 	URL := RESTPrefix + IteratorX + iter.ID
+	ctx = api.WithDryRun(api.WithAllowDestructive(ctx, true), false)
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
 	err := c.api.Delete(ctx, URL, c.getDefaultHeaders(), nil)
@@ -225,6 +253,9 @@ func (c *Client) GetVolumeIDList(ctx context.Context, symID string, volumeIdenti
 
 // GetVolumeIDListInStorageGroup - Gets a list of volume in a SG
 func (c *Client) GetVolumeIDListInStorageGroup(ctx context.Context, symID string, storageGroupID string) ([]string, error) {
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
 	iter, err := c.GetVolumesInStorageGroupIterator(ctx, symID, storageGroupID)
 	if err != nil {
 		return nil, err
@@ -308,6 +339,191 @@ func (c *Client) GetVolumeByID(ctx context.Context, symID string, volumeID strin
 	return volume, nil
 }
 
+// GetVolumeDetailList returns the details of all the volumes matching the query parameters in one call.
+// The detailed listing of the volume endpoint is used where the array supports it; otherwise only the
+// volume IDs are returned by the array and the details of each volume are fetched in parallel. When a single
+// volume cannot be fetched, its error is recorded in the VolumeDetail instead of failing the whole listing.
+func (c *Client) GetVolumeDetailList(ctx context.Context, symID string, queryParams map[string]string) ([]types.VolumeDetail, error) {
+	defer c.TimeSpent("GetVolumeDetailList", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	query := "?details=true"
+	for key, val := range queryParams {
+		query += fmt.Sprintf("&%s=%s", key, val)
+	}
+	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XVolume + query
+	iter := &types.VolumeDetailIterator{}
+	if err := c.getWithTimeout(ctx, URL, iter); err != nil {
+		log.Error("GetVolumeDetailList failed: " + err.Error())
+		return nil, err
+	}
+	if iter.MaxPageSize < iter.Count {
+		defer func() {
+			_ = c.DeleteVolumeIDsIterator(ctx, &types.VolumeIterator{ID: iter.ID})
+		}()
+	}
+	volumes := iter.ResultList.VolumeList
+	for from := iter.ResultList.To + 1; from <= iter.Count; {
+		to := from + iter.MaxPageSize - 1
+		if to > iter.Count {
+			to = iter.Count
+		}
+		page := &types.VolumeDetailResultList{}
+		URL = RESTPrefix + IteratorX + iter.ID + XPage + fmt.Sprintf("?from=%d&to=%d", from, to)
+		if err := c.getWithTimeout(ctx, URL, page); err != nil {
+			log.Error("GetVolumeDetailList failed: " + err.Error())
+			return nil, err
+		}
+		if len(page.VolumeList) == 0 {
+			return nil, fmt.Errorf("Expected %d volumes but got %d volumes", iter.Count, len(volumes))
+		}
+		volumes = append(volumes, page.VolumeList...)
+		from += len(page.VolumeList)
+	}
+
+	// arrays without a detailed listing only return the volume IDs
+	for i := range volumes {
+		if volumes[i].Emulation == "" {
+			c.fetchVolumeDetails(ctx, symID, volumes)
+			break
+		}
+	}
+	return volumes, nil
+}
+
+// XQuery is the path of the POST endpoints looking up many objects by ID in one request
+const XQuery = "/query"
+
+// GetVolumesByIDs returns the details of the volumes, in the order of volumeIDs. The volumes are looked up
+// in batches of up to MaxBulkQueryKeys IDs per request on the Unisphere releases with the volume query
+// endpoint; on the others, which answer 404, the volumes are fetched one by one, in parallel. A volume
+// which cannot be found has its error recorded in its VolumeDetail instead of failing the whole lookup.
+func (c *Client) GetVolumesByIDs(ctx context.Context, symID string, volumeIDs []string) ([]types.VolumeDetail, error) {
+	defer c.TimeSpent("GetVolumesByIDs", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XVolume + XQuery
+	found := make(map[string]types.Volume, len(volumeIDs))
+	for batch := range slices.Chunk(volumeIDs, MaxBulkQueryKeys) {
+		result := &types.VolumeDetailResultList{}
+		err := c.postWithTimeout(api.WithDryRun(ctx, false), URL, types.VolumeQueryParam{VolumeIDs: batch}, result)
+		var apiErr *types.Error
+		if errors.As(err, &apiErr) && apiErr.HTTPStatusCode == http.StatusNotFound && len(found) == 0 {
+			log.Debug("The volume query endpoint is not supported, fetching the volumes one by one")
+			volumes := make([]types.VolumeDetail, len(volumeIDs))
+			for i, volumeID := range volumeIDs {
+				volumes[i].VolumeID = volumeID
+			}
+			c.fetchVolumeDetails(ctx, symID, volumes)
+			return volumes, nil
+		}
+		if err != nil {
+			log.Error("GetVolumesByIDs failed: " + err.Error())
+			return nil, err
+		}
+		for _, vol := range result.VolumeList {
+			found[vol.VolumeID] = vol.Volume
+		}
+	}
+	volumes := make([]types.VolumeDetail, len(volumeIDs))
+	for i, volumeID := range volumeIDs {
+		vol, ok := found[volumeID]
+		if !ok {
+			volumes[i] = types.VolumeDetail{Volume: types.Volume{VolumeID: volumeID}, Error: fmt.Sprintf("volume %s not found", volumeID)}
+			continue
+		}
+		volumes[i].Volume = vol
+	}
+	return volumes, nil
+}
+
+// GetStorageGroupVolumeList returns the details of the volumes of a storage group, filtered and ordered
+// according to the options. The filters are sent to the array, and applied again to the returned volumes
+// for the arrays ignoring them.
+func (c *Client) GetStorageGroupVolumeList(ctx context.Context, symID string, storageGroupID string, opts types.VolumeListOptions) ([]types.VolumeDetail, error) {
+	defer c.TimeSpent("GetStorageGroupVolumeList", time.Now())
+	if storageGroupID == "" {
+		return nil, fmt.Errorf("storageGroupID is empty")
+	}
+	queryParams := map[string]string{"storageGroupId": url.QueryEscape(storageGroupID)}
+	if opts.Emulation != "" {
+		queryParams["emulation"] = url.QueryEscape(opts.Emulation)
+	}
+	if opts.ExcludeGatekeepers {
+		queryParams["cap_cyl"] = url.QueryEscape(fmt.Sprintf(">%d", GatekeeperMaxCylinders))
+	}
+	volumes, err := c.GetVolumeDetailList(ctx, symID, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]types.VolumeDetail, 0, len(volumes))
+	for _, vol := range volumes {
+		if opts.Emulation != "" && vol.Error == "" && !strings.EqualFold(vol.Emulation, opts.Emulation) {
+			continue
+		}
+		if opts.ExcludeGatekeepers && vol.CapacityCYL > 0 && vol.CapacityCYL <= GatekeeperMaxCylinders {
+			continue
+		}
+		filtered = append(filtered, vol)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		a, b := filtered[i], filtered[j]
+		if opts.Descending {
+			a, b = b, a
+		}
+		if opts.SortByCapacity && a.CapacityCYL != b.CapacityCYL {
+			return a.CapacityCYL < b.CapacityCYL
+		}
+		return a.VolumeID < b.VolumeID
+	})
+	return filtered, nil
+}
+
+// fetchVolumeDetails fills in the details of each of the volumes, fetching up to MaxVolumeDetailWorkers volumes in parallel
+func (c *Client) fetchVolumeDetails(ctx context.Context, symID string, volumes []types.VolumeDetail) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, MaxVolumeDetailWorkers)
+	for i := range volumes {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(detail *types.VolumeDetail) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			vol, err := c.GetVolumeByID(ctx, symID, detail.VolumeID)
+			if err != nil {
+				detail.Error = err.Error()
+				return
+			}
+			detail.Volume = *vol
+		}(&volumes[i])
+	}
+	wg.Wait()
+}
+
+// getWithTimeout does a GET of the URL, bounded by the context timeout of the client, and decodes the response into resp
+func (c *Client) getWithTimeout(ctx context.Context, URL string, resp interface{}) error {
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	return c.api.Get(ctx, URL, c.getDefaultHeaders(), resp)
+}
+
+// postWithTimeout does a POST of the payload to the URL, bounded by the context timeout of the client, and decodes the response into resp
+func (c *Client) postWithTimeout(ctx context.Context, URL string, payload, resp interface{}) error {
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	return c.api.Post(ctx, URL, c.getDefaultHeaders(), payload, resp)
+}
+
+// putWithTimeout does a PUT of the payload to the URL, bounded by the context timeout of the client, and decodes the response into resp
+func (c *Client) putWithTimeout(ctx context.Context, URL string, payload, resp interface{}) error {
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	return c.api.Put(ctx, URL, c.getDefaultHeaders(), payload, resp)
+}
+
 // GetStorageGroupIDList returns a list of StorageGroupIds in a StorageGroupIDList type.
 func (c *Client) GetStorageGroupIDList(ctx context.Context, symID, storageGroupIDMatch string, like bool) (*types.StorageGroupIDList, error) {
 	defer c.TimeSpent("GetStorageGroupIDList", time.Now())
@@ -348,45 +564,8 @@ func (c *Client) GetStorageGroupIDList(ctx context.Context, symID, storageGroupI
 }
 
 // GetCreateStorageGroupPayload returns U4P payload for creating storage group
-func (c *Client) GetCreateStorageGroupPayload(storageGroupID, srpID, serviceLevel string, thickVolumes bool, optionalPayload map[string]interface{}) (payload interface{}) {
-	workload := "None"
-	sloParams := []types.SLOBasedStorageGroupParam{}
-	var snapshotPolicies []string
-	if srpID != "None" {
-		sloParams = []types.SLOBasedStorageGroupParam{
-			{
-				SLOID:             serviceLevel,
-				WorkloadSelection: workload,
-				VolumeAttributes: []types.VolumeAttributeType{
-					{
-						VolumeSize:      "0",
-						CapacityUnit:    "CYL",
-						NumberOfVolumes: 0,
-					},
-				},
-				AllocateCapacityForEachVol: thickVolumes,
-				// compression not allowed with thick volumes
-				NoCompression: thickVolumes,
-			},
-		}
-
-		if len(optionalPayload) > 0 {
-			hostLimit, ok := optionalPayload["hostLimits"]
-			if ok {
-				sloParams[0].SetHostIOLimitsParam = hostLimit.(*types.SetHostIOLimitsParam)
-			}
-			snapshotPolicies, _ = optionalPayload["snapshotPolicies"].([]string)
-		}
-	}
-	createStorageGroupParam := &types.CreateStorageGroupParam{
-		StorageGroupID:            storageGroupID,
-		SRPID:                     srpID,
-		Emulation:                 Emulation,
-		ExecutionOption:           types.ExecutionOptionSynchronous,
-		SLOBasedStorageGroupParam: sloParams,
-		SnapshotPolicies:          snapshotPolicies,
-	}
-	return createStorageGroupParam
+func (c *Client) GetCreateStorageGroupPayload(storageGroupID, srpID, serviceLevel string, thickVolumes bool, optionalPayload map[string]interface{}) interface{} {
+	return payload.CreateStorageGroup(storageGroupID, srpID, serviceLevel, thickVolumes, optionalPayload)
 }
 
 // CreateStorageGroup creates a Storage Group given the storageGroupID (name), srpID (storage resource pool), service level, and boolean for thick volumes.
@@ -396,8 +575,15 @@ func (c *Client) CreateStorageGroup(ctx context.Context, symID, storageGroupID,
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.validateName(StorageGroupKind, storageGroupID); err != nil {
+		return nil, err
+	}
 	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XStorageGroup
 	payload := c.GetCreateStorageGroupPayload(storageGroupID, srpID, serviceLevel, thickVolumes, optionalPayload)
+	if err := c.setStorageGroupThinPool(ctx, symID, payload); err != nil {
+		log.Error("CreateStorageGroup failed: " + err.Error())
+		return nil, err
+	}
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
 	resp, err := c.api.DoAndGetResponseBody(
@@ -436,6 +622,150 @@ func (c *Client) DeleteStorageGroup(ctx context.Context, symID string, storageGr
 	return nil
 }
 
+// DefaultCascadeRetryBackoff is the wait before the first retry of the delete of DeleteStorageGroupCascade
+// when DeleteStorageGroupCascadeOptions.RetryBackoff is not set
+const DefaultCascadeRetryBackoff = time.Second
+
+// DeleteStorageGroupCascade deletes a storage group after optionally deleting its unlinked snapshots,
+// removing its volumes and removing it from its parent storage groups. Snapshots are handled before the
+// volumes, as a storage group snapshot can only be found through the volumes of the storage group.
+// The final delete is retried with an exponential back-off as the array can report the storage group
+// as busy for a short time after its volumes have been removed; other errors are returned right away.
+// In dry-run mode, of the options or of the client, nothing is modified and the returned report lists the
+// actions that would be taken. In safe mode, the delete is refused before anything is modified.
+func (c *Client) DeleteStorageGroupCascade(ctx context.Context, symID string, storageGroupID string, opts types.DeleteStorageGroupCascadeOptions) (*types.DeleteStorageGroupCascadeReport, error) {
+	defer c.TimeSpent("DeleteStorageGroupCascade", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	report := &types.DeleteStorageGroupCascadeReport{
+		StorageGroupID: storageGroupID,
+		DryRun:         opts.DryRun,
+	}
+	sg, err := c.GetStorageGroup(ctx, symID, storageGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if len(sg.MaskingView) > 0 {
+		return nil, fmt.Errorf("storage group %s is part of masking view(s) %v and cannot be deleted", storageGroupID, sg.MaskingView)
+	}
+	// the storage group is only stripped if it can then be deleted
+	if !opts.DryRun {
+		URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XStorageGroup + "/" + storageGroupID
+		if err = c.api.CheckRequest(ctx, http.MethodDelete, URL); api.IsDryRunError(err) {
+			opts.DryRun, report.DryRun = true, true
+		} else if err != nil {
+			log.Error("DeleteStorageGroupCascade failed: " + err.Error())
+			return nil, err
+		}
+	}
+
+	if opts.DeleteSnapshots {
+		snapshots, err := c.GetStorageGroupSnapshots(ctx, symID, storageGroupID, false, false)
+		if err != nil {
+			return report, err
+		}
+		for _, snapshotName := range snapshots.Name {
+			snapIDs, err := c.GetStorageGroupSnapshotSnapIDs(ctx, symID, storageGroupID, snapshotName)
+			if err != nil {
+				return report, err
+			}
+			for _, id := range snapIDs.SnapIDs {
+				ref := types.StorageGroupSnapshotRef{SnapshotName: snapshotName, SnapID: strconv.FormatInt(id, 10)}
+				snap, err := c.GetStorageGroupSnapshotSnap(ctx, symID, storageGroupID, snapshotName, ref.SnapID)
+				if err != nil {
+					return report, err
+				}
+				if snap.Linked {
+					report.SkippedSnapshots = append(report.SkippedSnapshots, ref)
+					continue
+				}
+				if !opts.DryRun {
+					if err = c.DeleteStorageGroupSnapshot(ctx, symID, storageGroupID, snapshotName, ref.SnapID); err != nil {
+						return report, err
+					}
+				}
+				report.DeletedSnapshots = append(report.DeletedSnapshots, ref)
+			}
+		}
+	}
+
+	if opts.RemoveVolumes {
+		volumeIDs, err := c.GetVolumeIDListInStorageGroup(ctx, symID, storageGroupID)
+		if err != nil {
+			return report, err
+		}
+		if len(volumeIDs) > 0 && !opts.DryRun {
+			if _, err = c.RemoveVolumesFromStorageGroup(ctx, symID, storageGroupID, true, volumeIDs...); err != nil {
+				return report, err
+			}
+		}
+		report.RemovedVolumes = volumeIDs
+	}
+
+	if opts.RemoveFromParents {
+		for _, parentID := range sg.ParentStorageGroup {
+			if !opts.DryRun {
+				payload := &types.UpdateStorageGroupPayload{
+					EditStorageGroupActionParam: types.EditStorageGroupActionParam{
+						RemoveStorageGroupParam: &types.RemoveStorageGroupParam{
+							StorageGroupIDs: []string{storageGroupID},
+							Force:           true,
+						},
+					},
+					ExecutionOption: types.ExecutionOptionSynchronous,
+				}
+				if err = c.UpdateStorageGroupS(ctx, symID, parentID, payload); err != nil {
+					return report, err
+				}
+			}
+			report.RemovedFromParents = append(report.RemovedFromParents, parentID)
+		}
+	}
+
+	if opts.DryRun {
+		log.Info(fmt.Sprintf("Dry run of cascading delete of SG %s: %+v", storageGroupID, report))
+		return report, nil
+	}
+
+	backoff := opts.RetryBackoff
+	if backoff <= 0 {
+		backoff = DefaultCascadeRetryBackoff
+	}
+	for {
+		report.DeleteAttempts++
+		err = c.DeleteStorageGroup(ctx, symID, storageGroupID)
+		if err == nil {
+			report.StorageGroupDeleted = true
+			return report, nil
+		}
+		if report.DeleteAttempts > opts.MaxRetries || !isStorageGroupBusy(err) {
+			return report, err
+		}
+		log.Debugf("retrying delete of SG %s in %v: %s", storageGroupID, backoff, err.Error())
+		select {
+		case <-ctx.Done():
+			return report, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// isStorageGroupBusy returns true for the errors of a delete which can succeed later: the storage group is busy,
+// or Unisphere is temporarily unavailable
+func isStorageGroupBusy(err error) bool {
+	var apiErr *types.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.HTTPStatusCode {
+	case http.StatusConflict, http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
 // DeleteMaskingView deletes a storage group
 func (c *Client) DeleteMaskingView(ctx context.Context, symID string, maskingViewID string) error {
 	defer c.TimeSpent("DeleteMaskingView", time.Now())
@@ -455,11 +785,49 @@ func (c *Client) DeleteMaskingView(ctx context.Context, symID string, maskingVie
 }
 
 // GetStorageGroup returns a StorageGroup given the Symmetrix ID and Storage Group ID (which is really a name).
-func (c *Client) GetStorageGroup(ctx context.Context, symID string, storageGroupID string) (*types.StorageGroup, error) {
+// With the IncludeVolumes option, the details of its volumes are returned in StorageGroup.Volumes, see GetStorageGroupOptions.
+func (c *Client) GetStorageGroup(ctx context.Context, symID string, storageGroupID string, opts ...types.GetStorageGroupOptions) (*types.StorageGroup, error) {
 	defer c.TimeSpent("GetStorageGroup", time.Now())
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	includeVolumes := false
+	for _, opt := range opts {
+		includeVolumes = includeVolumes || opt.IncludeVolumes
+	}
+	if !includeVolumes {
+		return c.getStorageGroup(ctx, symID, storageGroupID)
+	}
+
+	// the volumes are read after the storage group, so they are read again if the storage group changed meanwhile
+	var storageGroup *types.StorageGroup
+	for attempt := 1; ; attempt++ {
+		sg, err := c.getStorageGroup(ctx, symID, storageGroupID)
+		if err != nil {
+			return nil, err
+		}
+		volumes, err := c.GetVolumeDetailList(ctx, symID, map[string]string{"storageGroupId": url.QueryEscape(storageGroupID)})
+		if err != nil {
+			return nil, err
+		}
+		if volumes == nil {
+			volumes = []types.VolumeDetail{}
+		}
+		sg.Volumes = volumes
+		storageGroup = sg
+		if len(volumes) == sg.NumOfVolumes {
+			break
+		}
+		if attempt == MaxStorageGroupReadAttempts {
+			log.Warn(fmt.Sprintf("Storage group %s has %d volumes but %d were listed, it is being changed", storageGroupID, sg.NumOfVolumes, len(volumes)))
+			break
+		}
+	}
+	return storageGroup, nil
+}
+
+// getStorageGroup returns a StorageGroup, without its volumes
+func (c *Client) getStorageGroup(ctx context.Context, symID string, storageGroupID string) (*types.StorageGroup, error) {
 	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XStorageGroup + "/" + storageGroupID
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
@@ -492,6 +860,9 @@ func (c *Client) GetStorageGroupSnapshotPolicy(ctx context.Context, symID, snaps
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.verifySupport(ctx, symID, "GetStorageGroupSnapshotPolicy"); err != nil {
+		return nil, err
+	}
 
 	URL := c.urlPrefix() + Replication + SymmetrixX + symID + SnapshotPolicy + "/" + snapshotPolicyID + XStorageGroup + "/" + storageGroupID
 	ctx, cancel := c.GetTimeoutContext(ctx)
@@ -525,6 +896,102 @@ func (c *Client) GetStoragePool(ctx context.Context, symID string, storagePoolID
 	return storagePool, nil
 }
 
+// ModifyStoragePool changes the reserved capacity, SRDF/A DSE usage or description of a storage pool
+func (c *Client) ModifyStoragePool(ctx context.Context, symID string, storagePoolID string, param *types.ModifyStoragePoolParam) (*types.StoragePool, error) {
+	defer c.TimeSpent("ModifyStoragePool", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	if param == nil {
+		return nil, fmt.Errorf("nothing to modify on storage pool %s", storagePoolID)
+	}
+	if param.ReservedCapPercent != nil && (*param.ReservedCapPercent < 0 || *param.ReservedCapPercent > 80) {
+		return nil, fmt.Errorf("reserved capacity of storage pool %s must be between 0 and 80 percent", storagePoolID)
+	}
+	payload := &types.UpdateStoragePoolParam{
+		EditStoragePoolActionParam: types.EditStoragePoolActionParam{ModifyStoragePoolParam: param},
+		ExecutionOption:            types.ExecutionOptionSynchronous,
+	}
+	ifDebugLogPayload(payload)
+	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + "/" + StorageResourcePool + "/" + storagePoolID
+	storagePool := &types.StoragePool{}
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	err := c.api.Put(ctx, URL, c.getDefaultHeaders(), payload, storagePool)
+	if err != nil {
+		log.Error("ModifyStoragePool failed: " + err.Error())
+		return nil, err
+	}
+	log.Info(fmt.Sprintf("Successfully modified storage pool: %s", storagePoolID))
+	return storagePool, nil
+}
+
+// ServiceLevelLatencyBands are the expected average response times of the service levels, by base service level.
+// Optimized has no expected response time.
+var ServiceLevelLatencyBands = map[string]types.LatencyBand{
+	"Diamond":  {MaxResponseTime: 600 * time.Microsecond},
+	"Platinum": {MinResponseTime: 600 * time.Microsecond, MaxResponseTime: 800 * time.Microsecond},
+	"Gold":     {MinResponseTime: 800 * time.Microsecond, MaxResponseTime: time.Millisecond},
+	"Silver":   {MinResponseTime: time.Millisecond, MaxResponseTime: 3600 * time.Microsecond},
+	"Bronze":   {MinResponseTime: 3600 * time.Microsecond, MaxResponseTime: 7200 * time.Microsecond},
+}
+
+// GetServiceLevelList returns the IDs of the service levels of the array
+func (c *Client) GetServiceLevelList(ctx context.Context, symID string) (*types.ServiceLevelList, error) {
+	defer c.TimeSpent("GetServiceLevelList", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XSLO
+	serviceLevels := &types.ServiceLevelList{}
+	if err := c.getWithTimeout(ctx, URL, serviceLevels); err != nil {
+		log.Error("GetServiceLevelList failed: " + err.Error())
+		return nil, err
+	}
+	return serviceLevels, nil
+}
+
+// GetServiceLevel returns a service level of the array, with the latency band expected from its base service level
+func (c *Client) GetServiceLevel(ctx context.Context, symID, serviceLevelID string) (*types.ServiceLevel, error) {
+	defer c.TimeSpent("GetServiceLevel", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XSLO + "/" + url.PathEscape(serviceLevelID)
+	serviceLevel := &types.ServiceLevel{}
+	if err := c.getWithTimeout(ctx, URL, serviceLevel); err != nil {
+		log.Error("GetServiceLevel failed: " + err.Error())
+		return nil, err
+	}
+	baseID := serviceLevel.BaseServiceLevelID
+	if baseID == "" {
+		baseID = serviceLevel.ServiceLevelID
+	}
+	if band, ok := ServiceLevelLatencyBands[baseID]; ok {
+		serviceLevel.LatencyBand = &band
+	}
+	return serviceLevel, nil
+}
+
+// GetStoragePoolServiceLevels returns the service levels available in a storage pool, with their expected latency bands,
+// in the order the storage pool lists them
+func (c *Client) GetStoragePoolServiceLevels(ctx context.Context, symID, storagePoolID string) ([]types.ServiceLevel, error) {
+	defer c.TimeSpent("GetStoragePoolServiceLevels", time.Now())
+	storagePool, err := c.GetStoragePool(ctx, symID, storagePoolID)
+	if err != nil {
+		return nil, err
+	}
+	serviceLevels := make([]types.ServiceLevel, 0, len(storagePool.ServiceLevels))
+	for _, serviceLevelID := range storagePool.ServiceLevels {
+		serviceLevel, err := c.GetServiceLevel(ctx, symID, serviceLevelID)
+		if err != nil {
+			return nil, err
+		}
+		serviceLevels = append(serviceLevels, *serviceLevel)
+	}
+	return serviceLevels, nil
+}
+
 // UpdateStorageGroup is a general method to update a StorageGroup (PUT operation) using a UpdateStorageGroupPayload.
 func (c *Client) UpdateStorageGroup(ctx context.Context, symID string, storageGroupID string, payload interface{}) (*types.Job, error) {
 	defer c.TimeSpent("UpdateStorageGroup", time.Now())
@@ -582,6 +1049,58 @@ func ifDebugLogPayload(payload interface{}) {
 	}
 }
 
+// ModifyStorageGroupSLO changes the service level of a storage group, waiting on the job to complete
+func (c *Client) ModifyStorageGroupSLO(ctx context.Context, symID, storageGroupID, sloID string) error {
+	defer c.TimeSpent("ModifyStorageGroupSLO", time.Now())
+	return c.modifyStorageGroupAsync(ctx, symID, storageGroupID, types.EditStorageGroupActionParam{
+		EditStorageGroupSLOParam: &types.EditStorageGroupSLOParam{SLOID: sloID},
+	})
+}
+
+// ModifyStorageGroupWorkload changes the workload of a storage group, waiting on the job to complete
+func (c *Client) ModifyStorageGroupWorkload(ctx context.Context, symID, storageGroupID, workload string) error {
+	defer c.TimeSpent("ModifyStorageGroupWorkload", time.Now())
+	return c.modifyStorageGroupAsync(ctx, symID, storageGroupID, types.EditStorageGroupActionParam{
+		EditStorageGroupWorkloadParam: &types.EditStorageGroupWorkloadParam{WorkloadSelection: workload},
+	})
+}
+
+// ModifyStorageGroupSRP changes the storage resource pool of a storage group, waiting on the job to complete
+func (c *Client) ModifyStorageGroupSRP(ctx context.Context, symID, storageGroupID, srpID string) error {
+	defer c.TimeSpent("ModifyStorageGroupSRP", time.Now())
+	return c.modifyStorageGroupAsync(ctx, symID, storageGroupID, types.EditStorageGroupActionParam{
+		EditStorageGroupSRPParam: &types.EditStorageGroupSRPParam{SRPID: srpID},
+	})
+}
+
+// modifyStorageGroupAsync submits an edit action on a storage group as a job and waits on its completion
+func (c *Client) modifyStorageGroupAsync(ctx context.Context, symID, storageGroupID string, action types.EditStorageGroupActionParam) error {
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return err
+	}
+	payload := &types.UpdateStorageGroupPayload{
+		EditStorageGroupActionParam: action,
+		ExecutionOption:             types.ExecutionOptionAsynchronous,
+	}
+	ifDebugLogPayload(payload)
+	job, err := c.UpdateStorageGroup(ctx, symID, storageGroupID, payload)
+	if err != nil {
+		return err
+	}
+	if job == nil || job.JobID == "" {
+		return fmt.Errorf("A job was not returned from UpdateStorageGroup")
+	}
+	job, err = c.WaitOnJobCompletion(ctx, symID, job.JobID)
+	if err != nil {
+		return err
+	}
+	if job.Status == types.JobStatusFailed {
+		return fmt.Errorf("error: UpdateStorageGroup job failed: %s", c.JobToString(job))
+	}
+	log.Info(fmt.Sprintf("Successfully modified SG: %s", storageGroupID))
+	return nil
+}
+
 // CreateVolumeInStorageGroup creates a volume in the specified Storage Group with a given volumeName
 // and the size of the volume in cylinders.
 func (c *Client) CreateVolumeInStorageGroup(ctx context.Context, symID string, storageGroupID string, volumeName string, volumeSize interface{}, volOpts map[string]interface{}) (*types.Volume, error) {
@@ -630,28 +1149,128 @@ func (c *Client) CreateVolumeInStorageGroup(ctx context.Context, symID string, s
 	return volume, err
 }
 
-// GetVolumeByIdentifier on the given symmetrix in specific storage group with a volume name and having size in cylinders
-func (c *Client) GetVolumeByIdentifier(ctx context.Context, symID, storageGroupID string, volumeName string, volumeSize interface{}, capUnit string) (*types.Volume, error) {
-	var volSizeInCyl int
-	var volSizeInBytes float64
-	var err error
-	if valueInInt, isInt := volumeSize.(int); isInt {
-		volSizeInCyl = valueInInt
-	} else if valueInString, isString := volumeSize.(string); isString {
-		volSizeInBytes, err = strconv.ParseFloat(valueInString, 64)
-		if err != nil {
-			return nil, fmt.Errorf("couldn't parse volume size to uniquely identify the volume")
-		}
+// VolumeIdentifierAppendNumber is the volume identifier choice naming the volumes created together with
+// an identifier followed by a number, incremented for each volume
+const VolumeIdentifierAppendNumber = "identifier_name_plus_append_number"
+
+// CreateVolumesWithAppendNumber creates count volumes in the storage group, named identifierPrefix followed by
+// a number starting at startNumber, and returns the created volumes ordered by number. The volumes of the
+// storage group already named with the prefix are not returned, so the numbers actually assigned by the array
+// are reported even if some were already in use.
+func (c *Client) CreateVolumesWithAppendNumber(ctx context.Context, symID, storageGroupID, identifierPrefix string, count, startNumber int, volumeSize interface{}, capUnit string) ([]types.NumberedVolume, error) {
+	defer c.TimeSpent("CreateVolumesWithAppendNumber", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
 	}
-	volIDList, err := c.GetVolumeIDList(ctx, symID, volumeName, false)
+	if count <= 0 || startNumber < 0 {
+		return nil, fmt.Errorf("invalid number of volumes %d or start number %d", count, startNumber)
+	}
+	if len(identifierPrefix)+len(strconv.Itoa(startNumber+count-1)) > MaxVolIdentifierLength {
+		return nil, fmt.Errorf("Length of volumeName exceeds max limit")
+	}
+	existing, err := c.GetVolumesByIdentifierRange(ctx, symID, storageGroupID, identifierPrefix, 0, math.MaxInt)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't get Volume ID List: %s", err.Error())
+		return nil, err
 	}
-	if len(volIDList) > 1 {
-		log.Warning("Found multiple volumes matching the identifier " + volumeName)
+
+	payload := c.GetCreateVolInSGPayload(volumeSize, capUnit, identifierPrefix, true, false, "", "").(*types.UpdateStorageGroupPayload)
+	attributes := &payload.EditStorageGroupActionParam.ExpandStorageGroupParam.AddVolumeParam.VolumeAttributes[0]
+	attributes.NumberOfVolumes = count
+	attributes.VolumeIdentifier.VolumeIdentifierChoice = VolumeIdentifierAppendNumber
+	attributes.VolumeIdentifier.AppendNumber = strconv.Itoa(startNumber)
+	if err = c.UpdateStorageGroupS(ctx, symID, storageGroupID, payload); err != nil {
+		return nil, fmt.Errorf("couldn't create volumes. error - %s", err.Error())
 	}
-	for _, volumeID := range volIDList {
-		vol, err := c.GetVolumeByID(ctx, symID, volumeID)
+
+	all, err := c.GetVolumesByIdentifierRange(ctx, symID, storageGroupID, identifierPrefix, 0, math.MaxInt)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(existing))
+	for _, vol := range existing {
+		known[vol.VolumeID] = true
+	}
+	created := make([]types.NumberedVolume, 0, count)
+	for _, vol := range all {
+		if !known[vol.VolumeID] {
+			created = append(created, vol)
+		}
+	}
+	if len(created) != count {
+		return created, fmt.Errorf("expected %d volumes named %s in SG %s but found %d", count, identifierPrefix, storageGroupID, len(created))
+	}
+	log.Info(fmt.Sprintf("Successfully created %d volumes named %s in SG %s", count, identifierPrefix, storageGroupID))
+	return created, nil
+}
+
+// GetVolumesByIdentifierRange returns the volumes named identifierPrefix followed by a number between from and to,
+// inclusive, optionally separated by an underscore, ordered by number. Only the volumes of the storage group are
+// returned if storageGroupID is not empty.
+func (c *Client) GetVolumesByIdentifierRange(ctx context.Context, symID, storageGroupID, identifierPrefix string, from, to int) ([]types.NumberedVolume, error) {
+	defer c.TimeSpent("GetVolumesByIdentifierRange", time.Now())
+	if identifierPrefix == "" {
+		return nil, fmt.Errorf("an identifier prefix has to be specified")
+	}
+	queryParams := map[string]string{"volume_identifier": url.QueryEscape("<like>" + identifierPrefix)}
+	if storageGroupID != "" {
+		queryParams["storageGroupId"] = url.QueryEscape(storageGroupID)
+	}
+	volumes, err := c.GetVolumeDetailList(ctx, symID, queryParams)
+	if err != nil {
+		return nil, err
+	}
+	numbered := make([]types.NumberedVolume, 0)
+	for _, vol := range volumes {
+		if !strings.HasPrefix(vol.VolumeIdentifier, identifierPrefix) {
+			continue
+		}
+		suffix := strings.TrimPrefix(vol.VolumeIdentifier[len(identifierPrefix):], "_")
+		if suffix == "" || strings.TrimLeft(suffix, "0123456789") != "" {
+			continue
+		}
+		number, err := strconv.Atoi(suffix)
+		if err != nil || number < from || number > to {
+			continue
+		}
+		if storageGroupID != "" && !slices.Contains(vol.StorageGroupIDList, storageGroupID) {
+			continue
+		}
+		numbered = append(numbered, types.NumberedVolume{Number: number, VolumeID: vol.VolumeID, VolumeIdentifier: vol.VolumeIdentifier})
+	}
+	sort.Slice(numbered, func(i, j int) bool {
+		if numbered[i].Number != numbered[j].Number {
+			return numbered[i].Number < numbered[j].Number
+		}
+		return numbered[i].VolumeID < numbered[j].VolumeID
+	})
+	return numbered, nil
+}
+
+// GetVolumeByIdentifier on the given symmetrix in specific storage group with a volume name and having size in cylinders
+func (c *Client) GetVolumeByIdentifier(ctx context.Context, symID, storageGroupID string, volumeName string, volumeSize interface{}, capUnit string) (*types.Volume, error) {
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	var volSizeInCyl int
+	var volSizeInBytes float64
+	var err error
+	if valueInInt, isInt := volumeSize.(int); isInt {
+		volSizeInCyl = valueInInt
+	} else if valueInString, isString := volumeSize.(string); isString {
+		volSizeInBytes, err = strconv.ParseFloat(valueInString, 64)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse volume size to uniquely identify the volume")
+		}
+	}
+	volIDList, err := c.GetVolumeIDList(ctx, symID, volumeName, false)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get Volume ID List: %s", err.Error())
+	}
+	if len(volIDList) > 1 {
+		log.Warning("Found multiple volumes matching the identifier " + volumeName)
+	}
+	for _, volumeID := range volIDList {
+		vol, err := c.GetVolumeByID(ctx, symID, volumeID)
 		if err == nil {
 			for _, sgID := range vol.StorageGroupIDList {
 				if sgID == storageGroupID {
@@ -752,6 +1371,9 @@ func (c *Client) CreateVolumeInProtectedStorageGroupS(ctx context.Context, symID
 
 // ExpandVolume expands an existing volume to a new (larger) size in CYL
 func (c *Client) ExpandVolume(ctx context.Context, symID string, volumeID string, rdfGNo int, volumeSize interface{}, capUnits ...string) (*types.Volume, error) {
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
 	var size string
 	capUnit := "CYL"
 	if len(capUnits) > 0 {
@@ -802,6 +1424,18 @@ func (c *Client) AddVolumesToStorageGroup(ctx context.Context, symID, storageGro
 	if len(volumeIDs) == 0 {
 		return fmt.Errorf("At least one volume id has to be specified")
 	}
+	if c.coalescer.enabled(ctx) {
+		key := storageGroupEdit{operation: "AddVolumesToStorageGroup", symID: symID, storageGroupID: storageGroupID, force: force}
+		_, err := c.coalescer.do(ctx, key, volumeIDs, func(ctx context.Context, volumeIDs []string) (*types.StorageGroup, error) {
+			return nil, c.addVolumesToStorageGroup(ctx, symID, storageGroupID, force, volumeIDs...)
+		})
+		return err
+	}
+	return c.addVolumesToStorageGroup(ctx, symID, storageGroupID, force, volumeIDs...)
+}
+
+// addVolumesToStorageGroup sends the edit adding the volumes to the storage group and waits for its job
+func (c *Client) addVolumesToStorageGroup(ctx context.Context, symID, storageGroupID string, force bool, volumeIDs ...string) error {
 	payload := c.GetAddVolumeToSGPayload(false, force, "", "", volumeIDs...)
 	job, err := c.UpdateStorageGroup(ctx, symID, storageGroupID, payload)
 	if err != nil || job == nil {
@@ -829,6 +1463,18 @@ func (c *Client) AddVolumesToStorageGroupS(ctx context.Context, symID, storageGr
 	if len(volumeIDs) == 0 {
 		return fmt.Errorf("at least one volume id has to be specified")
 	}
+	if c.coalescer.enabled(ctx) {
+		key := storageGroupEdit{operation: "AddVolumesToStorageGroupS", symID: symID, storageGroupID: storageGroupID, force: force}
+		_, err := c.coalescer.do(ctx, key, volumeIDs, func(ctx context.Context, volumeIDs []string) (*types.StorageGroup, error) {
+			return nil, c.addVolumesToStorageGroupS(ctx, symID, storageGroupID, force, volumeIDs...)
+		})
+		return err
+	}
+	return c.addVolumesToStorageGroupS(ctx, symID, storageGroupID, force, volumeIDs...)
+}
+
+// addVolumesToStorageGroupS sends the synchronous edit adding the volumes to the storage group
+func (c *Client) addVolumesToStorageGroupS(ctx context.Context, symID, storageGroupID string, force bool, volumeIDs ...string) error {
 	payload := c.GetAddVolumeToSGPayload(true, force, "", "", volumeIDs...)
 	err := c.UpdateStorageGroupS(ctx, symID, storageGroupID, payload)
 	if err != nil {
@@ -865,6 +1511,17 @@ func (c *Client) RemoveVolumesFromStorageGroup(ctx context.Context, symID string
 	if len(volumeIDs) == 0 {
 		return nil, fmt.Errorf("at least one volume id has to be specified")
 	}
+	if c.coalescer.enabled(ctx) {
+		key := storageGroupEdit{operation: "RemoveVolumesFromStorageGroup", symID: symID, storageGroupID: storageGroupID, force: force}
+		return c.coalescer.do(ctx, key, volumeIDs, func(ctx context.Context, volumeIDs []string) (*types.StorageGroup, error) {
+			return c.removeVolumesFromStorageGroup(ctx, symID, storageGroupID, force, volumeIDs...)
+		})
+	}
+	return c.removeVolumesFromStorageGroup(ctx, symID, storageGroupID, force, volumeIDs...)
+}
+
+// removeVolumesFromStorageGroup sends the edit removing the volumes from the storage group
+func (c *Client) removeVolumesFromStorageGroup(ctx context.Context, symID string, storageGroupID string, force bool, volumeIDs ...string) (*types.StorageGroup, error) {
 	payload := c.GetRemoveVolumeFromSGPayload(force, "", "", volumeIDs...)
 	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XStorageGroup + "/" + storageGroupID
 	fields := map[string]interface{}{
@@ -915,52 +1572,11 @@ func (c *Client) RemoveVolumesFromProtectedStorageGroup(ctx context.Context, sym
 
 // GetCreateVolInSGPayload returns payload for adding volume/s to SG.
 // if remoteSymID is passed then the payload includes RemoteSymmSGInfoParam.
-func (c *Client) GetCreateVolInSGPayload(volumeSize interface{}, capUnit string, volumeName string, isSync, enableMobility bool, remoteSymID, remoteStorageGroupID string, opts ...http.Header) (payload interface{}) {
-	var executionOption, size string
-	if val, isInt := volumeSize.(int); isInt {
-		size = strconv.Itoa(val)
-	} else if val, isString := volumeSize.(string); isString {
-		size = val
-	}
-	if isSync {
-		executionOption = types.ExecutionOptionSynchronous
-	} else {
-		executionOption = types.ExecutionOptionAsynchronous
-	}
-	addVolumeParam := &types.AddVolumeParam{
-		CreateNewVolumes: true,
-		Emulation:        "FBA",
-		EnableMobilityID: enableMobility,
-		VolumeAttributes: []types.VolumeAttributeType{
-			{
-				NumberOfVolumes: 1,
-				VolumeIdentifier: &types.VolumeIdentifierType{
-					VolumeIdentifierChoice: "identifier_name",
-					IdentifierName:         volumeName,
-				},
-				CapacityUnit: capUnit,
-				VolumeSize:   size,
-			},
-		},
-		RemoteSymmetrixSGInfo: types.RemoteSymmSGInfoParam{
-			Force: true,
-		},
-	}
-	if remoteSymID != "" {
-		addVolumeParam.RemoteSymmetrixSGInfo.RemoteSymmetrix1ID = remoteSymID
-		addVolumeParam.RemoteSymmetrixSGInfo.RemoteSymmetrix1SGs = []string{remoteStorageGroupID}
-	}
-	payload = &types.UpdateStorageGroupPayload{
-		EditStorageGroupActionParam: types.EditStorageGroupActionParam{
-			ExpandStorageGroupParam: &types.ExpandStorageGroupParam{
-				AddVolumeParam: addVolumeParam,
-			},
-		},
-		ExecutionOption: executionOption,
-	}
+func (c *Client) GetCreateVolInSGPayload(volumeSize interface{}, capUnit string, volumeName string, isSync, enableMobility bool, remoteSymID, remoteStorageGroupID string, opts ...http.Header) interface{} {
+	updatePayload := payload.CreateVolumeInStorageGroup(volumeSize, capUnit, volumeName, isSync, enableMobility, remoteSymID, remoteStorageGroupID)
 	if opts != nil && len(opts) != 0 {
 		// If the payload has a SetMetaData method, set the metadata headers.
-		if t, ok := interface{}(payload).(interface {
+		if t, ok := interface{}(updatePayload).(interface {
 			SetMetaData(metadata http.Header)
 		}); ok {
 			t.SetMetaData(opts[0])
@@ -968,66 +1584,22 @@ func (c *Client) GetCreateVolInSGPayload(volumeSize interface{}, capUnit string,
 			log.Println("warning: gopowermax.UpdateStorageGroupPayload: no SetMetaData method exists, consider updating gopowermax library.")
 		}
 	}
-	if payload != nil {
-		ifDebugLogPayload(payload)
-	}
-	return payload
+	ifDebugLogPayload(updatePayload)
+	return updatePayload
 }
 
 // GetAddVolumeToSGPayload returns payload for adding specific volume/s to SG.
-func (c *Client) GetAddVolumeToSGPayload(isSync, force bool, remoteSymID, remoteStorageGroupID string, volumeIDs ...string) (payload interface{}) {
-	executionOption := ""
-	if isSync {
-		executionOption = types.ExecutionOptionSynchronous
-	} else {
-		executionOption = types.ExecutionOptionAsynchronous
-	}
-	addSpecificVolumeParam := &types.AddSpecificVolumeParam{
-		VolumeIDs: volumeIDs,
-		RemoteSymmetrixSGInfo: types.RemoteSymmSGInfoParam{
-			Force: force,
-		},
-	}
-	if remoteSymID != "" {
-		addSpecificVolumeParam.RemoteSymmetrixSGInfo.RemoteSymmetrix1ID = remoteSymID
-		addSpecificVolumeParam.RemoteSymmetrixSGInfo.RemoteSymmetrix1SGs = []string{remoteStorageGroupID}
-	}
-	payload = &types.UpdateStorageGroupPayload{
-		EditStorageGroupActionParam: types.EditStorageGroupActionParam{
-			ExpandStorageGroupParam: &types.ExpandStorageGroupParam{
-				AddSpecificVolumeParam: addSpecificVolumeParam,
-			},
-		},
-		ExecutionOption: executionOption,
-	}
-	if payload != nil {
-		ifDebugLogPayload(payload)
-	}
-	return payload
+func (c *Client) GetAddVolumeToSGPayload(isSync, force bool, remoteSymID, remoteStorageGroupID string, volumeIDs ...string) interface{} {
+	updatePayload := payload.AddVolumesToStorageGroup(isSync, force, remoteSymID, remoteStorageGroupID, volumeIDs...)
+	ifDebugLogPayload(updatePayload)
+	return updatePayload
 }
 
 // GetRemoveVolumeFromSGPayload returns payload for removing volume/s from SG.
-func (c *Client) GetRemoveVolumeFromSGPayload(force bool, remoteSymID, remoteStorageGroupID string, volumeIDs ...string) (payload interface{}) {
-	removeVolumeParam := &types.RemoveVolumeParam{
-		VolumeIDs: volumeIDs,
-		RemoteSymmSGInfoParam: types.RemoteSymmSGInfoParam{
-			Force: force,
-		},
-	}
-	if remoteSymID != "" {
-		removeVolumeParam.RemoteSymmSGInfoParam.RemoteSymmetrix1ID = remoteSymID
-		removeVolumeParam.RemoteSymmSGInfoParam.RemoteSymmetrix1SGs = []string{remoteStorageGroupID}
-	}
-	payload = &types.UpdateStorageGroupPayload{
-		EditStorageGroupActionParam: types.EditStorageGroupActionParam{
-			RemoveVolumeParam: removeVolumeParam,
-		},
-		ExecutionOption: types.ExecutionOptionSynchronous,
-	}
-	if payload != nil {
-		ifDebugLogPayload(payload)
-	}
-	return payload
+func (c *Client) GetRemoveVolumeFromSGPayload(force bool, remoteSymID, remoteStorageGroupID string, volumeIDs ...string) interface{} {
+	updatePayload := payload.RemoveVolumesFromStorageGroup(force, remoteSymID, remoteStorageGroupID, volumeIDs...)
+	ifDebugLogPayload(updatePayload)
+	return updatePayload
 }
 
 // GetStoragePoolList returns a StoragePoolList object, which contains a list of all the Storage Pool names.
@@ -1089,14 +1661,84 @@ func (c *Client) RenameVolume(ctx context.Context, symID string, volumeID string
 	return volume, nil
 }
 
+// RelabelStorageGroupVolumes sets the identifiers of all the volumes of a storage group to identifierPrefix followed
+// by an index, starting at startIndex, in the order of the volume ids. E.g. the prefix "db_" names the volumes db_1,
+// db_2, etc. It is meant to restore the identifiers lost after a clone or a migration. The volumes are renamed in
+// parallel, those already named as expected are left unchanged, so that a failed relabel can be run again.
+// The outcome of every volume is returned, with an error if any volume could not be renamed.
+func (c *Client) RelabelStorageGroupVolumes(ctx context.Context, symID, storageGroupID, identifierPrefix string, startIndex int) ([]types.VolumeRelabel, error) {
+	defer c.TimeSpent("RelabelStorageGroupVolumes", time.Now())
+	if identifierPrefix == "" {
+		return nil, fmt.Errorf("an identifier prefix has to be specified")
+	}
+	if startIndex < 0 {
+		return nil, fmt.Errorf("the start index must not be negative")
+	}
+	volumes, err := c.GetStorageGroupVolumeList(ctx, symID, storageGroupID, types.VolumeListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if lastName := identifierPrefix + strconv.Itoa(startIndex+len(volumes)-1); len(lastName) > MaxVolIdentifierLength {
+		return nil, fmt.Errorf("volume identifier %s is longer than %d characters", lastName, MaxVolIdentifierLength)
+	}
+
+	relabels := make([]types.VolumeRelabel, len(volumes))
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, MaxVolumeRelabelWorkers)
+	)
+	for i, vol := range volumes {
+		name := identifierPrefix + strconv.Itoa(startIndex+i)
+		relabels[i] = types.VolumeRelabel{
+			VolumeID:          vol.VolumeID,
+			OldIdentifier:     vol.VolumeIdentifier,
+			VolumeIdentifier:  name,
+			AlreadyIdentified: vol.Error == "" && vol.VolumeIdentifier == name,
+		}
+		if relabels[i].AlreadyIdentified {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(relabel *types.VolumeRelabel) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if _, err := c.RenameVolume(ctx, symID, relabel.VolumeID, relabel.VolumeIdentifier); err != nil {
+				relabel.Error = err.Error()
+			}
+		}(&relabels[i])
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, relabel := range relabels {
+		if relabel.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return relabels, fmt.Errorf("%d of the %d volumes of storage group %s could not be relabeled", failed, len(relabels), storageGroupID)
+	}
+	log.Info(fmt.Sprintf("Relabeled the %d volumes of storage group %s with prefix %s", len(relabels), storageGroupID, identifierPrefix))
+	return relabels, nil
+}
+
 // DeleteVolume deletes a volume given the symmetrix ID and volume ID.
 // Any storage tracks for the volume must have been previously deallocated using InitiateDeallocationOfTracksFromVolume,
 // and the volume must not be a member of any Storage Group.
-func (c *Client) DeleteVolume(ctx context.Context, symID string, volumeID string) error {
+// If DeleteVolumeOptions are given, the volume is checked for the blockers which would make the deletion
+// fail, and a VolumeInUseError listing them is returned. With RemoveBlockers set, the removable blockers
+// are cleaned up instead, and a VolumeInUseError is only returned for the others.
+func (c *Client) DeleteVolume(ctx context.Context, symID string, volumeID string, opts ...types.DeleteVolumeOptions) error {
 	defer c.TimeSpent("DeleteVolume", time.Now())
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return err
 	}
+	if len(opts) > 0 && (opts[0].CheckBlockers || opts[0].RemoveBlockers) {
+		if err := c.checkVolumeBlockers(ctx, symID, volumeID, opts[0]); err != nil {
+			return err
+		}
+	}
 	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XVolume + "/" + volumeID
 	fields := map[string]interface{}{
 		http.MethodPut: URL,
@@ -1114,6 +1756,299 @@ func (c *Client) DeleteVolume(ctx context.Context, symID string, volumeID string
 	return err
 }
 
+// DefaultDeallocationPollInterval is how often DeleteVolumeWithDeallocate checks the deallocation when no interval is given
+const DefaultDeallocationPollInterval = 5 * time.Second
+
+// DefaultDeallocationTimeout is how long DeleteVolumeWithDeallocate waits for the deallocation when ctx has no deadline
+const DefaultDeallocationTimeout = time.Hour
+
+// DeleteVolumeWithDeallocate deletes a volume in two phases: its tracks are freed first, then, once the
+// deallocation job has succeeded and no track is allocated anymore, the volume is deleted. Deleting a large
+// thin volume right away often fails because its tracks are still allocated.
+// The deallocation is checked every pollInterval, and progress, if not nil, is called after every check.
+// It stops, leaving the volume in place, when the deallocation job fails or is in an unknown state, or when ctx
+// is done, or after DefaultDeallocationTimeout if ctx has no deadline.
+func (c *Client) DeleteVolumeWithDeallocate(ctx context.Context, symID string, volumeID string, pollInterval time.Duration, progress func(types.DeallocationProgress)) error {
+	defer c.TimeSpent("DeleteVolumeWithDeallocate", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return err
+	}
+	if pollInterval <= 0 {
+		pollInterval = DefaultDeallocationPollInterval
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultDeallocationTimeout)
+		defer cancel()
+	}
+	vol, err := c.GetVolumeByID(ctx, symID, volumeID)
+	if err != nil {
+		return err
+	}
+	if vol.AllocatedPercent > 0 {
+		job, err := c.InitiateDeallocationOfTracksFromVolume(ctx, symID, volumeID)
+		if err != nil {
+			return err
+		}
+		status := types.DeallocationProgress{VolumeID: volumeID, JobID: job.JobID, JobStatus: job.Status, AllocatedPercent: vol.AllocatedPercent}
+		for status.JobStatus != types.JobStatusSucceeded || status.AllocatedPercent > 0 {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("deallocation of volume %s not completed, %d%% still allocated: %w", volumeID, status.AllocatedPercent, ctx.Err())
+			case <-time.After(pollInterval):
+			}
+			if status.JobStatus != types.JobStatusSucceeded {
+				if job, err = c.GetJobByID(ctx, symID, job.JobID); err != nil {
+					return err
+				}
+				status.JobStatus = job.Status
+				switch job.Status {
+				case types.JobStatusFailed:
+					return fmt.Errorf("deallocation of volume %s failed: %s", volumeID, job.Result)
+				case types.JobStatusUnscheduled, types.JobStatusScheduled, types.JobStatusRunning, types.JobStatusSucceeded:
+				default:
+					return fmt.Errorf("deallocation job %s of volume %s is in the unknown state %q", job.JobID, volumeID, job.Status)
+				}
+			}
+			if vol, err = c.GetVolumeByID(ctx, symID, volumeID); err != nil {
+				return err
+			}
+			status.AllocatedPercent = vol.AllocatedPercent
+			if progress != nil {
+				progress(status)
+			}
+		}
+		log.Info(fmt.Sprintf("Successfully deallocated volume: %s", volumeID))
+	}
+	return c.DeleteVolume(ctx, symID, volumeID)
+}
+
+// VolumeInUseError is returned by DeleteVolume when blockers prevent the volume from being deleted
+type VolumeInUseError struct {
+	SymmetrixID string
+	VolumeID    string
+	Blockers    []types.VolumeBlocker
+}
+
+func (e *VolumeInUseError) Error() string {
+	blockers := make([]string, 0, len(e.Blockers))
+	for _, b := range e.Blockers {
+		blockers = append(blockers, fmt.Sprintf("%s %s", b.Kind, b.Name))
+	}
+	return fmt.Sprintf("volume %s on array %s is in use: %s", e.VolumeID, e.SymmetrixID, strings.Join(blockers, ", "))
+}
+
+// IsVolumeInUseError returns true if the error is, or wraps, a VolumeInUseError
+func IsVolumeInUseError(err error) bool {
+	var inUse *VolumeInUseError
+	return errors.As(err, &inUse)
+}
+
+// GatekeeperMaxCylinders is the size up to which a volume is considered to be a gatekeeper by DeleteVolume
+// and GetStorageGroupVolumeList
+const GatekeeperMaxCylinders = 10
+
+// getVolumeBlockers returns the snapshots, RDF pairs, masking views and storage groups using a volume
+func (c *Client) getVolumeBlockers(ctx context.Context, symID string, volume *types.Volume, allowGatekeeper bool) ([]types.VolumeBlocker, error) {
+	var blockers []types.VolumeBlocker
+	if !allowGatekeeper && volume.CapacityCYL > 0 && volume.CapacityCYL <= GatekeeperMaxCylinders {
+		blockers = append(blockers, types.VolumeBlocker{Kind: types.VolumeBlockerGatekeeper, Name: volume.VolumeID})
+	}
+	for _, rdfGroup := range volume.RDFGroupIDList {
+		blockers = append(blockers, types.VolumeBlocker{Kind: types.VolumeBlockerRDFPair, Name: strconv.Itoa(rdfGroup.RDFGroupNumber)})
+	}
+	if volume.SnapSource || volume.SnapTarget {
+		snapInfo, err := c.GetVolumeSnapInfo(ctx, symID, volume.VolumeID)
+		if err != nil {
+			return nil, err
+		}
+		for _, link := range snapInfo.VolumeSnapshotLink {
+			blockers = append(blockers, types.VolumeBlocker{Kind: types.VolumeBlockerLinkedTarget, Name: link.LinkSource})
+		}
+		// newest generations last, so that removing them in order does not renumber the next ones
+		sources := snapInfo.VolumeSnapshotSource
+		sort.SliceStable(sources, func(i, j int) bool { return sources[i].Generation > sources[j].Generation })
+		for _, source := range sources {
+			blockers = append(blockers, types.VolumeBlocker{
+				Kind:       types.VolumeBlockerSnapshot,
+				Name:       source.SnapshotName,
+				Generation: source.Generation,
+				Removable:  len(source.LinkedVolumes) == 0,
+			})
+		}
+	}
+	for _, sgID := range volume.StorageGroupIDList {
+		sg, err := c.GetStorageGroup(ctx, symID, sgID)
+		if err != nil {
+			return nil, err
+		}
+		if sg.NumOfMaskingViews > 0 || len(sg.MaskingView) > 0 {
+			blockers = append(blockers, types.VolumeBlocker{Kind: types.VolumeBlockerMaskingView, Name: sgID})
+		} else {
+			blockers = append(blockers, types.VolumeBlocker{Kind: types.VolumeBlockerStorageGroup, Name: sgID, Removable: true})
+		}
+	}
+	return blockers, nil
+}
+
+// checkVolumeBlockers returns a VolumeInUseError if the volume cannot be deleted, after removing the
+// removable blockers if asked to
+func (c *Client) checkVolumeBlockers(ctx context.Context, symID string, volumeID string, opts types.DeleteVolumeOptions) error {
+	volume, err := c.GetVolumeByID(ctx, symID, volumeID)
+	if err != nil {
+		return err
+	}
+	blockers, err := c.getVolumeBlockers(ctx, symID, volume, opts.AllowGatekeeper)
+	if err != nil {
+		return err
+	}
+	var remaining []types.VolumeBlocker
+	for _, b := range blockers {
+		if !b.Removable || !opts.RemoveBlockers {
+			remaining = append(remaining, b)
+		}
+	}
+	if len(remaining) > 0 {
+		log.Error(fmt.Sprintf("Volume %s cannot be deleted, %d blockers found", volumeID, len(remaining)))
+		return &VolumeInUseError{SymmetrixID: symID, VolumeID: volumeID, Blockers: remaining}
+	}
+	for _, b := range blockers {
+		switch b.Kind {
+		case types.VolumeBlockerSnapshot:
+			err = c.DeleteSnapshot(ctx, symID, b.Name, []types.VolumeList{{Name: volumeID}}, b.Generation)
+		case types.VolumeBlockerStorageGroup:
+			_, err = c.RemoveVolumesFromStorageGroup(ctx, symID, b.Name, true, volumeID)
+		}
+		if err != nil {
+			log.Error(fmt.Sprintf("Removing %s %s from volume %s failed: %s", b.Kind, b.Name, volumeID, err.Error()))
+			return err
+		}
+		log.Info(fmt.Sprintf("Removed %s %s from volume %s", b.Kind, b.Name, volumeID))
+	}
+	return nil
+}
+
+// GetVolumePaths returns the masking views exposing a volume, through the storage groups holding it or their parents
+func (c *Client) GetVolumePaths(ctx context.Context, symID string, volumeID string) ([]types.VolumePath, error) {
+	defer c.TimeSpent("GetVolumePaths", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	unmaps, err := c.getVolumeUnmaps(ctx, symID, volumeID)
+	if err != nil {
+		log.Error("GetVolumePaths failed: " + err.Error())
+		return nil, err
+	}
+	var paths []types.VolumePath
+	for _, unmap := range unmaps {
+		paths = append(paths, unmap.Paths...)
+	}
+	return paths, nil
+}
+
+// UnmapVolume removes a volume from every storage group exposing it in a masking view, directly or through a parent
+// storage group, e.g. before the volume is repurposed. The storage groups which are not in a masking view are left.
+// The volume is not removed from a storage group, or from a child of a storage group, in a masking view when it is
+// the last volume of the storage group, as a masking view cannot be left empty; the removal is reported as blocked.
+// With UnmapVolumeOptions.DryRun, the removals are only listed. Every removal is attempted, and the outcome of each
+// is returned; an error is returned if any of them is blocked or failed.
+func (c *Client) UnmapVolume(ctx context.Context, symID string, volumeID string, opts ...types.UnmapVolumeOptions) ([]types.VolumeUnmap, error) {
+	defer c.TimeSpent("UnmapVolume", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	unmaps, err := c.getVolumeUnmaps(ctx, symID, volumeID)
+	if err != nil {
+		log.Error("UnmapVolume failed: " + err.Error())
+		return nil, err
+	}
+	dryRun := len(opts) > 0 && opts[0].DryRun
+	failed := 0
+	for i := range unmaps {
+		unmap := &unmaps[i]
+		if unmap.Blocked != "" {
+			failed++
+			continue
+		}
+		if dryRun {
+			continue
+		}
+		if _, err = c.RemoveVolumesFromStorageGroup(ctx, symID, unmap.StorageGroupID, true, volumeID); err != nil {
+			unmap.Error = err.Error()
+			failed++
+			continue
+		}
+		unmap.Removed = true
+		log.Info(fmt.Sprintf("Removed volume %s from storage group %s, unmapping it from %d masking views", volumeID, unmap.StorageGroupID, len(unmap.Paths)))
+	}
+	if failed > 0 {
+		return unmaps, fmt.Errorf("volume %s cannot be removed from %d of the %d storage groups exposing it", volumeID, failed, len(unmaps))
+	}
+	return unmaps, nil
+}
+
+// getVolumeUnmaps returns the removals of the volume from the storage groups exposing it, with their paths
+func (c *Client) getVolumeUnmaps(ctx context.Context, symID string, volumeID string) ([]types.VolumeUnmap, error) {
+	volume, err := c.GetVolumeByID(ctx, symID, volumeID)
+	if err != nil {
+		return nil, err
+	}
+	var unmaps []types.VolumeUnmap
+	for _, sgID := range volume.StorageGroupIDList {
+		sg, err := c.GetStorageGroup(ctx, symID, sgID)
+		if err != nil {
+			return nil, err
+		}
+		unmap := types.VolumeUnmap{StorageGroupID: sgID}
+		for _, mvID := range sg.MaskingView {
+			path, err := c.getVolumePath(ctx, symID, sgID, "", mvID)
+			if err != nil {
+				return nil, err
+			}
+			unmap.Paths = append(unmap.Paths, *path)
+			if sg.NumOfVolumes <= 1 {
+				unmap.Blocked = fmt.Sprintf("volume %s is the last volume of storage group %s in masking view %s", volumeID, sgID, mvID)
+			}
+		}
+		for _, parentID := range sg.ParentStorageGroup {
+			parent, err := c.GetStorageGroup(ctx, symID, parentID)
+			if err != nil {
+				return nil, err
+			}
+			for _, mvID := range parent.MaskingView {
+				path, err := c.getVolumePath(ctx, symID, sgID, parentID, mvID)
+				if err != nil {
+					return nil, err
+				}
+				unmap.Paths = append(unmap.Paths, *path)
+				if parent.NumOfVolumes <= 1 && unmap.Blocked == "" {
+					unmap.Blocked = fmt.Sprintf("volume %s is the last volume of storage group %s in masking view %s", volumeID, parentID, mvID)
+				}
+			}
+		}
+		if len(unmap.Paths) > 0 {
+			unmaps = append(unmaps, unmap)
+		}
+	}
+	return unmaps, nil
+}
+
+// getVolumePath returns the path of a masking view exposing the volumes of a storage group
+func (c *Client) getVolumePath(ctx context.Context, symID, storageGroupID, parentStorageGroupID, maskingViewID string) (*types.VolumePath, error) {
+	mv, err := c.GetMaskingViewByID(ctx, symID, maskingViewID)
+	if err != nil {
+		return nil, err
+	}
+	return &types.VolumePath{
+		StorageGroupID:       storageGroupID,
+		ParentStorageGroupID: parentStorageGroupID,
+		MaskingViewID:        maskingViewID,
+		HostID:               mv.HostID,
+		HostGroupID:          mv.HostGroupID,
+		PortGroupID:          mv.PortGroupID,
+	}, nil
+}
+
 // InitiateDeallocationOfTracksFromVolume is an asynchrnous operation (that returns a job) to remove tracks from a volume.
 func (c *Client) InitiateDeallocationOfTracksFromVolume(ctx context.Context, symID string, volumeID string) (*types.Job, error) {
 	defer c.TimeSpent("InitiateDeallocationOfTracksFromVolume", time.Now())
@@ -1138,6 +2073,7 @@ func (c *Client) InitiateDeallocationOfTracksFromVolume(ctx context.Context, sym
 		"VolumeID":     volumeID,
 	}
 	log.WithFields(fields).Info("Initiating track deletion...")
+	ctx = api.WithDestructiveOperation(ctx, "InitiateDeallocationOfTracksFromVolume")
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
 	err := c.api.Put(ctx, URL, c.getDefaultHeaders(), payload, job)
@@ -1148,6 +2084,114 @@ func (c *Client) InitiateDeallocationOfTracksFromVolume(ctx context.Context, sym
 	return job, nil
 }
 
+// FormatVolume starts formatting a volume, which erases all its data, and returns the asynchronous job doing it,
+// to be followed with WaitOnJobCompletion or GetJobByID. symForce is passed through to the array and is required
+// to format devices that are mapped or in use.
+func (c *Client) FormatVolume(ctx context.Context, symID string, volumeID string, symForce bool) (*types.Job, error) {
+	defer c.TimeSpent("FormatVolume", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	payload := &types.EditVolumeParam{
+		EditVolumeActionParam: types.EditVolumeActionParam{
+			FormatVolumeParam: &types.FormatVolumeParam{
+				Format:   true,
+				SymForce: symForce,
+			},
+		},
+		ExecutionOption: types.ExecutionOptionAsynchronous,
+	}
+	ifDebugLogPayload(payload)
+	job := &types.Job{}
+
+	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XVolume + "/" + volumeID
+	fields := map[string]interface{}{
+		http.MethodPut: URL,
+		"VolumeID":     volumeID,
+	}
+	log.WithFields(fields).Info("Formatting volume")
+	ctx = api.WithDestructiveOperation(ctx, "FormatVolume")
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	err := c.api.Put(ctx, URL, c.getDefaultHeaders(), payload, job)
+	if err != nil {
+		log.WithFields(fields).Error("Error in FormatVolume: " + err.Error())
+		return nil, err
+	}
+	return job, nil
+}
+
+// MigrateVolumesToSRP starts moving the data of the volumes to the targetSRP storage resource pool, one asynchronous
+// job per volume, and returns the IDs of the jobs, to be followed with GetSRPMigrationProgress.
+// If starting the migration of a volume fails, the IDs of the jobs already started are returned along with the error.
+func (c *Client) MigrateVolumesToSRP(ctx context.Context, symID string, volumeIDs []string, targetSRP string) ([]string, error) {
+	defer c.TimeSpent("MigrateVolumesToSRP", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	if len(volumeIDs) == 0 {
+		return nil, fmt.Errorf("no volume to migrate to %s", targetSRP)
+	}
+	if _, err := c.GetStoragePool(ctx, symID, targetSRP); err != nil {
+		return nil, err
+	}
+	jobIDs := make([]string, 0, len(volumeIDs))
+	for _, volumeID := range volumeIDs {
+		payload := &types.EditVolumeParam{
+			EditVolumeActionParam: types.EditVolumeActionParam{
+				MigrateVolumeParam: &types.MigrateVolumeParam{SRPID: targetSRP},
+			},
+			ExecutionOption: types.ExecutionOptionAsynchronous,
+		}
+		ifDebugLogPayload(payload)
+		job := &types.Job{}
+		URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XVolume + "/" + volumeID
+		fields := map[string]interface{}{
+			http.MethodPut: URL,
+			"VolumeID":     volumeID,
+			"TargetSRP":    targetSRP,
+		}
+		log.WithFields(fields).Info("Initiating volume migration...")
+		err := c.putWithTimeout(ctx, URL, payload, job)
+		if err != nil {
+			log.WithFields(fields).Error("Error in MigrateVolumesToSRP: " + err.Error())
+			return jobIDs, err
+		}
+		jobIDs = append(jobIDs, job.JobID)
+	}
+	return jobIDs, nil
+}
+
+// GetSRPMigrationProgress returns the progress of the jobs started by MigrateVolumesToSRP
+func (c *Client) GetSRPMigrationProgress(ctx context.Context, symID string, targetSRP string, jobIDs []string) (*types.SRPMigrationProgress, error) {
+	defer c.TimeSpent("GetSRPMigrationProgress", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	progress := &types.SRPMigrationProgress{TargetSRP: targetSRP, Jobs: make(map[string]string)}
+	for _, jobID := range jobIDs {
+		job, err := c.GetJobByID(ctx, symID, jobID)
+		if err != nil {
+			return nil, err
+		}
+		progress.Jobs[jobID] = job.Status
+		switch job.Status {
+		case types.JobStatusSucceeded:
+			progress.Succeeded++
+		case types.JobStatusFailed:
+			progress.Failed++
+			progress.Errors = append(progress.Errors, c.JobToString(job))
+		default:
+			progress.Running++
+		}
+	}
+	if len(jobIDs) > 0 {
+		progress.PercentComplete = (progress.Succeeded + progress.Failed) * 100 / len(jobIDs)
+	}
+	progress.Done = progress.Running == 0
+	return progress, nil
+}
+
 // GetPortGroupList returns a PortGroupList object, which contains a list of the Port Groups
 // which can be optionally filtered based on type
 func (c *Client) GetPortGroupList(ctx context.Context, symID string, portGroupType string) (*types.PortGroupList, error) {
@@ -1195,6 +2239,183 @@ func (c *Client) GetPortGroupByID(ctx context.Context, symID string, portGroupID
 	return portGroup, nil
 }
 
+// GetDirectorPortMembership returns the port groups containing the director port, e.g. FA-1D and 4,
+// and the masking views using these port groups, to be checked before taking the port offline.
+func (c *Client) GetDirectorPortMembership(ctx context.Context, symID string, directorID string, portID string) (*types.DirectorPortMembership, error) {
+	defer c.TimeSpent("GetDirectorPortMembership", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	if directorID == "" || portID == "" {
+		return nil, fmt.Errorf("director id and port id have to be specified")
+	}
+	query := url.Values{}
+	query.Set("dir_port", directorID+":"+portID)
+	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XPortGroup + "?" + query.Encode()
+	pgList := &types.PortGroupList{}
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	if err := c.api.Get(ctx, URL, c.getDefaultHeaders(), pgList); err != nil {
+		log.Error("GetDirectorPortMembership failed: " + err.Error())
+		return nil, err
+	}
+
+	membership := &types.DirectorPortMembership{
+		SymmetrixID:  symID,
+		DirectorID:   directorID,
+		PortID:       portID,
+		PortGroups:   []string{},
+		MaskingViews: []string{},
+	}
+	for _, portGroupID := range pgList.PortGroupIDs {
+		portGroup, err := c.GetPortGroupByID(ctx, symID, portGroupID)
+		if err != nil {
+			return nil, err
+		}
+		// the filter is not supported by every Unisphere release, so the ports are checked here too
+		for _, port := range portGroup.SymmetrixPortKey {
+			if strings.EqualFold(port.DirectorID, directorID) && port.PortID == portID {
+				membership.PortGroups = append(membership.PortGroups, portGroupID)
+				membership.MaskingViews = append(membership.MaskingViews, portGroup.MaskingView...)
+				break
+			}
+		}
+	}
+	sort.Strings(membership.MaskingViews)
+	log.Info(fmt.Sprintf("Director port %s:%s is in %d port groups and %d masking views", directorID, portID, len(membership.PortGroups), len(membership.MaskingViews)))
+	return membership, nil
+}
+
+// portTransportQueries are the port list queries selecting the front-end ports of each transport
+var portTransportQueries = map[string]string{
+	types.HostTypeFibre:   "type=FibreChannel",
+	types.HostTypeISCSI:   "iscsi_target=true",
+	types.HostTypeNVMeTCP: "nvmetcp_endpoint=true",
+}
+
+// PortUtilizationWindow is the period of performance data over which RecommendPortGroup averages the utilization of the ports
+const PortUtilizationWindow = time.Hour
+
+// RecommendPortGroup suggests count front-end ports of the transport (types.HostTypeFibre, types.HostTypeISCSI or
+// types.HostTypeNVMeTCP) for a new port group. The online ports are ranked on their average PercentBusy over the
+// last PortUtilizationWindow of performance data, then on the number of port groups, masking views and volumes
+// mapped to them; the ports are picked from the least loaded, one per director in turn, to spread the port group
+// over as many directors as possible. Ports whose utilization is not available are ranked after the others.
+func (c *Client) RecommendPortGroup(ctx context.Context, symID string, transport string, count int) (*types.PortGroupRecommendation, error) {
+	defer c.TimeSpent("RecommendPortGroup", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	query, ok := portTransportQueries[transport]
+	if !ok {
+		return nil, fmt.Errorf("transport %s is not supported, %s, %s or %s is expected", transport, types.HostTypeFibre, types.HostTypeISCSI, types.HostTypeNVMeTCP)
+	}
+	if count <= 0 {
+		return nil, fmt.Errorf("the number of ports has to be positive, got %d", count)
+	}
+	directors, err := c.GetDirectorIDList(ctx, symID)
+	if err != nil {
+		return nil, err
+	}
+	recommendation := &types.PortGroupRecommendation{
+		SymmetrixID: symID,
+		Transport:   transport,
+		Ports:       []types.PortKey{},
+		Candidates:  []types.PortCandidate{},
+	}
+	registration, err := c.GetPerformanceRegistration(ctx, symID)
+	if err != nil {
+		log.Warn(fmt.Sprintf("Port utilization of %s is not available: %s", symID, err.Error()))
+	} else {
+		recommendation.MetricsAvailable = registration.Registered && registration.LastAvailableDate > 0
+	}
+
+	for _, directorID := range directors.DirectorIDs {
+		ports, err := c.GetPortList(ctx, symID, directorID, query)
+		if err != nil {
+			// Ignore the error and continue
+			log.Errorf("Failed to get the %s ports of director: %s. Error: %s", transport, directorID, err.Error())
+			continue
+		}
+		for _, p := range ports.SymmetrixPortKey {
+			port, err := c.GetPort(ctx, symID, p.DirectorID, p.PortID)
+			if err != nil {
+				// Ignore the error and continue
+				log.Errorf("Failed to fetch port details for %s:%s. Error: %s", p.DirectorID, p.PortID, err.Error())
+				continue
+			}
+			if port.SymmetrixPort.PortStatus != "ON" || port.SymmetrixPort.DirectorStatus != "Online" {
+				continue
+			}
+			candidate := types.PortCandidate{
+				DirectorID:    p.DirectorID,
+				PortID:        p.PortID,
+				Identifier:    port.SymmetrixPort.Identifier,
+				PercentBusy:   -1,
+				PortGroups:    port.SymmetrixPort.NumOfPortGroups,
+				MaskingViews:  port.SymmetrixPort.NumOfMaskingViews,
+				MappedVolumes: port.SymmetrixPort.NumOfMappedVols,
+			}
+			if recommendation.MetricsAvailable {
+				end := registration.LastAvailableDate
+				metrics, err := c.GetFEPortMetrics(ctx, symID, p.DirectorID, p.PortID, []string{"PercentBusy"}, end-PortUtilizationWindow.Milliseconds(), end)
+				if err != nil {
+					log.Warn(fmt.Sprintf("Utilization of port %s:%s is not available: %s", p.DirectorID, p.PortID, err.Error()))
+				} else if results := metrics.ResultList.Result; len(results) > 0 {
+					var busy float64
+					for _, result := range results {
+						busy += result.PercentBusy
+					}
+					candidate.PercentBusy = busy / float64(len(results))
+				}
+			}
+			recommendation.Candidates = append(recommendation.Candidates, candidate)
+		}
+	}
+	if len(recommendation.Candidates) < count {
+		return nil, fmt.Errorf("only %d online %s ports are available on %s, %d requested", len(recommendation.Candidates), transport, symID, count)
+	}
+
+	sort.SliceStable(recommendation.Candidates, func(i, j int) bool {
+		return lessLoadedPort(recommendation.Candidates[i], recommendation.Candidates[j])
+	})
+	picked := make([]bool, len(recommendation.Candidates))
+	for len(recommendation.Ports) < count {
+		// every round picks the least loaded port left on each director
+		round := map[string]bool{}
+		for i, candidate := range recommendation.Candidates {
+			if len(recommendation.Ports) == count {
+				break
+			}
+			if picked[i] || round[candidate.DirectorID] {
+				continue
+			}
+			picked[i] = true
+			round[candidate.DirectorID] = true
+			recommendation.Ports = append(recommendation.Ports, types.PortKey{DirectorID: candidate.DirectorID, PortID: candidate.PortID})
+		}
+	}
+	log.Info(fmt.Sprintf("Recommended %d of the %d %s ports of %s", count, len(recommendation.Candidates), transport, symID))
+	return recommendation, nil
+}
+
+// lessLoadedPort returns true if port a is less loaded than port b
+func lessLoadedPort(a, b types.PortCandidate) bool {
+	if (a.PercentBusy < 0) != (b.PercentBusy < 0) {
+		return b.PercentBusy < 0
+	}
+	if a.PercentBusy != b.PercentBusy {
+		return a.PercentBusy < b.PercentBusy
+	}
+	if a.PortGroups != b.PortGroups {
+		return a.PortGroups < b.PortGroups
+	}
+	if a.MaskingViews != b.MaskingViews {
+		return a.MaskingViews < b.MaskingViews
+	}
+	return a.MappedVolumes < b.MappedVolumes
+}
+
 // GetInitiatorList returns an InitiatorList object, which contains a list of all the Initiators.
 // initiatorHBA, isISCSI, inHost are optional arguments which act as filters for the initiator list
 func (c *Client) GetInitiatorList(ctx context.Context, symID string, initiatorHBA string, isISCSI bool, inHost bool) (*types.InitiatorList, error) {
@@ -1288,6 +2509,85 @@ func (c *Client) GetHostByID(ctx context.Context, symID string, hostID string) (
 	return host, nil
 }
 
+// HostFlagPolicySelector returns the host flag policy a host has to comply with, e.g. from its name or initiators,
+// or nil if the host is not audited
+type HostFlagPolicySelector func(host *types.Host) *types.HostFlagPolicy
+
+// AuditHostFlags compares the flags of all the hosts of the array against the policy selectPolicy returns for them,
+// and reports the hosts whose flags drifted. A flag required enabled has to be enabled on the host, a flag not
+// overridden on the host follows the port and is reported as missing. A flag required disabled must not be enabled
+// on the host. The hosts which cannot be read are reported with their error.
+func (c *Client) AuditHostFlags(ctx context.Context, symID string, selectPolicy HostFlagPolicySelector) (*types.HostFlagDriftReport, error) {
+	defer c.TimeSpent("AuditHostFlags", time.Now())
+	if selectPolicy == nil {
+		return nil, fmt.Errorf("a host flag policy selector has to be specified")
+	}
+	hostList, err := c.GetHostList(ctx, symID)
+	if err != nil {
+		return nil, err
+	}
+	report := &types.HostFlagDriftReport{SymmetrixID: symID}
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, MaxHostAuditWorkers)
+	)
+	for _, hostID := range hostList.HostIDs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(hostID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			drift := types.HostFlagDrift{HostID: hostID}
+			host, err := c.GetHostByID(ctx, symID, hostID)
+			var policy *types.HostFlagPolicy
+			if err != nil {
+				drift.Error = err.Error()
+			} else if policy = selectPolicy(host); policy != nil {
+				drift.Policy = policy.Name
+				drift.MissingFlags, drift.UnexpectedFlags = hostFlagDrift(host, policy)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil && policy == nil:
+				report.HostsSkipped++
+				return
+			case err == nil:
+				report.HostsAudited++
+			}
+			if drift.Error != "" || len(drift.MissingFlags) > 0 || len(drift.UnexpectedFlags) > 0 {
+				report.Drifts = append(report.Drifts, drift)
+			}
+		}(hostID)
+	}
+	wg.Wait()
+	sort.Slice(report.Drifts, func(i, j int) bool { return report.Drifts[i].HostID < report.Drifts[j].HostID })
+	log.Info(fmt.Sprintf("Audited the flags of %d hosts on array %s, %d skipped, %d drifted or failed",
+		report.HostsAudited, symID, report.HostsSkipped, len(report.Drifts)))
+	return report, nil
+}
+
+// hostFlagDrift returns the flags the policy requires enabled which are not enabled on the host,
+// and the flags it requires disabled which are enabled on the host
+func hostFlagDrift(host *types.Host, policy *types.HostFlagPolicy) (missing, unexpected []string) {
+	enabled := strings.Split(host.EnabledFlags, ",")
+	isEnabled := func(flag string) bool {
+		return slices.ContainsFunc(enabled, func(f string) bool { return strings.EqualFold(strings.TrimSpace(f), flag) })
+	}
+	for _, flag := range policy.Enabled {
+		if !isEnabled(flag) {
+			missing = append(missing, flag)
+		}
+	}
+	for _, flag := range policy.Disabled {
+		if isEnabled(flag) {
+			unexpected = append(unexpected, flag)
+		}
+	}
+	return missing, unexpected
+}
+
 // CreateHost creates a host from a list of InitiatorIDs (and optional HostFlags) return returns a types.Host.
 // Initiator IDs do not contain the storage port designations, just the IQN string or FC WWN.
 // Initiator IDs cannot be a member of more than one host.
@@ -1296,6 +2596,15 @@ func (c *Client) CreateHost(ctx context.Context, symID string, hostID string, in
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.validateName(HostKind, hostID); err != nil {
+		return nil, err
+	}
+	if c.opts.validateInitiators {
+		if err := ValidateHostInitiators(initiatorIDs, c.opts.allowMixedInitiators); err != nil {
+			log.Error("CreateHost failed: " + err.Error())
+			return nil, err
+		}
+	}
 	hostParam := &types.CreateHostParam{
 		HostID:          hostID,
 		InitiatorIDs:    initiatorIDs,
@@ -1317,6 +2626,147 @@ func (c *Client) CreateHost(ctx context.Context, symID string, hostID string, in
 	return host, nil
 }
 
+// maxNQNLength is the maximum length of an NVMe Qualified Name, in bytes
+const maxNQNLength = 223
+
+var (
+	nqnRegex   = regexp.MustCompile(`^nqn\.[0-9]{4}-[0-9]{2}\.[A-Za-z0-9][A-Za-z0-9.-]*(:.+)?$`)
+	fcWWNRegex = regexp.MustCompile(`^(0x)?[0-9A-Fa-f]{16}$`)
+)
+
+// ValidateNQN returns an error if nqn is not a valid NVMe Qualified Name,
+// e.g. nqn.2014-08.org.nvmexpress:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6
+func ValidateNQN(nqn string) error {
+	if len(nqn) > maxNQNLength {
+		return fmt.Errorf("NQN %s is longer than %d bytes", nqn, maxNQNLength)
+	}
+	if !nqnRegex.MatchString(nqn) {
+		return fmt.Errorf("NQN %s is not of the form nqn.yyyy-mm.reverse.domain[:identifier]", nqn)
+	}
+	return nil
+}
+
+// InitiatorTransport returns the transport type, one of types.HostTypeFibre, types.HostTypeISCSI and
+// types.HostTypeNVMeTCP, of an initiator ID, with or without its director:port prefix, or an empty
+// string if it is not recognized
+func InitiatorTransport(initiatorID string) string {
+	switch {
+	case strings.Contains(initiatorID, "nqn."):
+		return types.HostTypeNVMeTCP
+	case strings.Contains(initiatorID, "iqn.") || strings.Contains(initiatorID, "eui."):
+		return types.HostTypeISCSI
+	}
+	parts := strings.Split(initiatorID, ":")
+	if fcWWNRegex.MatchString(parts[len(parts)-1]) {
+		return types.HostTypeFibre
+	}
+	return ""
+}
+
+// ValidateHostInitiators checks that the initiator IDs of a host are valid FC WWNs, iSCSI names or NQNs,
+// and, unless allowMixed is set for arrays supporting it, that they all use the same transport
+func ValidateHostInitiators(initiatorIDs []string, allowMixed bool) error {
+	transport := ""
+	for _, initiatorID := range initiatorIDs {
+		t := InitiatorTransport(initiatorID)
+		if t == "" {
+			return fmt.Errorf("initiator %s is not a FC WWN, iSCSI name or NQN", initiatorID)
+		}
+		if t == types.HostTypeNVMeTCP {
+			if err := ValidateNQN(initiatorID[strings.Index(initiatorID, "nqn."):]); err != nil {
+				return err
+			}
+		}
+		if transport != "" && t != transport && !allowMixed {
+			return fmt.Errorf("initiator %s is %s while the other initiators are %s, mixing transports is not allowed", initiatorID, t, transport)
+		}
+		transport = t
+	}
+	return nil
+}
+
+// GetNVMeInitiatorList returns the IDs of the NVMe initiators of the array, optionally only those in a host
+func (c *Client) GetNVMeInitiatorList(ctx context.Context, symID string, inHost bool) ([]string, error) {
+	defer c.TimeSpent("GetNVMeInitiatorList", time.Now())
+	initList, err := c.GetInitiatorList(ctx, symID, "", false, inHost)
+	if err != nil {
+		return nil, err
+	}
+	nvmeInitiators := make([]string, 0)
+	for _, initiatorID := range initList.InitiatorIDs {
+		if InitiatorTransport(initiatorID) == types.HostTypeNVMeTCP {
+			nvmeInitiators = append(nvmeInitiators, initiatorID)
+		}
+	}
+	return nvmeInitiators, nil
+}
+
+// GetHostIDListByInitiator returns the hosts and masking views of an initiator WWN, IQN or NQN. Only the initiator ids
+// of this initiator are looked up, using the initiator_hba filter, so that no host has to be listed. The lists are empty
+// if the initiator is not in a host.
+func (c *Client) GetHostIDListByInitiator(ctx context.Context, symID string, initiator string) (*types.InitiatorHosts, error) {
+	defer c.TimeSpent("GetHostIDListByInitiator", time.Now())
+	if initiator == "" {
+		return nil, fmt.Errorf("an initiator has to be specified")
+	}
+	initList, err := c.GetInitiatorList(ctx, symID, initiator, false, true)
+	if err != nil {
+		return nil, err
+	}
+	result := &types.InitiatorHosts{
+		Initiator:      initiator,
+		InitiatorIDs:   []string{},
+		HostIDs:        []string{},
+		MaskingViewIDs: []string{},
+	}
+	hosts := map[string]bool{}
+	maskingViews := map[string]bool{}
+	for _, initiatorID := range initList.InitiatorIDs {
+		// the filter may match initiators containing the given one on older Unisphere releases
+		if !strings.EqualFold(initiatorID, initiator) && !strings.HasSuffix(strings.ToLower(initiatorID), ":"+strings.ToLower(initiator)) {
+			continue
+		}
+		details, err := c.GetInitiatorByID(ctx, symID, initiatorID)
+		if err != nil {
+			return nil, err
+		}
+		result.InitiatorIDs = append(result.InitiatorIDs, initiatorID)
+		hostID := details.HostID
+		if hostID == "" {
+			hostID = details.Host
+		}
+		if hostID != "" && !hosts[hostID] {
+			hosts[hostID] = true
+			result.HostIDs = append(result.HostIDs, hostID)
+		}
+		for _, mv := range details.MaskingView {
+			if !maskingViews[mv] {
+				maskingViews[mv] = true
+				result.MaskingViewIDs = append(result.MaskingViewIDs, mv)
+			}
+		}
+	}
+	sort.Strings(result.InitiatorIDs)
+	sort.Strings(result.HostIDs)
+	sort.Strings(result.MaskingViewIDs)
+	return result, nil
+}
+
+// CreateNVMeHost creates a host from a list of host NQNs, which are validated before the host is created
+func (c *Client) CreateNVMeHost(ctx context.Context, symID string, hostID string, nqns []string, hostFlags *types.HostFlags) (*types.Host, error) {
+	defer c.TimeSpent("CreateNVMeHost", time.Now())
+	if len(nqns) == 0 {
+		return nil, fmt.Errorf("at least one NQN is needed to create host %s", hostID)
+	}
+	for _, nqn := range nqns {
+		if err := ValidateNQN(nqn); err != nil {
+			log.Error("CreateNVMeHost failed: " + err.Error())
+			return nil, err
+		}
+	}
+	return c.CreateHost(ctx, symID, hostID, nqns, hostFlags)
+}
+
 // UpdateHostFlags updates the host flags
 func (c *Client) UpdateHostFlags(ctx context.Context, symID string, hostID string, hostFlags *types.HostFlags) (*types.Host, error) {
 	defer c.TimeSpent("UpdateHostFlags", time.Now())
@@ -1346,6 +2796,70 @@ func (c *Client) UpdateHostFlags(ctx context.Context, symID string, hostID strin
 	return updatedHost, nil
 }
 
+// SetInitiatorPortFlags overrides the flags of the host of an initiator, given by its director:port:initiator id,
+// on the port of the initiator, e.g. for the hosts of a cluster running different operating systems.
+// The flags with Override set are overridden; the others keep the flags of the host.
+func (c *Client) SetInitiatorPortFlags(ctx context.Context, symID string, initID string, hostFlags *types.HostFlags) (*types.Initiator, error) {
+	defer c.TimeSpent("SetInitiatorPortFlags", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	if hostFlags == nil {
+		return nil, fmt.Errorf("hostFlags can't be nil")
+	}
+	initiatorParam := &types.UpdateInitiatorParam{
+		EditInitiatorAction: &types.EditInitiatorParams{
+			SetHostFlags: &types.SetHostFlags{
+				HostFlags: hostFlags,
+			},
+		},
+		ExecutionOption: types.ExecutionOptionSynchronous,
+	}
+	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XInitiator + "/" + initID
+	initiator := &types.Initiator{}
+	if err := c.putWithTimeout(ctx, URL, initiatorParam, initiator); err != nil {
+		log.Error("SetInitiatorPortFlags failed: " + err.Error())
+		return nil, err
+	}
+	log.Info(fmt.Sprintf("Successfully set the port flags of initiator %s", initID))
+	return initiator, nil
+}
+
+// GetInitiatorFlags returns the port flags set on an initiator and the flags in effect for it. On the arrays
+// not reporting the flags in effect, they are computed from the flags of the host of the initiator.
+func (c *Client) GetInitiatorFlags(ctx context.Context, symID string, initID string) (*types.InitiatorFlags, error) {
+	defer c.TimeSpent("GetInitiatorFlags", time.Now())
+	initiator, err := c.GetInitiatorByID(ctx, symID, initID)
+	if err != nil {
+		return nil, err
+	}
+	flags := &types.InitiatorFlags{
+		InitiatorID:       initiator.InitiatorID,
+		HostID:            initiator.Host,
+		PortFlagsOverride: initiator.PortFlagsOverride,
+		EnabledFlags:      splitHostFlags(initiator.EnabledFlags),
+		DisabledFlags:     splitHostFlags(initiator.DisabledFlags),
+		EffectiveFlags:    splitHostFlags(initiator.FlagsInEffect),
+	}
+	if initiator.FlagsInEffect != "" {
+		return flags, nil
+	}
+	var hostEnabled, hostDisabled []string
+	if initiator.Host != "" {
+		host, err := c.GetHostByID(ctx, symID, initiator.Host)
+		if err != nil {
+			return nil, err
+		}
+		hostEnabled, hostDisabled = splitHostFlags(host.EnabledFlags), splitHostFlags(host.DisabledFlags)
+	}
+	override := &types.Host{}
+	if initiator.PortFlagsOverride {
+		override.EnabledFlags, override.DisabledFlags = initiator.EnabledFlags, initiator.DisabledFlags
+	}
+	flags.EffectiveFlags, _ = inheritHostFlags(hostEnabled, hostDisabled, override)
+	return flags, nil
+}
+
 // UpdateHostInitiators updates a host from a list of InitiatorIDs and returns a types.Host.
 func (c *Client) UpdateHostInitiators(ctx context.Context, symID string, host *types.Host, initiatorIDs []string) (*types.Host, error) {
 	defer c.TimeSpent("UpdateHostInitiators", time.Now())
@@ -1355,11 +2869,49 @@ func (c *Client) UpdateHostInitiators(ctx context.Context, symID string, host *t
 	if host == nil {
 		return nil, fmt.Errorf("Host can't be nil")
 	}
-	initRemove := []string{}
-	initAdd := []string{}
 	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XHost + "/" + host.HostID
 	updatedHost := &types.Host{}
+	initAdd, initRemove := hostInitiatorChanges(host, initiatorIDs)
 
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	// add initiators if needed
+	if len(initAdd) > 0 {
+		hostParam := &types.UpdateHostAddInitiatorsParam{}
+		hostParam.EditHostAction = &types.AddHostInitiators{}
+		hostParam.EditHostAction.AddInitiator = &types.ChangeInitiatorParam{}
+		hostParam.EditHostAction.AddInitiator.Initiators = initAdd
+		hostParam.ExecutionOption = types.ExecutionOptionSynchronous
+
+		ifDebugLogPayload(hostParam)
+		err := c.api.Put(ctx, URL, c.getDefaultHeaders(), hostParam, updatedHost)
+		if err != nil {
+			log.Error("UpdateHostInitiators failed: " + err.Error())
+			return nil, err
+		}
+	}
+	// remove initiators if needed
+	if len(initRemove) > 0 {
+		hostParam := &types.UpdateHostRemoveInititorsParam{}
+		hostParam.EditHostAction = &types.RemoveHostInitiators{}
+		hostParam.EditHostAction.RemoveInitiator = &types.ChangeInitiatorParam{}
+		hostParam.EditHostAction.RemoveInitiator.Initiators = initRemove
+		hostParam.ExecutionOption = types.ExecutionOptionSynchronous
+
+		ifDebugLogPayload(hostParam)
+		err := c.api.Put(ctx, URL, c.getDefaultHeaders(), hostParam, updatedHost)
+		if err != nil {
+			log.Error("UpdateHostInitiators failed: " + err.Error())
+			return nil, err
+		}
+	}
+
+	return updatedHost, nil
+}
+
+// hostInitiatorChanges returns the initiators to add to and to remove from a host so that it has the initiators initiatorIDs
+func hostInitiatorChanges(host *types.Host, initiatorIDs []string) (initAdd []string, initRemove []string) {
+	initAdd, initRemove = []string{}, []string{}
 	// figure out which initiators are being added
 	for _, init := range initiatorIDs {
 		// if this initiator is not in the list of current initiators, add it
@@ -1373,70 +2925,124 @@ func (c *Client) UpdateHostInitiators(ctx context.Context, symID string, host *t
 			initRemove = append(initRemove, init)
 		}
 	}
-
+	return initAdd, initRemove
+}
+
+// UpdateHostInitiatorsInChunks updates a host so that it has the initiators initiatorIDs, as UpdateHostInitiators does,
+// but adds and removes the initiators up to chunkSize at a time, or DefaultHostInitiatorChunkSize if chunkSize is 0,
+// and continues when a chunk fails. The initiators of a failed chunk are retried one by one, so that the result of every
+// initiator is reported in the returned update, with the host as updated. An error is only returned if the host cannot be read.
+func (c *Client) UpdateHostInitiatorsInChunks(ctx context.Context, symID string, host *types.Host, initiatorIDs []string, chunkSize int) (*types.HostInitiatorsUpdate, error) {
+	defer c.TimeSpent("UpdateHostInitiatorsInChunks", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	if host == nil {
+		return nil, fmt.Errorf("Host can't be nil")
+	}
+	if chunkSize <= 0 {
+		chunkSize = DefaultHostInitiatorChunkSize
+	}
+	initAdd, initRemove := hostInitiatorChanges(host, initiatorIDs)
+	update := &types.HostInitiatorsUpdate{HostID: host.HostID, Results: []types.HostInitiatorResult{}}
+	for _, change := range []struct {
+		action     string
+		initiators []string
+	}{
+		{types.HostInitiatorActionAdd, initAdd},
+		{types.HostInitiatorActionRemove, initRemove},
+	} {
+		for chunk := range slices.Chunk(change.initiators, chunkSize) {
+			err := c.changeHostInitiators(ctx, symID, host.HostID, change.action, chunk)
+			if err == nil || len(chunk) == 1 {
+				update.Results = append(update.Results, hostInitiatorResults(change.action, chunk, err)...)
+				continue
+			}
+			log.Warn(fmt.Sprintf("Unable to %s %d initiators of host %s, retrying them one by one: %s", change.action, len(chunk), host.HostID, err.Error()))
+			for _, initiator := range chunk {
+				err = c.changeHostInitiators(ctx, symID, host.HostID, change.action, []string{initiator})
+				update.Results = append(update.Results, hostInitiatorResults(change.action, []string{initiator}, err)...)
+			}
+		}
+	}
+	updatedHost, err := c.GetHostByID(ctx, symID, host.HostID)
+	if err != nil {
+		return nil, err
+	}
+	update.Host = updatedHost
+	for _, result := range update.Results {
+		if result.Error != "" {
+			update.Failed++
+		}
+	}
+	log.Info(fmt.Sprintf("Updated the initiators of host %s: %d changes, %d failed", host.HostID, len(update.Results), update.Failed))
+	return update, nil
+}
+
+// changeHostInitiators adds or removes initiators of a host, depending on action
+func (c *Client) changeHostInitiators(ctx context.Context, symID, hostID, action string, initiators []string) error {
+	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XHost + "/" + hostID
+	var hostParam interface{}
+	if action == types.HostInitiatorActionAdd {
+		hostParam = &types.UpdateHostAddInitiatorsParam{
+			EditHostAction: &types.AddHostInitiators{
+				AddInitiator: &types.ChangeInitiatorParam{Initiators: initiators},
+			},
+			ExecutionOption: types.ExecutionOptionSynchronous,
+		}
+	} else {
+		hostParam = &types.UpdateHostRemoveInititorsParam{
+			EditHostAction: &types.RemoveHostInitiators{
+				RemoveInitiator: &types.ChangeInitiatorParam{Initiators: initiators},
+			},
+			ExecutionOption: types.ExecutionOptionSynchronous,
+		}
+	}
+	ifDebugLogPayload(hostParam)
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
-	// add initiators if needed
-	if len(initAdd) > 0 {
-		hostParam := &types.UpdateHostAddInitiatorsParam{}
-		hostParam.EditHostAction = &types.AddHostInitiators{}
-		hostParam.EditHostAction.AddInitiator = &types.ChangeInitiatorParam{}
-		hostParam.EditHostAction.AddInitiator.Initiators = initAdd
-		hostParam.ExecutionOption = types.ExecutionOptionSynchronous
-
-		ifDebugLogPayload(hostParam)
-		err := c.api.Put(ctx, URL, c.getDefaultHeaders(), hostParam, updatedHost)
-		if err != nil {
-			log.Error("UpdateHostInitiators failed: " + err.Error())
-			return nil, err
-		}
-	}
-	// remove initiators if needed
-	if len(initRemove) > 0 {
-		hostParam := &types.UpdateHostRemoveInititorsParam{}
-		hostParam.EditHostAction = &types.RemoveHostInitiators{}
-		hostParam.EditHostAction.RemoveInitiator = &types.ChangeInitiatorParam{}
-		hostParam.EditHostAction.RemoveInitiator.Initiators = initRemove
-		hostParam.ExecutionOption = types.ExecutionOptionSynchronous
+	return c.api.Put(ctx, URL, c.getDefaultHeaders(), hostParam, nil)
+}
 
-		ifDebugLogPayload(hostParam)
-		err := c.api.Put(ctx, URL, c.getDefaultHeaders(), hostParam, updatedHost)
+// hostInitiatorResults returns the result of the same change of every initiator
+func hostInitiatorResults(action string, initiators []string, err error) []types.HostInitiatorResult {
+	results := make([]types.HostInitiatorResult, len(initiators))
+	for i, initiator := range initiators {
+		results[i] = types.HostInitiatorResult{InitiatorID: initiator, Action: action}
 		if err != nil {
-			log.Error("UpdateHostInitiators failed: " + err.Error())
-			return nil, err
+			results[i].Error = err.Error()
 		}
 	}
-
-	return updatedHost, nil
+	return results
 }
 
-// UpdateHostName updates a host with new hostID and returns a types.Host.
+// UpdateHostName renames a host to newHostID and returns the renamed types.Host.
 func (c *Client) UpdateHostName(ctx context.Context, symID, oldHostID, newHostID string) (*types.Host, error) {
 	defer c.TimeSpent("UpdateHostName", time.Now())
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
 
+	if newHostID == "" {
+		return nil, fmt.Errorf("a new name is required to rename host %s", oldHostID)
+	}
 	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XHost + "/" + oldHostID
 	updatedHost := &types.Host{}
 
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
-	// add initiators if needed
-	if newHostID != "" {
-		hostParam := &types.UpdateHostParam{}
-		hostParam.EditHostAction = &types.EditHostParams{}
-		hostParam.EditHostAction.RenameHostParam = &types.RenameHostParam{}
-		hostParam.EditHostAction.RenameHostParam.NewHostName = newHostID
-		hostParam.ExecutionOption = types.ExecutionOptionSynchronous
-		ifDebugLogPayload(hostParam)
-		err := c.api.Put(ctx, URL, c.getDefaultHeaders(), hostParam, updatedHost)
-		if err != nil {
-			log.Error("UpdateHostName failed: " + err.Error())
-			return nil, err
-		}
+	hostParam := &types.UpdateHostParam{}
+	hostParam.EditHostAction = &types.EditHostParams{}
+	hostParam.EditHostAction.RenameHostParam = &types.RenameHostParam{}
+	hostParam.EditHostAction.RenameHostParam.NewHostName = newHostID
+	hostParam.ExecutionOption = types.ExecutionOptionSynchronous
+	ifDebugLogPayload(hostParam)
+	err := c.api.Put(ctx, URL, c.getDefaultHeaders(), hostParam, updatedHost)
+	if err != nil {
+		log.Error("UpdateHostName failed: " + err.Error())
+		return nil, err
 	}
-
+	log.Info(fmt.Sprintf("Successfully renamed Host %s to %s", oldHostID, newHostID))
 	return updatedHost, nil
 }
 
@@ -1450,11 +3056,22 @@ func stringInSlice(a string, list []string) bool {
 }
 
 // DeleteHost deletes a host entry.
-func (c *Client) DeleteHost(ctx context.Context, symID string, hostID string) error {
+// If DeleteHostOptions are given with CheckMaskingViews set, the host is only deleted if no masking view
+// references it, otherwise a HostInUseError listing the masking views is returned.
+func (c *Client) DeleteHost(ctx context.Context, symID string, hostID string, opts ...types.DeleteHostOptions) error {
 	defer c.TimeSpent("DeleteHost", time.Now())
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return err
 	}
+	if len(opts) > 0 && opts[0].CheckMaskingViews {
+		host, err := c.GetHostByID(ctx, symID, hostID)
+		if err != nil {
+			return err
+		}
+		if len(host.MaskingviewIDs) > 0 {
+			return &HostInUseError{SymmetrixID: symID, HostID: hostID, MaskingViews: host.MaskingviewIDs}
+		}
+	}
 	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XHost + "/" + hostID
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
@@ -1467,13 +3084,47 @@ func (c *Client) DeleteHost(ctx context.Context, symID string, hostID string) er
 	return nil
 }
 
+// HostInUseError is returned by DeleteHost when masking views prevent the host from being deleted
+type HostInUseError struct {
+	SymmetrixID  string
+	HostID       string
+	MaskingViews []string
+}
+
+func (e *HostInUseError) Error() string {
+	return fmt.Sprintf("host %s on array %s is in use by masking views: %s", e.HostID, e.SymmetrixID, strings.Join(e.MaskingViews, ", "))
+}
+
+// IsHostInUseError returns true if the error is, or wraps, a HostInUseError
+func IsHostInUseError(err error) bool {
+	var inUse *HostInUseError
+	return errors.As(err, &inUse)
+}
+
 // GetMaskingViewList  returns a list of the MaskingView names.
-func (c *Client) GetMaskingViewList(ctx context.Context, symID string) (*types.MaskingViewList, error) {
+// If a MaskingViewFilter is given, only the masking views using its host or host group, port group
+// and storage group are returned, as filtered by Unisphere.
+func (c *Client) GetMaskingViewList(ctx context.Context, symID string, filters ...types.MaskingViewFilter) (*types.MaskingViewList, error) {
 	defer c.TimeSpent("GetMaskingViewList", time.Now())
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
 	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XMaskingView
+	if len(filters) > 0 {
+		query := url.Values{}
+		if filters[0].HostOrHostGroupID != "" {
+			query.Set("host_or_host_group_name", filters[0].HostOrHostGroupID)
+		}
+		if filters[0].PortGroupID != "" {
+			query.Set("port_group_name", filters[0].PortGroupID)
+		}
+		if filters[0].StorageGroupID != "" {
+			query.Set("storage_group_name", filters[0].StorageGroupID)
+		}
+		if len(query) > 0 {
+			URL += "?" + query.Encode()
+		}
+	}
 	mvList := &types.MaskingViewList{}
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
@@ -1561,12 +3212,89 @@ func (c *Client) RenameMaskingView(ctx context.Context, symID string, maskingVie
 	return maskingView, nil
 }
 
+// SwapMaskingViewPortGroup replaces the port group of a masking view, e.g. to move it to new front-end ports.
+// Unisphere cannot change the port group of a masking view, so the masking view is deleted and recreated: a masking
+// view with the same storage group and host, and the new port group, is created first, then the original one is
+// deleted and the new one renamed with its name. The volumes stay mapped through the original or the new ports.
+// The swap is a destructive operation, refused as a whole in safe mode, and nothing is sent in dry-run mode.
+func (c *Client) SwapMaskingViewPortGroup(ctx context.Context, symID string, maskingViewID string, portGroupID string) (*types.MaskingView, error) {
+	defer c.TimeSpent("SwapMaskingViewPortGroup", time.Now())
+	return c.swapMaskingView(ctx, symID, maskingViewID, func(mv *types.MaskingView) {
+		mv.PortGroupID = portGroupID
+	})
+}
+
+// SwapMaskingViewHost replaces the host, or host group, of a masking view, keeping its storage group and port group.
+// The masking view is deleted and recreated, see SwapMaskingViewPortGroup.
+func (c *Client) SwapMaskingViewHost(ctx context.Context, symID string, maskingViewID string, hostOrhostGroupID string, isHost bool) (*types.MaskingView, error) {
+	defer c.TimeSpent("SwapMaskingViewHost", time.Now())
+	return c.swapMaskingView(ctx, symID, maskingViewID, func(mv *types.MaskingView) {
+		mv.HostID, mv.HostGroupID = "", ""
+		if isHost {
+			mv.HostID = hostOrhostGroupID
+		} else {
+			mv.HostGroupID = hostOrhostGroupID
+		}
+	})
+}
+
+// swapMaskingView deletes a masking view and recreates it with the components changed by swap, and the same name
+func (c *Client) swapMaskingView(ctx context.Context, symID string, maskingViewID string, swap func(mv *types.MaskingView)) (*types.MaskingView, error) {
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	mv, err := c.GetMaskingViewByID(ctx, symID, maskingViewID)
+	if err != nil {
+		return nil, err
+	}
+	swapped := *mv
+	swap(&swapped)
+	if swapped == *mv {
+		return mv, nil
+	}
+	// the original masking view has to be deleted, so nothing is created if the delete would be refused
+	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XMaskingView + "/" + maskingViewID
+	if err = c.api.CheckRequest(api.WithDestructiveOperation(ctx, "SwapMaskingView"), http.MethodDelete, URL); err != nil {
+		log.Error("SwapMaskingView failed: " + err.Error())
+		return nil, err
+	}
+	tmpName := maskingViewID
+	if len(tmpName) > payload.MaxNameLength-len("_swap") {
+		tmpName = tmpName[:payload.MaxNameLength-len("_swap")]
+	}
+	tmpName += "_swap"
+
+	isHost := swapped.HostID != ""
+	hostOrHostGroupID := swapped.HostGroupID
+	if isHost {
+		hostOrHostGroupID = swapped.HostID
+	}
+	if _, err = c.CreateMaskingView(ctx, symID, tmpName, swapped.StorageGroupID, hostOrHostGroupID, isHost, swapped.PortGroupID); err != nil {
+		return nil, err
+	}
+	if err = c.DeleteMaskingView(ctx, symID, maskingViewID); err != nil {
+		if rollbackErr := c.DeleteMaskingView(ctx, symID, tmpName); rollbackErr != nil {
+			log.Error(fmt.Sprintf("Unable to delete masking view %s after failing to swap %s: %s", tmpName, maskingViewID, rollbackErr.Error()))
+		}
+		return nil, err
+	}
+	renamed, err := c.RenameMaskingView(ctx, symID, tmpName, maskingViewID)
+	if err != nil {
+		return nil, fmt.Errorf("masking view %s was replaced by %s, which could not be renamed: %s", maskingViewID, tmpName, err.Error())
+	}
+	log.Info(fmt.Sprintf("Successfully swapped Masking View: %s", maskingViewID))
+	return renamed, nil
+}
+
 // CreatePortGroup - Creates a Port Group
 func (c *Client) CreatePortGroup(ctx context.Context, symID string, portGroupID string, dirPorts []types.PortKey, protocol string) (*types.PortGroup, error) {
 	defer c.TimeSpent("CreatePortGroup", time.Now())
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.validateName(PortGroupKind, portGroupID); err != nil {
+		return nil, err
+	}
 	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XPortGroup
 	createPortGroupParams := &types.CreatePortGroupParams{
 		PortGroupID:       portGroupID,
@@ -1629,6 +3357,9 @@ func (c *Client) CreateMaskingView(ctx context.Context, symID string, maskingVie
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.validateName(MaskingViewKind, maskingViewID); err != nil {
+		return nil, err
+	}
 	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XMaskingView
 	useExistingStorageGroupParam := &types.UseExistingStorageGroupParam{
 		StorageGroupID: storageGroupID,
@@ -1671,6 +3402,9 @@ func (c *Client) CreateMaskingView(ctx context.Context, symID string, maskingVie
 
 // DeletePortGroup - Deletes a PG
 func (c *Client) DeletePortGroup(ctx context.Context, symID string, portGroupID string) error {
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return err
+	}
 	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XPortGroup + "/" + portGroupID
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
@@ -1688,6 +3422,9 @@ func (c *Client) DeletePortGroup(ctx context.Context, symID string, portGroupID
 // the PortGroup and make appropriate REST calls sequentially. Take this into
 // consideration when making parallel calls.
 func (c *Client) UpdatePortGroup(ctx context.Context, symID string, portGroupID string, ports []types.PortKey) (*types.PortGroup, error) {
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
 	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XPortGroup + "/" + portGroupID
 	fmt.Println(URL)
 
@@ -1711,26 +3448,9 @@ func (c *Client) UpdatePortGroup(ctx context.Context, symID string, portGroupID
 		return nil, err
 	}
 
-	portIDRegex, _ := regexp.Compile("\\w+:(\\d+)")
-
 	// Create map of string "<DIRECTOR ID>/<PORT ID>" to a SymmetrixPortKeyType object based on what's found
 	// in the PortGroup
-	pgPorts := make(map[string]*types.SymmetrixPortKeyType)
-	for _, p := range pg.SymmetrixPortKey {
-		director := strings.ToUpper(p.DirectorID)
-		// PortID string may come as a combination of directory + port_number
-		// Extract just the port_number part
-		port := strings.ToLower(p.PortID)
-		submatch := portIDRegex.FindAllStringSubmatch(port, -1)
-		if len(submatch) > 0 {
-			port = submatch[0][1]
-		}
-		key := fmt.Sprintf("%s/%s", director, port)
-		pgPorts[key] = &types.SymmetrixPortKeyType{
-			DirectorID: director,
-			PortID:     port,
-		}
-	}
+	pgPorts := portGroupPorts(pg)
 
 	// Diff ports in request with ones in PortGroup --> ports to add
 	var added []types.SymmetrixPortKeyType
@@ -1789,12 +3509,118 @@ func (c *Client) UpdatePortGroup(ctx context.Context, symID string, portGroupID
 	return pg, nil
 }
 
+// portIDRegex extracts the port number of a port id combining the director and the port number, e.g. FA-1D:4
+var portIDRegex = regexp.MustCompile(`\w+:(\d+)`)
+
+// portGroupPortKey returns the key "<DIRECTOR ID>/<PORT ID>" of a port of a port group, with the port id
+// reduced to its port number, along with the port
+func portGroupPortKey(p types.PortKey) (string, *types.SymmetrixPortKeyType) {
+	director := strings.ToUpper(p.DirectorID)
+	// PortID string may come as a combination of directory + port_number
+	// Extract just the port_number part
+	port := strings.ToLower(p.PortID)
+	submatch := portIDRegex.FindAllStringSubmatch(port, -1)
+	if len(submatch) > 0 {
+		port = submatch[0][1]
+	}
+	return fmt.Sprintf("%s/%s", director, port), &types.SymmetrixPortKeyType{
+		DirectorID: director,
+		PortID:     port,
+	}
+}
+
+// portGroupPorts returns the ports of a port group keyed by portGroupPortKey
+func portGroupPorts(pg *types.PortGroup) map[string]*types.SymmetrixPortKeyType {
+	ports := make(map[string]*types.SymmetrixPortKeyType)
+	for _, p := range pg.SymmetrixPortKey {
+		key, port := portGroupPortKey(p)
+		ports[key] = port
+	}
+	return ports
+}
+
+// ModifyPortGroup adds ports to, and removes ports from, a port group, leaving its other ports in place.
+// The ports already in the port group are not added again, and the ports not in it are not removed.
+// The ports are added before the others are removed, and a PortGroupInUseError is returned, without changing
+// the port group, if the removal would leave a port group used by masking views without ports.
+func (c *Client) ModifyPortGroup(ctx context.Context, symID string, portGroupID string, add, remove []types.PortKey) (*types.PortGroup, error) {
+	defer c.TimeSpent("ModifyPortGroup", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	pg, err := c.GetPortGroupByID(ctx, symID, portGroupID)
+	if err != nil {
+		log.Error("ModifyPortGroup failed: " + err.Error())
+		return nil, err
+	}
+	remaining := portGroupPorts(pg)
+	var added, removed []types.SymmetrixPortKeyType
+	for _, p := range add {
+		if key, port := portGroupPortKey(p); remaining[key] == nil {
+			remaining[key] = port
+			added = append(added, *port)
+		}
+	}
+	for _, p := range remove {
+		if key, port := portGroupPortKey(p); remaining[key] != nil {
+			delete(remaining, key)
+			removed = append(removed, *port)
+		}
+	}
+	if len(remaining) == 0 && len(removed) > 0 && len(pg.MaskingView) > 0 {
+		return nil, &PortGroupInUseError{SymmetrixID: symID, PortGroupID: portGroupID, MaskingViews: pg.MaskingView}
+	}
+
+	URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XPortGroup + "/" + portGroupID
+	edits := []*types.EditPortGroupActionParam{}
+	if len(added) > 0 {
+		edits = append(edits, &types.EditPortGroupActionParam{AddPortParam: &types.AddPortParam{Ports: added}})
+	}
+	if len(removed) > 0 {
+		edits = append(edits, &types.EditPortGroupActionParam{RemovePortParam: &types.RemovePortParam{Ports: removed}})
+	}
+	for _, edit := range edits {
+		payload := types.EditPortGroup{
+			ExecutionOption:          types.ExecutionOptionSynchronous,
+			EditPortGroupActionParam: edit,
+		}
+		pg = &types.PortGroup{}
+		if err = c.putWithTimeout(ctx, URL, payload, pg); err != nil {
+			log.Error("ModifyPortGroup failed: " + err.Error())
+			return nil, err
+		}
+	}
+	log.Info(fmt.Sprintf("Successfully added %d ports to and removed %d ports from port group %s", len(added), len(removed), portGroupID))
+	return pg, nil
+}
+
+// PortGroupInUseError is returned by ModifyPortGroup when removing ports would leave a port group used by masking views without ports
+type PortGroupInUseError struct {
+	SymmetrixID  string
+	PortGroupID  string
+	MaskingViews []string
+}
+
+func (e *PortGroupInUseError) Error() string {
+	return fmt.Sprintf("port group %s on array %s would have no ports left while in use by masking views: %s", e.PortGroupID, e.SymmetrixID, strings.Join(e.MaskingViews, ", "))
+}
+
+// IsPortGroupInUseError returns true if the error is, or wraps, a PortGroupInUseError
+func IsPortGroupInUseError(err error) bool {
+	var inUse *PortGroupInUseError
+	return errors.As(err, &inUse)
+}
+
 // ModifyMobilityForVolume enables/disables mobility for the volume. The volume should not be associated with any maskingview if mobility has to be enabled.
+// Mobility ID lets the volume keep its identity when it is moved to another array, as during NDM and SRDF setups.
 func (c *Client) ModifyMobilityForVolume(ctx context.Context, symID string, volumeID string, mobility bool) (*types.Volume, error) {
 	defer c.TimeSpent("ModifyMobilityForVolume", time.Now())
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.verifySupport(ctx, symID, "ModifyMobilityForVolume"); err != nil {
+		return nil, err
+	}
 	EnableMobilityIDParam := &types.EnableMobilityIDParam{
 		EnableMobilityID: mobility,
 	}
@@ -1826,12 +3652,106 @@ func (c *Client) ModifyMobilityForVolume(ctx context.Context, symID string, volu
 	return volume, nil
 }
 
+// SetVolumeReadyState sets the device ready state (types.VolumeReady, types.VolumeNotReady or
+// types.VolumeUserNotReady) on each of the given volumes. symForce is passed through to the array
+// and is required to change the state of devices that are mapped or in use.
+// The state is set on every volume, even if it fails on some of them, and the outcome of each volume
+// is returned; an error is returned if any of them failed.
+func (c *Client) SetVolumeReadyState(ctx context.Context, symID string, volumeIDs []string, readyState string, symForce bool) ([]types.VolumeActionOutcome, error) {
+	defer c.TimeSpent("SetVolumeReadyState", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	if len(volumeIDs) == 0 {
+		return nil, fmt.Errorf("at least one volume ID must be supplied")
+	}
+	switch readyState {
+	case types.VolumeReady, types.VolumeNotReady, types.VolumeUserNotReady:
+	default:
+		return nil, fmt.Errorf("invalid ready state: %s", readyState)
+	}
+	payload := &types.EditVolumeParam{
+		EditVolumeActionParam: types.EditVolumeActionParam{
+			SetVolumeReadyStateParam: &types.SetVolumeReadyStateParam{
+				ReadyState: readyState,
+				SymForce:   symForce,
+			},
+		},
+		ExecutionOption: types.ExecutionOptionSynchronous,
+	}
+	ifDebugLogPayload(payload)
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	outcomes := make([]types.VolumeActionOutcome, len(volumeIDs))
+	failed := 0
+	for i, volumeID := range volumeIDs {
+		outcomes[i].VolumeID = volumeID
+		URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XVolume + "/" + volumeID
+		fields := map[string]interface{}{
+			http.MethodPut: URL,
+			"VolumeID":     volumeID,
+			"ReadyState":   readyState,
+		}
+		log.WithFields(fields).Info("Setting ready state for volume")
+		err := c.api.Put(ctx, URL, c.getDefaultHeaders(), payload, nil)
+		if err != nil {
+			log.WithFields(fields).Error("Error in SetVolumeReadyState: " + err.Error())
+			outcomes[i].Error = err.Error()
+			failed++
+		}
+	}
+	if failed > 0 {
+		if len(volumeIDs) == 1 {
+			return outcomes, fmt.Errorf("%s", outcomes[0].Error)
+		}
+		return outcomes, fmt.Errorf("ready state %s could not be set on %d of the %d volumes", readyState, failed, len(volumeIDs))
+	}
+	log.Info(fmt.Sprintf("Successfully set ready state %s on volumes: %v", readyState, volumeIDs))
+	return outcomes, nil
+}
+
+// SetPortACLX enables or disables the access control (ACLX) of a front-end port. With ACLX, the volumes
+// mapped to the port are only visible to the initiators of the masking views of the port; without it, they
+// are visible to every initiator logged in to the port. Disabling ACLX is a destructive operation, refused
+// in safe mode, as it exposes the volumes to every host of the fabric.
+func (c *Client) SetPortACLX(ctx context.Context, symID, directorID, portID string, enabled bool) (*types.Port, error) {
+	defer c.TimeSpent("SetPortACLX", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	payload := &types.EditPortParam{
+		EditPortActionParam: types.EditPortActionParam{
+			SetPortAttributesActionParam: &types.SetPortAttributesActionParam{
+				PortAttributes: types.PortAttributes{Aclx: &enabled},
+			},
+		},
+		ExecutionOption: types.ExecutionOptionSynchronous,
+	}
+	ifDebugLogPayload(payload)
+	URL := c.getSymmetrixIDListURL() + "/" + symID + "/director/" + directorID + "/port/" + portID
+	if !enabled {
+		ctx = api.WithDestructiveOperation(ctx, "SetPortACLX")
+	}
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	port := &types.Port{}
+	if err := c.api.Put(ctx, URL, c.getDefaultHeaders(), payload, port); err != nil {
+		log.Error("SetPortACLX failed: " + err.Error())
+		return nil, err
+	}
+	log.Info(fmt.Sprintf("Successfully set ACLX %t on port %s:%s", enabled, directorID, portID))
+	return port, nil
+}
+
 // CreateHostGroup creates a hostGroup from a list of hostIDs (and optional HostFlags) return returns a types.HostGroup.
 func (c *Client) CreateHostGroup(ctx context.Context, symID string, hostGroupID string, hostIDs []string, hostFlags *types.HostFlags) (*types.HostGroup, error) {
 	defer c.TimeSpent("CreateHostGroup", time.Now())
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
 	}
+	if err := c.validateName(HostGroupKind, hostGroupID); err != nil {
+		return nil, err
+	}
 	hostGroupParam := &types.CreateHostGroupParam{
 		HostGroupID:     hostGroupID,
 		HostIDs:         hostIDs,
@@ -1889,6 +3809,140 @@ func (c *Client) GetHostGroupList(ctx context.Context, symID string) (*types.Hos
 	return hostgroupList, nil
 }
 
+// GetHostGroupInheritance returns the initiators and flags in effect for a host group and each of its hosts, a flag
+// set on a host overriding the one of the group. The flags enabled by some of the group and its hosts and disabled
+// by others, and the consistent_lun settings differing from the group, are reported as conflicts. The hosts which
+// cannot be read are reported with their error and the initiators and flags known from the group.
+func (c *Client) GetHostGroupInheritance(ctx context.Context, symID string, hostGroupID string) (*types.HostGroupInheritance, error) {
+	defer c.TimeSpent("GetHostGroupInheritance", time.Now())
+	hostGroup, err := c.GetHostGroupByID(ctx, symID, hostGroupID)
+	if err != nil {
+		return nil, err
+	}
+	inheritance := &types.HostGroupInheritance{
+		HostGroupID:   hostGroup.HostGroupID,
+		EnabledFlags:  splitHostFlags(hostGroup.EnabledFlags),
+		DisabledFlags: splitHostFlags(hostGroup.DisabledFlags),
+		ConsistentLun: hostGroup.ConsistentLun,
+		Hosts:         make([]types.EffectiveHost, len(hostGroup.Hosts)),
+	}
+	hosts := make([]*types.Host, len(hostGroup.Hosts))
+	_ = fetchInParallel(len(hostGroup.Hosts), func(i int) error {
+		hostID := hostGroup.Hosts[i].HostID
+		host, err := c.GetHostByID(ctx, symID, hostID)
+		if err != nil {
+			inheritance.Hosts[i] = types.EffectiveHost{
+				HostID:        hostID,
+				Initiators:    hostGroup.Hosts[i].Initiators,
+				EnabledFlags:  inheritance.EnabledFlags,
+				DisabledFlags: inheritance.DisabledFlags,
+				ConsistentLun: hostGroup.ConsistentLun,
+				Error:         err.Error(),
+			}
+			return nil
+		}
+		hosts[i] = host
+		enabled, disabled := inheritHostFlags(inheritance.EnabledFlags, inheritance.DisabledFlags, host)
+		inheritance.Hosts[i] = types.EffectiveHost{
+			HostID:        hostID,
+			Initiators:    host.Initiators,
+			EnabledFlags:  enabled,
+			DisabledFlags: disabled,
+			ConsistentLun: host.ConsistentLun,
+		}
+		return nil
+	})
+
+	var initiators []string
+	for _, host := range inheritance.Hosts {
+		initiators = append(initiators, host.Initiators...)
+	}
+	slices.Sort(initiators)
+	inheritance.Initiators = slices.Compact(initiators)
+	inheritance.Conflicts = hostFlagConflicts(hostGroup, hosts)
+	log.Info(fmt.Sprintf("Resolved the initiators and flags of host group %s: %d hosts, %d initiators, %d conflicting flags",
+		hostGroupID, len(inheritance.Hosts), len(inheritance.Initiators), len(inheritance.Conflicts)))
+	return inheritance, nil
+}
+
+// splitHostFlags returns the flags of a comma separated list of flags, as in the enabled_flags of a host
+func splitHostFlags(flags string) []string {
+	var split []string
+	for _, flag := range strings.Split(flags, ",") {
+		if flag = strings.TrimSpace(flag); flag != "" {
+			split = append(split, flag)
+		}
+	}
+	return split
+}
+
+// inheritHostFlags returns the flags enabled and disabled on a host of a host group, the flags set on the host
+// overriding the ones of the group
+func inheritHostFlags(groupEnabled, groupDisabled []string, host *types.Host) (enabled, disabled []string) {
+	hostEnabled, hostDisabled := splitHostFlags(host.EnabledFlags), splitHostFlags(host.DisabledFlags)
+	for _, flag := range groupEnabled {
+		if !slices.Contains(hostDisabled, flag) {
+			enabled = append(enabled, flag)
+		}
+	}
+	for _, flag := range groupDisabled {
+		if !slices.Contains(hostEnabled, flag) {
+			disabled = append(disabled, flag)
+		}
+	}
+	enabled, disabled = append(enabled, hostEnabled...), append(disabled, hostDisabled...)
+	slices.Sort(enabled)
+	slices.Sort(disabled)
+	return slices.Compact(enabled), slices.Compact(disabled)
+}
+
+// hostFlagConflicts returns the flags enabled by some of a host group and its hosts and disabled by others,
+// the hosts not read being nil
+func hostFlagConflicts(hostGroup *types.HostGroup, hosts []*types.Host) []types.HostFlagConflict {
+	setters := make(map[string]*types.HostFlagConflict)
+	set := func(id, flags string, enabled bool) {
+		for _, flag := range splitHostFlags(flags) {
+			if setters[flag] == nil {
+				setters[flag] = &types.HostFlagConflict{Flag: flag}
+			}
+			if enabled {
+				setters[flag].EnabledBy = append(setters[flag].EnabledBy, id)
+			} else {
+				setters[flag].DisabledBy = append(setters[flag].DisabledBy, id)
+			}
+		}
+	}
+	consistentLun := &types.HostFlagConflict{Flag: types.ConsistentLunFlag}
+	setConsistentLun := func(id string, enabled bool) {
+		if enabled {
+			consistentLun.EnabledBy = append(consistentLun.EnabledBy, id)
+		} else {
+			consistentLun.DisabledBy = append(consistentLun.DisabledBy, id)
+		}
+	}
+	set(hostGroup.HostGroupID, hostGroup.EnabledFlags, true)
+	set(hostGroup.HostGroupID, hostGroup.DisabledFlags, false)
+	setConsistentLun(hostGroup.HostGroupID, hostGroup.ConsistentLun)
+	for _, host := range hosts {
+		if host == nil {
+			continue
+		}
+		set(host.HostID, host.EnabledFlags, true)
+		set(host.HostID, host.DisabledFlags, false)
+		setConsistentLun(host.HostID, host.ConsistentLun)
+	}
+	setters[consistentLun.Flag] = consistentLun
+
+	var conflicts []types.HostFlagConflict
+	for _, setter := range setters {
+		if len(setter.EnabledBy) > 0 && len(setter.DisabledBy) > 0 {
+			conflicts = append(conflicts, *setter)
+		}
+	}
+	slices.SortFunc(conflicts, func(a, b types.HostFlagConflict) int { return cmp.Compare(a.Flag, b.Flag) })
+	return conflicts
+}
+
 // DeleteHostGroup deletes a host entry.
 func (c *Client) DeleteHostGroup(ctx context.Context, symID string, hostGroupID string) error {
 	defer c.TimeSpent("DeleteHostGroup", time.Now())
@@ -2051,3 +4105,84 @@ func (c *Client) UpdateHostGroupHosts(ctx context.Context, symID string, hostGro
 	}
 	return updatedHostGroup, nil
 }
+
+// ModifiedSinceParam is the query parameter filtering the objects on their last modification time, in milliseconds
+// since the epoch. It is not part of the documented Unisphere API, so it is only sent with WithModifiedSinceFilter.
+const ModifiedSinceParam = "modification_time"
+
+// listModifiedSince returns the IDs listed by list with a query selecting the objects modified after since, if filter
+// is true. All the IDs are listed, and the result is marked as full, if filter is false, since is zero or the array
+// rejects the query.
+func listModifiedSince(since time.Time, filter bool, list func(query string) ([]string, error)) (*types.ModifiedIDList, error) {
+	result := &types.ModifiedIDList{ListedAt: time.Now()}
+	if filter && !since.IsZero() {
+		ids, err := list(fmt.Sprintf("?%s=%%3E%d", ModifiedSinceParam, since.UnixMilli()))
+		var apiErr *types.Error
+		if err == nil {
+			result.IDs = ids
+			return result, nil
+		} else if !errors.As(err, &apiErr) || apiErr.HTTPStatusCode != http.StatusBadRequest {
+			return nil, err
+		}
+		log.Debug("Filtering on the modification time is not supported, listing all the objects: " + err.Error())
+	}
+	ids, err := list("")
+	if err != nil {
+		return nil, err
+	}
+	result.IDs = ids
+	result.Full = true
+	return result, nil
+}
+
+// ListStorageGroupsModifiedSince returns the IDs of the storage groups modified after since, so that they can be
+// resynchronized incrementally. Unless WithModifiedSinceFilter is set, or if the array rejects the filter, all the
+// storage groups are returned and the result is marked as full. The ListedAt time of the result, minus a margin for the clock
+// difference with the array, is the since time of the next call.
+func (c *Client) ListStorageGroupsModifiedSince(ctx context.Context, symID string, since time.Time) (*types.ModifiedIDList, error) {
+	defer c.TimeSpent("ListStorageGroupsModifiedSince", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	return listModifiedSince(since, c.opts.filterModifiedSince, func(query string) ([]string, error) {
+		URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XStorageGroup + query
+		sgIDList := &types.StorageGroupIDList{}
+		if err := c.getWithTimeout(ctx, URL, sgIDList); err != nil {
+			log.Error("ListStorageGroupsModifiedSince failed: " + err.Error())
+			return nil, err
+		}
+		return sgIDList.StorageGroupIDs, nil
+	})
+}
+
+// ListVolumesModifiedSince returns the IDs of the volumes modified after since, see ListStorageGroupsModifiedSince
+func (c *Client) ListVolumesModifiedSince(ctx context.Context, symID string, since time.Time) (*types.ModifiedIDList, error) {
+	defer c.TimeSpent("ListVolumesModifiedSince", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	return listModifiedSince(since, c.opts.filterModifiedSince, func(query string) ([]string, error) {
+		iter, err := c.getVolumeIDsIteratorBase(ctx, symID, query)
+		if err != nil {
+			return nil, err
+		}
+		return c.volumeIteratorToVolIDList(ctx, iter)
+	})
+}
+
+// ListMaskingViewsModifiedSince returns the IDs of the masking views modified after since, see ListStorageGroupsModifiedSince
+func (c *Client) ListMaskingViewsModifiedSince(ctx context.Context, symID string, since time.Time) (*types.ModifiedIDList, error) {
+	defer c.TimeSpent("ListMaskingViewsModifiedSince", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	return listModifiedSince(since, c.opts.filterModifiedSince, func(query string) ([]string, error) {
+		URL := c.urlPrefix() + SLOProvisioningX + SymmetrixX + symID + XMaskingView + query
+		mvList := &types.MaskingViewList{}
+		if err := c.getWithTimeout(ctx, URL, mvList); err != nil {
+			log.Error("ListMaskingViewsModifiedSince failed: " + err.Error())
+			return nil, err
+		}
+		return mvList.MaskingViewIDs, nil
+	})
+}
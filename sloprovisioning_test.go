@@ -0,0 +1,2288 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pmax
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dell/gopowermax/v2/api"
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+func TestSetVolumeReadyState(t *testing.T) {
+	type testCase struct {
+		server      *httptest.Server
+		symID       string
+		volumeIDs   []string
+		readyState  string
+		expectedErr error
+	}
+
+	cases := map[string]testCase{
+		"set not ready on two volumes": {
+			symID:      "mock-local-sym-id",
+			volumeIDs:  []string{"00001", "00002"},
+			readyState: types.VolumeNotReady,
+			server: httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+				payload := &types.EditVolumeParam{}
+				if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+					t.Fatal(err)
+				}
+				param := payload.EditVolumeActionParam.SetVolumeReadyStateParam
+				if req.Method != http.MethodPut || param == nil || param.ReadyState != types.VolumeNotReady || !param.SymForce {
+					resp.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				resp.WriteHeader(http.StatusOK)
+			})),
+			expectedErr: nil,
+		},
+		"invalid ready state": {
+			symID:      "mock-local-sym-id",
+			volumeIDs:  []string{"00001"},
+			readyState: "Sleepy",
+			server: httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, _ *http.Request) {
+				resp.WriteHeader(http.StatusOK)
+			})),
+			expectedErr: errors.New("invalid ready state: Sleepy"),
+		},
+		"bad request": {
+			symID:      "mock-local-sym-id",
+			volumeIDs:  []string{"00001"},
+			readyState: types.VolumeReady,
+			server: httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, _ *http.Request) {
+				resp.WriteHeader(http.StatusBadRequest)
+				resp.Write([]byte(`{"message":"bad request","httpStatusCode":400,"errorCode":0}`))
+			})),
+			expectedErr: errors.New("bad request"),
+		},
+		"invalid array": {
+			symID:      "invalid-array-id",
+			volumeIDs:  []string{"00001"},
+			readyState: types.VolumeReady,
+			server: httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, _ *http.Request) {
+				resp.WriteHeader(http.StatusOK)
+			})),
+			expectedErr: errors.New("the requested array (invalid-array-id) is ignored as it is not managed"),
+		},
+	}
+
+	for name, tc := range cases {
+		client, err := NewClientWithArgs(tc.server.URL, "", true, true, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		client.SetAllowedArrays([]string{"mock-local-sym-id"})
+		_, err = client.SetVolumeReadyState(context.TODO(), tc.symID, tc.volumeIDs, tc.readyState, true)
+		if (err == nil) != (tc.expectedErr == nil) || (err != nil && err.Error() != tc.expectedErr.Error()) {
+			t.Fatalf("%s: expected error %v, got %v", name, tc.expectedErr, err)
+		}
+		tc.server.Close()
+	}
+}
+
+func TestSetVolumeReadyStateOutcomes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, "/00002") {
+			resp.WriteHeader(http.StatusBadRequest)
+			_, _ = resp.Write([]byte(`{"message":"volume 00002 is mapped","httpStatusCode":400,"errorCode":0}`))
+			return
+		}
+		resp.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	outcomes, err := client.SetVolumeReadyState(context.TODO(), "mock-sym-id", []string{"00001", "00002", "00003"}, types.VolumeNotReady, false)
+	if err == nil {
+		t.Fatal("expected an error as the state cannot be set on a volume")
+	}
+	expected := []types.VolumeActionOutcome{{VolumeID: "00001"}, {VolumeID: "00002", Error: "volume 00002 is mapped"}, {VolumeID: "00003"}}
+	if !reflect.DeepEqual(expected, outcomes) {
+		t.Fatalf("unexpected outcomes %+v", outcomes)
+	}
+}
+
+func TestSetPortACLX(t *testing.T) {
+	portURL := urlPrefix + "system/symmetrix/mock-sym-id/director/FA-1D/port/4"
+	var payloads []types.EditPortParam
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPut || req.URL.Path != portURL {
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		payload := types.EditPortParam{}
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			t.Fatal(err)
+		}
+		payloads = append(payloads, payload)
+		aclx := *payload.EditPortActionParam.SetPortAttributesActionParam.PortAttributes.Aclx
+		content, _ := json.Marshal(&types.Port{SymmetrixPort: types.SymmetrixPortType{Aclx: aclx}})
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := client.SetPortACLX(context.TODO(), "mock-sym-id", "FA-1D", "4", true)
+	if err != nil || !port.SymmetrixPort.Aclx {
+		t.Fatalf("expected ACLX to be enabled, got %+v and %v", port, err)
+	}
+	port, err = client.SetPortACLX(context.TODO(), "mock-sym-id", "FA-1D", "4", false)
+	if err != nil || port.SymmetrixPort.Aclx {
+		t.Fatalf("expected ACLX to be disabled, got %+v and %v", port, err)
+	}
+
+	client.SetSafeMode(true)
+	if _, err = client.SetPortACLX(context.TODO(), "mock-sym-id", "FA-1D", "4", false); !api.IsDestructiveOperationError(err) {
+		t.Errorf("expected disabling ACLX to be refused in safe mode, got %v", err)
+	}
+	if _, err = client.SetPortACLX(context.TODO(), "mock-sym-id", "FA-1D", "4", true); err != nil {
+		t.Error(err)
+	}
+	if len(payloads) != 3 {
+		t.Errorf("expected 3 edits, got %d", len(payloads))
+	}
+}
+
+func newCascadeDeleteServer(t *testing.T, deleteFailures int, calls *[]string) *httptest.Server {
+	return newCascadeDeleteServerWithStatus(t, deleteFailures, http.StatusConflict, calls)
+}
+
+func newCascadeDeleteServerWithStatus(t *testing.T, deleteFailures int, deleteStatus int, calls *[]string) *httptest.Server {
+	sgURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-local-sym-id" + XStorageGroup + "/"
+	snapURL := urlPrefix + Replication + SymmetrixX + "mock-local-sym-id" + XStorageGroup + "/sg-1" + XSnapshot
+	writeJSON := func(resp http.ResponseWriter, body interface{}) {
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.WriteHeader(http.StatusOK)
+		_, _ = resp.Write(content)
+	}
+	return httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		*calls = append(*calls, req.Method+" "+req.URL.Path)
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == sgURL+"sg-1":
+			writeJSON(resp, &types.StorageGroup{StorageGroupID: "sg-1", ParentStorageGroup: []string{"parent-sg"}})
+		case req.Method == http.MethodGet && req.URL.Path == snapURL:
+			writeJSON(resp, &types.StorageGroupSnapshot{Name: []string{"snap-1"}})
+		case req.Method == http.MethodGet && req.URL.Path == snapURL+"/snap-1"+SnapID:
+			writeJSON(resp, &types.SnapID{SnapIDs: []int64{1, 2}})
+		case req.Method == http.MethodGet && strings.HasPrefix(req.URL.Path, snapURL+"/snap-1"+SnapID+"/"):
+			writeJSON(resp, &types.StorageGroupSnap{Name: "snap-1", Linked: strings.HasSuffix(req.URL.Path, "/2")})
+		case req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, XVolume):
+			writeJSON(resp, &types.VolumeIterator{
+				ResultList:  types.VolumeResultList{VolumeList: []types.VolumeIDList{{VolumeIDs: "00001"}}, From: 1, To: 1},
+				Count:       1,
+				MaxPageSize: 1000,
+			})
+		case req.Method == http.MethodDelete && req.URL.Path == sgURL+"sg-1":
+			if deleteFailures > 0 {
+				deleteFailures--
+				resp.WriteHeader(deleteStatus)
+				_, _ = resp.Write([]byte(fmt.Sprintf(`{"message":"storage group is busy","httpStatusCode":%d,"errorCode":0}`, deleteStatus)))
+				return
+			}
+			resp.WriteHeader(http.StatusNoContent)
+		case req.Method == http.MethodPut || req.Method == http.MethodDelete:
+			writeJSON(resp, &types.StorageGroup{})
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestDeleteStorageGroupCascade(t *testing.T) {
+	allSteps := types.DeleteStorageGroupCascadeOptions{
+		RemoveVolumes:     true,
+		DeleteSnapshots:   true,
+		RemoveFromParents: true,
+		MaxRetries:        2,
+		RetryBackoff:      time.Millisecond,
+	}
+
+	t.Run("dry run does not modify", func(t *testing.T) {
+		var calls []string
+		server := newCascadeDeleteServer(t, 0, &calls)
+		defer server.Close()
+		client, err := NewClientWithArgs(server.URL, "", true, true, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		opts := allSteps
+		opts.DryRun = true
+		report, err := client.DeleteStorageGroupCascade(context.TODO(), "mock-local-sym-id", "sg-1", opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, call := range calls {
+			if !strings.HasPrefix(call, http.MethodGet) {
+				t.Fatalf("unexpected call in dry run: %s", call)
+			}
+		}
+		if len(report.DeletedSnapshots) != 1 || len(report.SkippedSnapshots) != 1 || len(report.RemovedVolumes) != 1 ||
+			len(report.RemovedFromParents) != 1 || report.StorageGroupDeleted {
+			t.Fatalf("unexpected report: %+v", report)
+		}
+	})
+
+	t.Run("delete retried after busy", func(t *testing.T) {
+		var calls []string
+		server := newCascadeDeleteServer(t, 2, &calls)
+		defer server.Close()
+		client, err := NewClientWithArgs(server.URL, "", true, true, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		report, err := client.DeleteStorageGroupCascade(context.TODO(), "mock-local-sym-id", "sg-1", allSteps)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !report.StorageGroupDeleted || report.DeleteAttempts != 3 {
+			t.Fatalf("unexpected report: %+v", report)
+		}
+	})
+
+	t.Run("delete gives up after retries", func(t *testing.T) {
+		var calls []string
+		server := newCascadeDeleteServer(t, 5, &calls)
+		defer server.Close()
+		client, err := NewClientWithArgs(server.URL, "", true, true, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		report, err := client.DeleteStorageGroupCascade(context.TODO(), "mock-local-sym-id", "sg-1", allSteps)
+		if err == nil || err.Error() != "storage group is busy" {
+			t.Fatalf("expected busy error, got %v", err)
+		}
+		if report.StorageGroupDeleted || report.DeleteAttempts != 3 {
+			t.Fatalf("unexpected report: %+v", report)
+		}
+	})
+
+	t.Run("delete not retried after a client error", func(t *testing.T) {
+		var calls []string
+		server := newCascadeDeleteServerWithStatus(t, 1, http.StatusBadRequest, &calls)
+		defer server.Close()
+		client, err := NewClientWithArgs(server.URL, "", true, true, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		report, err := client.DeleteStorageGroupCascade(context.TODO(), "mock-local-sym-id", "sg-1", allSteps)
+		if err == nil || report.DeleteAttempts != 1 {
+			t.Fatalf("expected a single failed attempt, got %+v, %v", report, err)
+		}
+	})
+
+	t.Run("safe mode refuses before any change", func(t *testing.T) {
+		var calls []string
+		server := newCascadeDeleteServer(t, 0, &calls)
+		defer server.Close()
+		client, err := NewClientWithArgs(server.URL, "", true, true, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		client.SetSafeMode(true)
+		if _, err = client.DeleteStorageGroupCascade(context.TODO(), "mock-local-sym-id", "sg-1", allSteps); !api.IsDestructiveOperationError(err) {
+			t.Fatalf("expected a DestructiveOperationError, got %v", err)
+		}
+		for _, call := range calls {
+			if !strings.HasPrefix(call, http.MethodGet) {
+				t.Fatalf("unexpected call in safe mode: %s", call)
+			}
+		}
+	})
+
+	t.Run("client dry run reports", func(t *testing.T) {
+		var calls []string
+		server := newCascadeDeleteServer(t, 0, &calls)
+		defer server.Close()
+		client, err := NewClientWithArgs(server.URL, "", true, true, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		client.SetDryRun(true)
+		report, err := client.DeleteStorageGroupCascade(context.TODO(), "mock-local-sym-id", "sg-1", allSteps)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !report.DryRun || len(report.RemovedVolumes) != 1 || report.StorageGroupDeleted {
+			t.Fatalf("unexpected report: %+v", report)
+		}
+	})
+
+	t.Run("delete waits without a backoff", func(t *testing.T) {
+		var calls []string
+		server := newCascadeDeleteServer(t, 5, &calls)
+		defer server.Close()
+		client, err := NewClientWithArgs(server.URL, "", true, true, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithTimeout(context.TODO(), 100*time.Millisecond)
+		defer cancel()
+		report, err := client.DeleteStorageGroupCascade(ctx, "mock-local-sym-id", "sg-1", types.DeleteStorageGroupCascadeOptions{MaxRetries: 5})
+		if err != context.DeadlineExceeded {
+			t.Fatalf("expected the retry to wait for the default backoff, got %v", err)
+		}
+		if report.DeleteAttempts != 1 {
+			t.Fatalf("unexpected report: %+v", report)
+		}
+	})
+}
+
+func TestGetVolumeDetailList(t *testing.T) {
+	volURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-local-sym-id" + XVolume
+	newServer := func(detailed bool) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			var body interface{}
+			switch req.URL.Path {
+			case volURL:
+				if req.URL.Query().Get("details") != "true" {
+					t.Fatalf("expected detailed listing, got %s", req.URL.RawQuery)
+				}
+				first := types.VolumeDetail{Volume: types.Volume{VolumeID: "00001"}}
+				if detailed {
+					first.Emulation = "FBA"
+				}
+				body = &types.VolumeDetailIterator{
+					ResultList:  types.VolumeDetailResultList{VolumeList: []types.VolumeDetail{first}, From: 1, To: 1},
+					ID:          "iter-1",
+					Count:       2,
+					MaxPageSize: 1,
+				}
+			case "/" + RESTPrefix + IteratorX + "iter-1" + XPage:
+				second := types.VolumeDetail{Volume: types.Volume{VolumeID: "00002"}}
+				if detailed {
+					second.Emulation = "FBA"
+				}
+				body = &types.VolumeDetailResultList{VolumeList: []types.VolumeDetail{second}, From: 2, To: 2}
+			case "/" + RESTPrefix + IteratorX + "iter-1":
+				resp.WriteHeader(http.StatusNoContent)
+				return
+			case volURL + "/00001":
+				if detailed {
+					t.Fatal("volume should not be fetched when the listing is detailed")
+				}
+				body = &types.Volume{VolumeID: "00001", Emulation: "FBA"}
+			default:
+				resp.WriteHeader(http.StatusNotFound)
+				_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+				return
+			}
+			content, err := json.Marshal(body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _ = resp.Write(content)
+		}))
+	}
+
+	t.Run("detailed listing", func(t *testing.T) {
+		server := newServer(true)
+		defer server.Close()
+		client, err := NewClientWithArgs(server.URL, "", true, true, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		volumes, err := client.GetVolumeDetailList(context.TODO(), "mock-local-sym-id", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(volumes) != 2 || volumes[0].Emulation != "FBA" || volumes[1].Emulation != "FBA" {
+			t.Fatalf("unexpected volumes %+v", volumes)
+		}
+	})
+
+	t.Run("emulated listing", func(t *testing.T) {
+		server := newServer(false)
+		defer server.Close()
+		client, err := NewClientWithArgs(server.URL, "", true, true, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		volumes, err := client.GetVolumeDetailList(context.TODO(), "mock-local-sym-id", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(volumes) != 2 || volumes[0].Emulation != "FBA" || volumes[0].Error != "" || volumes[1].Error != "not found" {
+			t.Fatalf("unexpected volumes %+v", volumes)
+		}
+	})
+}
+
+func TestGetVolumesByIDs(t *testing.T) {
+	volURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-local-sym-id" + XVolume
+	newServer := func(bulk bool, queries *int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			var body interface{}
+			switch {
+			case req.URL.Path == volURL+XQuery && bulk:
+				*queries++
+				param := types.VolumeQueryParam{}
+				if err := json.NewDecoder(req.Body).Decode(&param); err != nil {
+					t.Fatal(err)
+				}
+				if len(param.VolumeIDs) > MaxBulkQueryKeys {
+					t.Fatalf("expected at most %d volumes per query, got %d", MaxBulkQueryKeys, len(param.VolumeIDs))
+				}
+				result := &types.VolumeDetailResultList{}
+				for _, id := range param.VolumeIDs {
+					if id != "missing" {
+						result.VolumeList = append(result.VolumeList, types.VolumeDetail{Volume: types.Volume{VolumeID: id, Emulation: "FBA"}})
+					}
+				}
+				body = result
+			case req.URL.Path == volURL+"/00001" && !bulk:
+				body = &types.Volume{VolumeID: "00001", Emulation: "FBA"}
+			default:
+				resp.WriteHeader(http.StatusNotFound)
+				_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+				return
+			}
+			content, err := json.Marshal(body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _ = resp.Write(content)
+		}))
+	}
+
+	t.Run("bulk query", func(t *testing.T) {
+		queries := 0
+		server := newServer(true, &queries)
+		defer server.Close()
+		client, err := NewClientWithArgs(server.URL, "", true, true, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := []string{"missing"}
+		for i := 1; i <= MaxBulkQueryKeys+1; i++ {
+			ids = append(ids, fmt.Sprintf("%05X", i))
+		}
+		volumes, err := client.GetVolumesByIDs(context.TODO(), "mock-local-sym-id", ids)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if queries != 2 || len(volumes) != len(ids) {
+			t.Fatalf("expected %d volumes in 2 queries, got %d volumes in %d queries", len(ids), len(volumes), queries)
+		}
+		if volumes[0].Error == "" || volumes[1].VolumeID != "00001" || volumes[1].Emulation != "FBA" || volumes[1].Error != "" {
+			t.Fatalf("unexpected volumes %+v", volumes[:2])
+		}
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		queries := 0
+		server := newServer(false, &queries)
+		defer server.Close()
+		client, err := NewClientWithArgs(server.URL, "", true, true, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		volumes, err := client.GetVolumesByIDs(context.TODO(), "mock-local-sym-id", []string{"00001", "00002"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(volumes) != 2 || volumes[0].Emulation != "FBA" || volumes[0].Error != "" || volumes[1].VolumeID != "00002" || volumes[1].Error == "" {
+			t.Fatalf("unexpected volumes %+v", volumes)
+		}
+	})
+}
+
+func TestModifyStorageGroupSLOWorkloadSRP(t *testing.T) {
+	sgURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-local-sym-id" + XStorageGroup + "/sg-1"
+	jobURL := urlPrefix + "system/symmetrix/mock-local-sym-id/job/job-1"
+	newServer := func(jobStatus string, check func(action types.EditStorageGroupActionParam) bool) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			var body interface{}
+			switch {
+			case req.Method == http.MethodPut && req.URL.Path == sgURL:
+				payload := &types.UpdateStorageGroupPayload{}
+				if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+					t.Fatal(err)
+				}
+				if payload.ExecutionOption != types.ExecutionOptionAsynchronous || !check(payload.EditStorageGroupActionParam) {
+					resp.WriteHeader(http.StatusBadRequest)
+					_, _ = resp.Write([]byte(`{"message":"unexpected payload","httpStatusCode":400,"errorCode":0}`))
+					return
+				}
+				body = &types.Job{JobID: "job-1", Status: types.JobStatusScheduled}
+			case req.Method == http.MethodGet && req.URL.Path == jobURL:
+				body = &types.Job{JobID: "job-1", Status: jobStatus}
+			default:
+				resp.WriteHeader(http.StatusNotFound)
+				return
+			}
+			content, err := json.Marshal(body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _ = resp.Write(content)
+		}))
+	}
+
+	type testCase struct {
+		jobStatus   string
+		check       func(action types.EditStorageGroupActionParam) bool
+		modify      func(client Pmax) error
+		expectedErr bool
+	}
+	cases := map[string]testCase{
+		"modify slo": {
+			jobStatus: types.JobStatusSucceeded,
+			check: func(action types.EditStorageGroupActionParam) bool {
+				return action.EditStorageGroupSLOParam != nil && action.EditStorageGroupSLOParam.SLOID == "Silver"
+			},
+			modify: func(client Pmax) error {
+				return client.ModifyStorageGroupSLO(context.TODO(), "mock-local-sym-id", "sg-1", "Silver")
+			},
+		},
+		"modify workload": {
+			jobStatus: types.JobStatusSucceeded,
+			check: func(action types.EditStorageGroupActionParam) bool {
+				return action.EditStorageGroupWorkloadParam != nil && action.EditStorageGroupWorkloadParam.WorkloadSelection == "OLTP"
+			},
+			modify: func(client Pmax) error {
+				return client.ModifyStorageGroupWorkload(context.TODO(), "mock-local-sym-id", "sg-1", "OLTP")
+			},
+		},
+		"modify srp job failed": {
+			jobStatus: types.JobStatusFailed,
+			check: func(action types.EditStorageGroupActionParam) bool {
+				return action.EditStorageGroupSRPParam != nil && action.EditStorageGroupSRPParam.SRPID == "SRP_2"
+			},
+			modify: func(client Pmax) error {
+				return client.ModifyStorageGroupSRP(context.TODO(), "mock-local-sym-id", "sg-1", "SRP_2")
+			},
+			expectedErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		server := newServer(tc.jobStatus, tc.check)
+		client, err := NewClientWithArgs(server.URL, "", true, true, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = tc.modify(client)
+		if (err != nil) != tc.expectedErr {
+			t.Fatalf("%s: unexpected error %v", name, err)
+		}
+		server.Close()
+	}
+}
+
+func TestDeleteVolumeWithBlockers(t *testing.T) {
+	volURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id" + XVolume
+	sgURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id" + XStorageGroup
+	snapURL := "/" + RESTPrefix + PrivateX + "100/" + ReplicationX + SymmetrixX + "mock-sym-id"
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		calls = append(calls, req.Method+" "+req.URL.Path)
+		var body interface{}
+		switch req.Method + " " + req.URL.Path {
+		case "GET " + volURL + "/00001":
+			body = &types.Volume{VolumeID: "00001", CapacityCYL: 547, StorageGroupIDList: []string{"sg-mv"},
+				RDFGroupIDList: []types.RDFGroupID{{RDFGroupNumber: 10}}}
+		case "GET " + volURL + "/00002":
+			body = &types.Volume{VolumeID: "00002", CapacityCYL: 547, StorageGroupIDList: []string{"sg-free"}, SnapSource: true}
+		case "GET " + volURL + "/00003":
+			body = &types.Volume{VolumeID: "00003", CapacityCYL: 3}
+		case "GET " + snapURL + XVolume + "/00002" + XSnapshot:
+			body = &types.SnapshotVolumeGeneration{VolumeSnapshotSource: []types.VolumeSnapshotSource{{SnapshotName: "snap", Generation: 0}}}
+		case "GET " + sgURL + "/sg-mv":
+			body = &types.StorageGroup{StorageGroupID: "sg-mv", NumOfMaskingViews: 1}
+		case "GET " + sgURL + "/sg-free":
+			body = &types.StorageGroup{StorageGroupID: "sg-free"}
+		case "PUT " + sgURL + "/sg-free":
+			body = &types.StorageGroup{StorageGroupID: "sg-free"}
+		case "DELETE " + snapURL + XSnapshot + "/snap":
+			body = &types.Job{JobID: "job-1", Status: types.JobStatusScheduled}
+		case "GET " + urlPrefix + "system/symmetrix/mock-sym-id/job/job-1":
+			body = &types.Job{JobID: "job-1", Status: types.JobStatusSucceeded}
+		case "DELETE " + volURL + "/00001", "DELETE " + volURL + "/00002", "DELETE " + volURL + "/00003":
+			resp.WriteHeader(http.StatusNoContent)
+			return
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Error(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.DeleteVolume(context.TODO(), "mock-sym-id", "00001", types.DeleteVolumeOptions{RemoveBlockers: true})
+	var inUse *VolumeInUseError
+	if !errors.As(err, &inUse) || !IsVolumeInUseError(err) {
+		t.Fatalf("expected VolumeInUseError, got %v", err)
+	}
+	expected := []types.VolumeBlocker{
+		{Kind: types.VolumeBlockerRDFPair, Name: "10"},
+		{Kind: types.VolumeBlockerMaskingView, Name: "sg-mv"},
+	}
+	if !reflect.DeepEqual(expected, inUse.Blockers) {
+		t.Fatalf("expected blockers %+v, got %+v", expected, inUse.Blockers)
+	}
+
+	err = client.DeleteVolume(context.TODO(), "mock-sym-id", "00002", types.DeleteVolumeOptions{CheckBlockers: true})
+	if !errors.As(err, &inUse) || len(inUse.Blockers) != 2 || !inUse.Blockers[0].Removable || !inUse.Blockers[1].Removable {
+		t.Fatalf("expected removable blockers, got %v", err)
+	}
+
+	calls = nil
+	if err = client.DeleteVolume(context.TODO(), "mock-sym-id", "00002", types.DeleteVolumeOptions{RemoveBlockers: true}); err != nil {
+		t.Fatal(err)
+	}
+	if calls[len(calls)-1] != "DELETE "+volURL+"/00002" || !strings.Contains(strings.Join(calls, ","), "PUT "+sgURL+"/sg-free") {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+
+	err = client.DeleteVolume(context.TODO(), "mock-sym-id", "00003", types.DeleteVolumeOptions{CheckBlockers: true})
+	if !errors.As(err, &inUse) || inUse.Blockers[0].Kind != types.VolumeBlockerGatekeeper {
+		t.Fatalf("expected gatekeeper blocker, got %v", err)
+	}
+	if err = client.DeleteVolume(context.TODO(), "mock-sym-id", "00003", types.DeleteVolumeOptions{CheckBlockers: true, AllowGatekeeper: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.DeleteVolume(context.TODO(), "mock-sym-id", "00001"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateHostInitiators(t *testing.T) {
+	nqn := "nqn.2014-08.org.nvmexpress:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+	cases := map[string]struct {
+		initiators  []string
+		allowMixed  bool
+		expectedErr bool
+	}{
+		"fc":               {initiators: []string{"10000090fa66060a", "FA-1D:4:10000090fa66060b"}},
+		"iscsi":            {initiators: []string{"iqn.1993-08.org.debian:01:5ae293b352a2"}},
+		"nvme":             {initiators: []string{nqn, "OR-1C:001:" + nqn}},
+		"invalid nqn":      {initiators: []string{"nqn.2014.org.nvmexpress"}, expectedErr: true},
+		"unknown":          {initiators: []string{"host-1"}, expectedErr: true},
+		"mixed":            {initiators: []string{"10000090fa66060a", nqn}, expectedErr: true},
+		"mixed allowed":    {initiators: []string{"10000090fa66060a", nqn}, allowMixed: true},
+		"nqn too long":     {initiators: []string{"nqn.2014-08.org.nvmexpress:" + strings.Repeat("a", 200)}, expectedErr: true},
+		"iscsi and nvme":   {initiators: []string{"iqn.1993-08.org.debian:01:5ae293b352a2", nqn}, expectedErr: true},
+		"no initiator set": {initiators: nil},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateHostInitiators(tc.initiators, tc.allowMixed)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestCreateHostWithInitiatorValidation(t *testing.T) {
+	hostURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id" + XHost
+	var created []string
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if req.Method+" "+req.URL.Path != "POST "+hostURL {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+			resp.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		param := &types.CreateHostParam{}
+		if err := json.NewDecoder(req.Body).Decode(param); err != nil {
+			t.Error(err)
+		}
+		created = append(created, param.HostID)
+		content, _ := json.Marshal(&types.Host{HostID: param.HostID})
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	nqn := "nqn.2014-08.org.nvmexpress:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+	mixed := []string{"10000090fa66060a", nqn}
+	client, err := New(server.URL, WithInitiatorValidation(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+	if _, err = client.CreateHost(ctx, "mock-sym-id", "host-mixed", mixed, nil); err == nil {
+		t.Error("expected mixed initiators to be refused")
+	}
+	if _, err = client.CreateHost(ctx, "mock-sym-id", "host-fc", mixed[:1], nil); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err = New(server.URL, WithInitiatorValidation(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.CreateHost(ctx, "mock-sym-id", "host-mixed", mixed, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(created, []string{"host-fc", "host-mixed"}) {
+		t.Errorf("unexpected hosts created %v", created)
+	}
+}
+
+func TestDeleteHostWithMaskingViews(t *testing.T) {
+	hostURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id" + XHost
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.Method + " " + req.URL.Path {
+		case "GET " + hostURL + "/host-mv":
+			body = &types.Host{HostID: "host-mv", MaskingviewIDs: []string{"mv-1", "mv-2"}}
+		case "GET " + hostURL + "/host-free":
+			body = &types.Host{HostID: "host-free"}
+		case "PUT " + hostURL + "/host-free":
+			param := &types.UpdateHostParam{}
+			if err := json.NewDecoder(req.Body).Decode(param); err != nil {
+				t.Error(err)
+			}
+			body = &types.Host{HostID: param.EditHostAction.RenameHostParam.NewHostName}
+		case "DELETE " + hostURL + "/host-mv", "DELETE " + hostURL + "/host-free":
+			deleted = append(deleted, path.Base(req.URL.Path))
+			resp.WriteHeader(http.StatusNoContent)
+			return
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Error(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+	err = client.DeleteHost(ctx, "mock-sym-id", "host-mv", types.DeleteHostOptions{CheckMaskingViews: true})
+	var inUse *HostInUseError
+	if !errors.As(err, &inUse) || !IsHostInUseError(err) {
+		t.Fatalf("expected HostInUseError, got %v", err)
+	}
+	if !reflect.DeepEqual(inUse.MaskingViews, []string{"mv-1", "mv-2"}) {
+		t.Errorf("unexpected masking views %v", inUse.MaskingViews)
+	}
+	if len(deleted) != 0 {
+		t.Fatalf("expected no deletion, got %v", deleted)
+	}
+	if err = client.DeleteHost(ctx, "mock-sym-id", "host-free", types.DeleteHostOptions{CheckMaskingViews: true}); err != nil {
+		t.Fatal(err)
+	}
+	// without options the masking views are not checked
+	if err = client.DeleteHost(ctx, "mock-sym-id", "host-mv"); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(deleted, []string{"host-free", "host-mv"}) {
+		t.Errorf("unexpected deletions %v", deleted)
+	}
+
+	host, err := client.UpdateHostName(ctx, "mock-sym-id", "host-free", "host-renamed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host.HostID != "host-renamed" {
+		t.Errorf("unexpected host %+v", host)
+	}
+	if _, err = client.UpdateHostName(ctx, "mock-sym-id", "host-free", ""); err == nil {
+		t.Error("expected an error for an empty name")
+	}
+}
+
+func TestNVMeHosts(t *testing.T) {
+	nqn := "nqn.1988-11.com.dell.mock:00:e6e2d5b871f1403E169D0"
+	symURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id"
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == symURL+XInitiator:
+			body = &types.InitiatorList{InitiatorIDs: []string{"OR-1C:001:" + nqn, "FA-1D:4:10000090fa66060a"}}
+		case req.Method == http.MethodPost && req.URL.Path == symURL+XHost:
+			param := &types.CreateHostParam{}
+			if err := json.NewDecoder(req.Body).Decode(param); err != nil {
+				t.Error(err)
+			}
+			body = &types.Host{HostID: param.HostID, HostType: types.HostTypeNVMeTCP, Initiators: param.InitiatorIDs}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Error(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	initiators, err := client.GetNVMeInitiatorList(context.TODO(), "mock-sym-id", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(initiators, []string{"OR-1C:001:" + nqn}) {
+		t.Fatalf("unexpected NVMe initiators %v", initiators)
+	}
+	host, err := client.CreateNVMeHost(context.TODO(), "mock-sym-id", "nvme-host", []string{nqn}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host.HostID != "nvme-host" || host.HostType != types.HostTypeNVMeTCP {
+		t.Fatalf("unexpected host %+v", host)
+	}
+	if _, err = client.CreateNVMeHost(context.TODO(), "mock-sym-id", "fc-host", []string{"10000090fa66060a"}, nil); err == nil {
+		t.Fatal("expected error for FC initiator")
+	}
+}
+
+func TestMigrateVolumesToSRP(t *testing.T) {
+	symURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id"
+	jobURL := urlPrefix + "system/symmetrix/mock-sym-id/job/"
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == symURL+"/srp/SRP_2":
+			body = &types.StoragePool{StoragePoolID: "SRP_2"}
+		case req.Method == http.MethodPut && strings.HasPrefix(req.URL.Path, symURL+XVolume+"/"):
+			payload := &types.EditVolumeParam{}
+			if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+				t.Error(err)
+			}
+			volumeID := strings.TrimPrefix(req.URL.Path, symURL+XVolume+"/")
+			if volumeID == "00003" || payload.EditVolumeActionParam.MigrateVolumeParam == nil ||
+				payload.EditVolumeActionParam.MigrateVolumeParam.SRPID != "SRP_2" || payload.ExecutionOption != types.ExecutionOptionAsynchronous {
+				resp.WriteHeader(http.StatusBadRequest)
+				_, _ = resp.Write([]byte(`{"message":"cannot migrate","httpStatusCode":400,"errorCode":0}`))
+				return
+			}
+			body = &types.Job{JobID: "job-" + volumeID, Status: types.JobStatusScheduled}
+		case req.Method == http.MethodGet && req.URL.Path == jobURL+"job-00001":
+			body = &types.Job{JobID: "job-00001", Status: types.JobStatusSucceeded}
+		case req.Method == http.MethodGet && req.URL.Path == jobURL+"job-00002":
+			body = &types.Job{JobID: "job-00002", Status: types.JobStatusRunning}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Error(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobIDs, err := client.MigrateVolumesToSRP(context.TODO(), "mock-sym-id", []string{"00001", "00002"}, "SRP_2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(jobIDs, []string{"job-00001", "job-00002"}) {
+		t.Fatalf("unexpected jobs %v", jobIDs)
+	}
+	progress, err := client.GetSRPMigrationProgress(context.TODO(), "mock-sym-id", "SRP_2", jobIDs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress.Done || progress.Succeeded != 1 || progress.Running != 1 || progress.PercentComplete != 50 {
+		t.Fatalf("unexpected progress %+v", progress)
+	}
+
+	jobIDs, err = client.MigrateVolumesToSRP(context.TODO(), "mock-sym-id", []string{"00001", "00003"}, "SRP_2")
+	if err == nil || !reflect.DeepEqual(jobIDs, []string{"job-00001"}) {
+		t.Fatalf("expected error with the started jobs, got %v, %v", jobIDs, err)
+	}
+	if _, err = client.MigrateVolumesToSRP(context.TODO(), "mock-sym-id", []string{"00001"}, "SRP_3"); err == nil {
+		t.Fatal("expected error for unknown SRP")
+	}
+}
+
+func TestGetDirectorPortMembership(t *testing.T) {
+	pgURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id" + XPortGroup
+	portGroups := map[string]*types.PortGroup{
+		"pg1": {PortGroupID: "pg1", SymmetrixPortKey: []types.PortKey{{DirectorID: "FA-1D", PortID: "4"}}, MaskingView: []string{"mv2", "mv1"}},
+		"pg2": {PortGroupID: "pg2", SymmetrixPortKey: []types.PortKey{{DirectorID: "FA-2D", PortID: "4"}}, MaskingView: []string{"mv3"}},
+		"pg3": {PortGroupID: "pg3", SymmetrixPortKey: []types.PortKey{{DirectorID: "FA-2D", PortID: "5"}, {DirectorID: "FA-1D", PortID: "4"}}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.URL.Path {
+		case pgURL:
+			if req.URL.Query().Get("dir_port") != "FA-1D:4" {
+				t.Errorf("unexpected query %s", req.URL.RawQuery)
+			}
+			// ignore the filter, as older releases do
+			body = &types.PortGroupList{PortGroupIDs: []string{"pg1", "pg2", "pg3"}}
+		default:
+			body = portGroups[strings.TrimPrefix(req.URL.Path, pgURL+"/")]
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	membership, err := client.GetDirectorPortMembership(context.TODO(), "mock-sym-id", "FA-1D", "4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(membership.PortGroups, []string{"pg1", "pg3"}) || !reflect.DeepEqual(membership.MaskingViews, []string{"mv1", "mv2"}) {
+		t.Errorf("unexpected membership %+v", membership)
+	}
+	if _, err = client.GetDirectorPortMembership(context.TODO(), "mock-sym-id", "FA-1D", ""); err == nil {
+		t.Error("expected an error for a missing port id")
+	}
+}
+
+func TestGetMaskingViewListWithFilter(t *testing.T) {
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != urlPrefix+SLOProvisioningX+SymmetrixX+"mock-sym-id"+XMaskingView {
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		queries = append(queries, req.URL.Query())
+		content, _ := json.Marshal(&types.MaskingViewList{MaskingViewIDs: []string{"mv-1"}})
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+	if _, err = client.GetMaskingViewList(ctx, "mock-sym-id"); err != nil {
+		t.Fatal(err)
+	}
+	mvList, err := client.GetMaskingViewList(ctx, "mock-sym-id", types.MaskingViewFilter{StorageGroupID: "sg 1", PortGroupID: "pg-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(mvList.MaskingViewIDs, []string{"mv-1"}) {
+		t.Errorf("unexpected masking views %v", mvList.MaskingViewIDs)
+	}
+	if _, err = client.GetMaskingViewList(ctx, "mock-sym-id", types.MaskingViewFilter{HostOrHostGroupID: "host-1"}); err != nil {
+		t.Fatal(err)
+	}
+	expected := []url.Values{
+		{},
+		{"storage_group_name": {"sg 1"}, "port_group_name": {"pg-1"}},
+		{"host_or_host_group_name": {"host-1"}},
+	}
+	if !reflect.DeepEqual(queries, expected) {
+		t.Errorf("expected queries %v, got %v", expected, queries)
+	}
+}
+
+func TestRecommendPortGroup(t *testing.T) {
+	symURL := urlPrefix + "system/symmetrix/000000000001"
+	ports := map[string]types.SymmetrixPortType{
+		"FA-1D:4": {PortStatus: "ON", DirectorStatus: "Online", Identifier: "wwn-1d4", NumOfPortGroups: 1},
+		"FA-1D:5": {PortStatus: "ON", DirectorStatus: "Online", Identifier: "wwn-1d5", NumOfPortGroups: 3},
+		"FA-2D:4": {PortStatus: "ON", DirectorStatus: "Online", Identifier: "wwn-2d4", NumOfPortGroups: 2},
+		"FA-2D:5": {PortStatus: "OFF", DirectorStatus: "Online", Identifier: "wwn-2d5"},
+	}
+	busy := map[string]float64{"FA-1D:4": 50, "FA-1D:5": 10, "FA-2D:4": 30}
+	registered := true
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch {
+		case req.URL.Path == symURL+"/director":
+			body = &types.DirectorIDList{DirectorIDs: []string{"FA-1D", "FA-2D"}}
+		case strings.HasSuffix(req.URL.Path, "/port"):
+			if req.URL.RawQuery != "type=FibreChannel" {
+				t.Errorf("unexpected port query %s", req.URL.RawQuery)
+			}
+			directorID := path.Base(path.Dir(req.URL.Path))
+			body = &types.PortList{SymmetrixPortKey: []types.PortKey{{DirectorID: directorID, PortID: "4"}, {DirectorID: directorID, PortID: "5"}}}
+		case strings.HasPrefix(req.URL.Path, symURL+"/director/"):
+			portID := path.Base(req.URL.Path)
+			directorID := path.Base(path.Dir(path.Dir(req.URL.Path)))
+			body = &types.Port{SymmetrixPort: ports[directorID+":"+portID]}
+		case req.URL.Path == "/"+RESTPrefix+Performance+Array+RegistrationDetails+"000000000001":
+			body = &types.PerformanceRegistrationDetailsResult{RegistrationDetails: []types.PerformanceRegistrationDetails{
+				{SymmetrixID: "000000000001", Diagnostic: registered},
+			}}
+		case req.URL.Path == "/"+RESTPrefix+Performance+Array+Keys:
+			body = &types.ArrayKeysResult{ArrayInfos: []types.ArrayInfo{{SymmetrixID: "000000000001", LastAvailableDate: 7200000}}}
+		case req.URL.Path == "/"+RESTPrefix+Performance+FEPort+Metrics:
+			param := types.FEPortMetricsParam{}
+			if err := json.NewDecoder(req.Body).Decode(&param); err != nil {
+				t.Error(err)
+			}
+			if param.StartDate != 3600000 || param.EndDate != 7200000 {
+				t.Errorf("unexpected metrics window %d-%d", param.StartDate, param.EndDate)
+			}
+			portBusy := busy[param.DirectorID+":"+param.PortID]
+			body = &types.FEPortMetricsIterator{ResultList: types.FEPortMetricsResultList{Result: []types.FEPortMetric{
+				{PercentBusy: portBusy - 5}, {PercentBusy: portBusy + 5},
+			}}}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+	recommendation, err := client.RecommendPortGroup(ctx, "000000000001", types.HostTypeFibre, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the least busy port of each director first, then the remaining port
+	expected := []types.PortKey{{DirectorID: "FA-1D", PortID: "5"}, {DirectorID: "FA-2D", PortID: "4"}, {DirectorID: "FA-1D", PortID: "4"}}
+	if !reflect.DeepEqual(recommendation.Ports, expected) {
+		t.Errorf("expected ports %v, got %v", expected, recommendation.Ports)
+	}
+	if !recommendation.MetricsAvailable || len(recommendation.Candidates) != 3 || recommendation.Candidates[0].PercentBusy != 10 {
+		t.Errorf("unexpected candidates %+v", recommendation.Candidates)
+	}
+
+	// without performance metrics the ports are ranked on their port groups
+	registered = false
+	if recommendation, err = client.RecommendPortGroup(ctx, "000000000001", types.HostTypeFibre, 2); err != nil {
+		t.Fatal(err)
+	}
+	expected = []types.PortKey{{DirectorID: "FA-1D", PortID: "4"}, {DirectorID: "FA-2D", PortID: "4"}}
+	if !reflect.DeepEqual(recommendation.Ports, expected) || recommendation.MetricsAvailable || recommendation.Candidates[0].PercentBusy != -1 {
+		t.Errorf("unexpected recommendation %+v", recommendation)
+	}
+
+	if _, err = client.RecommendPortGroup(ctx, "000000000001", types.HostTypeFibre, 4); err == nil {
+		t.Error("expected an error when not enough ports are online")
+	}
+	if _, err = client.RecommendPortGroup(ctx, "000000000001", "SAS", 2); err == nil {
+		t.Error("expected an error for an unsupported transport")
+	}
+}
+
+func TestModifyPortGroup(t *testing.T) {
+	pgURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id" + XPortGroup
+	var edits []types.EditPortGroupActionParam
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.Method + " " + req.URL.Path {
+		case "GET " + pgURL + "/pg-used":
+			body = &types.PortGroup{
+				PortGroupID:      "pg-used",
+				SymmetrixPortKey: []types.PortKey{{DirectorID: "FA-1D", PortID: "FA-1D:4"}, {DirectorID: "FA-2D", PortID: "FA-2D:4"}},
+				MaskingView:      []string{"mv-1"},
+			}
+		case "PUT " + pgURL + "/pg-used":
+			edit := types.EditPortGroup{}
+			if err := json.NewDecoder(req.Body).Decode(&edit); err != nil {
+				t.Fatal(err)
+			}
+			edits = append(edits, *edit.EditPortGroupActionParam)
+			body = &types.PortGroup{PortGroupID: "pg-used"}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	add := []types.PortKey{{DirectorID: "fa-1d", PortID: "4"}, {DirectorID: "FA-3D", PortID: "4"}}
+	remove := []types.PortKey{{DirectorID: "FA-2D", PortID: "4"}, {DirectorID: "FA-4D", PortID: "4"}}
+	if _, err = client.ModifyPortGroup(context.TODO(), "mock-sym-id", "pg-used", add, remove); err != nil {
+		t.Fatal(err)
+	}
+	expected := []types.EditPortGroupActionParam{
+		{AddPortParam: &types.AddPortParam{Ports: []types.SymmetrixPortKeyType{{DirectorID: "FA-3D", PortID: "4"}}}},
+		{RemovePortParam: &types.RemovePortParam{Ports: []types.SymmetrixPortKeyType{{DirectorID: "FA-2D", PortID: "4"}}}},
+	}
+	if !reflect.DeepEqual(expected, edits) {
+		t.Fatalf("unexpected edits %+v", edits)
+	}
+
+	edits = nil
+	remove = []types.PortKey{{DirectorID: "FA-1D", PortID: "4"}, {DirectorID: "FA-2D", PortID: "4"}}
+	_, err = client.ModifyPortGroup(context.TODO(), "mock-sym-id", "pg-used", nil, remove)
+	if !IsPortGroupInUseError(err) || len(edits) != 0 {
+		t.Fatalf("expected a PortGroupInUseError without edits, got %v and %d edits", err, len(edits))
+	}
+	if _, err = client.ModifyPortGroup(context.TODO(), "mock-sym-id", "pg-unknown", add, nil); err == nil {
+		t.Fatal("expected an error for an unknown port group")
+	}
+}
+
+func TestGetStorageGroupVolumeList(t *testing.T) {
+	volURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id" + XVolume
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != volURL {
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		query := req.URL.Query()
+		if query.Get("storageGroupId") != "sg 1" || query.Get("cap_cyl") != ">10" || query.Get("emulation") != "FBA" {
+			t.Errorf("unexpected query %s", req.URL.RawQuery)
+		}
+		// the filters are ignored, as by older releases
+		volumes := []types.VolumeDetail{
+			{Volume: types.Volume{VolumeID: "00001", Emulation: "FBA", CapacityCYL: 547}},
+			{Volume: types.Volume{VolumeID: "00002", Emulation: "FBA", CapacityCYL: 3}},
+			{Volume: types.Volume{VolumeID: "00003", Emulation: "CKD-3390", CapacityCYL: 1093}},
+			{Volume: types.Volume{VolumeID: "00004", Emulation: "FBA", CapacityCYL: 1093}},
+			{Volume: types.Volume{VolumeID: "00005", Emulation: "FBA", CapacityCYL: 547}},
+		}
+		content, _ := json.Marshal(&types.VolumeDetailIterator{
+			ResultList:  types.VolumeDetailResultList{VolumeList: volumes, From: 1, To: len(volumes)},
+			Count:       len(volumes),
+			MaxPageSize: 1000,
+		})
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	volumeIDs := func(volumes []types.VolumeDetail) []string {
+		ids := []string{}
+		for _, vol := range volumes {
+			ids = append(ids, vol.VolumeID)
+		}
+		return ids
+	}
+	opts := types.VolumeListOptions{ExcludeGatekeepers: true, Emulation: "FBA", SortByCapacity: true, Descending: true}
+	volumes, err := client.GetStorageGroupVolumeList(context.TODO(), "mock-sym-id", "sg 1", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := volumeIDs(volumes); !reflect.DeepEqual(ids, []string{"00004", "00005", "00001"}) {
+		t.Errorf("unexpected volumes %v", ids)
+	}
+	opts.Descending = false
+	volumes, err = client.GetStorageGroupVolumeList(context.TODO(), "mock-sym-id", "sg 1", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := volumeIDs(volumes); !reflect.DeepEqual(ids, []string{"00001", "00005", "00004"}) {
+		t.Errorf("unexpected volumes %v", ids)
+	}
+	if _, err = client.GetStorageGroupVolumeList(context.TODO(), "mock-sym-id", "", opts); err == nil {
+		t.Error("expected an error for a missing storage group")
+	}
+}
+
+func TestFormatVolume(t *testing.T) {
+	volURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id" + XVolume
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.Method + " " + req.URL.Path {
+		case "PUT " + volURL + "/00001":
+			payload := &types.EditVolumeParam{}
+			if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+				t.Error(err)
+			}
+			format := payload.EditVolumeActionParam.FormatVolumeParam
+			if format == nil || !format.Format || !format.SymForce || payload.ExecutionOption != types.ExecutionOptionAsynchronous {
+				t.Errorf("unexpected format payload %+v", payload)
+			}
+			body = &types.Job{JobID: "job-1", Status: types.JobStatusRunning}
+		case "GET " + urlPrefix + "system/symmetrix/mock-sym-id/job/job-1":
+			body = &types.Job{JobID: "job-1", Status: types.JobStatusSucceeded}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	job, err := client.FormatVolume(context.TODO(), "mock-sym-id", "00001", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job, err = client.WaitOnJobCompletion(context.TODO(), "mock-sym-id", job.JobID); err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != types.JobStatusSucceeded {
+		t.Errorf("unexpected job %+v", job)
+	}
+	if _, err = client.FormatVolume(context.TODO(), "mock-sym-id", "00002", false); err == nil {
+		t.Error("expected an error for an unknown volume")
+	}
+
+	client.SetSafeMode(true)
+	if _, err = client.FormatVolume(context.TODO(), "mock-sym-id", "00001", true); !api.IsDestructiveOperationError(err) {
+		t.Errorf("expected the format to be refused in safe mode, got %v", err)
+	}
+	if _, err = client.FormatVolume(api.WithAllowDestructive(context.TODO(), true), "mock-sym-id", "00001", true); err != nil {
+		t.Errorf("expected the format to be allowed, got %v", err)
+	}
+}
+
+func TestDeleteVolumeWithDeallocate(t *testing.T) {
+	volURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id" + XVolume + "/00001"
+	jobURL := urlPrefix + "system/symmetrix/mock-sym-id/job/job-1"
+	allocated := []int{100, 60, 20, 0}
+	jobStatus := []string{types.JobStatusRunning, types.JobStatusRunning, types.JobStatusSucceeded}
+	var deleted bool
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == volURL:
+			body = &types.Volume{VolumeID: "00001", AllocatedPercent: allocated[0]}
+			if len(allocated) > 1 {
+				allocated = allocated[1:]
+			}
+		case req.Method == http.MethodPut && req.URL.Path == volURL:
+			body = &types.Job{JobID: "job-1", Status: types.JobStatusScheduled}
+		case req.Method == http.MethodGet && req.URL.Path == jobURL:
+			body = &types.Job{JobID: "job-1", Status: jobStatus[0]}
+			if len(jobStatus) > 1 {
+				jobStatus = jobStatus[1:]
+			}
+		case req.Method == http.MethodDelete && req.URL.Path == volURL:
+			if allocated[0] != 0 {
+				t.Error("volume deleted before its tracks were deallocated")
+			}
+			deleted = true
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if body != nil {
+			content, _ := json.Marshal(body)
+			_, _ = resp.Write(content)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reported []types.DeallocationProgress
+	err = client.DeleteVolumeWithDeallocate(context.TODO(), "mock-sym-id", "00001", time.Millisecond, func(p types.DeallocationProgress) {
+		reported = append(reported, p)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !deleted {
+		t.Error("expected the volume to be deleted")
+	}
+	last := reported[len(reported)-1]
+	if len(reported) != 3 || last.JobStatus != types.JobStatusSucceeded || last.AllocatedPercent != 0 || reported[0].AllocatedPercent != 60 {
+		t.Errorf("unexpected progress %+v", reported)
+	}
+
+	// a failed deallocation job stops the deletion
+	allocated = []int{100}
+	jobStatus = []string{types.JobStatusFailed}
+	deleted = false
+	if err = client.DeleteVolumeWithDeallocate(context.TODO(), "mock-sym-id", "00001", time.Millisecond, nil); err == nil || deleted {
+		t.Errorf("expected the deallocation to fail without deleting the volume, got %v", err)
+	}
+
+	// as does a job in an unknown state
+	allocated = []int{100}
+	jobStatus = []string{"SUSPENDED"}
+	if err = client.DeleteVolumeWithDeallocate(context.TODO(), "mock-sym-id", "00001", time.Millisecond, nil); err == nil || deleted {
+		t.Errorf("expected the deallocation to fail without deleting the volume, got %v", err)
+	}
+
+	// and the context
+	jobStatus = []string{types.JobStatusRunning}
+	ctx, cancel := context.WithTimeout(context.TODO(), 20*time.Millisecond)
+	defer cancel()
+	if err = client.DeleteVolumeWithDeallocate(ctx, "mock-sym-id", "00001", time.Millisecond, nil); !errors.Is(err, context.DeadlineExceeded) || deleted {
+		t.Errorf("expected the deallocation to time out without deleting the volume, got %v", err)
+	}
+}
+
+func TestListModifiedSince(t *testing.T) {
+	since := time.UnixMilli(1700000000000)
+	filter := "?" + ModifiedSinceParam + "=%3E1700000000000"
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch strings.TrimPrefix(req.RequestURI, urlPrefix+SLOProvisioningX+SymmetrixX+"sym-id") {
+		case XStorageGroup + filter:
+			body = &types.StorageGroupIDList{StorageGroupIDs: []string{"sg-2"}}
+		case XStorageGroup:
+			body = &types.StorageGroupIDList{StorageGroupIDs: []string{"sg-1", "sg-2"}}
+		case XVolume + filter:
+			body = &types.VolumeIterator{Count: 1, MaxPageSize: 1000, ResultList: types.VolumeResultList{
+				VolumeList: []types.VolumeIDList{{VolumeIDs: "00002"}}, From: 1, To: 1,
+			}}
+		case XMaskingView:
+			body = &types.MaskingViewList{MaskingViewIDs: []string{"mv-1"}}
+		case XMaskingView + filter:
+			resp.WriteHeader(http.StatusBadRequest)
+			_, _ = resp.Write([]byte(`{"message":"Invalid query parameter","httpStatusCode":400,"errorCode":0}`))
+			return
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Error(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	ctx := context.TODO()
+	// without the filter everything is listed
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sgs, err := client.ListStorageGroupsModifiedSince(ctx, "sym-id", since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sgs.Full || len(sgs.IDs) != 2 {
+		t.Fatalf("unexpected storage groups %+v", sgs)
+	}
+
+	client, err = New(server.URL, WithInsecure(), WithModifiedSinceFilter())
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+
+	sgs, err = client.ListStorageGroupsModifiedSince(ctx, "sym-id", since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sgs.Full || !reflect.DeepEqual([]string{"sg-2"}, sgs.IDs) || sgs.ListedAt.Before(start) {
+		t.Fatalf("unexpected storage groups %+v", sgs)
+	}
+	// a zero time lists everything
+	sgs, err = client.ListStorageGroupsModifiedSince(ctx, "sym-id", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sgs.Full || len(sgs.IDs) != 2 {
+		t.Fatalf("unexpected storage groups %+v", sgs)
+	}
+
+	volumes, err := client.ListVolumesModifiedSince(ctx, "sym-id", since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if volumes.Full || !reflect.DeepEqual([]string{"00002"}, volumes.IDs) {
+		t.Fatalf("unexpected volumes %+v", volumes)
+	}
+
+	// the filter is rejected, all the masking views are listed
+	mvs, err := client.ListMaskingViewsModifiedSince(ctx, "sym-id", since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !mvs.Full || !reflect.DeepEqual([]string{"mv-1"}, mvs.IDs) {
+		t.Fatalf("unexpected masking views %+v", mvs)
+	}
+
+	// other errors are returned
+	if _, err = client.ListVolumesModifiedSince(ctx, "sym-id", time.Time{}); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestSwapMaskingView(t *testing.T) {
+	views := map[string]types.MaskingView{
+		"mv-1": {MaskingViewID: "mv-1", HostID: "host-1", PortGroupID: "pg-1", StorageGroupID: "sg-1"},
+	}
+	failDelete := false
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		mvPrefix := urlPrefix + SLOProvisioningX + SymmetrixX + "sym-id" + XMaskingView
+		name := strings.TrimPrefix(strings.TrimPrefix(req.URL.Path, mvPrefix), "/")
+		calls = append(calls, req.Method+" "+name)
+		var body interface{}
+		switch req.Method {
+		case http.MethodGet:
+			mv, ok := views[name]
+			if !ok {
+				resp.WriteHeader(http.StatusNotFound)
+				_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+				return
+			}
+			body = mv
+		case http.MethodPost:
+			payload := &types.MaskingViewCreateParam{}
+			if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+				t.Error(err)
+			}
+			mv := types.MaskingView{
+				MaskingViewID:  payload.MaskingViewID,
+				PortGroupID:    payload.PortGroupSelection.UseExistingPortGroupParam.PortGroupID,
+				StorageGroupID: payload.StorageGroupSelection.UseExistingStorageGroupParam.StorageGroupID,
+			}
+			if host := payload.HostOrHostGroupSelection.UseExistingHostParam; host != nil {
+				mv.HostID = host.HostID
+			} else {
+				mv.HostGroupID = payload.HostOrHostGroupSelection.UseExistingHostGroupParam.HostGroupID
+			}
+			views[mv.MaskingViewID] = mv
+			body = mv
+		case http.MethodDelete:
+			if failDelete && name == "mv-1" {
+				resp.WriteHeader(http.StatusBadRequest)
+				_, _ = resp.Write([]byte(`{"message":"cannot delete","httpStatusCode":400,"errorCode":0}`))
+				return
+			}
+			delete(views, name)
+			resp.WriteHeader(http.StatusNoContent)
+			return
+		case http.MethodPut:
+			payload := &types.EditMaskingViewParam{}
+			if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+				t.Error(err)
+			}
+			mv := views[name]
+			delete(views, name)
+			mv.MaskingViewID = payload.EditMaskingViewActionParam.RenameMaskingViewParam.NewMaskingViewName
+			views[mv.MaskingViewID] = mv
+			body = mv
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Error(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mv, err := client.SwapMaskingViewPortGroup(context.TODO(), "sym-id", "mv-1", "pg-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := types.MaskingView{MaskingViewID: "mv-1", HostID: "host-1", PortGroupID: "pg-2", StorageGroupID: "sg-1"}
+	if *mv != expected || len(views) != 1 || views["mv-1"] != expected {
+		t.Fatalf("unexpected masking views %+v %+v", mv, views)
+	}
+	expectedCalls := []string{"GET mv-1", "POST ", "DELETE mv-1", "PUT mv-1_swap"}
+	if !reflect.DeepEqual(expectedCalls, calls) {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+
+	// nothing to swap
+	calls = nil
+	if _, err = client.SwapMaskingViewPortGroup(context.TODO(), "sym-id", "mv-1", "pg-2"); err != nil || len(calls) != 1 {
+		t.Fatalf("unexpected calls %v, error %v", calls, err)
+	}
+
+	// the new masking view is removed if the original one cannot be deleted
+	failDelete = true
+	if _, err = client.SwapMaskingViewHost(context.TODO(), "sym-id", "mv-1", "hg-1", false); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(views) != 1 || views["mv-1"] != expected {
+		t.Fatalf("unexpected masking views %+v", views)
+	}
+
+	failDelete = false
+	// nothing is created in safe mode or dry-run mode
+	for _, set := range []func(bool){client.SetSafeMode, client.SetDryRun} {
+		set(true)
+		calls = nil
+		if _, err = client.SwapMaskingViewHost(context.TODO(), "sym-id", "mv-1", "hg-1", false); err == nil {
+			t.Fatal("expected an error")
+		}
+		if !reflect.DeepEqual([]string{"GET mv-1"}, calls) || len(views) != 1 {
+			t.Fatalf("unexpected calls %v, masking views %+v", calls, views)
+		}
+		set(false)
+	}
+
+	mv, err = client.SwapMaskingViewHost(context.TODO(), "sym-id", "mv-1", "hg-1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mv.HostGroupID != "hg-1" || mv.HostID != "" || mv.PortGroupID != "pg-2" {
+		t.Fatalf("unexpected masking view %+v", mv)
+	}
+}
+
+func TestGetHostIDListByInitiator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch strings.TrimPrefix(req.RequestURI, urlPrefix+SLOProvisioningX+SymmetrixX+"sym-id"+XInitiator) {
+		case "?in_a_host=true&initiator_hba=10000090fa66060a":
+			body = &types.InitiatorList{InitiatorIDs: []string{"FA-2D:4:10000090fa66060a", "FA-1D:4:10000090fa66060a", "FA-1D:4:10000090fa66060ab"}}
+		case "?in_a_host=true&initiator_hba=10000090fa660000":
+			body = &types.InitiatorList{}
+		case "/FA-1D:4:10000090fa66060a":
+			body = &types.Initiator{InitiatorID: "10000090fa66060a", HostID: "host-1", MaskingView: []string{"mv-2", "mv-1"}}
+		case "/FA-2D:4:10000090fa66060a":
+			body = &types.Initiator{InitiatorID: "10000090fa66060a", Host: "host-1", MaskingView: []string{"mv-1"}}
+		default:
+			t.Errorf("unexpected request %s", req.RequestURI)
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Error(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hosts, err := client.GetHostIDListByInitiator(context.TODO(), "sym-id", "10000090fa66060a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &types.InitiatorHosts{
+		Initiator:      "10000090fa66060a",
+		InitiatorIDs:   []string{"FA-1D:4:10000090fa66060a", "FA-2D:4:10000090fa66060a"},
+		HostIDs:        []string{"host-1"},
+		MaskingViewIDs: []string{"mv-1", "mv-2"},
+	}
+	if !reflect.DeepEqual(expected, hosts) {
+		t.Fatalf("expected %+v, got %+v", expected, hosts)
+	}
+
+	hosts, err = client.GetHostIDListByInitiator(context.TODO(), "sym-id", "10000090fa660000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts.HostIDs) != 0 || len(hosts.InitiatorIDs) != 0 {
+		t.Fatalf("unexpected hosts %+v", hosts)
+	}
+}
+
+func TestCreateVolumesWithAppendNumber(t *testing.T) {
+	volumes := []types.VolumeDetail{
+		{Volume: types.Volume{VolumeID: "00001", VolumeIdentifier: "vol_1", Emulation: "FBA", StorageGroupIDList: []string{"sg-1"}}},
+		{Volume: types.Volume{VolumeID: "00002", VolumeIdentifier: "volume_2", Emulation: "FBA", StorageGroupIDList: []string{"sg-1"}}},
+		{Volume: types.Volume{VolumeID: "00003", VolumeIdentifier: "vol_3", Emulation: "FBA", StorageGroupIDList: []string{"sg-2"}}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case urlPrefix + SLOProvisioningX + SymmetrixX + "sym-id" + XVolume:
+			if query := req.URL.Query(); query.Get("volume_identifier") != "<like>vol" || query.Get("storageGroupId") != "sg-1" {
+				t.Errorf("unexpected query %s", req.URL.RawQuery)
+			}
+			// the filters are ignored, as by older releases
+			content, _ := json.Marshal(&types.VolumeDetailIterator{
+				ResultList:  types.VolumeDetailResultList{VolumeList: volumes, From: 1, To: len(volumes)},
+				Count:       len(volumes),
+				MaxPageSize: 1000,
+			})
+			_, _ = resp.Write(content)
+		case urlPrefix + SLOProvisioningX + SymmetrixX + "sym-id" + XStorageGroup + "/sg-1":
+			payload := &types.UpdateStorageGroupPayload{}
+			if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+				t.Error(err)
+			}
+			attributes := payload.EditStorageGroupActionParam.ExpandStorageGroupParam.AddVolumeParam.VolumeAttributes[0]
+			if attributes.NumberOfVolumes != 2 || attributes.VolumeSize != "10" || attributes.CapacityUnit != "GB" ||
+				*attributes.VolumeIdentifier != (types.VolumeIdentifierType{VolumeIdentifierChoice: VolumeIdentifierAppendNumber, IdentifierName: "vol", AppendNumber: "1"}) {
+				t.Errorf("unexpected volume attributes %+v", attributes)
+			}
+			// vol_1 exists, so the numbers 2 and 4 are used
+			volumes = append(volumes,
+				types.VolumeDetail{Volume: types.Volume{VolumeID: "00005", VolumeIdentifier: "vol_4", Emulation: "FBA", StorageGroupIDList: []string{"sg-1"}}},
+				types.VolumeDetail{Volume: types.Volume{VolumeID: "00004", VolumeIdentifier: "vol_2", Emulation: "FBA", StorageGroupIDList: []string{"sg-1"}}},
+			)
+			_, _ = resp.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL)
+			resp.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	created, err := client.CreateVolumesWithAppendNumber(context.TODO(), "sym-id", "sg-1", "vol", 2, 1, 10, "GB")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []types.NumberedVolume{{Number: 2, VolumeID: "00004", VolumeIdentifier: "vol_2"}, {Number: 4, VolumeID: "00005", VolumeIdentifier: "vol_4"}}
+	if !reflect.DeepEqual(expected, created) {
+		t.Fatalf("expected %+v, got %+v", expected, created)
+	}
+
+	inRange, err := client.GetVolumesByIdentifierRange(context.TODO(), "sym-id", "sg-1", "vol", 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = []types.NumberedVolume{{Number: 1, VolumeID: "00001", VolumeIdentifier: "vol_1"}, {Number: 2, VolumeID: "00004", VolumeIdentifier: "vol_2"}}
+	if !reflect.DeepEqual(expected, inRange) {
+		t.Fatalf("expected %+v, got %+v", expected, inRange)
+	}
+
+	if _, err = client.CreateVolumesWithAppendNumber(context.TODO(), "sym-id", "sg-1", "vol", 0, 1, 10, "GB"); err == nil {
+		t.Fatal("expected an error for no volumes")
+	}
+}
+
+func TestGetStoragePoolServiceLevels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch strings.TrimPrefix(req.URL.Path, urlPrefix+SLOProvisioningX+SymmetrixX+"sym-id") {
+		case "/" + StorageResourcePool + "/SRP_1":
+			body = &types.StoragePool{StoragePoolID: "SRP_1", ServiceLevels: []string{"Gold Fast", "Optimized"}}
+		case XSLO:
+			body = &types.ServiceLevelList{ServiceLevelIDs: []string{"Diamond", "Gold Fast", "Optimized"}}
+		case XSLO + "/Gold Fast":
+			body = &types.ServiceLevel{ServiceLevelID: "Gold Fast", BaseServiceLevelID: "Gold", NumStorageGroups: 1, StorageGroupIDs: []string{"sg-1"}}
+		case XSLO + "/Optimized":
+			body = &types.ServiceLevel{ServiceLevelID: "Optimized"}
+		default:
+			t.Errorf("unexpected request %s", req.URL)
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Error(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err := client.GetServiceLevelList(context.TODO(), "sym-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.ServiceLevelIDs) != 3 {
+		t.Fatalf("unexpected service levels %v", list.ServiceLevelIDs)
+	}
+	serviceLevels, err := client.GetStoragePoolServiceLevels(context.TODO(), "sym-id", "SRP_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(serviceLevels) != 2 || serviceLevels[0].ServiceLevelID != "Gold Fast" || serviceLevels[1].ServiceLevelID != "Optimized" {
+		t.Fatalf("unexpected service levels %+v", serviceLevels)
+	}
+	if band := serviceLevels[0].LatencyBand; band == nil || *band != ServiceLevelLatencyBands["Gold"] {
+		t.Fatalf("unexpected latency band %+v", band)
+	}
+	if serviceLevels[1].LatencyBand != nil {
+		t.Fatalf("unexpected latency band %+v for Optimized", serviceLevels[1].LatencyBand)
+	}
+}
+
+func TestAuditHostFlags(t *testing.T) {
+	hosts := map[string]*types.Host{
+		"linux-ok":      {HostID: "linux-ok", EnabledFlags: "SPC2_Protocol_Version,Volume_Set_Addressing"},
+		"linux-drifted": {HostID: "linux-drifted", EnabledFlags: "Volume_Set_Addressing, SCSI_3"},
+		"esx-ok":        {HostID: "esx-ok"},
+		"other":         {HostID: "other"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		hostID := strings.TrimPrefix(req.URL.Path, urlPrefix+SLOProvisioningX+SymmetrixX+"sym-id"+XHost)
+		switch hostID {
+		case "":
+			body = &types.HostList{HostIDs: []string{"linux-ok", "linux-drifted", "esx-ok", "other", "linux-missing"}}
+		default:
+			host, ok := hosts[strings.TrimPrefix(hostID, "/")]
+			if !ok {
+				resp.WriteHeader(http.StatusNotFound)
+				_, _ = resp.Write([]byte(`{"message":"host not found","httpStatusCode":404,"errorCode":0}`))
+				return
+			}
+			body = host
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Error(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	linux := &types.HostFlagPolicy{Name: "linux", Enabled: []string{"SPC2_Protocol_Version"}, Disabled: []string{"SCSI_3"}}
+	esx := &types.HostFlagPolicy{Name: "esx", Disabled: []string{"SCSI_3"}}
+	report, err := client.AuditHostFlags(context.TODO(), "sym-id", func(host *types.Host) *types.HostFlagPolicy {
+		switch {
+		case strings.HasPrefix(host.HostID, "linux"):
+			return linux
+		case strings.HasPrefix(host.HostID, "esx"):
+			return esx
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.HostsAudited != 3 || report.HostsSkipped != 1 || len(report.Drifts) != 2 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	expected := types.HostFlagDrift{HostID: "linux-drifted", Policy: "linux", MissingFlags: []string{"SPC2_Protocol_Version"}, UnexpectedFlags: []string{"SCSI_3"}}
+	if !reflect.DeepEqual(expected, report.Drifts[0]) {
+		t.Fatalf("expected %+v, got %+v", expected, report.Drifts[0])
+	}
+	if report.Drifts[1].HostID != "linux-missing" || !strings.Contains(report.Drifts[1].Error, "host not found") {
+		t.Fatalf("unexpected drift %+v", report.Drifts[1])
+	}
+}
+
+func TestRelabelStorageGroupVolumes(t *testing.T) {
+	volumes := []types.VolumeDetail{
+		{Volume: types.Volume{VolumeID: "00003", Emulation: "FBA", VolumeIdentifier: "db_3"}},
+		{Volume: types.Volume{VolumeID: "00001", Emulation: "FBA"}},
+		{Volume: types.Volume{VolumeID: "00002", Emulation: "FBA", VolumeIdentifier: "clone"}},
+	}
+	var mu sync.Mutex
+	renamed := map[string]string{}
+	failVolume := "00002"
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		volumesURL := urlPrefix + SLOProvisioningX + SymmetrixX + "sym-id" + XVolume
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == volumesURL:
+			content, _ := json.Marshal(&types.VolumeDetailIterator{
+				ResultList:  types.VolumeDetailResultList{VolumeList: volumes, From: 1, To: len(volumes)},
+				Count:       len(volumes),
+				MaxPageSize: 1000,
+			})
+			_, _ = resp.Write(content)
+		case req.Method == http.MethodPut && strings.HasPrefix(req.URL.Path, volumesURL+"/"):
+			volumeID := strings.TrimPrefix(req.URL.Path, volumesURL+"/")
+			if volumeID == failVolume {
+				resp.WriteHeader(http.StatusBadRequest)
+				_, _ = resp.Write([]byte(`{"message":"volume is busy","httpStatusCode":400,"errorCode":0}`))
+				return
+			}
+			payload := &types.EditVolumeParam{}
+			if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+				t.Error(err)
+			}
+			mu.Lock()
+			renamed[volumeID] = payload.EditVolumeActionParam.ModifyVolumeIdentifierParam.VolumeIdentifier.IdentifierName
+			mu.Unlock()
+			_, _ = resp.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL)
+			resp.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	relabels, err := client.RelabelStorageGroupVolumes(context.TODO(), "sym-id", "sg-1", "db_", 1)
+	if err == nil || !strings.Contains(err.Error(), "1 of the 3 volumes") {
+		t.Fatalf("expected an error for the busy volume, got %v", err)
+	}
+	expected := []types.VolumeRelabel{
+		{VolumeID: "00001", VolumeIdentifier: "db_1"},
+		{VolumeID: "00002", OldIdentifier: "clone", VolumeIdentifier: "db_2", Error: relabels[1].Error},
+		{VolumeID: "00003", OldIdentifier: "db_3", VolumeIdentifier: "db_3", AlreadyIdentified: true},
+	}
+	if !reflect.DeepEqual(expected, relabels) || !strings.Contains(relabels[1].Error, "volume is busy") {
+		t.Fatalf("expected %+v, got %+v", expected, relabels)
+	}
+	if !reflect.DeepEqual(map[string]string{"00001": "db_1"}, renamed) {
+		t.Fatalf("unexpected renames %v", renamed)
+	}
+
+	failVolume = ""
+	if _, err = client.RelabelStorageGroupVolumes(context.TODO(), "sym-id", "sg-1", "db_", 1); err != nil {
+		t.Fatal(err)
+	}
+	if renamed["00002"] != "db_2" {
+		t.Fatalf("unexpected renames %v", renamed)
+	}
+	if _, err = client.RelabelStorageGroupVolumes(context.TODO(), "sym-id", "sg-1", strings.Repeat("x", MaxVolIdentifierLength), 1); err == nil {
+		t.Fatal("expected an error for a too long identifier")
+	}
+}
+
+func TestUpdateHostInitiatorsInChunks(t *testing.T) {
+	hostURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id" + XHost + "/host1"
+	initiators := []string{"10000000", "10000001"}
+	var requests []int
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != hostURL {
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if req.Method == http.MethodGet {
+			content, _ := json.Marshal(&types.Host{HostID: "host1", Initiators: initiators})
+			_, _ = resp.Write(content)
+			return
+		}
+		param := struct {
+			EditHostAction struct {
+				AddInitiator    *types.ChangeInitiatorParam `json:"addInitiatorParam"`
+				RemoveInitiator *types.ChangeInitiatorParam `json:"removeInitiatorParam"`
+			} `json:"editHostActionParam"`
+		}{}
+		if err := json.NewDecoder(req.Body).Decode(&param); err != nil {
+			t.Error(err)
+		}
+		if change := param.EditHostAction.AddInitiator; change != nil {
+			requests = append(requests, len(change.Initiators))
+			// the initiator 20000003 is logged in to another host
+			if slices.Contains(change.Initiators, "20000003") {
+				resp.WriteHeader(http.StatusBadRequest)
+				_, _ = resp.Write([]byte(`{"message":"initiator in use","httpStatusCode":400,"errorCode":0}`))
+				return
+			}
+			initiators = append(initiators, change.Initiators...)
+		}
+		if change := param.EditHostAction.RemoveInitiator; change != nil {
+			requests = append(requests, -len(change.Initiators))
+			initiators = slices.DeleteFunc(initiators, func(initiator string) bool { return slices.Contains(change.Initiators, initiator) })
+		}
+		resp.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	host := &types.Host{HostID: "host1", Initiators: slices.Clone(initiators)}
+	update, err := client.UpdateHostInitiatorsInChunks(context.TODO(), "mock-sym-id", host,
+		[]string{"10000000", "20000001", "20000002", "20000003", "20000004", "20000005"}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the chunk with 20000003 fails and is retried one by one
+	if !slices.Equal(requests, []int{2, 2, 1, 1, 1, -1}) {
+		t.Errorf("unexpected requests %v", requests)
+	}
+	if update.Failed != 1 || len(update.Results) != 6 {
+		t.Fatalf("unexpected update %+v", update)
+	}
+	for _, result := range update.Results {
+		if (result.Error != "") != (result.InitiatorID == "20000003") {
+			t.Errorf("unexpected result %+v", result)
+		}
+	}
+	if last := update.Results[5]; last.InitiatorID != "10000001" || last.Action != types.HostInitiatorActionRemove {
+		t.Errorf("unexpected removal %+v", last)
+	}
+	if want := []string{"10000000", "20000001", "20000002", "20000004", "20000005"}; !slices.Equal(update.Host.Initiators, want) {
+		t.Errorf("expected the host to have %v, got %v", want, update.Host.Initiators)
+	}
+}
+
+func TestGetStorageGroupIncludeVolumes(t *testing.T) {
+	symURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id"
+	volumes := []types.VolumeDetail{{Volume: types.Volume{VolumeID: "00001", Emulation: "FBA", CapacityGB: 10}}}
+	sgReads, volumeReads := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.URL.Path {
+		case symURL + XStorageGroup + "/sg 1":
+			sgReads++
+			body = &types.StorageGroup{StorageGroupID: "sg 1", NumOfVolumes: 2}
+		case symURL + XVolume:
+			volumeReads++
+			if req.URL.Query().Get("storageGroupId") != "sg 1" || req.URL.Query().Get("details") != "true" {
+				t.Errorf("unexpected query %s", req.URL.RawQuery)
+			}
+			body = &types.VolumeDetailIterator{
+				ResultList:  types.VolumeDetailResultList{VolumeList: volumes, From: 1, To: len(volumes)},
+				Count:       len(volumes),
+				MaxPageSize: 1000,
+			}
+			// a volume is added while the storage group is read
+			volumes = append(volumes, types.VolumeDetail{Volume: types.Volume{VolumeID: "00002", Emulation: "FBA", CapacityGB: 20}})
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sg, err := client.GetStorageGroup(context.TODO(), "mock-sym-id", "sg 1")
+	if err != nil || sg.Volumes != nil || volumeReads != 0 {
+		t.Fatalf("expected the volumes not to be read, got %+v, %v", sg, err)
+	}
+	sgReads = 0
+	sg, err = client.GetStorageGroup(context.TODO(), "mock-sym-id", "sg 1", types.GetStorageGroupOptions{IncludeVolumes: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sg.Volumes) != 2 || sg.Volumes[1].VolumeID != "00002" || sg.Volumes[1].CapacityGB != 20 {
+		t.Errorf("unexpected volumes %+v", sg.Volumes)
+	}
+	if sgReads != 2 || volumeReads != 2 {
+		t.Errorf("expected the storage group to be read again, got %d and %d reads", sgReads, volumeReads)
+	}
+}
+
+func TestGetHostGroupInheritance(t *testing.T) {
+	provisioningURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id"
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.URL.Path {
+		case provisioningURL + XHostGroup + "/cluster":
+			body = &types.HostGroup{
+				HostGroupID:   "cluster",
+				EnabledFlags:  "SPC2_Protocol_Version,SCSI_3",
+				DisabledFlags: "OpenVMS",
+				ConsistentLun: true,
+				Hosts: []types.HostSummary{
+					{HostID: "node1", Initiators: []string{"iqn.b"}},
+					{HostID: "node2", Initiators: []string{"iqn.c"}},
+					{HostID: "node3", Initiators: []string{"iqn.d"}},
+				},
+			}
+		case provisioningURL + XHost + "/node1":
+			body = &types.Host{HostID: "node1", Initiators: []string{"iqn.a", "iqn.b"}, ConsistentLun: true}
+		case provisioningURL + XHost + "/node2":
+			body = &types.Host{HostID: "node2", Initiators: []string{"iqn.b", "iqn.c"}, EnabledFlags: "OpenVMS", DisabledFlags: "SCSI_3"}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	inheritance, err := client.GetHostGroupInheritance(context.TODO(), "mock-sym-id", "cluster")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual([]string{"iqn.a", "iqn.b", "iqn.c", "iqn.d"}, inheritance.Initiators) {
+		t.Errorf("unexpected initiators %v", inheritance.Initiators)
+	}
+	node1, node2, node3 := inheritance.Hosts[0], inheritance.Hosts[1], inheritance.Hosts[2]
+	if !reflect.DeepEqual([]string{"SCSI_3", "SPC2_Protocol_Version"}, node1.EnabledFlags) || !reflect.DeepEqual([]string{"OpenVMS"}, node1.DisabledFlags) {
+		t.Errorf("unexpected flags of node1 %+v", node1)
+	}
+	if !reflect.DeepEqual([]string{"OpenVMS", "SPC2_Protocol_Version"}, node2.EnabledFlags) || !reflect.DeepEqual([]string{"SCSI_3"}, node2.DisabledFlags) {
+		t.Errorf("unexpected flags of node2 %+v", node2)
+	}
+	if node3.Error == "" || !reflect.DeepEqual([]string{"iqn.d"}, node3.Initiators) || !node3.ConsistentLun {
+		t.Errorf("unexpected unreadable host %+v", node3)
+	}
+	expected := []types.HostFlagConflict{
+		{Flag: types.ConsistentLunFlag, EnabledBy: []string{"cluster", "node1"}, DisabledBy: []string{"node2"}},
+		{Flag: "OpenVMS", EnabledBy: []string{"node2"}, DisabledBy: []string{"cluster"}},
+		{Flag: "SCSI_3", EnabledBy: []string{"cluster"}, DisabledBy: []string{"node2"}},
+	}
+	if !reflect.DeepEqual(expected, inheritance.Conflicts) {
+		t.Errorf("unexpected conflicts %+v", inheritance.Conflicts)
+	}
+	if _, err = client.GetHostGroupInheritance(context.TODO(), "mock-sym-id", "unknown"); err == nil {
+		t.Error("expected an error for an unknown host group")
+	}
+}
+
+func TestInitiatorPortFlags(t *testing.T) {
+	provisioningURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id"
+	var update *types.UpdateInitiatorParam
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.Method + " " + req.URL.Path {
+		case "PUT " + provisioningURL + XInitiator + "/FA-1D:4:iqn.a":
+			update = &types.UpdateInitiatorParam{}
+			if err := json.NewDecoder(req.Body).Decode(update); err != nil {
+				t.Fatal(err)
+			}
+			body = &types.Initiator{InitiatorID: "FA-1D:4:iqn.a", Host: "node1", PortFlagsOverride: true, EnabledFlags: "OpenVMS"}
+		case "GET " + provisioningURL + XInitiator + "/FA-1D:4:iqn.a":
+			body = &types.Initiator{InitiatorID: "FA-1D:4:iqn.a", Host: "node1", PortFlagsOverride: true, EnabledFlags: "OpenVMS", DisabledFlags: "SCSI_3"}
+		case "GET " + provisioningURL + XInitiator + "/FA-1D:4:iqn.b":
+			body = &types.Initiator{InitiatorID: "FA-1D:4:iqn.b", Host: "node1", EnabledFlags: "OpenVMS", FlagsInEffect: "SCSI_3, SPC2_Protocol_Version"}
+		case "GET " + provisioningURL + XHost + "/node1":
+			body = &types.Host{HostID: "node1", EnabledFlags: "SPC2_Protocol_Version,SCSI_3"}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flags := &types.HostFlags{OpenVMS: &types.HostFlag{Enabled: true, Override: true}}
+	initiator, err := client.SetInitiatorPortFlags(context.TODO(), "mock-sym-id", "FA-1D:4:iqn.a", flags)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !initiator.PortFlagsOverride || update.EditInitiatorAction.SetHostFlags.HostFlags.OpenVMS == nil || !update.EditInitiatorAction.SetHostFlags.HostFlags.OpenVMS.Override {
+		t.Fatalf("unexpected update %+v of initiator %+v", update, initiator)
+	}
+	if _, err = client.SetInitiatorPortFlags(context.TODO(), "mock-sym-id", "FA-1D:4:iqn.a", nil); err == nil {
+		t.Fatal("expected an error without flags")
+	}
+
+	computed, err := client.GetInitiatorFlags(context.TODO(), "mock-sym-id", "FA-1D:4:iqn.a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual([]string{"OpenVMS", "SPC2_Protocol_Version"}, computed.EffectiveFlags) || !reflect.DeepEqual([]string{"SCSI_3"}, computed.DisabledFlags) {
+		t.Errorf("unexpected computed flags %+v", computed)
+	}
+	reported, err := client.GetInitiatorFlags(context.TODO(), "mock-sym-id", "FA-1D:4:iqn.b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual([]string{"SCSI_3", "SPC2_Protocol_Version"}, reported.EffectiveFlags) || reported.PortFlagsOverride {
+		t.Errorf("unexpected reported flags %+v", reported)
+	}
+}
+
+func TestUnmapVolume(t *testing.T) {
+	symURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id"
+	var removed []string
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.Method + " " + req.URL.Path {
+		case "GET " + symURL + XVolume + "/00001":
+			body = &types.Volume{VolumeID: "00001", StorageGroupIDList: []string{"sg-mapped", "sg-child", "sg-unmapped"}}
+		case "GET " + symURL + XVolume + "/00002":
+			body = &types.Volume{VolumeID: "00002", StorageGroupIDList: []string{"sg-mapped", "sg-last"}}
+		case "GET " + symURL + XStorageGroup + "/sg-mapped":
+			body = &types.StorageGroup{StorageGroupID: "sg-mapped", NumOfVolumes: 2, MaskingView: []string{"mv-1"}}
+		case "GET " + symURL + XStorageGroup + "/sg-child":
+			body = &types.StorageGroup{StorageGroupID: "sg-child", NumOfVolumes: 1, ParentStorageGroup: []string{"sg-parent"}}
+		case "GET " + symURL + XStorageGroup + "/sg-parent":
+			body = &types.StorageGroup{StorageGroupID: "sg-parent", NumOfVolumes: 4, MaskingView: []string{"mv-2"}}
+		case "GET " + symURL + XStorageGroup + "/sg-unmapped":
+			body = &types.StorageGroup{StorageGroupID: "sg-unmapped", NumOfVolumes: 1}
+		case "GET " + symURL + XStorageGroup + "/sg-last":
+			body = &types.StorageGroup{StorageGroupID: "sg-last", NumOfVolumes: 1, MaskingView: []string{"mv-3"}}
+		case "GET " + symURL + XMaskingView + "/mv-1":
+			body = &types.MaskingView{MaskingViewID: "mv-1", HostID: "host-1", PortGroupID: "pg-1", StorageGroupID: "sg-mapped"}
+		case "GET " + symURL + XMaskingView + "/mv-2":
+			body = &types.MaskingView{MaskingViewID: "mv-2", HostGroupID: "hg-1", PortGroupID: "pg-2", StorageGroupID: "sg-parent"}
+		case "GET " + symURL + XMaskingView + "/mv-3":
+			body = &types.MaskingView{MaskingViewID: "mv-3", HostID: "host-3", PortGroupID: "pg-3", StorageGroupID: "sg-last"}
+		case "PUT " + symURL + XStorageGroup + "/sg-mapped", "PUT " + symURL + XStorageGroup + "/sg-child":
+			id := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+			removed = append(removed, id)
+			body = &types.StorageGroup{StorageGroupID: id}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths, err := client.GetVolumePaths(context.TODO(), "mock-sym-id", "00001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedPaths := []types.VolumePath{
+		{StorageGroupID: "sg-mapped", MaskingViewID: "mv-1", HostID: "host-1", PortGroupID: "pg-1"},
+		{StorageGroupID: "sg-child", ParentStorageGroupID: "sg-parent", MaskingViewID: "mv-2", HostGroupID: "hg-1", PortGroupID: "pg-2"},
+	}
+	if !reflect.DeepEqual(expectedPaths, paths) {
+		t.Fatalf("unexpected paths %+v", paths)
+	}
+
+	unmaps, err := client.UnmapVolume(context.TODO(), "mock-sym-id", "00001", types.UnmapVolumeOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unmaps) != 2 || unmaps[0].Removed || unmaps[1].Removed || len(removed) != 0 {
+		t.Fatalf("expected the removals to be listed only, got %+v and %v", unmaps, removed)
+	}
+
+	unmaps, err = client.UnmapVolume(context.TODO(), "mock-sym-id", "00001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !unmaps[0].Removed || !unmaps[1].Removed || !reflect.DeepEqual([]string{"sg-mapped", "sg-child"}, removed) {
+		t.Fatalf("expected the volume to be removed from both storage groups, got %+v and %v", unmaps, removed)
+	}
+
+	removed = nil
+	unmaps, err = client.UnmapVolume(context.TODO(), "mock-sym-id", "00002")
+	if err == nil {
+		t.Fatal("expected an error for the last volume of a storage group in a masking view")
+	}
+	if len(unmaps) != 2 || !unmaps[0].Removed || unmaps[1].Blocked == "" || !reflect.DeepEqual([]string{"sg-mapped"}, removed) {
+		t.Fatalf("expected the removal from sg-last to be blocked, got %+v and %v", unmaps, removed)
+	}
+}
+
+func TestDeleteVolumeIDsIteratorInSafeMode(t *testing.T) {
+	symURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id"
+	var deleted int
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.Method + " " + req.RequestURI {
+		case "GET " + symURL + XVolume:
+			body = &types.VolumeIterator{ID: "it1", Count: 3, MaxPageSize: 2, ResultList: types.VolumeResultList{
+				VolumeList: []types.VolumeIDList{{VolumeIDs: "00001"}, {VolumeIDs: "00002"}}, From: 1, To: 2,
+			}}
+		case "GET /" + RESTPrefix + IteratorX + "it1" + XPage + "?from=3&to=3":
+			body = &types.VolumeResultList{VolumeList: []types.VolumeIDList{{VolumeIDs: "00003"}}, From: 3, To: 3}
+		case "DELETE /" + RESTPrefix + IteratorX + "it1":
+			deleted++
+			return
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL, WithSafeMode(), WithDryRun())
+	if err != nil {
+		t.Fatal(err)
+	}
+	volumeIDs, err := client.GetVolumeIDList(context.TODO(), "mock-sym-id", "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(volumeIDs) != 3 || deleted != 1 {
+		t.Fatalf("expected the iterator to be deleted in safe and dry-run modes, got %v and %d deletes", volumeIDs, deleted)
+	}
+}
+
+func TestVolumeListsCheckAllowedArrays(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		resp.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = client.SetAllowedArrays([]string{"000000000001"}); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+	if _, err = client.GetVolumesInStorageGroupIterator(ctx, "000000000002", "sg"); err == nil {
+		t.Error("expected GetVolumesInStorageGroupIterator to fail on an array not allowed")
+	}
+	if _, err = client.GetVolumeIDListInStorageGroup(ctx, "000000000002", "sg"); err == nil {
+		t.Error("expected GetVolumeIDListInStorageGroup to fail on an array not allowed")
+	}
+	if _, err = client.(*Client).GetVolumeByIdentifier(ctx, "000000000002", "sg", "vol", 1, "CYL"); err == nil {
+		t.Error("expected GetVolumeByIdentifier to fail on an array not allowed")
+	}
+	if _, err = client.(*Client).getVolumeIDsIteratorBase(ctx, "000000000002", ""); err == nil {
+		t.Error("expected getVolumeIDsIteratorBase to fail on an array not allowed")
+	}
+}
@@ -0,0 +1,326 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package spec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	pmax "github.com/dell/gopowermax/v2"
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+// Actions of a Change
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionNone   = "none"
+)
+
+// Kinds of the objects of a Change, besides the ConfigurationKind constants of the types package
+const (
+	KindReplication = "replication"
+)
+
+// Change is an object of the Spec created, updated or found as described by Apply
+type Change struct {
+	Kind   string `json:"kind"`
+	ID     string `json:"id"`
+	Action string `json:"action"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Result lists the changes made, or to be made in a dry run, by Apply, in the order they were made
+type Result struct {
+	SymmetrixID string   `json:"symmetrixId"`
+	DryRun      bool     `json:"dryRun"`
+	Changes     []Change `json:"changes"`
+}
+
+// Changed returns the changes which created or updated an object
+func (r *Result) Changed() []Change {
+	var changed []Change
+	for _, change := range r.Changes {
+		if change.Action != ActionNone {
+			changed = append(changed, change)
+		}
+	}
+	return changed
+}
+
+// ApplyOptions are the options of Apply
+type ApplyOptions struct {
+	// DryRun reports the changes to make without making them
+	DryRun bool
+}
+
+// Apply makes the array match the Spec: the storage groups, volumes, hosts, port groups, masking views and
+// replication missing are created, the service level of the storage groups is changed, and the missing
+// initiators and ports are added to the hosts and port groups. Nothing is deleted, and applying the same Spec
+// twice makes no change the second time. A masking view existing with other objects than the ones of the Spec
+// is an error. Apply stops at the first error, returning it with the changes already made.
+// The changes made are reported as they are made to the handler of pmax.WithEvents, if any.
+func Apply(ctx context.Context, client pmax.Pmax, s *Spec, opts ApplyOptions) (*Result, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	a := &applier{client: client, symID: s.SymmetrixID, dryRun: opts.DryRun,
+		result: &Result{SymmetrixID: s.SymmetrixID, DryRun: opts.DryRun}}
+	for _, sg := range s.StorageGroups {
+		if err := a.applyStorageGroup(ctx, sg); err != nil {
+			return a.result, fmt.Errorf("storage group %s: %w", sg.ID, err)
+		}
+	}
+	for _, host := range s.Hosts {
+		if err := a.applyHost(ctx, host); err != nil {
+			return a.result, fmt.Errorf("host %s: %w", host.ID, err)
+		}
+	}
+	for _, pg := range s.PortGroups {
+		if err := a.applyPortGroup(ctx, pg); err != nil {
+			return a.result, fmt.Errorf("port group %s: %w", pg.ID, err)
+		}
+	}
+	for _, mv := range s.MaskingViews {
+		if err := a.applyMaskingView(ctx, mv); err != nil {
+			return a.result, fmt.Errorf("masking view %s: %w", mv.ID, err)
+		}
+	}
+	for _, rep := range s.Replication {
+		if err := a.applyReplication(ctx, rep); err != nil {
+			return a.result, fmt.Errorf("replication of storage group %s: %w", rep.StorageGroup, err)
+		}
+	}
+	return a.result, nil
+}
+
+type applier struct {
+	client pmax.Pmax
+	symID  string
+	dryRun bool
+	result *Result
+}
+
+// change records a change, and makes it with do unless in a dry run
+func (a *applier) change(ctx context.Context, kind, id, action, detail string, do func() error) error {
+	if action != ActionNone && !a.dryRun {
+		if err := pmax.RunStep(ctx, "Apply", action+" "+kind+" "+id, do); err != nil {
+			return err
+		}
+	}
+	a.result.Changes = append(a.result.Changes, Change{Kind: kind, ID: id, Action: action, Detail: detail})
+	return nil
+}
+
+func (a *applier) applyStorageGroup(ctx context.Context, sg StorageGroup) error {
+	existing, err := a.client.GetStorageGroup(ctx, a.symID, sg.ID)
+	switch {
+	case isNotFound(err):
+		srp := sg.SRP
+		if srp == "" {
+			srp = DefaultSRP
+		}
+		err = a.change(ctx, types.ConfigurationKindStorageGroup, sg.ID, ActionCreate, "", func() error {
+			_, err := a.client.CreateStorageGroup(ctx, a.symID, sg.ID, srp, sg.ServiceLevel, false, nil)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		for _, vol := range sg.Volumes {
+			if err = a.createVolume(ctx, sg.ID, vol); err != nil {
+				return err
+			}
+		}
+		return nil
+	case err != nil:
+		return err
+	}
+
+	serviceLevel := existing.SLO
+	if serviceLevel == "" {
+		serviceLevel = existing.ServiceLevel
+	}
+	action, detail := ActionNone, ""
+	if sg.ServiceLevel != "" && !strings.EqualFold(sg.ServiceLevel, serviceLevel) {
+		action, detail = ActionUpdate, fmt.Sprintf("service level %s to %s", serviceLevel, sg.ServiceLevel)
+	}
+	err = a.change(ctx, types.ConfigurationKindStorageGroup, sg.ID, action, detail, func() error {
+		return a.client.ModifyStorageGroupSLO(ctx, a.symID, sg.ID, sg.ServiceLevel)
+	})
+	if err != nil {
+		return err
+	}
+	if len(sg.Volumes) == 0 {
+		return nil
+	}
+	// a volume of the same name in another storage group is not a volume of this storage group
+	volumes, err := a.client.GetStorageGroupVolumeList(ctx, a.symID, sg.ID, types.VolumeListOptions{})
+	if err != nil {
+		return err
+	}
+	present := make(map[string]bool, len(volumes))
+	for _, vol := range volumes {
+		present[vol.VolumeIdentifier] = true
+	}
+	for _, vol := range sg.Volumes {
+		if present[vol.Name] {
+			a.result.Changes = append(a.result.Changes, Change{Kind: types.ConfigurationKindVolume, ID: vol.Name, Action: ActionNone})
+			continue
+		}
+		if err = a.createVolume(ctx, sg.ID, vol); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *applier) createVolume(ctx context.Context, storageGroupID string, vol Volume) error {
+	unit := strings.ToUpper(vol.CapacityUnit)
+	if unit == "" {
+		unit = DefaultCapacityUnit
+	}
+	detail := fmt.Sprintf("%d %s in storage group %s", vol.Size, unit, storageGroupID)
+	return a.change(ctx, types.ConfigurationKindVolume, vol.Name, ActionCreate, detail, func() error {
+		_, err := a.client.CreateVolumeInStorageGroupS(ctx, a.symID, storageGroupID, vol.Name, vol.Size,
+			map[string]interface{}{"capacityUnit": unit})
+		return err
+	})
+}
+
+func (a *applier) applyHost(ctx context.Context, host Host) error {
+	existing, err := a.client.GetHostByID(ctx, a.symID, host.ID)
+	switch {
+	case isNotFound(err):
+		return a.change(ctx, types.ConfigurationKindHost, host.ID, ActionCreate, "", func() error {
+			_, err := a.client.CreateHost(ctx, a.symID, host.ID, host.Initiators, nil)
+			return err
+		})
+	case err != nil:
+		return err
+	}
+	missing := missingValues(existing.Initiators, host.Initiators)
+	action, detail := ActionNone, ""
+	if len(missing) > 0 {
+		action, detail = ActionUpdate, "add initiators "+strings.Join(missing, ", ")
+	}
+	return a.change(ctx, types.ConfigurationKindHost, host.ID, action, detail, func() error {
+		_, err := a.client.UpdateHostInitiators(ctx, a.symID, existing, append(slices.Clone(existing.Initiators), missing...))
+		return err
+	})
+}
+
+func (a *applier) applyPortGroup(ctx context.Context, pg PortGroup) error {
+	ports := make([]types.PortKey, len(pg.Ports))
+	for i, port := range pg.Ports {
+		ports[i], _ = parsePort(port)
+	}
+	existing, err := a.client.GetPortGroupByID(ctx, a.symID, pg.ID)
+	switch {
+	case isNotFound(err):
+		return a.change(ctx, types.ConfigurationKindPortGroup, pg.ID, ActionCreate, "", func() error {
+			_, err := a.client.CreatePortGroup(ctx, a.symID, pg.ID, ports, pg.Protocol)
+			return err
+		})
+	case err != nil:
+		return err
+	}
+	existingPorts := make([]string, len(existing.SymmetrixPortKey))
+	for i, key := range existing.SymmetrixPortKey {
+		existingPorts[i] = key.DirectorID + ":" + key.PortID
+	}
+	missing := missingValues(existingPorts, pg.Ports)
+	action, detail := ActionNone, ""
+	if len(missing) > 0 {
+		action, detail = ActionUpdate, "add ports "+strings.Join(missing, ", ")
+	}
+	return a.change(ctx, types.ConfigurationKindPortGroup, pg.ID, action, detail, func() error {
+		updated := slices.Clone(existing.SymmetrixPortKey)
+		for _, port := range missing {
+			key, _ := parsePort(port)
+			updated = append(updated, key)
+		}
+		_, err := a.client.UpdatePortGroup(ctx, a.symID, pg.ID, updated)
+		return err
+	})
+}
+
+func (a *applier) applyMaskingView(ctx context.Context, mv MaskingView) error {
+	existing, err := a.client.GetMaskingViewByID(ctx, a.symID, mv.ID)
+	switch {
+	case isNotFound(err):
+		return a.change(ctx, types.ConfigurationKindMaskingView, mv.ID, ActionCreate, "", func() error {
+			_, err := a.client.CreateMaskingView(ctx, a.symID, mv.ID, mv.StorageGroup, mv.Host, true, mv.PortGroup)
+			return err
+		})
+	case err != nil:
+		return err
+	}
+	if existing.StorageGroupID != mv.StorageGroup || existing.HostID != mv.Host || existing.PortGroupID != mv.PortGroup {
+		return fmt.Errorf("exists with storage group %q, host %q and port group %q",
+			existing.StorageGroupID, existing.HostID, existing.PortGroupID)
+	}
+	return a.change(ctx, types.ConfigurationKindMaskingView, mv.ID, ActionNone, "", nil)
+}
+
+func (a *applier) applyReplication(ctx context.Context, rep Replication) error {
+	protected, err := a.client.GetProtectedStorageGroup(ctx, a.symID, rep.StorageGroup)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	if err == nil && protected.Rdf {
+		return a.change(ctx, KindReplication, rep.StorageGroup, ActionNone, "", nil)
+	}
+	remoteStorageGroup := rep.RemoteStorageGroup
+	if remoteStorageGroup == "" {
+		remoteStorageGroup = rep.StorageGroup
+	}
+	detail := fmt.Sprintf("%s to %s/%s in RDF group %s", rep.Mode, rep.RemoteSymmetrixID, remoteStorageGroup, rep.RDFGroup)
+	return a.change(ctx, KindReplication, rep.StorageGroup, ActionCreate, detail, func() error {
+		_, err := a.client.CreateSGReplica(ctx, a.symID, rep.RemoteSymmetrixID, rep.Mode, rep.RDFGroup, rep.StorageGroup,
+			remoteStorageGroup, rep.RemoteServiceLevel, rep.Bias)
+		return err
+	})
+}
+
+// isNotFound returns true if the error is the 404 of an object not on the array
+func isNotFound(err error) bool {
+	var apiErr *types.Error
+	return errors.As(err, &apiErr) && apiErr.HTTPStatusCode == http.StatusNotFound
+}
+
+// missingValues returns the wanted values not in values, compared case insensitively
+func missingValues(values, wanted []string) []string {
+	var missing []string
+	for _, value := range wanted {
+		if !slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, value) }) {
+			missing = append(missing, value)
+		}
+	}
+	return missing
+}
+
+// parsePort parses a port formatted as director:port, e.g. FA-1D:4
+func parsePort(port string) (types.PortKey, error) {
+	director, portID, ok := strings.Cut(port, ":")
+	if !ok || director == "" || portID == "" {
+		return types.PortKey{}, fmt.Errorf("invalid port %q, expected director:port", port)
+	}
+	return types.PortKey{DirectorID: director, PortID: portID}, nil
+}
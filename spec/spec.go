@@ -0,0 +1,229 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+// Package spec loads a declarative description of the provisioning objects of an array, its storage groups, hosts,
+// port groups, masking views and storage group replication, from YAML or JSON, validates it and applies it
+// idempotently with a pmax client: the objects missing on the array are created, the ones present are left as they
+// are or, for the volumes of a storage group, the initiators of a host and the ports of a port group, completed.
+//
+//	symmetrixId: "000000000001"
+//	storageGroups:
+//	  - id: app-sg
+//	    serviceLevel: Diamond
+//	    volumes:
+//	      - name: app-data
+//	        size: 100
+//	hosts:
+//	  - id: app-host
+//	    initiators: ["10000090fa000001"]
+//	portGroups:
+//	  - id: app-pg
+//	    protocol: SCSI_FC
+//	    ports: ["FA-1D:4"]
+//	maskingViews:
+//	  - id: app-mv
+//	    storageGroup: app-sg
+//	    host: app-host
+//	    portGroup: app-pg
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/dell/gopowermax/v2/payload"
+	"gopkg.in/yaml.v3"
+)
+
+// Defaults of the optional settings of a Spec
+const (
+	DefaultSRP          = "SRP_1"
+	DefaultCapacityUnit = "GB"
+)
+
+// Spec is the declarative description of the provisioning objects of an array
+type Spec struct {
+	SymmetrixID   string         `json:"symmetrixId" yaml:"symmetrixId"`
+	StorageGroups []StorageGroup `json:"storageGroups,omitempty" yaml:"storageGroups,omitempty"`
+	Hosts         []Host         `json:"hosts,omitempty" yaml:"hosts,omitempty"`
+	PortGroups    []PortGroup    `json:"portGroups,omitempty" yaml:"portGroups,omitempty"`
+	MaskingViews  []MaskingView  `json:"maskingViews,omitempty" yaml:"maskingViews,omitempty"`
+	Replication   []Replication  `json:"replication,omitempty" yaml:"replication,omitempty"`
+}
+
+// StorageGroup is a storage group with its volumes
+type StorageGroup struct {
+	ID string `json:"id" yaml:"id"`
+	// SRP defaults to DefaultSRP
+	SRP          string   `json:"srp,omitempty" yaml:"srp,omitempty"`
+	ServiceLevel string   `json:"serviceLevel,omitempty" yaml:"serviceLevel,omitempty"`
+	Volumes      []Volume `json:"volumes,omitempty" yaml:"volumes,omitempty"`
+}
+
+// Volume is a volume of a storage group, identified by its name
+type Volume struct {
+	Name string `json:"name" yaml:"name"`
+	Size int    `json:"size" yaml:"size"`
+	// CapacityUnit is the unit of Size, CYL, MB, GB or TB, and defaults to DefaultCapacityUnit
+	CapacityUnit string `json:"capacityUnit,omitempty" yaml:"capacityUnit,omitempty"`
+}
+
+// Host is a host with its initiators
+type Host struct {
+	ID         string   `json:"id" yaml:"id"`
+	Initiators []string `json:"initiators" yaml:"initiators"`
+}
+
+// PortGroup is a port group with its ports, formatted as director:port, e.g. FA-1D:4
+type PortGroup struct {
+	ID       string   `json:"id" yaml:"id"`
+	Protocol string   `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	Ports    []string `json:"ports" yaml:"ports"`
+}
+
+// MaskingView is a masking view of a storage group, host and port group of the Spec
+type MaskingView struct {
+	ID           string `json:"id" yaml:"id"`
+	StorageGroup string `json:"storageGroup" yaml:"storageGroup"`
+	Host         string `json:"host" yaml:"host"`
+	PortGroup    string `json:"portGroup" yaml:"portGroup"`
+}
+
+// Replication is the SRDF protection of a storage group of the Spec
+type Replication struct {
+	StorageGroup      string `json:"storageGroup" yaml:"storageGroup"`
+	RemoteSymmetrixID string `json:"remoteSymmetrixId" yaml:"remoteSymmetrixId"`
+	RDFGroup          string `json:"rdfGroup" yaml:"rdfGroup"`
+	// Mode is ASYNC, SYNC or METRO
+	Mode string `json:"mode" yaml:"mode"`
+	// RemoteStorageGroup defaults to StorageGroup
+	RemoteStorageGroup string `json:"remoteStorageGroup,omitempty" yaml:"remoteStorageGroup,omitempty"`
+	RemoteServiceLevel string `json:"remoteServiceLevel,omitempty" yaml:"remoteServiceLevel,omitempty"`
+	// Bias sets the bias of an SRDF/Metro protection
+	Bias bool `json:"bias,omitempty" yaml:"bias,omitempty"`
+}
+
+// Parse parses a Spec from YAML or JSON, JSON being a subset of YAML, and validates it
+func Parse(data []byte) (*Spec, error) {
+	s := &Spec{}
+	if err := yaml.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("cannot parse the spec: %w", err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Load reads and parses a Spec
+func Load(r io.Reader) (*Spec, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the spec: %w", err)
+	}
+	return Parse(data)
+}
+
+// LoadFile reads and parses the Spec of a file
+func LoadFile(path string) (*Spec, error) {
+	data, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the spec: %w", err)
+	}
+	return Parse(data)
+}
+
+// Validate checks that the objects of the Spec have an ID, unique for their kind, and the settings they require,
+// and that the masking views and replication only reference objects of the Spec. It returns all the errors found.
+func (s *Spec) Validate() error {
+	var errs []error
+	if s.SymmetrixID == "" {
+		errs = append(errs, errors.New("the symmetrixId has to be set"))
+	}
+	storageGroups := ids(&errs, "storage group", s.StorageGroups, func(sg StorageGroup) string { return sg.ID })
+	hosts := ids(&errs, "host", s.Hosts, func(host Host) string { return host.ID })
+	portGroups := ids(&errs, "port group", s.PortGroups, func(pg PortGroup) string { return pg.ID })
+	ids(&errs, "masking view", s.MaskingViews, func(mv MaskingView) string { return mv.ID })
+
+	for _, sg := range s.StorageGroups {
+		ids(&errs, "volume of storage group "+sg.ID, sg.Volumes, func(vol Volume) string { return vol.Name })
+		for _, vol := range sg.Volumes {
+			if vol.Size <= 0 {
+				errs = append(errs, fmt.Errorf("volume %s of storage group %s: the size has to be positive", vol.Name, sg.ID))
+			}
+			if unit := strings.ToUpper(vol.CapacityUnit); unit != "" && !slices.Contains([]string{"CYL", "MB", "GB", "TB"}, unit) {
+				errs = append(errs, fmt.Errorf("volume %s of storage group %s: invalid capacity unit %s", vol.Name, sg.ID, vol.CapacityUnit))
+			}
+		}
+	}
+	for _, host := range s.Hosts {
+		if len(host.Initiators) == 0 {
+			errs = append(errs, fmt.Errorf("host %s: at least one initiator has to be set", host.ID))
+		}
+	}
+	for _, pg := range s.PortGroups {
+		if len(pg.Ports) == 0 {
+			errs = append(errs, fmt.Errorf("port group %s: at least one port has to be set", pg.ID))
+		}
+		for _, port := range pg.Ports {
+			if _, err := parsePort(port); err != nil {
+				errs = append(errs, fmt.Errorf("port group %s: %w", pg.ID, err))
+			}
+		}
+	}
+	for _, mv := range s.MaskingViews {
+		errs = appendUnknown(errs, "masking view "+mv.ID, "storage group", mv.StorageGroup, storageGroups)
+		errs = appendUnknown(errs, "masking view "+mv.ID, "host", mv.Host, hosts)
+		errs = appendUnknown(errs, "masking view "+mv.ID, "port group", mv.PortGroup, portGroups)
+	}
+	for _, rep := range s.Replication {
+		name := "replication of storage group " + rep.StorageGroup
+		errs = appendUnknown(errs, name, "storage group", rep.StorageGroup, storageGroups)
+		if rep.RemoteSymmetrixID == "" || rep.RDFGroup == "" {
+			errs = append(errs, fmt.Errorf("%s: the remoteSymmetrixId and rdfGroup have to be set", name))
+		}
+		if !slices.Contains([]string{payload.RDFModeAsync, payload.RDFModeSync, payload.RDFModeMetro}, rep.Mode) {
+			errs = append(errs, fmt.Errorf("%s: invalid mode %q", name, rep.Mode))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ids returns the IDs of the objects of a kind, appending an error for each ID missing or duplicated
+func ids[T any](errs *[]error, kind string, objects []T, id func(T) string) []string {
+	var seen []string
+	for i, object := range objects {
+		switch objectID := id(object); {
+		case objectID == "":
+			*errs = append(*errs, fmt.Errorf("%s %d: the id has to be set", kind, i+1))
+		case slices.Contains(seen, objectID):
+			*errs = append(*errs, fmt.Errorf("%s %s: duplicated", kind, objectID))
+		default:
+			seen = append(seen, objectID)
+		}
+	}
+	return seen
+}
+
+// appendUnknown appends an error if the id referenced by an object is not one of ids
+func appendUnknown(errs []error, object, kind, id string, ids []string) []error {
+	if !slices.Contains(ids, id) {
+		return append(errs, fmt.Errorf("%s: unknown %s %q", object, kind, id))
+	}
+	return errs
+}
@@ -0,0 +1,213 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package spec
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	pmax "github.com/dell/gopowermax/v2"
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+const testSpec = `
+symmetrixId: "000000000001"
+storageGroups:
+  - id: app-sg
+    serviceLevel: Diamond
+    volumes:
+      - name: app-data
+        size: 100
+hosts:
+  - id: app-host
+    initiators: ["10000090fa000001", "10000090fa000002"]
+portGroups:
+  - id: app-pg
+    protocol: SCSI_FC
+    ports: ["FA-1D:4", "FA-2D:4"]
+maskingViews:
+  - id: app-mv
+    storageGroup: app-sg
+    host: app-host
+    portGroup: app-pg
+replication:
+  - storageGroup: app-sg
+    remoteSymmetrixId: "000000000002"
+    rdfGroup: "10"
+    mode: ASYNC
+`
+
+func TestParse(t *testing.T) {
+	fromYAML, err := Parse([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := json.Marshal(fromYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromJSON, err := Load(strings.NewReader(string(content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromYAML, fromJSON) {
+		t.Errorf("expected the YAML and JSON specs to be equal, got %+v and %+v", fromYAML, fromJSON)
+	}
+	if fromYAML.StorageGroups[0].Volumes[0].Size != 100 || fromYAML.Replication[0].Mode != "ASYNC" {
+		t.Errorf("unexpected spec %+v", fromYAML)
+	}
+
+	_, err = Parse([]byte(`
+storageGroups:
+  - id: sg
+    volumes:
+      - name: vol
+        size: 0
+hosts:
+  - id: host
+    initiators: ["i1"]
+  - id: host
+    initiators: ["i2"]
+portGroups:
+  - id: pg
+    ports: ["FA-1D"]
+maskingViews:
+  - id: mv
+    storageGroup: sg
+    host: host
+    portGroup: other-pg
+replication:
+  - storageGroup: sg
+    remoteSymmetrixId: "000000000002"
+    rdfGroup: "10"
+    mode: ADAPTIVE
+`))
+	if err == nil {
+		t.Fatal("expected the spec to be invalid")
+	}
+	for _, expected := range []string{
+		"symmetrixId has to be set",
+		"volume vol of storage group sg: the size has to be positive",
+		"host host: duplicated",
+		`invalid port "FA-1D"`,
+		`masking view mv: unknown port group "other-pg"`,
+		`invalid mode "ADAPTIVE"`,
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected %q in %v", expected, err)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	prefix := "/univmax/restapi/100/sloprovisioning/symmetrix/000000000001"
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch {
+		case req.Method != http.MethodGet:
+			mu.Lock()
+			calls = append(calls, req.Method+" "+strings.TrimPrefix(req.URL.Path, prefix))
+			mu.Unlock()
+			body = &types.Job{JobID: "job-1", Status: types.JobStatusSucceeded}
+		case req.URL.Path == prefix+"/storagegroup/app-sg":
+			body = &types.StorageGroup{StorageGroupID: "app-sg", SLO: "Gold"}
+		case req.URL.Path == prefix+"/volume" && req.URL.Query().Get("storageGroupId") == "app-sg":
+			body = &types.VolumeDetailIterator{Count: 1, MaxPageSize: 1000, ResultList: types.VolumeDetailResultList{
+				VolumeList: []types.VolumeDetail{{Volume: types.Volume{VolumeID: "00001", VolumeIdentifier: "app-data"}}}, From: 1, To: 1,
+			}}
+		case req.URL.Path == prefix+"/volume" && req.URL.Query().Get("volume_identifier") == "app-logs":
+			// a volume of the same name in another storage group
+			body = &types.VolumeIterator{Count: 1, MaxPageSize: 1000, ResultList: types.VolumeResultList{
+				VolumeList: []types.VolumeIDList{{VolumeIDs: "00002"}}, From: 1, To: 1,
+			}}
+		case req.URL.Path == prefix+"/host/app-host":
+			body = &types.Host{HostID: "app-host", Initiators: []string{"10000090FA000001"}}
+		case req.URL.Path == "/univmax/restapi/100/system/symmetrix/000000000001/job/job-1":
+			body = &types.Job{JobID: "job-1", Status: types.JobStatusSucceeded}
+		case strings.HasSuffix(req.URL.Path, "/storagegroup/app-sg"):
+			body = &types.RDFStorageGroup{Name: "app-sg", Rdf: true}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := pmax.NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := Parse([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.StorageGroups[0].Volumes = append(s.StorageGroups[0].Volumes, Volume{Name: "app-logs", Size: 10})
+
+	result, err := Apply(context.TODO(), client, s, ApplyOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 0 {
+		t.Fatalf("unexpected calls in a dry run %v", calls)
+	}
+	expected := []Change{
+		{Kind: types.ConfigurationKindStorageGroup, ID: "app-sg", Action: ActionUpdate, Detail: "service level Gold to Diamond"},
+		{Kind: types.ConfigurationKindVolume, ID: "app-data", Action: ActionNone},
+		{Kind: types.ConfigurationKindVolume, ID: "app-logs", Action: ActionCreate, Detail: "10 GB in storage group app-sg"},
+		{Kind: types.ConfigurationKindHost, ID: "app-host", Action: ActionUpdate, Detail: "add initiators 10000090fa000002"},
+		{Kind: types.ConfigurationKindPortGroup, ID: "app-pg", Action: ActionCreate},
+		{Kind: types.ConfigurationKindMaskingView, ID: "app-mv", Action: ActionCreate},
+		{Kind: KindReplication, ID: "app-sg", Action: ActionNone},
+	}
+	if !reflect.DeepEqual(expected, result.Changes) || len(result.Changed()) != 5 {
+		t.Fatalf("unexpected changes %+v", result.Changes)
+	}
+
+	s.StorageGroups[0].Volumes = s.StorageGroups[0].Volumes[:1]
+	var steps []string
+	ctx := pmax.WithEvents(context.TODO(), func(event types.WorkflowEvent) {
+		steps = append(steps, event.Status+" "+event.Step)
+	})
+	if _, err = Apply(ctx, client, s, ApplyOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	expectedCalls := []string{"PUT /storagegroup/app-sg", "PUT /host/app-host", "POST /portgroup", "POST /maskingview"}
+	if !reflect.DeepEqual(expectedCalls, calls) {
+		t.Errorf("unexpected calls %v", calls)
+	}
+	expectedSteps := []string{
+		"started update storageGroup app-sg", "succeeded update storageGroup app-sg",
+		"started update host app-host", "succeeded update host app-host",
+		"started create portGroup app-pg", "succeeded create portGroup app-pg",
+		"started create maskingView app-mv", "succeeded create maskingView app-mv",
+	}
+	if !reflect.DeepEqual(expectedSteps, steps) {
+		t.Errorf("unexpected events %v", steps)
+	}
+}
@@ -17,9 +17,13 @@ package pmax
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	types "github.com/dell/gopowermax/v2/types/v100"
@@ -91,7 +95,7 @@ func (c *Client) GetSymmetrixIDList(ctx context.Context) (*types.SymmetrixIDList
 	if len(c.GetAllowedArrays()) != 0 {
 		allowed := make([]string, 0)
 		for _, array := range symIDList.SymmetrixIDs {
-			if ok, _ := c.IsAllowedArray(array); ok == true {
+			if c.isInAllowedArrays(array) {
 				allowed = append(allowed, array)
 			}
 		}
@@ -109,6 +113,11 @@ func (c *Client) GetSymmetrixByID(ctx context.Context, id string) (*types.Symmet
 	if _, err := c.IsAllowedArray(id); err != nil {
 		return nil, err
 	}
+	return c.getSymmetrixByID(ctx, id)
+}
+
+// getSymmetrixByID returns a Symmetrix without checking that the array is allowed, for the discovery of the arrays
+func (c *Client) getSymmetrixByID(ctx context.Context, id string) (*types.Symmetrix, error) {
 	url := c.getSymmetrixIDListURL() + "/" + id
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
@@ -134,6 +143,94 @@ func (c *Client) GetSymmetrixByID(ctx context.Context, id string) (*types.Symmet
 	return symmetrix, nil
 }
 
+// GetArraySummary returns the model, ucode, service tag, connectivity and storage pool capacity of an array.
+// The symmetrix details and the storage pools are queried in parallel.
+func (c *Client) GetArraySummary(ctx context.Context, symID string) (*types.ArraySummary, error) {
+	defer c.TimeSpent("GetArraySummary", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+
+	var (
+		wg        sync.WaitGroup
+		symmetrix *types.Symmetrix
+		symErr    error
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		symmetrix, symErr = c.GetSymmetrixByID(ctx, symID)
+	}()
+
+	pools, poolErr := c.getStoragePoolSummaries(ctx, symID)
+	wg.Wait()
+	if symErr != nil {
+		return nil, symErr
+	}
+	if poolErr != nil {
+		return nil, poolErr
+	}
+
+	summary := &types.ArraySummary{
+		SymmetrixID:    symmetrix.SymmetrixID,
+		DisplayName:    symmetrix.DisplayName,
+		DellServiceTag: symmetrix.DellServiceTag,
+		Model:          symmetrix.Model,
+		Ucode:          symmetrix.Ucode,
+		UcodeDate:      symmetrix.UcodeDate,
+		AllFlash:       symmetrix.AllFlash,
+		Local:          symmetrix.Local,
+		DeviceCount:    symmetrix.DeviceCount,
+		StoragePools:   pools,
+	}
+	log.Info(fmt.Sprintf("Successfully built summary of array %s", symID))
+	return summary, nil
+}
+
+// getStoragePoolSummaries returns the capacity of all the storage pools of an array, sorted by id
+func (c *Client) getStoragePoolSummaries(ctx context.Context, symID string) ([]types.StoragePoolSummary, error) {
+	poolList, err := c.GetStoragePoolList(ctx, symID)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		errs  []error
+		pools = make([]types.StoragePoolSummary, 0, len(poolList.StoragePoolIDs))
+	)
+	for _, poolID := range poolList.StoragePoolIDs {
+		wg.Add(1)
+		go func(poolID string) {
+			defer wg.Done()
+			pool, err := c.GetStoragePool(ctx, symID, poolID)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			summary := types.StoragePoolSummary{
+				StoragePoolID:                pool.StoragePoolID,
+				EffectiveUsedCapacityPercent: pool.EffectiveUsedCapPerc,
+				ServiceLevels:                pool.ServiceLevels,
+			}
+			if pool.SrpCap != nil {
+				summary.UsableTotalTB = pool.SrpCap.UsableTotInTB
+				summary.UsableUsedTB = pool.SrpCap.UsableUsedInTB
+				summary.SubscribedTotalTB = pool.SrpCap.SubTotInTB
+			}
+			pools = append(pools, summary)
+		}(poolID)
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	sort.Slice(pools, func(i, j int) bool { return pools[i].StoragePoolID < pools[j].StoragePoolID })
+	return pools, nil
+}
+
 // GetJobIDList returns a list of all the jobs in the symmetrix system.
 // If optional statusQuery is something like JobStatusRunning it will search for running jobs.
 func (c *Client) GetJobIDList(ctx context.Context, symID string, statusQuery string) ([]string, error) {
@@ -368,6 +465,52 @@ func (c *Client) GetISCSITargets(ctx context.Context, symID string) ([]ISCSITarg
 	return targets, nil
 }
 
+// GetFCTargets returns the list of front-end FC port WWPNs along with the speed and status of each director port
+func (c *Client) GetFCTargets(ctx context.Context, symID string) ([]FCTarget, error) {
+	defer c.TimeSpent("GetFCTargets", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	targets := make([]FCTarget, 0)
+	// Get list of all directors
+	directors, err := c.GetDirectorIDList(ctx, symID)
+	if err != nil {
+		return []FCTarget{}, err
+	}
+
+	for _, d := range directors.DirectorIDs {
+		ports, err := c.GetPortList(ctx, symID, d, "type=FibreChannel")
+		if err != nil {
+			// Ignore the error and continue
+			log.Errorf("Failed to get ports of type FibreChannel for director: %s. Error: %s",
+				d, err.Error())
+			continue
+		}
+		for _, p := range ports.SymmetrixPortKey {
+			port, err := c.GetPort(ctx, symID, p.DirectorID, p.PortID)
+			if err != nil {
+				// Ignore the error and continue
+				log.Errorf("Failed to fetch port details for %s:%s. Error: %s",
+					p.DirectorID, p.PortID, err.Error())
+				continue
+			}
+			// the identifier of a FC port is its WWPN
+			if port.SymmetrixPort.Identifier != "" {
+				tgt := FCTarget{
+					WWPN:            port.SymmetrixPort.Identifier,
+					DirectorID:      p.DirectorID,
+					PortID:          p.PortID,
+					NegotiatedSpeed: port.SymmetrixPort.NegotiatedSpeed,
+					PortStatus:      port.SymmetrixPort.PortStatus,
+					DirectorStatus:  port.SymmetrixPort.DirectorStatus,
+				}
+				targets = append(targets, tgt)
+			}
+		}
+	}
+	return targets, nil
+}
+
 // GetNVMeTCPTargets returns list of target addresses
 func (c *Client) GetNVMeTCPTargets(ctx context.Context, symID string) ([]NVMeTCPTarget, error) {
 	if _, err := c.IsAllowedArray(symID); err != nil {
@@ -444,10 +587,34 @@ func (c *Client) RefreshSymmetrix(ctx context.Context, symID string) error {
 	return nil
 }
 
+// ArrayNotAllowedError is returned, before any request is sent to Unisphere,
+// by calls referencing an array which is not in the allowed arrays of the client
+type ArrayNotAllowedError struct {
+	SymmetrixID string
+}
+
+func (e *ArrayNotAllowedError) Error() string {
+	return fmt.Sprintf("the requested array (%s) is ignored as it is not managed", e.SymmetrixID)
+}
+
+// IsArrayNotAllowedError returns true if the error is, or wraps, an ArrayNotAllowedError
+func IsArrayNotAllowedError(err error) bool {
+	var notAllowed *ArrayNotAllowedError
+	return errors.As(err, &notAllowed)
+}
+
 // SetAllowedArrays sets the list of arrays which can be manipulated
 // an empty list will allow all arrays to be accessed
 func (c *Client) SetAllowedArrays(arrays []string) error {
-	c.allowedArrays = arrays
+	allowed := make([]string, 0, len(arrays))
+	for _, a := range arrays {
+		a = strings.TrimSpace(a)
+		if a == "" {
+			continue
+		}
+		allowed = append(allowed, a)
+	}
+	c.allowedArrays = allowed
 	return nil
 }
 
@@ -456,18 +623,448 @@ func (c *Client) GetAllowedArrays() []string {
 	return c.allowedArrays
 }
 
-// IsAllowedArray checks to see if we can manipulate the specified array
+// IsAllowedArray checks to see if we can manipulate the specified array.
+// Once DiscoverArrays is called, the array must also be one of the arrays discovered.
 func (c *Client) IsAllowedArray(array string) (bool, error) {
+	if !c.isInAllowedArrays(array) {
+		return false, &ArrayNotAllowedError{SymmetrixID: array}
+	}
+	if err := c.discovery.check(c, array); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// isInAllowedArrays checks to see if the specified array is in the allowed arrays
+func (c *Client) isInAllowedArrays(array string) bool {
 	// if no list has been specified, allow all arrays
 	if len(c.allowedArrays) == 0 {
-		return true, nil
+		return true
 	}
 	// check to see if the specified array in in the list
 	for _, a := range c.allowedArrays {
 		if a == array {
-			return true, nil
+			return true
 		}
 	}
 	// we did not find the array
-	return false, fmt.Errorf("the requested array (%s) is ignored as it is not managed", array)
+	return false
+}
+
+// DefaultListSinceChunk is the time window used by the ListSince calls when no chunk is given,
+// small enough for a day of history to stay under the maximum number of records returned by Unisphere
+const DefaultListSinceChunk = 24 * time.Hour
+
+// timeWindow is a [Start, End) interval of a ListSince query
+type timeWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// splitTimeWindow splits [since, until) into consecutive windows of at most chunk
+func splitTimeWindow(since, until time.Time, chunk time.Duration) []timeWindow {
+	if chunk <= 0 {
+		chunk = DefaultListSinceChunk
+	}
+	windows := make([]timeWindow, 0)
+	for start := since; start.Before(until); start = start.Add(chunk) {
+		end := start.Add(chunk)
+		if end.After(until) {
+			end = until
+		}
+		windows = append(windows, timeWindow{Start: start, End: end})
+	}
+	return windows
+}
+
+// timeWindowQuery returns the query filtering the millisecond timestamp field on the window
+func timeWindowQuery(field string, window timeWindow) string {
+	return fmt.Sprintf("%s=%s&%s=%s",
+		field, url.QueryEscape(fmt.Sprintf(">%d", window.Start.UnixMilli()-1)),
+		field, url.QueryEscape(fmt.Sprintf("<%d", window.End.UnixMilli())))
+}
+
+// listSinceResultCap is the number of records at which Unisphere stops a listing,
+// a window returning as many is split in two and queried again
+const listSinceResultCap = 1000
+
+// listSince queries every window from since until now, oldest first, splitting the windows
+// whose result is capped, and merges the items returned, dropping those of the same key
+func listSince[T any](ctx context.Context, since time.Time, chunk time.Duration, list func(ctx context.Context, window timeWindow) ([]T, bool, error), key func(T) string) ([]T, error) {
+	items := make([]T, 0)
+	seen := make(map[string]bool)
+	windows := splitTimeWindow(since, time.Now(), chunk)
+	for len(windows) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		window := windows[0]
+		windows = windows[1:]
+		windowItems, capped, err := list(ctx, window)
+		if err != nil {
+			return nil, err
+		}
+		if capped {
+			half := window.End.Sub(window.Start) / 2
+			if half < time.Millisecond {
+				return nil, fmt.Errorf("more than %d records in the window from %s to %s, the listing would be incomplete",
+					listSinceResultCap, window.Start.Format(time.RFC3339Nano), window.End.Format(time.RFC3339Nano))
+			}
+			middle := window.Start.Add(half)
+			windows = append([]timeWindow{{Start: window.Start, End: middle}, {Start: middle, End: window.End}}, windows...)
+			continue
+		}
+		for _, item := range windowItems {
+			if k := key(item); !seen[k] {
+				seen[k] = true
+				items = append(items, item)
+			}
+		}
+	}
+	return items, nil
+}
+
+// idKey is the key of the items of an ID listing
+func idKey(id string) string {
+	return id
+}
+
+// ListJobsSince returns the IDs of the jobs modified since the given time, querying one chunk,
+// DefaultListSinceChunk if zero, of history at a time
+func (c *Client) ListJobsSince(ctx context.Context, symID string, since time.Time, chunk time.Duration) ([]string, error) {
+	defer c.TimeSpent("ListJobsSince", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	return listSince(ctx, since, chunk, func(ctx context.Context, window timeWindow) ([]string, bool, error) {
+		URL := c.getSymmetrixIDListURL() + "/" + symID + "/job?" + timeWindowQuery("last_modified_date_milliseconds", window)
+		jobIDList := &types.JobIDList{}
+		ctx, cancel := c.GetTimeoutContext(ctx)
+		defer cancel()
+		if err := c.api.Get(ctx, URL, c.getDefaultHeaders(), jobIDList); err != nil {
+			log.Error("ListJobsSince failed: " + err.Error())
+			return nil, false, err
+		}
+		return jobIDList.JobIDs, len(jobIDList.JobIDs) >= listSinceResultCap, nil
+	}, idKey)
+}
+
+// ListAlertsSince returns the IDs of the alerts created since the given time, querying one chunk,
+// DefaultListSinceChunk if zero, of history at a time
+func (c *Client) ListAlertsSince(ctx context.Context, symID string, since time.Time, chunk time.Duration) ([]string, error) {
+	defer c.TimeSpent("ListAlertsSince", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	return listSince(ctx, since, chunk, func(ctx context.Context, window timeWindow) ([]string, bool, error) {
+		URL := c.getSymmetrixIDListURL() + "/" + symID + "/alert?" + timeWindowQuery("created_date_milliseconds", window)
+		alertIDList := &types.AlertIDList{}
+		ctx, cancel := c.GetTimeoutContext(ctx)
+		defer cancel()
+		if err := c.api.Get(ctx, URL, c.getDefaultHeaders(), alertIDList); err != nil {
+			log.Error("ListAlertsSince failed: " + err.Error())
+			return nil, false, err
+		}
+		return alertIDList.AlertIDs, len(alertIDList.AlertIDs) >= listSinceResultCap, nil
+	}, idKey)
+}
+
+// ListAuditLogRecordsSince returns the audit log records of the array entered since the given time,
+// oldest window first, querying one chunk, DefaultListSinceChunk if zero, of history at a time
+func (c *Client) ListAuditLogRecordsSince(ctx context.Context, symID string, since time.Time, chunk time.Duration) ([]types.AuditLogRecord, error) {
+	defer c.TimeSpent("ListAuditLogRecordsSince", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	return listSince(ctx, since, chunk, func(ctx context.Context, window timeWindow) ([]types.AuditLogRecord, bool, error) {
+		URL := c.getSymmetrixIDListURL() + "/" + symID + "/audit_log_record?" + timeWindowQuery("entry_date_milliseconds", window)
+		recordList := &types.AuditLogRecordList{}
+		ctx, cancel := c.GetTimeoutContext(ctx)
+		defer cancel()
+		if err := c.api.Get(ctx, URL, c.getDefaultHeaders(), recordList); err != nil {
+			log.Error("ListAuditLogRecordsSince failed: " + err.Error())
+			return nil, false, err
+		}
+		// the count is that of the records matching the window, which may be more than those returned
+		capped := recordList.Count > len(recordList.AuditLogRecords) || len(recordList.AuditLogRecords) >= listSinceResultCap
+		return recordList.AuditLogRecords, capped, nil
+	}, func(record types.AuditLogRecord) string {
+		return fmt.Sprintf("%d", record.RecordNumber)
+	})
+}
+
+// capacityThresholdsURL returns the URL of the capacity thresholds of the array, or of one of its storage pools
+func (c *Client) capacityThresholdsURL(symID, storagePoolID string) string {
+	URL := c.getSymmetrixIDListURL() + "/" + symID
+	if storagePoolID != "" {
+		URL += "/" + StorageResourcePool + "/" + storagePoolID
+	}
+	return URL + "/capacity_threshold"
+}
+
+// GetCapacityThresholds returns the capacity alert thresholds of the array, or of a storage pool if storagePoolID is set
+func (c *Client) GetCapacityThresholds(ctx context.Context, symID string, storagePoolID string) (*types.CapacityThresholds, error) {
+	defer c.TimeSpent("GetCapacityThresholds", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	thresholds := &types.CapacityThresholds{}
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	err := c.api.Get(ctx, c.capacityThresholdsURL(symID, storagePoolID), c.getDefaultHeaders(), thresholds)
+	if err != nil {
+		log.Error("GetCapacityThresholds failed: " + err.Error())
+		return nil, err
+	}
+	return thresholds, nil
+}
+
+// SetCapacityThresholds sets the capacity alert thresholds of the array, or of a storage pool if storagePoolID is set.
+// The thresholds must be increasing, warning before critical before fatal, and at most 100 percent.
+func (c *Client) SetCapacityThresholds(ctx context.Context, symID string, storagePoolID string, thresholds *types.CapacityThresholds) error {
+	defer c.TimeSpent("SetCapacityThresholds", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return err
+	}
+	if thresholds == nil {
+		return fmt.Errorf("capacity thresholds can't be nil")
+	}
+	if thresholds.WarningPercent <= 0 || thresholds.WarningPercent >= thresholds.CriticalPercent || thresholds.CriticalPercent > 100 ||
+		(thresholds.FatalPercent != 0 && (thresholds.FatalPercent <= thresholds.CriticalPercent || thresholds.FatalPercent > 100)) {
+		return fmt.Errorf("invalid capacity thresholds: warning %d%%, critical %d%%, fatal %d%%",
+			thresholds.WarningPercent, thresholds.CriticalPercent, thresholds.FatalPercent)
+	}
+	payload := *thresholds
+	payload.SymmetrixID = symID
+	payload.StoragePoolID = storagePoolID
+	ifDebugLogPayload(payload)
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	err := c.api.Put(ctx, c.capacityThresholdsURL(symID, storagePoolID), c.getDefaultHeaders(), &payload, nil)
+	if err != nil {
+		log.Error("SetCapacityThresholds failed: " + err.Error())
+		return err
+	}
+	log.Info(fmt.Sprintf("Successfully set capacity thresholds of %s %s", symID, storagePoolID))
+	return nil
+}
+
+// GetAlertNotificationPolicies returns the alert notification policies of the array
+func (c *Client) GetAlertNotificationPolicies(ctx context.Context, symID string) (*types.AlertNotificationPolicyList, error) {
+	defer c.TimeSpent("GetAlertNotificationPolicies", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	URL := c.getSymmetrixIDListURL() + "/" + symID + "/alert_policy"
+	policies := &types.AlertNotificationPolicyList{}
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	err := c.api.Get(ctx, URL, c.getDefaultHeaders(), policies)
+	if err != nil {
+		log.Error("GetAlertNotificationPolicies failed: " + err.Error())
+		return nil, err
+	}
+	return policies, nil
+}
+
+// SetAlertNotificationPolicy enables or disables an alert notification policy of the array and sets how it is notified
+func (c *Client) SetAlertNotificationPolicy(ctx context.Context, symID string, policy *types.AlertNotificationPolicy) error {
+	defer c.TimeSpent("SetAlertNotificationPolicy", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return err
+	}
+	if policy == nil || policy.PolicyName == "" {
+		return fmt.Errorf("alert notification policy name is required")
+	}
+	ifDebugLogPayload(policy)
+	URL := c.getSymmetrixIDListURL() + "/" + symID + "/alert_policy/" + url.PathEscape(policy.PolicyName)
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	err := c.api.Put(ctx, URL, c.getDefaultHeaders(), policy, nil)
+	if err != nil {
+		log.Error("SetAlertNotificationPolicy failed: " + err.Error())
+		return err
+	}
+	log.Info(fmt.Sprintf("Successfully set alert notification policy %s", policy.PolicyName))
+	return nil
+}
+
+// GetEncryptionStatus returns the data at rest encryption (D@RE) capability and status of the array.
+// Use EncryptionStatus.Active to check that every drive is encrypted.
+func (c *Client) GetEncryptionStatus(ctx context.Context, symID string) (*types.EncryptionStatus, error) {
+	defer c.TimeSpent("GetEncryptionStatus", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	URL := c.getSymmetrixIDListURL() + "/" + symID + "/dare"
+	status := &types.EncryptionStatus{}
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	err := c.api.Get(ctx, URL, c.getDefaultHeaders(), status)
+	if err != nil {
+		log.Error("GetEncryptionStatus failed: " + err.Error())
+		return nil, err
+	}
+	return status, nil
+}
+
+// GetKeyManagerConfig returns the key manager of the array and, if it is external, the KMIP servers it uses
+func (c *Client) GetKeyManagerConfig(ctx context.Context, symID string) (*types.KeyManagerConfig, error) {
+	defer c.TimeSpent("GetKeyManagerConfig", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	URL := c.getSymmetrixIDListURL() + "/" + symID + "/dare/key_manager"
+	config := &types.KeyManagerConfig{}
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	err := c.api.Get(ctx, URL, c.getDefaultHeaders(), config)
+	if err != nil {
+		log.Error("GetKeyManagerConfig failed: " + err.Error())
+		return nil, err
+	}
+	return config, nil
+}
+
+// GetSystemHealth returns the health scores and the number of failed disks of the array
+func (c *Client) GetSystemHealth(ctx context.Context, symID string) (*types.SystemHealth, error) {
+	defer c.TimeSpent("GetSystemHealth", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	URL := c.getSymmetrixIDListURL() + "/" + symID + "/health"
+	health := &types.SystemHealth{}
+	if err := c.getWithTimeout(ctx, URL, health); err != nil {
+		log.Error("GetSystemHealth failed: " + err.Error())
+		return nil, err
+	}
+	return health, nil
+}
+
+// GetServerLoad returns the CPU and memory utilization and the request counts of the Unisphere management server,
+// e.g. to shed load, see WithAdaptiveThrottle. The server_load resource is not served by every Unisphere release;
+// the error of a release without it, a types.Error with a 404 status, is returned as is.
+func (c *Client) GetServerLoad(ctx context.Context) (*types.ServerLoad, error) {
+	defer c.TimeSpent("GetServerLoad", time.Now())
+	URL := c.urlPrefix() + "system/server_load"
+	load := &types.ServerLoad{}
+	if err := c.getWithTimeout(ctx, URL, load); err != nil {
+		log.Error("GetServerLoad failed: " + err.Error())
+		return nil, err
+	}
+	return load, nil
+}
+
+// GetHealthCheckList returns the ids of the health checks run on the array
+func (c *Client) GetHealthCheckList(ctx context.Context, symID string) (*types.HealthCheckList, error) {
+	defer c.TimeSpent("GetHealthCheckList", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	URL := c.getSymmetrixIDListURL() + "/" + symID + "/health/health_check"
+	healthChecks := &types.HealthCheckList{}
+	if err := c.getWithTimeout(ctx, URL, healthChecks); err != nil {
+		log.Error("GetHealthCheckList failed: " + err.Error())
+		return nil, err
+	}
+	return healthChecks, nil
+}
+
+// GetHealthCheck returns the test results of a health check run on the array
+func (c *Client) GetHealthCheck(ctx context.Context, symID, healthCheckID string) (*types.HealthCheck, error) {
+	defer c.TimeSpent("GetHealthCheck", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	URL := c.getSymmetrixIDListURL() + "/" + symID + "/health/health_check/" + healthCheckID
+	healthCheck := &types.HealthCheck{}
+	if err := c.getWithTimeout(ctx, URL, healthCheck); err != nil {
+		log.Error("GetHealthCheck failed: " + err.Error())
+		return nil, err
+	}
+	return healthCheck, nil
+}
+
+// MinUpgradeHealthScore is the lowest health score GetUpgradeReadiness accepts for an upgrade
+const MinUpgradeHealthScore = 80
+
+// GetUpgradeReadiness checks whether the array can be upgraded to the PowerMaxOS version targetUcode, e.g. "6079.175.0",
+// so that the upgrade can be gated before a maintenance window is scheduled. The array is ready if targetUcode is
+// more recent than its current version, and if the array is reliable, has no failed disk, health scores of
+// at least MinUpgradeHealthScore, and a most recent health check without failed tests.
+func (c *Client) GetUpgradeReadiness(ctx context.Context, symID, targetUcode string) (*types.UpgradeReadiness, error) {
+	defer c.TimeSpent("GetUpgradeReadiness", time.Now())
+	if targetUcode == "" {
+		return nil, fmt.Errorf("a target PowerMaxOS version has to be specified")
+	}
+	symmetrix, err := c.GetSymmetrixByID(ctx, symID)
+	if err != nil {
+		return nil, err
+	}
+	readiness := &types.UpgradeReadiness{
+		SymmetrixID:  symID,
+		CurrentUcode: symmetrix.Ucode,
+		TargetUcode:  targetUcode,
+		NewerVersion: compareVersions(targetUcode, symmetrix.Ucode) > 0,
+	}
+	addCheck := func(name string, passed bool, detail string) {
+		readiness.Checks = append(readiness.Checks, types.UpgradeCheck{Name: name, Passed: passed, Detail: detail})
+	}
+
+	if readiness.NewerVersion {
+		addCheck("version", true, fmt.Sprintf("%s is more recent than %s", targetUcode, symmetrix.Ucode))
+	} else {
+		addCheck("version", false, fmt.Sprintf("%s is not more recent than %s", targetUcode, symmetrix.Ucode))
+	}
+	addCheck("reliability", strings.EqualFold(symmetrix.ReliabilityState, "Normal"), "reliability state is "+symmetrix.ReliabilityState)
+
+	health, err := c.GetSystemHealth(ctx, symID)
+	if err != nil {
+		return nil, err
+	}
+	addCheck("failed disks", health.NumFailedDisks == 0, fmt.Sprintf("%d failed disks", health.NumFailedDisks))
+	for _, metric := range health.HealthScoreMetrics {
+		detail := fmt.Sprintf("health score %.0f", metric.HealthScore)
+		if metric.Expired {
+			detail += ", expired"
+		}
+		addCheck("health score "+metric.Metric, !metric.Expired && metric.HealthScore >= MinUpgradeHealthScore, detail)
+	}
+
+	healthChecks, err := c.GetHealthCheckList(ctx, symID)
+	if err != nil {
+		return nil, err
+	}
+	var latest *types.HealthCheck
+	for _, healthCheckID := range healthChecks.HealthCheckIDs {
+		healthCheck, err := c.GetHealthCheck(ctx, symID, healthCheckID)
+		if err != nil {
+			return nil, err
+		}
+		if latest == nil || healthCheck.Date > latest.Date {
+			latest = healthCheck
+		}
+	}
+	if latest == nil {
+		addCheck("health check", false, "no health check was run on the array")
+	} else {
+		var failed []string
+		for _, test := range latest.TestResult {
+			if !test.Result {
+				failed = append(failed, test.ItemName)
+			}
+		}
+		detail := fmt.Sprintf("health check %s run at %s", latest.JobID, time.UnixMilli(latest.Date).UTC().Format(time.RFC3339))
+		if len(failed) > 0 {
+			detail += ", failed tests: " + strings.Join(failed, ", ")
+		}
+		addCheck("health check", len(failed) == 0, detail)
+	}
+
+	readiness.Ready = true
+	for _, check := range readiness.Checks {
+		readiness.Ready = readiness.Ready && check.Passed
+	}
+	log.Info(fmt.Sprintf("Array %s at %s ready for an upgrade to %s: %t", symID, symmetrix.Ucode, targetUcode, readiness.Ready))
+	return readiness, nil
 }
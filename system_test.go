@@ -0,0 +1,447 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pmax
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+func TestGetFCTargets(t *testing.T) {
+	symURL := urlPrefix + "system/symmetrix/mock-local-sym-id"
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.RequestURI {
+		case symURL + "/director":
+			body = &types.DirectorIDList{DirectorIDs: []string{"OR-1C", "OR-2C"}}
+		case symURL + "/director/OR-1C/port?type=FibreChannel":
+			body = &types.PortList{SymmetrixPortKey: []types.PortKey{{DirectorID: "OR-1C", PortID: "0"}}}
+		case symURL + "/director/OR-1C/port/0":
+			body = &types.Port{SymmetrixPort: types.SymmetrixPortType{
+				Identifier:      "5000097300000001",
+				NegotiatedSpeed: "32",
+				PortStatus:      "ON",
+				DirectorStatus:  "Online",
+			}}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	targets, err := client.GetFCTargets(context.TODO(), "mock-local-sym-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []FCTarget{{
+		WWPN:            "5000097300000001",
+		DirectorID:      "OR-1C",
+		PortID:          "0",
+		NegotiatedSpeed: "32",
+		PortStatus:      "ON",
+		DirectorStatus:  "Online",
+	}}
+	if !reflect.DeepEqual(expected, targets) {
+		t.Fatalf("expected %+v, got %+v", expected, targets)
+	}
+
+	client.SetAllowedArrays([]string{"other-sym-id"})
+	if _, err = client.GetFCTargets(context.TODO(), "mock-local-sym-id"); err == nil {
+		t.Fatal("expected error for array that is not allowed")
+	}
+}
+
+func TestAllowedArrays(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		t.Fatal("no request should be sent for an array which is not allowed")
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = client.SetAllowedArrays([]string{" 000000000001 ", ""}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual([]string{"000000000001"}, client.GetAllowedArrays()) {
+		t.Fatalf("unexpected allowed arrays %v", client.GetAllowedArrays())
+	}
+	if ok, err := client.IsAllowedArray("000000000001"); !ok || err != nil {
+		t.Fatalf("expected array to be allowed, got %v", err)
+	}
+	_, err = client.GetStorageGroupSnapshots(context.TODO(), "000000000002", "sg", false, false)
+	if !IsArrayNotAllowedError(err) {
+		t.Fatalf("expected ArrayNotAllowedError, got %v", err)
+	}
+	if err.Error() != "the requested array (000000000002) is ignored as it is not managed" {
+		t.Fatalf("unexpected error message %s", err.Error())
+	}
+}
+
+func TestSplitTimeWindow(t *testing.T) {
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	windows := splitTimeWindow(since, since.Add(60*time.Hour), 24*time.Hour)
+	expected := []timeWindow{
+		{Start: since, End: since.Add(24 * time.Hour)},
+		{Start: since.Add(24 * time.Hour), End: since.Add(48 * time.Hour)},
+		{Start: since.Add(48 * time.Hour), End: since.Add(60 * time.Hour)},
+	}
+	if !reflect.DeepEqual(expected, windows) {
+		t.Fatalf("expected %v, got %v", expected, windows)
+	}
+	if windows = splitTimeWindow(since, since, 0); len(windows) != 0 {
+		t.Fatalf("expected no window, got %v", windows)
+	}
+}
+
+func TestListSince(t *testing.T) {
+	symURL := urlPrefix + "system/symmetrix/mock-sym-id"
+	var queries int
+	auditCount := 2
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		queries++
+		var body interface{}
+		switch req.URL.Path {
+		case symURL + "/job":
+			filters := req.URL.Query()["last_modified_date_milliseconds"]
+			if len(filters) != 2 || !strings.HasPrefix(filters[0], ">") || !strings.HasPrefix(filters[1], "<") {
+				resp.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			// the same job is returned by every window, along with one per window
+			body = &types.JobIDList{JobIDs: []string{"job-0", "job-" + filters[1][1:]}}
+		case symURL + "/alert":
+			filters := req.URL.Query()["created_date_milliseconds"]
+			start, _ := strconv.ParseInt(filters[0][1:], 10, 64)
+			end, _ := strconv.ParseInt(filters[1][1:], 10, 64)
+			// windows longer than 13 hours are capped
+			if time.Duration(end-start-1)*time.Millisecond > 13*time.Hour {
+				ids := make([]string, listSinceResultCap)
+				for i := range ids {
+					ids[i] = fmt.Sprintf("capped-%d", i)
+				}
+				body = &types.AlertIDList{AlertIDs: ids}
+				break
+			}
+			body = &types.AlertIDList{AlertIDs: []string{"alert-1"}}
+		case symURL + "/audit_log_record":
+			if filters := req.URL.Query()["entry_date_milliseconds"]; len(filters) != 2 {
+				resp.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			body = &types.AuditLogRecordList{Count: auditCount, AuditLogRecords: []types.AuditLogRecord{{RecordNumber: 1}, {RecordNumber: 2}}}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Error(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	since := time.Now().Add(-71 * time.Hour)
+	jobIDs, err := client.ListJobsSince(context.TODO(), "mock-sym-id", since, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queries != 3 || len(jobIDs) != 4 || jobIDs[0] != "job-0" {
+		t.Fatalf("expected 4 jobs from 3 queries, got %v from %d", jobIDs, queries)
+	}
+	queries = 0
+	alertIDs, err := client.ListAlertsSince(context.TODO(), "mock-sym-id", since, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the 24 hour windows are capped and split in two
+	if !reflect.DeepEqual(alertIDs, []string{"alert-1"}) || queries != 9 {
+		t.Fatalf("unexpected alerts %v from %d queries", alertIDs, queries)
+	}
+	records, err := client.ListAuditLogRecordsSince(context.TODO(), "mock-sym-id", since, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("unexpected audit log records %v", records)
+	}
+
+	auditCount = 3
+	if _, err = client.ListAuditLogRecordsSince(context.TODO(), "mock-sym-id", since, 0); err == nil {
+		t.Fatal("expected error for audit log records that are always capped")
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	if _, err = client.ListJobsSince(ctx, "mock-sym-id", since, time.Hour); err == nil {
+		t.Fatal("expected error for cancelled context")
+	}
+}
+
+func TestAlertingConfiguration(t *testing.T) {
+	symURL := urlPrefix + "system/symmetrix/mock-sym-id"
+	srpURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id/srp/SRP_1"
+	stored := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == http.MethodPut && req.URL.Path == srpURL:
+			payload := &types.UpdateStoragePoolParam{}
+			if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+				t.Error(err)
+			}
+			content, _ := json.Marshal(&types.StoragePool{
+				StoragePoolID:   "SRP_1",
+				ReservedCapPerc: *payload.EditStoragePoolActionParam.ModifyStoragePoolParam.ReservedCapPercent,
+			})
+			_, _ = resp.Write(content)
+		case req.Method == http.MethodPut:
+			body, _ := io.ReadAll(req.Body)
+			stored[req.URL.Path] = body
+		case req.Method == http.MethodGet && stored[req.URL.Path] != nil:
+			_, _ = resp.Write(stored[req.URL.Path])
+		case req.Method == http.MethodGet && req.URL.Path == symURL+"/alert_policy":
+			content, _ := json.Marshal(&types.AlertNotificationPolicyList{AlertNotificationPolicies: []types.AlertNotificationPolicy{{PolicyName: "SRP Utilization Alert"}}})
+			_, _ = resp.Write(content)
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+
+	reserved := 10
+	srp, err := client.ModifyStoragePool(ctx, "mock-sym-id", "SRP_1", &types.ModifyStoragePoolParam{ReservedCapPercent: &reserved})
+	if err != nil || srp.ReservedCapPerc != 10 {
+		t.Fatalf("unexpected storage pool %+v, error %v", srp, err)
+	}
+	reserved = 90
+	if _, err = client.ModifyStoragePool(ctx, "mock-sym-id", "SRP_1", &types.ModifyStoragePoolParam{ReservedCapPercent: &reserved}); err == nil {
+		t.Fatal("expected error for reserved capacity above 80 percent")
+	}
+
+	thresholds := &types.CapacityThresholds{Enabled: true, WarningPercent: 75, CriticalPercent: 90, FatalPercent: 95}
+	if err = client.SetCapacityThresholds(ctx, "mock-sym-id", "SRP_1", thresholds); err != nil {
+		t.Fatal(err)
+	}
+	got, err := client.GetCapacityThresholds(ctx, "mock-sym-id", "SRP_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := *thresholds
+	expected.SymmetrixID = "mock-sym-id"
+	expected.StoragePoolID = "SRP_1"
+	if !reflect.DeepEqual(&expected, got) {
+		t.Fatalf("expected %+v, got %+v", expected, got)
+	}
+	if _, err = client.GetCapacityThresholds(ctx, "mock-sym-id", ""); err == nil {
+		t.Fatal("expected error for array thresholds that were not set")
+	}
+	if err = client.SetCapacityThresholds(ctx, "mock-sym-id", "", &types.CapacityThresholds{WarningPercent: 90, CriticalPercent: 80}); err == nil {
+		t.Fatal("expected error for decreasing thresholds")
+	}
+
+	policies, err := client.GetAlertNotificationPolicies(ctx, "mock-sym-id")
+	if err != nil || len(policies.AlertNotificationPolicies) != 1 {
+		t.Fatalf("unexpected policies %+v, error %v", policies, err)
+	}
+	policy := &types.AlertNotificationPolicy{PolicyName: "SRP Utilization Alert", Enabled: true, EmailAddresses: []string{"storage@example.com"}}
+	if err = client.SetAlertNotificationPolicy(ctx, "mock-sym-id", policy); err != nil {
+		t.Fatal(err)
+	}
+	if stored[symURL+"/alert_policy/SRP Utilization Alert"] == nil {
+		t.Fatalf("alert policy was not updated: %v", stored)
+	}
+	if err = client.SetAlertNotificationPolicy(ctx, "mock-sym-id", &types.AlertNotificationPolicy{}); err == nil {
+		t.Fatal("expected error for policy without name")
+	}
+}
+
+func TestGetArraySummary(t *testing.T) {
+	symURL := urlPrefix + "system/symmetrix/mock-sym-id"
+	srpURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id/srp"
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var content []byte
+		switch req.URL.Path {
+		case symURL:
+			content, _ = json.Marshal(&types.Symmetrix{SymmetrixID: "mock-sym-id", Model: "PowerMax_8500", Ucode: "10.1.0.2", DellServiceTag: "ABC1234", Local: true})
+		case srpURL:
+			content, _ = json.Marshal(&types.StoragePoolList{StoragePoolIDs: []string{"SRP_2", "SRP_1"}})
+		case srpURL + "/SRP_1", srpURL + "/SRP_2":
+			content, _ = json.Marshal(&types.StoragePool{
+				StoragePoolID: strings.TrimPrefix(req.URL.Path, srpURL+"/"),
+				SrpCap:        &types.SrpCap{UsableTotInTB: 100, UsableUsedInTB: 40, SubTotInTB: 150},
+			})
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	summary, err := client.GetArraySummary(context.TODO(), "mock-sym-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Model != "PowerMax_8500" || summary.Ucode != "10.1.0.2" || summary.DellServiceTag != "ABC1234" || !summary.Local {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+	if len(summary.StoragePools) != 2 || summary.StoragePools[0].StoragePoolID != "SRP_1" || summary.StoragePools[1].UsableUsedTB != 40 {
+		t.Errorf("unexpected storage pools %+v", summary.StoragePools)
+	}
+
+	if _, err = client.GetArraySummary(context.TODO(), "unknown-sym-id"); err == nil {
+		t.Error("expected an error for an unknown array")
+	}
+}
+
+func TestEncryption(t *testing.T) {
+	dareURL := urlPrefix + "system/symmetrix/mock-sym-id/dare"
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.URL.Path {
+		case dareURL:
+			body = &types.EncryptionStatus{SymmetrixID: "mock-sym-id", Capable: true, DataEncryption: types.EncryptionEnabled, KeyManager: types.KeyManagerExternal, EncryptedDrives: 32}
+		case dareURL + "/key_manager":
+			body = &types.KeyManagerConfig{SymmetrixID: "mock-sym-id", KeyManager: types.KeyManagerExternal, KeyServers: []types.KeyServer{{Name: "kmip1", Hostname: "kmip1.example.com", Port: 5696, Status: types.KeyServerConnected}}}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err := client.GetEncryptionStatus(context.TODO(), "mock-sym-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Capable || !status.Active() {
+		t.Errorf("expected encryption to be active: %+v", status)
+	}
+	status.NonEncryptedDrives = 1
+	if status.Active() {
+		t.Error("expected encryption not to be active with a non encrypted drive")
+	}
+	config, err := client.GetKeyManagerConfig(context.TODO(), "mock-sym-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.KeyServers) != 1 || config.KeyServers[0].Port != 5696 || config.KeyServers[0].Status != types.KeyServerConnected {
+		t.Errorf("unexpected key manager config %+v", config)
+	}
+	if _, err = client.GetEncryptionStatus(context.TODO(), "other-sym-id"); err == nil {
+		t.Error("expected an error for an unknown array")
+	}
+}
+
+func TestGetUpgradeReadiness(t *testing.T) {
+	symURL := urlPrefix + "system/symmetrix/mock-sym-id"
+	failedTest := false
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.URL.Path {
+		case symURL:
+			body = &types.Symmetrix{SymmetrixID: "mock-sym-id", Ucode: "5978.711.711", ReliabilityState: "Normal"}
+		case symURL + "/health":
+			body = &types.SystemHealth{HealthScoreMetrics: []types.HealthScoreMetric{{Metric: "OVERALL", HealthScore: 95}, {Metric: "CAPACITY", HealthScore: 90}}}
+		case symURL + "/health/health_check":
+			body = &types.HealthCheckList{HealthCheckIDs: []string{"old", "new"}}
+		case symURL + "/health/health_check/old":
+			body = &types.HealthCheck{JobID: "old", Date: 1000, TestResult: []types.HealthCheckTest{{ItemName: "Vault State Test", Result: false}}}
+		case symURL + "/health/health_check/new":
+			body = &types.HealthCheck{JobID: "new", Date: 2000, TestResult: []types.HealthCheckTest{{ItemName: "Vault State Test", Result: true}, {ItemName: "Spare Drives Test", Result: !failedTest}}}
+		default:
+			t.Errorf("unexpected request %s", req.URL)
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	readiness, err := client.GetUpgradeReadiness(context.TODO(), "mock-sym-id", "6079.175.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !readiness.NewerVersion || !readiness.Ready || len(readiness.Checks) != 6 {
+		t.Fatalf("expected the array to be ready: %+v", readiness)
+	}
+
+	failedTest = true
+	readiness, err = client.GetUpgradeReadiness(context.TODO(), "mock-sym-id", "6079.175.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if check := readiness.Checks[len(readiness.Checks)-1]; readiness.Ready || check.Passed || !strings.Contains(check.Detail, "Spare Drives Test") {
+		t.Fatalf("expected the health check to fail: %+v", readiness)
+	}
+
+	readiness, err = client.GetUpgradeReadiness(context.TODO(), "mock-sym-id", "5978.669.669")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if readiness.NewerVersion || readiness.Ready || readiness.Checks[0].Passed {
+		t.Fatalf("expected an older version not to be ready: %+v", readiness)
+	}
+}
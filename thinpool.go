@@ -0,0 +1,142 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pmax
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+	log "github.com/sirupsen/logrus"
+)
+
+// MinSRPPowerMaxOS is the first PowerMaxOS (Enginuity) version provisioning from a storage resource pool (SRP)
+// with service levels. The arrays running an older version, i.e. the VMAX arrays running Enginuity 5876, provision
+// from thin pools with FAST policies.
+const MinSRPPowerMaxOS = "5977"
+
+// ThinPoolRequiredError is returned when a storage group is to be created on an array provisioning from thin
+// pools, and no thin pool is configured for the array with WithThinPool
+type ThinPoolRequiredError struct {
+	SymmetrixID string
+	Ucode       string
+}
+
+func (e *ThinPoolRequiredError) Error() string {
+	return fmt.Sprintf("array %s runs %s, older than %s, and requires a thin pool, none is configured", e.SymmetrixID, e.Ucode, MinSRPPowerMaxOS)
+}
+
+// IsThinPoolRequiredError returns true if the error is, or wraps, a ThinPoolRequiredError
+func IsThinPoolRequiredError(err error) bool {
+	var required *ThinPoolRequiredError
+	return errors.As(err, &required)
+}
+
+// thinPools holds the thin pools configured with WithThinPool, and the arrays found to provision from thin pools.
+// The generation of an array does not change, so it is only looked up once.
+type thinPools struct {
+	mutex    sync.Mutex
+	settings map[string]types.ThinPoolSettings
+	legacy   map[string]*types.Symmetrix
+}
+
+// RequiresThinPools returns true if the array provisions from thin pools with FAST policies, rather than from a
+// storage resource pool with service levels, i.e. if it runs a version older than MinSRPPowerMaxOS
+func (c *Client) RequiresThinPools(ctx context.Context, symID string) (bool, error) {
+	defer c.TimeSpent("RequiresThinPools", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return false, err
+	}
+	symmetrix, err := c.getLegacyArray(ctx, symID)
+	if err != nil {
+		log.Error("RequiresThinPools failed: " + err.Error())
+		return false, err
+	}
+	return symmetrix != nil, nil
+}
+
+// getLegacyArray returns the array if it provisions from thin pools, or nil if it provisions from an SRP
+func (c *Client) getLegacyArray(ctx context.Context, symID string) (*types.Symmetrix, error) {
+	c.thinPools.mutex.Lock()
+	symmetrix, ok := c.thinPools.legacy[symID]
+	c.thinPools.mutex.Unlock()
+	if ok {
+		return symmetrix, nil
+	}
+	symmetrix, err := c.getSymmetrixByID(ctx, symID)
+	if err != nil {
+		return nil, err
+	}
+	if compareVersions(symmetrix.Ucode, MinSRPPowerMaxOS) >= 0 {
+		symmetrix = nil
+	}
+	c.thinPools.mutex.Lock()
+	if c.thinPools.legacy == nil {
+		c.thinPools.legacy = make(map[string]*types.Symmetrix)
+	}
+	c.thinPools.legacy[symID] = symmetrix
+	c.thinPools.mutex.Unlock()
+	return symmetrix, nil
+}
+
+// getThinPoolSettings returns the thin pool to provision from on the array, or nil if the array provisions from an
+// SRP. The arrays are only looked up if thin pools are configured, so that the clients managing PowerMax arrays only
+// do not send any additional request.
+func (c *Client) getThinPoolSettings(ctx context.Context, symID string) (*types.ThinPoolSettings, error) {
+	if len(c.thinPools.settings) == 0 {
+		return nil, nil
+	}
+	symmetrix, err := c.getLegacyArray(ctx, symID)
+	if err != nil || symmetrix == nil {
+		return nil, err
+	}
+	settings, ok := c.thinPools.settings[symID]
+	if !ok {
+		return nil, &ThinPoolRequiredError{SymmetrixID: symID, Ucode: symmetrix.Ucode}
+	}
+	return &settings, nil
+}
+
+// setStorageGroupThinPool sets the thin pool and FAST policy, instead of the SRP and service level, of a storage group
+// created on an array provisioning from thin pools
+func (c *Client) setStorageGroupThinPool(ctx context.Context, symID string, payload interface{}) error {
+	settings, err := c.getThinPoolSettings(ctx, symID)
+	if err != nil || settings == nil {
+		return err
+	}
+	if param, ok := payload.(*types.CreateStorageGroupParam); ok {
+		param.SRPID = ""
+		param.SLOBasedStorageGroupParam = nil
+		param.ThinPool = settings.ThinPool
+		param.FastPolicy = settings.FastPolicy
+	}
+	return nil
+}
+
+// setReplicaThinPool sets the thin pool and FAST policy, instead of the service level, of a storage group replica
+// created on an array provisioning from thin pools
+func (c *Client) setReplicaThinPool(ctx context.Context, remoteSymID string, payload *types.CreateSGSRDF) error {
+	settings, err := c.getThinPoolSettings(ctx, remoteSymID)
+	if err != nil || settings == nil || payload == nil {
+		return err
+	}
+	payload.ThinPool = settings.ThinPool
+	payload.FastPolicy = settings.FastPolicy
+	payload.RemoteSLO = ""
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pmax
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+func TestThinPools(t *testing.T) {
+	var mutex sync.Mutex
+	lookups := map[string]int{}
+	var storageGroups []map[string]interface{}
+	var replicas []types.CreateSGSRDF
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		var body interface{}
+		switch req.Method + " " + req.URL.Path {
+		case "GET " + urlPrefix + "system/symmetrix/000000000001", "GET " + urlPrefix + "system/symmetrix/000000000003":
+			id := req.URL.Path[len(req.URL.Path)-12:]
+			lookups[id]++
+			body = &types.Symmetrix{SymmetrixID: id, Ucode: "5876.309.196", Model: "VMAX40K"}
+		case "GET " + urlPrefix + "system/symmetrix/000000000002":
+			lookups["000000000002"]++
+			body = &types.Symmetrix{SymmetrixID: "000000000002", Ucode: "6079.175.0", Model: "PowerMax_8500"}
+		case "POST " + urlPrefix + SLOProvisioningX + SymmetrixX + "000000000001" + XStorageGroup,
+			"POST " + urlPrefix + SLOProvisioningX + SymmetrixX + "000000000002" + XStorageGroup,
+			"POST " + urlPrefix + SLOProvisioningX + SymmetrixX + "000000000003" + XStorageGroup:
+			payload := map[string]interface{}{}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				t.Fatal(err)
+			}
+			storageGroups = append(storageGroups, payload)
+			body = &types.StorageGroup{StorageGroupID: "sg-1"}
+		case "POST " + urlPrefix + ReplicationX + SymmetrixX + "000000000002" + XStorageGroup + "/sg-1" + XRDFGroup:
+			payload := types.CreateSGSRDF{}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				t.Fatal(err)
+			}
+			replicas = append(replicas, payload)
+			body = &types.SGRDFInfo{SymmetrixID: "000000000002"}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL, WithThinPool("000000000001", "FC_Pool", "Gold_FAST"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+	for _, symID := range []string{"000000000001", "000000000002", "000000000001"} {
+		if _, err = client.CreateStorageGroup(ctx, symID, "sg-1", "SRP_1", "Diamond", false, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok := storageGroups[0]["srpId"]; ok || storageGroups[0]["sloBasedStorageGroupParam"] != nil {
+		t.Errorf("expected the SRP and service level to be omitted on a VMAX array, got %v", storageGroups[0])
+	}
+	if storageGroups[0]["thinPool"] != "FC_Pool" || storageGroups[0]["fastPolicy"] != "Gold_FAST" {
+		t.Errorf("expected the thin pool and FAST policy on a VMAX array, got %v", storageGroups[0])
+	}
+	if _, ok := storageGroups[1]["thinPool"]; ok {
+		t.Errorf("expected no thin pool on a PowerMax array, got %v", storageGroups[1])
+	}
+	if storageGroups[1]["srpId"] != "SRP_1" || storageGroups[1]["sloBasedStorageGroupParam"] == nil {
+		t.Errorf("expected the SRP and service level on a PowerMax array, got %v", storageGroups[1])
+	}
+	if lookups["000000000001"] != 1 || lookups["000000000002"] != 1 {
+		t.Errorf("expected each array to be looked up once, got %v", lookups)
+	}
+
+	if _, err = client.CreateSGReplica(ctx, "000000000002", "000000000001", "ASYNC", "10", "sg-1", "sg-1", "Diamond", false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.CreateSGReplica(ctx, "000000000002", "000000000003", "ASYNC", "10", "sg-1", "sg-1", "Diamond", false); !IsThinPoolRequiredError(err) {
+		t.Errorf("expected a ThinPoolRequiredError for a VMAX array without thin pool, got %v", err)
+	}
+	if len(replicas) != 1 || replicas[0].ThinPool != "FC_Pool" || replicas[0].FastPolicy != "Gold_FAST" || replicas[0].RemoteSLO != "" {
+		t.Errorf("expected the replica on the VMAX array to use the thin pool, got %+v", replicas)
+	}
+
+	required, err := client.RequiresThinPools(ctx, "000000000002")
+	if err != nil || required {
+		t.Errorf("expected a PowerMax array not to require thin pools, got %t and %v", required, err)
+	}
+
+	storageGroups = nil
+	client, err = New(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.CreateStorageGroup(ctx, "000000000003", "sg-1", "SRP_1", "Diamond", false, nil); err != nil {
+		t.Fatal(err)
+	}
+	if storageGroups[0]["srpId"] != "SRP_1" || lookups["000000000003"] != 1 {
+		t.Errorf("expected the arrays not to be looked up without thin pools, got %v", lookups)
+	}
+}
@@ -0,0 +1,43 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v100
+
+import "time"
+
+// ExpiredSnapshot : a generation of a storage group snapshot past its time to live, which can be terminated
+type ExpiredSnapshot struct {
+	StorageGroupID       string `json:"storageGroupId"`
+	SnapshotName         string `json:"snapshotName"`
+	SnapID               int64  `json:"snapid"`
+	Generation           int64  `json:"generation"`
+	Timestamp            string `json:"timestamp"`
+	TimeToLiveExpiryDate string `json:"timeToLiveExpiryDate"`
+	// Linked snapshots have to be unlinked before they are terminated
+	Linked bool `json:"linked"`
+}
+
+// OrphanedResources : the resources of an array left unused, as found by GetOrphanedResources, sorted by id
+type OrphanedResources struct {
+	SymmetrixID string    `json:"symmetrixId"`
+	ScannedAt   time.Time `json:"scannedAt"`
+	// Volumes are the volumes not in any storage group
+	Volumes []string `json:"volumes"`
+	// StorageGroups are the storage groups not in any masking view, directly or through a parent storage group
+	StorageGroups []string `json:"storageGroups"`
+	// PortGroups are the port groups not in any masking view
+	PortGroups []string `json:"portGroups"`
+	// ExpiredSnapshots are the storage group snapshots past their time to live
+	ExpiredSnapshots []ExpiredSnapshot `json:"expiredSnapshots"`
+}
@@ -0,0 +1,259 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v100
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Some fields are sent with a different JSON type depending on the Unisphere release,
+// e.g. capacities as a number or as a string, port identifiers as a string or as a number,
+// and single values instead of lists. The types below decode any of these forms, and are
+// used by the UnmarshalJSON methods of the structures having such fields, so that their
+// exported fields keep their Go types.
+
+var jsonNull = []byte("null")
+
+// flexFloat decodes a JSON number, or a string holding a number
+type flexFloat float64
+
+func (f *flexFloat) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, jsonNull) {
+		return nil
+	}
+	text := string(data)
+	if data[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			*f = 0
+			return nil
+		}
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return fmt.Errorf("cannot decode %s as a number: %w", data, err)
+	}
+	*f = flexFloat(value)
+	return nil
+}
+
+// flexInt decodes a JSON integer, or a string holding an integer. Integral floats such as 10.0 are accepted.
+type flexInt int
+
+func (i *flexInt) UnmarshalJSON(data []byte) error {
+	var value flexFloat
+	if err := value.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	if value != flexFloat(math.Trunc(float64(value))) {
+		return fmt.Errorf("cannot decode %s as an integer", data)
+	}
+	*i = flexInt(value)
+	return nil
+}
+
+// flexString decodes a JSON string, or a number which is kept as sent
+type flexString string
+
+func (s *flexString) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, jsonNull) {
+		return nil
+	}
+	if data[0] == '"' {
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		*s = flexString(value)
+		return nil
+	}
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return fmt.Errorf("cannot decode %s as a string: %w", data, err)
+	}
+	*s = flexString(number)
+	return nil
+}
+
+// flexStrings decodes a JSON array of strings, or a single string
+type flexStrings []string
+
+func (s *flexStrings) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, jsonNull) {
+		return nil
+	}
+	if data[0] != '[' {
+		var value flexString
+		if err := value.UnmarshalJSON(data); err != nil {
+			return err
+		}
+		*s = flexStrings{string(value)}
+		return nil
+	}
+	var values []flexString
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*s = make(flexStrings, len(values))
+	for i, value := range values {
+		(*s)[i] = string(value)
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes a Volume, accepting capacities sent as strings and a single storage group id
+func (v *Volume) UnmarshalJSON(data []byte) error {
+	type volume Volume
+	aux := struct {
+		*volume
+		CapacityGB         flexFloat   `json:"cap_gb"`
+		FloatCapacityMB    flexFloat   `json:"cap_mb"`
+		CapacityCYL        flexInt     `json:"cap_cyl"`
+		StorageGroupIDList flexStrings `json:"storageGroupId"`
+	}{
+		volume:             (*volume)(v),
+		CapacityGB:         flexFloat(v.CapacityGB),
+		FloatCapacityMB:    flexFloat(v.FloatCapacityMB),
+		CapacityCYL:        flexInt(v.CapacityCYL),
+		StorageGroupIDList: flexStrings(v.StorageGroupIDList),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	v.CapacityGB = float64(aux.CapacityGB)
+	v.FloatCapacityMB = float64(aux.FloatCapacityMB)
+	v.CapacityCYL = int(aux.CapacityCYL)
+	v.StorageGroupIDList = aux.StorageGroupIDList
+	return nil
+}
+
+// UnmarshalJSON decodes a VolumeDetail, as the UnmarshalJSON method of the embedded Volume would otherwise drop its error
+func (v *VolumeDetail) UnmarshalJSON(data []byte) error {
+	if err := v.Volume.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	detail := struct {
+		Error string `json:"error"`
+	}{Error: v.Error}
+	if err := json.Unmarshal(data, &detail); err != nil {
+		return err
+	}
+	v.Error = detail.Error
+	return nil
+}
+
+// UnmarshalJSON decodes a StorageGroup, accepting a capacity sent as a string and single
+// child, parent and masking view names
+func (sg *StorageGroup) UnmarshalJSON(data []byte) error {
+	type storageGroup StorageGroup
+	aux := struct {
+		*storageGroup
+		CapacityGB         flexFloat   `json:"cap_gb"`
+		ChildStorageGroup  flexStrings `json:"child_storage_group"`
+		ParentStorageGroup flexStrings `json:"parent_storage_group"`
+		MaskingView        flexStrings `json:"maskingview"`
+	}{
+		storageGroup:       (*storageGroup)(sg),
+		CapacityGB:         flexFloat(sg.CapacityGB),
+		ChildStorageGroup:  flexStrings(sg.ChildStorageGroup),
+		ParentStorageGroup: flexStrings(sg.ParentStorageGroup),
+		MaskingView:        flexStrings(sg.MaskingView),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	sg.CapacityGB = float64(aux.CapacityGB)
+	sg.ChildStorageGroup = aux.ChildStorageGroup
+	sg.ParentStorageGroup = aux.ParentStorageGroup
+	sg.MaskingView = aux.MaskingView
+	return nil
+}
+
+// UnmarshalJSON decodes a SrpCap, accepting capacities sent as strings
+func (c *SrpCap) UnmarshalJSON(data []byte) error {
+	type srpCap SrpCap
+	aux := struct {
+		*srpCap
+		SubAllocCapInTB              flexFloat `json:"subscribed_allocated_tb"`
+		SubTotInTB                   flexFloat `json:"subscribed_total_tb"`
+		SnapModInTB                  flexFloat `json:"snapshot_modified_tb"`
+		SnapTotInTB                  flexFloat `json:"snapshot_total_tb"`
+		UsableUsedInTB               flexFloat `json:"usable_used_tb"`
+		UsableTotInTB                flexFloat `json:"usable_total_tb"`
+		EffectiveUsedCapacityPercent flexInt   `json:"effective_used_capacity_percent"`
+	}{
+		srpCap:                       (*srpCap)(c),
+		SubAllocCapInTB:              flexFloat(c.SubAllocCapInTB),
+		SubTotInTB:                   flexFloat(c.SubTotInTB),
+		SnapModInTB:                  flexFloat(c.SnapModInTB),
+		SnapTotInTB:                  flexFloat(c.SnapTotInTB),
+		UsableUsedInTB:               flexFloat(c.UsableUsedInTB),
+		UsableTotInTB:                flexFloat(c.UsableTotInTB),
+		EffectiveUsedCapacityPercent: flexInt(c.EffectiveUsedCapacityPercent),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	c.SubAllocCapInTB = float64(aux.SubAllocCapInTB)
+	c.SubTotInTB = float64(aux.SubTotInTB)
+	c.SnapModInTB = float64(aux.SnapModInTB)
+	c.SnapTotInTB = float64(aux.SnapTotInTB)
+	c.UsableUsedInTB = float64(aux.UsableUsedInTB)
+	c.UsableTotInTB = float64(aux.UsableTotInTB)
+	c.EffectiveUsedCapacityPercent = int(aux.EffectiveUsedCapacityPercent)
+	return nil
+}
+
+// UnmarshalJSON decodes a PortKey, accepting a port id sent as a number
+func (p *PortKey) UnmarshalJSON(data []byte) error {
+	type portKey PortKey
+	aux := struct {
+		*portKey
+		PortID flexString `json:"portId"`
+	}{
+		portKey: (*portKey)(p),
+		PortID:  flexString(p.PortID),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	p.PortID = string(aux.PortID)
+	return nil
+}
+
+// UnmarshalJSON decodes a SymmetrixPortKeyType, accepting a port id sent as a number
+func (p *SymmetrixPortKeyType) UnmarshalJSON(data []byte) error {
+	type symmetrixPortKey SymmetrixPortKeyType
+	aux := struct {
+		*symmetrixPortKey
+		PortID flexString `json:"portId"`
+	}{
+		symmetrixPortKey: (*symmetrixPortKey)(p),
+		PortID:           flexString(p.PortID),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	p.PortID = string(aux.PortID)
+	return nil
+}
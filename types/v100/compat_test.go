@@ -0,0 +1,76 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v100
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestUnmarshalAcrossVersions(t *testing.T) {
+	for _, body := range []string{
+		`{"volumeId":"00001","cap_gb":1.5,"cap_mb":1536,"cap_cyl":819,"storageGroupId":["sg1"],"symmetrixPortKey":[{"directorId":"FA-1D","portId":"4"}]}`,
+		`{"volumeId":"00001","cap_gb":"1.5","cap_mb":"1536","cap_cyl":"819","storageGroupId":"sg1","symmetrixPortKey":[{"directorId":"FA-1D","portId":4}]}`,
+	} {
+		vol := &Volume{}
+		if err := json.Unmarshal([]byte(body), vol); err != nil {
+			t.Fatalf("%s: %v", body, err)
+		}
+		expected := &Volume{
+			VolumeID:           "00001",
+			CapacityGB:         1.5,
+			FloatCapacityMB:    1536,
+			CapacityCYL:        819,
+			StorageGroupIDList: []string{"sg1"},
+			SymmetrixPortKey:   []SymmetrixPortKeyType{{DirectorID: "FA-1D", PortID: "4"}},
+		}
+		if !reflect.DeepEqual(vol, expected) {
+			t.Errorf("%s: expected %+v, got %+v", body, expected, vol)
+		}
+	}
+
+	sg := &StorageGroup{}
+	if err := json.Unmarshal([]byte(`{"storageGroupId":"sg1","cap_gb":"","maskingview":"mv1","child_storage_group":null}`), sg); err != nil {
+		t.Fatal(err)
+	}
+	if sg.StorageGroupID != "sg1" || sg.CapacityGB != 0 || !reflect.DeepEqual(sg.MaskingView, []string{"mv1"}) || sg.ChildStorageGroup != nil {
+		t.Errorf("unexpected storage group %+v", sg)
+	}
+
+	pool := &StoragePool{}
+	if err := json.Unmarshal([]byte(`{"srpId":"SRP_1","srp_capacity":{"usable_total_tb":"100.5","effective_used_capacity_percent":"40"}}`), pool); err != nil {
+		t.Fatal(err)
+	}
+	if pool.SrpCap.UsableTotInTB != 100.5 || pool.SrpCap.EffectiveUsedCapacityPercent != 40 {
+		t.Errorf("unexpected storage pool capacity %+v", pool.SrpCap)
+	}
+
+	for _, body := range []string{`{"cap_gb":"large"}`, `{"cap_cyl":1.5}`, `{"storageGroupId":{}}`} {
+		if err := json.Unmarshal([]byte(body), &Volume{}); err == nil {
+			t.Errorf("%s: expected an error", body)
+		}
+	}
+
+	detail := &VolumeDetail{}
+	if err := json.Unmarshal([]byte(`{"volumeId":"00001","cap_gb":"1.5","error":"not found"}`), detail); err != nil {
+		t.Fatal(err)
+	}
+	if detail.VolumeID != "00001" || detail.CapacityGB != 1.5 || detail.Error != "not found" {
+		t.Errorf("unexpected volume detail %+v", detail)
+	}
+}
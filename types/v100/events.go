@@ -0,0 +1,39 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v100
+
+import "time"
+
+// The statuses of a WorkflowEvent
+const (
+	StepStarted   = "started"
+	StepSucceeded = "succeeded"
+	StepFailed    = "failed"
+)
+
+// WorkflowEvent : the progress of a step of a call made of several steps, such as CopySnapshotToRemoteArray
+type WorkflowEvent struct {
+	// Workflow is the name of the call, e.g. "CopySnapshotToRemoteArray"
+	Workflow string `json:"workflow"`
+	// Step describes the step, e.g. "Suspend storage group sg1"
+	Step string `json:"step"`
+	// Status is StepStarted, StepSucceeded or StepFailed
+	Status string    `json:"status"`
+	Time   time.Time `json:"time"`
+	// Duration is the time the step took, once it succeeded or failed
+	Duration time.Duration `json:"duration,omitempty"`
+	// Error is the error of a failed step
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,179 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v100
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExportFormat is the format of an array configuration export
+type ExportFormat string
+
+// Formats of an array configuration export
+const (
+	// ExportFormatJSON writes the ArrayConfiguration as an indented JSON document
+	ExportFormatJSON ExportFormat = "json"
+	// ExportFormatCSV writes the ConfigurationRecords of the ArrayConfiguration, one per line, after a
+	// kind,id,attribute,value header
+	ExportFormatCSV ExportFormat = "csv"
+)
+
+// Kinds of the objects of a ConfigurationRecord
+const (
+	ConfigurationKindStorageGroup = "storageGroup"
+	ConfigurationKindMaskingView  = "maskingView"
+	ConfigurationKindHost         = "host"
+	ConfigurationKindPortGroup    = "portGroup"
+	ConfigurationKindVolume       = "volume"
+	ConfigurationKindRDFGroup     = "rdfGroup"
+)
+
+// ArrayConfiguration : the configuration of an array, normalized so that two exports of an unchanged array are equal.
+// Only the settings are kept, not the counters or the state, and the objects and their lists are sorted by id.
+type ArrayConfiguration struct {
+	SymmetrixID   string                      `json:"symmetrixId"`
+	ExportedAt    time.Time                   `json:"exportedAt"`
+	StorageGroups []StorageGroupConfiguration `json:"storageGroups"`
+	MaskingViews  []MaskingView               `json:"maskingViews"`
+	Hosts         []HostConfiguration         `json:"hosts"`
+	PortGroups    []PortGroupConfiguration    `json:"portGroups"`
+	Volumes       []VolumeConfiguration       `json:"volumes"`
+	RDFGroups     []RDFGroupConfiguration     `json:"rdfGroups"`
+}
+
+// StorageGroupConfiguration : the settings of a storage group in an ArrayConfiguration
+type StorageGroupConfiguration struct {
+	StorageGroupID      string   `json:"storageGroupId"`
+	ServiceLevel        string   `json:"serviceLevel,omitempty"`
+	SRP                 string   `json:"srp,omitempty"`
+	Workload            string   `json:"workload,omitempty"`
+	Compression         bool     `json:"compression"`
+	ParentStorageGroups []string `json:"parentStorageGroups,omitempty"`
+	ChildStorageGroups  []string `json:"childStorageGroups,omitempty"`
+	SnapshotPolicies    []string `json:"snapshotPolicies,omitempty"`
+	Tags                string   `json:"tags,omitempty"`
+}
+
+// HostConfiguration : the settings of a host in an ArrayConfiguration
+type HostConfiguration struct {
+	HostID        string   `json:"hostId"`
+	HostType      string   `json:"type,omitempty"`
+	Initiators    []string `json:"initiators,omitempty"`
+	ConsistentLun bool     `json:"consistentLun"`
+	EnabledFlags  string   `json:"enabledFlags,omitempty"`
+	DisabledFlags string   `json:"disabledFlags,omitempty"`
+}
+
+// PortGroupConfiguration : the settings of a port group in an ArrayConfiguration
+type PortGroupConfiguration struct {
+	PortGroupID string `json:"portGroupId"`
+	Protocol    string `json:"protocol,omitempty"`
+	// Ports are formatted as director:port, e.g. FA-1D:4
+	Ports []string `json:"ports,omitempty"`
+}
+
+// VolumeConfiguration : the settings of a volume in an ArrayConfiguration
+type VolumeConfiguration struct {
+	VolumeID         string   `json:"volumeId"`
+	VolumeIdentifier string   `json:"volumeIdentifier,omitempty"`
+	Emulation        string   `json:"emulation,omitempty"`
+	CapacityCYL      int      `json:"capacityCyl"`
+	WWN              string   `json:"wwn,omitempty"`
+	StorageGroups    []string `json:"storageGroups,omitempty"`
+}
+
+// RDFGroupConfiguration : the settings of an RDF group in an ArrayConfiguration
+type RDFGroupConfiguration struct {
+	RDFGroupNumber       int      `json:"rdfGroupNumber"`
+	Label                string   `json:"label,omitempty"`
+	RemoteSymmetrixID    string   `json:"remoteSymmetrixId,omitempty"`
+	RemoteRDFGroupNumber int      `json:"remoteRdfGroupNumber"`
+	Modes                []string `json:"modes,omitempty"`
+	Metro                bool     `json:"metro"`
+	LocalPorts           []string `json:"localPorts,omitempty"`
+	RemotePorts          []string `json:"remotePorts,omitempty"`
+}
+
+// ConfigurationRecord : one setting of one object of an ArrayConfiguration. Lists are joined with ";".
+type ConfigurationRecord struct {
+	Kind      string `json:"kind"`
+	ID        string `json:"id"`
+	Attribute string `json:"attribute"`
+	Value     string `json:"value"`
+}
+
+// Records flattens the configuration into one record per setting, in the order of the configuration,
+// so that two configurations can be compared line by line
+func (c *ArrayConfiguration) Records() []ConfigurationRecord {
+	var records []ConfigurationRecord
+	add := func(kind, id string, attributes ...string) {
+		for i := 0; i+1 < len(attributes); i += 2 {
+			records = append(records, ConfigurationRecord{Kind: kind, ID: id, Attribute: attributes[i], Value: attributes[i+1]})
+		}
+	}
+	list := func(values []string) string { return strings.Join(values, ";") }
+	for _, sg := range c.StorageGroups {
+		add(ConfigurationKindStorageGroup, sg.StorageGroupID,
+			"serviceLevel", sg.ServiceLevel,
+			"srp", sg.SRP,
+			"workload", sg.Workload,
+			"compression", strconv.FormatBool(sg.Compression),
+			"parentStorageGroups", list(sg.ParentStorageGroups),
+			"childStorageGroups", list(sg.ChildStorageGroups),
+			"snapshotPolicies", list(sg.SnapshotPolicies),
+			"tags", sg.Tags)
+	}
+	for _, mv := range c.MaskingViews {
+		add(ConfigurationKindMaskingView, mv.MaskingViewID,
+			"hostId", mv.HostID,
+			"hostGroupId", mv.HostGroupID,
+			"portGroupId", mv.PortGroupID,
+			"storageGroupId", mv.StorageGroupID)
+	}
+	for _, host := range c.Hosts {
+		add(ConfigurationKindHost, host.HostID,
+			"type", host.HostType,
+			"initiators", list(host.Initiators),
+			"consistentLun", strconv.FormatBool(host.ConsistentLun),
+			"enabledFlags", host.EnabledFlags,
+			"disabledFlags", host.DisabledFlags)
+	}
+	for _, pg := range c.PortGroups {
+		add(ConfigurationKindPortGroup, pg.PortGroupID,
+			"protocol", pg.Protocol,
+			"ports", list(pg.Ports))
+	}
+	for _, vol := range c.Volumes {
+		add(ConfigurationKindVolume, vol.VolumeID,
+			"volumeIdentifier", vol.VolumeIdentifier,
+			"emulation", vol.Emulation,
+			"capacityCyl", strconv.Itoa(vol.CapacityCYL),
+			"wwn", vol.WWN,
+			"storageGroups", list(vol.StorageGroups))
+	}
+	for _, rdfg := range c.RDFGroups {
+		add(ConfigurationKindRDFGroup, strconv.Itoa(rdfg.RDFGroupNumber),
+			"label", rdfg.Label,
+			"remoteSymmetrixId", rdfg.RemoteSymmetrixID,
+			"remoteRdfGroupNumber", strconv.Itoa(rdfg.RemoteRDFGroupNumber),
+			"modes", list(rdfg.Modes),
+			"metro", strconv.FormatBool(rdfg.Metro),
+			"localPorts", list(rdfg.LocalPorts),
+			"remotePorts", list(rdfg.RemotePorts))
+	}
+	return records
+}
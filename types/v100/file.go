@@ -187,6 +187,7 @@ type ModifyNFSExport struct {
 // CreateNFSExport holds param to create NFS export
 type CreateNFSExport struct {
 	StorageResource    string   `json:"storage_resource"`
+	Snap               string   `json:"snap,omitempty"`
 	Name               string   `json:"name"`
 	Path               string   `json:"path"`
 	Description        string   `json:"description,omitempty"`
@@ -289,3 +290,49 @@ type FileInterface struct {
 	IsDisabled bool   `json:"is_disabled"`
 	Override   bool   `json:"override"`
 }
+
+// FileSystemSnapshotList file system snapshot list resulted
+type FileSystemSnapshotList struct {
+	FileSystemSnapshotList []FileSystemIDName `json:"result"`
+	From                   int                `json:"from"`
+	To                     int                `json:"to"`
+}
+
+// FileSystemSnapshotIterator holds the iterator of resultant file system snapshot list
+type FileSystemSnapshotIterator struct {
+	ResultList     FileSystemSnapshotList `json:"resultList"`
+	ID             string                 `json:"id"`
+	Count          int                    `json:"count"`
+	ExpirationTime int64                  `json:"expirationTime"`
+	MaxPageSize    int                    `json:"maxPageSize"`
+}
+
+// FileSystemSnapshot holds information about a snapshot of a file system
+type FileSystemSnapshot struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	FileSystem     string `json:"file_system"`
+	Description    string `json:"description"`
+	CreationTime   string `json:"creation_time"`
+	ExpirationTime string `json:"expiration_time"`
+	AccessType     string `json:"access_type"`
+	ReadOnly       bool   `json:"read_only"`
+	SizeUsed       int64  `json:"size_used"`
+}
+
+// CreateFileSystemSnapshot has payload to create a file system snapshot
+type CreateFileSystemSnapshot struct {
+	FileSystem  string `json:"file_system"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// ExpirationTime is when the snapshot is deleted automatically, in the format of the array, e.g. 2024-01-02T10:00:00Z
+	ExpirationTime string `json:"expiration_time,omitempty"`
+	// AccessType is Snapshot, for a read-only checkpoint, or Protocol, to share the snapshot read-write
+	AccessType string `json:"access_type,omitempty"`
+}
+
+// RestoreFileSystemSnapshot has payload to restore a file system from one of its snapshots
+type RestoreFileSystemSnapshot struct {
+	// BackupSnapshotName creates a snapshot of the file system, with this name, before it is restored
+	BackupSnapshotName string `json:"backup_snap_name,omitempty"`
+}
@@ -73,3 +73,38 @@ type EditHostsParam struct {
 type HostGroupList struct {
 	HostGroupIDs []string `json:"hostGroupId"`
 }
+
+// ConsistentLunFlag is the name under which a difference of the consistent_lun setting is reported in a HostFlagConflict
+const ConsistentLunFlag = "Consistent_LUN"
+
+// EffectiveHost : a host of a host group, with the flags it inherits from the group
+type EffectiveHost struct {
+	HostID     string   `json:"hostId"`
+	Initiators []string `json:"initiators"`
+	// EnabledFlags and DisabledFlags are the flags of the host group with the ones set on the host overriding them
+	EnabledFlags  []string `json:"enabledFlags"`
+	DisabledFlags []string `json:"disabledFlags"`
+	ConsistentLun bool     `json:"consistentLun"`
+	// Error is set if the host cannot be read, its initiators and flags are then the ones known from the group
+	Error string `json:"error,omitempty"`
+}
+
+// HostFlagConflict : a flag enabled by some of a host group and its hosts and disabled by others
+type HostFlagConflict struct {
+	Flag string `json:"flag"`
+	// EnabledBy and DisabledBy are the IDs of the host group, and of the hosts, setting the flag
+	EnabledBy  []string `json:"enabledBy"`
+	DisabledBy []string `json:"disabledBy"`
+}
+
+// HostGroupInheritance : the initiators and flags in effect for a host group including its child hosts
+type HostGroupInheritance struct {
+	HostGroupID   string          `json:"hostGroupId"`
+	EnabledFlags  []string        `json:"enabledFlags"`
+	DisabledFlags []string        `json:"disabledFlags"`
+	ConsistentLun bool            `json:"consistentLun"`
+	Hosts         []EffectiveHost `json:"hosts"`
+	// Initiators are the initiators of all the hosts, sorted and without duplicates
+	Initiators []string           `json:"initiators"`
+	Conflicts  []HostFlagConflict `json:"conflicts,omitempty"`
+}
@@ -19,6 +19,14 @@ type MaskingViewList struct {
 	MaskingViewIDs []string `json:"maskingViewId"`
 }
 
+// MaskingViewFilter holds the filters of a masking view listing, empty fields do not filter
+type MaskingViewFilter struct {
+	// HostOrHostGroupID selects the masking views of a host or of a host group
+	HostOrHostGroupID string `json:"hostOrHostGroupId,omitempty"`
+	PortGroupID       string `json:"portGroupId,omitempty"`
+	StorageGroupID    string `json:"storageGroupId,omitempty"`
+}
+
 // MaskingView holds masking view fields
 type MaskingView struct {
 	MaskingViewID  string `json:"maskingViewId"`
@@ -1,5 +1,7 @@
 package v100
 
+import "slices"
+
 // MigrationEnv related data types
 type MigrationEnv struct {
 	ArrayID               string `json:"arrayId"`
@@ -51,6 +53,35 @@ type MigrationDevicePairs struct {
 	MissingTgt    bool   `json:"missingTgt"`
 }
 
+// MigrationDevicePairState contains the state and copy progress of a device pair amidst migration
+type MigrationDevicePairState struct {
+	SrcVolumeName     string  `json:"srcVolumeName"`
+	TgtVolumeName     string  `json:"tgtVolumeName"`
+	State             string  `json:"state"`
+	TotalCapacity     float64 `json:"totalCapacity"`
+	RemainingCapacity float64 `json:"remainingCapacity"`
+	PercentComplete   float64 `json:"percentComplete"`
+}
+
+// MigrationDevicePairStates contains the device pair states of a storage group migration session
+type MigrationDevicePairStates struct {
+	StorageGroup    string                     `json:"storageGroup"`
+	State           string                     `json:"state"`
+	PercentComplete float64                    `json:"percentComplete"`
+	DevicePairs     []MigrationDevicePairState `json:"devicePair"`
+}
+
+// NotInState returns the device pairs which are not in any of the given states, e.g. the pairs holding up a cutover
+func (s *MigrationDevicePairStates) NotInState(states ...string) []MigrationDevicePairState {
+	var pairs []MigrationDevicePairState
+	for _, pair := range s.DevicePairs {
+		if !slices.Contains(states, pair.State) {
+			pairs = append(pairs, pair)
+		}
+	}
+	return pairs
+}
+
 // SourceMaskingView contains source masking view information
 type SourceMaskingView struct {
 	Name           string         `json:"name"`
@@ -160,3 +160,70 @@ type FileSystemResult struct {
 	PercentBusy float64 `json:"PercentBusy"`
 	Timestamp   int64   `json:"timestamp"`
 }
+
+// FEPortMetricsParam parameters for the query of the metrics of a front-end director port
+type FEPortMetricsParam struct {
+	SymmetrixID string   `json:"symmetrixId"`
+	StartDate   int64    `json:"startDate"`
+	EndDate     int64    `json:"endDate"`
+	DataFormat  string   `json:"dataFormat"`
+	DirectorID  string   `json:"directorId"`
+	PortID      string   `json:"portId"`
+	Metrics     []string `json:"metrics"`
+}
+
+// FEPortMetricsIterator contains the result of query
+type FEPortMetricsIterator struct {
+	ResultList     FEPortMetricsResultList `json:"resultList"`
+	ID             string                  `json:"id"`
+	Count          int                     `json:"count"`
+	ExpirationTime int64                   `json:"expirationTime"`
+	MaxPageSize    int                     `json:"maxPageSize"`
+}
+
+// FEPortMetricsResultList contains the list of front-end port metrics
+type FEPortMetricsResultList struct {
+	Result []FEPortMetric `json:"result"`
+	From   int            `json:"from"`
+	To     int            `json:"to"`
+}
+
+// FEPortMetric is the struct of metric
+type FEPortMetric struct {
+	PercentBusy float64 `json:"PercentBusy"`
+	IOs         float64 `json:"IOs"`
+	MBs         float64 `json:"MBs"`
+	Timestamp   int64   `json:"timestamp"`
+}
+
+// PerformanceRegistrationParam is the parameter to register an array for performance metrics collection
+type PerformanceRegistrationParam struct {
+	SymmetrixID string `json:"symmetrixId"`
+	Diagnostic  bool   `json:"diagnostic"`
+	RealTime    bool   `json:"realtime"`
+}
+
+// PerformanceRegistrationDetails is the performance metrics collection registration of an array
+type PerformanceRegistrationDetails struct {
+	SymmetrixID            string `json:"symmetrixId"`
+	Diagnostic             bool   `json:"diagnostic"`
+	RealTime               bool   `json:"realtime"`
+	CollectionIntervalMins int    `json:"collectionintervalmins"`
+	Message                string `json:"message"`
+}
+
+// PerformanceRegistrationDetailsResult is the list of registrations returned by the registration details query
+type PerformanceRegistrationDetailsResult struct {
+	RegistrationDetails []PerformanceRegistrationDetails `json:"registrationDetailsInfo"`
+}
+
+// PerformanceRegistrationStatus is the registration of an array along with how far behind the
+// collected performance data is
+type PerformanceRegistrationStatus struct {
+	PerformanceRegistrationDetails
+	Registered         bool  `json:"registered"`
+	FirstAvailableDate int64 `json:"firstAvailableDate"`
+	LastAvailableDate  int64 `json:"lastAvailableDate"`
+	// BacklogMillis is the time elapsed since the last available performance data
+	BacklogMillis int64 `json:"backlogMillis"`
+}
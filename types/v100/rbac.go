@@ -0,0 +1,74 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v100
+
+// Unisphere roles, which are given to a user on an array, or on all the arrays if the symmetrix id is empty
+const (
+	RoleAdministrator      = "Administrator"
+	RoleStorageAdmin       = "StorageAdmin"
+	RoleSecurityAdmin      = "SecurityAdmin"
+	RoleAuditor            = "Auditor"
+	RoleMonitor            = "Monitor"
+	RolePerformanceMonitor = "PerformanceMonitor"
+	RoleDeviceManage       = "DeviceManage"
+	RoleLocalReplication   = "LocalReplication"
+	RoleRemoteReplication  = "RemoteReplication"
+)
+
+// Actions changing the roles of a user
+const (
+	UserActionAssignRole = "AssignRole"
+	UserActionRevokeRole = "RevokeRole"
+)
+
+// UserList : the ids of the Unisphere users, e.g. "ldap\\jdoe" or "admin"
+type UserList struct {
+	UserIDs []string `json:"user_id"`
+}
+
+// RoleAssignment : a role of a user, on an array, or on all the arrays if SymmetrixID is empty
+type RoleAssignment struct {
+	Role        string `json:"role"`
+	SymmetrixID string `json:"symmetrix_id,omitempty"`
+}
+
+// User : a Unisphere user and its roles
+type User struct {
+	UserID string           `json:"user_id"`
+	Roles  []RoleAssignment `json:"roles"`
+}
+
+// HasRole returns true if the user has the role on the array symID, or on all the arrays if symID is empty
+func (u *User) HasRole(symID, role string) bool {
+	for _, assignment := range u.Roles {
+		if assignment.Role == role && assignment.SymmetrixID == symID {
+			return true
+		}
+	}
+	return false
+}
+
+// ArrayUserList : the users having a role on an array, and their roles on it
+type ArrayUserList struct {
+	SymmetrixID string `json:"symmetrixId"`
+	Users       []User `json:"authorized_user"`
+}
+
+// ModifyUserParam : holds the parameters to assign a role to a user, or to revoke it
+type ModifyUserParam struct {
+	Action     string          `json:"action"`
+	AssignRole *RoleAssignment `json:"assignRoleParam,omitempty"`
+	RevokeRole *RoleAssignment `json:"revokeRoleParam,omitempty"`
+}
@@ -14,6 +14,11 @@
 
 package v100
 
+import (
+	"slices"
+	"strings"
+)
+
 // RDFGroup contains information about an RDF group
 type RDFGroup struct {
 	RdfgNumber               int      `json:"rdfgNumber"`
@@ -41,6 +46,10 @@ type RDFGroup struct {
 	RemoteOnlinePorts        []string `json:"remoteOnlinePorts"`
 	DevicePolarity           string   `json:"device_polarity"`
 	Offline                  bool     `json:"offline"`
+	// The SRDF/A session attributes, set for the groups in asynchronous mode, see SRDFAAttributes
+	MinimumCycleTime int  `json:"minimum_cycle_time"`
+	TransmitIdle     bool `json:"transmit_idle"`
+	DSEThreshold     int  `json:"dse_threshold"`
 }
 
 // RDFGroupIDL contains the RDF group when we list RDF groups
@@ -57,6 +66,16 @@ type RDFGroupList struct {
 	RDFGroupIDs   []RDFGroupIDL `json:"rdfGroupID"`
 }
 
+// RDFGroupFilter : the filters of an RDF group listing, zero fields do not filter
+type RDFGroupFilter struct {
+	RemoteSymmetrixID string `json:"remoteSymmetrixId,omitempty"`
+	// GroupType is the type of the groups, e.g. Dynamic
+	GroupType string `json:"groupType,omitempty"`
+	// MinVolumeCount and MaxVolumeCount bound the number of volumes of the groups
+	MinVolumeCount int `json:"minVolumeCount,omitempty"`
+	MaxVolumeCount int `json:"maxVolumeCount,omitempty"`
+}
+
 // RDFPortDetails has RDF ports details
 type RDFPortDetails struct {
 	SymmID     string `json:"symmetrixID"`
@@ -97,6 +116,80 @@ type RDFPortList struct {
 	RdfPorts []string `json:"portNumber"`
 }
 
+// RDFPort has the details of an RDF port, with the addresses of the SRDF over IP (GigE) ports
+type RDFPort struct {
+	SymmID      string `json:"symmetrixID"`
+	DirNum      int    `json:"directorNumber"`
+	DirID       string `json:"directorId"`
+	PortNum     int    `json:"portNumber"`
+	PortOnline  bool   `json:"online"`
+	PortWWN     string `json:"wwn,omitempty"`
+	IPv4Address string `json:"ipv4_address,omitempty"`
+	IPv6Address string `json:"ipv6_address,omitempty"`
+	PortSpeed   string `json:"port_speed,omitempty"`
+	// NegotiatedSpeed is the speed agreed with the fabric, which can be lower than PortSpeed
+	NegotiatedSpeed string `json:"negotiated_speed,omitempty"`
+	// Protocol is RDFProtocolFC or RDFProtocolGigE, according to the director of the port
+	Protocol string `json:"protocol,omitempty"`
+}
+
+// RDF port protocols
+const (
+	RDFProtocolFC   = "FC"
+	RDFProtocolGigE = "GigE"
+)
+
+// RDFDirector has the details of an RDF director and of all its ports
+type RDFDirector struct {
+	RDFDirDetails
+	Ports []RDFPort `json:"ports"`
+}
+
+// Protocol returns RDFProtocolGigE for the SRDF over IP directors, RDFProtocolFC otherwise
+func (d *RDFDirector) Protocol() string {
+	if d.DirProtocolGigE {
+		return RDFProtocolGigE
+	}
+	return RDFProtocolFC
+}
+
+// RDFPortInventory lists the RDF directors of an array with their ports, online or not
+type RDFPortInventory struct {
+	SymmetrixID string        `json:"symmetrixId"`
+	Directors   []RDFDirector `json:"directors"`
+}
+
+// RDFPortConnection : an online RDF port of an array with the remote RDF ports, and arrays, reachable through it
+type RDFPortConnection struct {
+	DirectorID      string `json:"directorId"`
+	PortNumber      int    `json:"portNumber"`
+	Protocol        string `json:"protocol"`
+	PortSpeed       string `json:"portSpeed,omitempty"`
+	NegotiatedSpeed string `json:"negotiatedSpeed,omitempty"`
+	// RemoteSymmetrixIDs are the arrays of the RemotePorts, sorted
+	RemoteSymmetrixIDs []string         `json:"remoteSymmetrixIds"`
+	RemotePorts        []RDFPortDetails `json:"remotePorts"`
+	// Error is set if the details or the remote ports of the port cannot be read
+	Error string `json:"error,omitempty"`
+}
+
+// RDFConnections lists the online RDF ports of an array with the remote arrays reachable through each of them
+type RDFConnections struct {
+	SymmetrixID string              `json:"symmetrixId"`
+	Ports       []RDFPortConnection `json:"ports"`
+}
+
+// PortsTo returns the ports through which the remote array is reachable
+func (c *RDFConnections) PortsTo(remoteSymID string) []RDFPortConnection {
+	var ports []RDFPortConnection
+	for _, port := range c.Ports {
+		if slices.Contains(port.RemoteSymmetrixIDs, remoteSymID) {
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
+
 // RemoteRDFPortDetails gets a list of Remote Directors:Port that are zoned to a given Local RDF Port.
 type RemoteRDFPortDetails struct {
 	RemotePorts []RDFPortDetails `json:"remotePort"`
@@ -156,6 +249,16 @@ type Swap struct {
 	RefreshR2 bool `json:"refreshR2"`
 }
 
+// Split action
+type Split struct {
+	Force     bool `json:"force"`
+	SymForce  bool `json:"symForce"`
+	Star      bool `json:"star"`
+	Hop2      bool `json:"hop2"`
+	Bypass    bool `json:"bypass"`
+	Immediate bool `json:"immediate"`
+}
+
 // Failback action
 type Failback struct {
 	Force        bool `json:"force"`
@@ -178,6 +281,71 @@ type Establish struct {
 	MetroBias bool `json:"metroBias"`
 }
 
+// RDFSide action, selecting the side of the pairs an action applies to
+type RDFSide struct {
+	// Side is RDFSideR1 or RDFSideR2
+	Side     string `json:"side"`
+	Force    bool   `json:"force"`
+	SymForce bool   `json:"symForce"`
+}
+
+// Sides of an RDF pair
+const (
+	RDFSideR1 = "R1"
+	RDFSideR2 = "R2"
+)
+
+// Actions changing the write state of the devices of RDF pairs
+const (
+	RDFActionWriteDisable = "WriteDisable"
+	RDFActionRWEnable     = "RWEnable"
+)
+
+// ModifyRDFDevicePair holds parameters for the updates of an RDF pair
+type ModifyRDFDevicePair struct {
+	Action          string   `json:"action"`
+	WriteDisable    *RDFSide `json:"writeDisable,omitempty"`
+	RWEnable        *RDFSide `json:"rwEnable,omitempty"`
+	ExecutionOption string   `json:"executionOption"`
+}
+
+// SRDFAAttributes : the SRDF/A session attributes of an RDF group
+type SRDFAAttributes struct {
+	RDFGroupNumber int `json:"rdfGroupNumber"`
+	// MinimumCycleTime is the minimum duration of an SRDF/A cycle, in seconds
+	MinimumCycleTime int `json:"minimumCycleTime"`
+	// TransmitIdle keeps the SRDF/A session active, buffering the writes, when all the links are lost
+	TransmitIdle bool `json:"transmitIdle"`
+	// DSEThreshold is the percentage of the system write pending limit at which delta set extension
+	// starts paging the SRDF/A cycles to the disks
+	DSEThreshold int `json:"dseThreshold"`
+}
+
+// Ranges of the SRDF/A session attributes
+const (
+	MinSRDFAMinimumCycleTime = 1
+	MaxSRDFAMinimumCycleTime = 59
+	MinSRDFADSEThreshold     = 20
+	MaxSRDFADSEThreshold     = 100
+)
+
+// SetSRDFAAttributesParam : the SRDF/A session attributes to change, nil attributes are left unchanged
+type SetSRDFAAttributesParam struct {
+	MinimumCycleTime *int  `json:"minimum_cycle_time,omitempty"`
+	TransmitIdle     *bool `json:"transmit_idle,omitempty"`
+	DSEThreshold     *int  `json:"dse_threshold,omitempty"`
+}
+
+// RDFGroupActionSetSRDFAAttributes is the action changing the SRDF/A session attributes of an RDF group
+const RDFGroupActionSetSRDFAAttributes = "SetSRDFAAttributes"
+
+// ModifyRDFGroup holds parameters for the updates of an RDF group
+type ModifyRDFGroup struct {
+	Action             string                   `json:"action"`
+	SetSRDFAAttributes *SetSRDFAAttributesParam `json:"setSRDFAAttributesParam,omitempty"`
+	ExecutionOption    string                   `json:"executionOption"`
+}
+
 // ModifySGRDFGroup holds parameters for rdf storage group updates
 type ModifySGRDFGroup struct {
 	Action          string     `json:"action"`
@@ -187,6 +355,7 @@ type ModifySGRDFGroup struct {
 	Failback        *Failback  `json:"failback,omitempty"`
 	Failover        *Failover  `json:"failover,omitempty"`
 	Swap            *Swap      `json:"swap,omitempty"`
+	Split           *Split     `json:"split,omitempty"`
 	ExecutionOption string     `json:"executionOption"`
 }
 
@@ -199,9 +368,9 @@ type CreateSGSRDF struct {
 	Establish              bool   `json:"establish"`
 	MetroBias              bool   `json:"metroBias"`
 	RemoteStorageGroupName string `json:"remoteStorageGroupName"`
-	ThinPool               string `json:"thinPool"`
-	FastPolicy             string `json:"fastPolicy"`
-	RemoteSLO              string `json:"remoteSLO"`
+	ThinPool               string `json:"thinPool,omitempty"`
+	FastPolicy             string `json:"fastPolicy,omitempty"`
+	RemoteSLO              string `json:"remoteSLO,omitempty"`
 	NoCompression          bool   `json:"noCompression"`
 	ExecutionOption        string `json:"executionOption"`
 }
@@ -277,9 +446,31 @@ type LocalDeviceAutoCriteria struct {
 // LocalDeviceListCriteria holds parameters for local device lis
 type LocalDeviceListCriteria struct {
 	LocalDeviceList    []string `json:"localDeviceList"`
+	RemoteDeviceList   []string `json:"remoteDeviceList,omitempty"`
 	RemoteThinPoolName string   `json:"remoteThinPoolName"`
 }
 
+// RDFPairSuggestion holds the remote devices proposed for a list of local devices.
+// Payload pairs the local devices with the matched remote devices, while AutoCreatePayload
+// contains the local devices with no matching remote device, for which the remote devices
+// are created by the array. Either payload is nil when there is nothing to submit.
+type RDFPairSuggestion struct {
+	LocalSymmetrixID  string             `json:"localSymmetrixId"`
+	RemoteSymmetrixID string             `json:"remoteSymmetrixId"`
+	Pairs             []SuggestedRDFPair `json:"pairs"`
+	Unmatched         []string           `json:"unmatched"`
+	Payload           *CreateRDFPair     `json:"payload,omitempty"`
+	AutoCreatePayload *CreateRDFPair     `json:"autoCreatePayload,omitempty"`
+}
+
+// SuggestedRDFPair holds a local device and the remote device it was matched with
+type SuggestedRDFPair struct {
+	LocalVolumeID  string `json:"localVolumeId"`
+	RemoteVolumeID string `json:"remoteVolumeId"`
+	Emulation      string `json:"emulation"`
+	CapacityCYL    int    `json:"capacityCYL"`
+}
+
 // CreateRDFPair holds SG create replica pair parameters
 type CreateRDFPair struct {
 	RdfMode                 string                   `json:"rdfMode"`
@@ -317,6 +508,35 @@ type RDFDevicePair struct {
 	RemoteWWNExternal    string `json:"remote_wwn_external"`
 }
 
+// RDFVolumeStateWriteDisabled is the state of a write disabled, i.e. read-only, device of an RDF pair
+const RDFVolumeStateWriteDisabled = "Write Disabled"
+
+// R2WriteDisabled returns true if the R2 device of the pair is write disabled, i.e. read-only to its hosts
+func (p *RDFDevicePair) R2WriteDisabled() bool {
+	if strings.HasPrefix(p.VolumeConfig, "RDF2") {
+		return p.LocalVolumeState == RDFVolumeStateWriteDisabled
+	}
+	return p.RemoteVolumeState == RDFVolumeStateWriteDisabled
+}
+
+// VolumeRDFInfo : the RDF pairs of a volume in all the RDF groups it is in, e.g. an SRDF/Metro and an SRDF/A group
+type VolumeRDFInfo struct {
+	SymmetrixID string `json:"symmetrixId"`
+	VolumeID    string `json:"volumeId"`
+	// Pairs are sorted by local RDF group number, and empty if the volume is not replicated
+	Pairs []RDFDevicePair `json:"pairs"`
+}
+
+// RDFDevicePairSides is an RDF pair seen from both arrays
+type RDFDevicePairSides struct {
+	Local *RDFDevicePair `json:"local"`
+	// Remote is the view of the remote array, nil if it cannot be reached through the remote_symmetrix endpoints
+	Remote          *RDFDevicePair `json:"remote,omitempty"`
+	RemoteReachable bool           `json:"remoteReachable"`
+	// RemoteError is the reason why the remote view is missing
+	RemoteError string `json:"remoteError,omitempty"`
+}
+
 // RDFDevicePairList holds list of newly created RDF volume pair information
 type RDFDevicePairList struct {
 	RDFDevicePair []RDFDevicePair `json:"devicePair"`
@@ -332,3 +552,87 @@ type StorageGroupRDFG struct {
 	Modes            []string `json:"modes"`
 	LargerRdfSides   []string `json:"largerRdfSides"`
 }
+
+// RDFLink is a local online RDF port zoned to an online RDF port of the remote array
+type RDFLink struct {
+	LocalDirectorID  string `json:"localDirectorId"`
+	LocalPort        int    `json:"localPortNumber"`
+	RemoteDirectorID string `json:"remoteDirectorId"`
+	RemotePort       int    `json:"remotePortNumber"`
+	RemotePortWWN    string `json:"remotePortWwn"`
+}
+
+// RDFGroupConnectivity is the state of an existing RDF group between the local and remote arrays.
+// The witness fields are those of the RDFGroup, set for the Metro groups protected by a witness.
+type RDFGroupConnectivity struct {
+	RdfgNumber        int      `json:"rdfgNumber"`
+	Label             string   `json:"label"`
+	Offline           bool     `json:"offline"`
+	LocalOnlinePorts  []string `json:"localOnlinePorts"`
+	RemoteOnlinePorts []string `json:"remoteOnlinePorts"`
+	WitnessConfigured bool     `json:"witnessConfigured"`
+	WitnessEffective  bool     `json:"witnessEffective"`
+}
+
+// RemoteArrayConnectivity reports whether the remote array can be reached over RDF from the local array.
+// Reasons explains why when Reachable is false, and lists the non-fatal findings otherwise.
+type RemoteArrayConnectivity struct {
+	LocalSymmetrixID  string                 `json:"localSymmetrixId"`
+	RemoteSymmetrixID string                 `json:"remoteSymmetrixId"`
+	Reachable         bool                   `json:"reachable"`
+	Links             []RDFLink              `json:"links"`
+	RDFGroups         []RDFGroupConnectivity `json:"rdfGroups"`
+	Reasons           []string               `json:"reasons"`
+}
+
+// RDFGroupSides is an RDF group seen from both arrays
+type RDFGroupSides struct {
+	Local *RDFGroup `json:"local"`
+	// Remote is the view of the remote array, nil if it cannot be reached through the remote_symmetrix endpoints
+	Remote          *RDFGroup `json:"remote,omitempty"`
+	RemoteReachable bool      `json:"remoteReachable"`
+	// RemoteError is the reason why the remote view is missing
+	RemoteError string `json:"remoteError,omitempty"`
+}
+
+// MetroVolumeReport : a volume added to an SRDF/Metro protected storage group, as seen on both arrays
+type MetroVolumeReport struct {
+	VolumeID       string `json:"volumeId"`
+	RemoteVolumeID string `json:"remoteVolumeId"`
+	PairState      string `json:"pairState"`
+	// InStorageGroup and InRemoteStorageGroup are set if the volume is in the storage group on its array
+	InStorageGroup       bool `json:"inStorageGroup"`
+	InRemoteStorageGroup bool `json:"inRemoteStorageGroup"`
+}
+
+// MetroStorageGroupReport : the outcome of the addition of volumes to an SRDF/Metro protected storage group
+type MetroStorageGroupReport struct {
+	SymmetrixID          string `json:"symmetrixId"`
+	StorageGroupID       string `json:"storageGroupId"`
+	RemoteSymmetrixID    string `json:"remoteSymmetrixId"`
+	RemoteStorageGroupID string `json:"remoteStorageGroupId"`
+	RDFGroupNumber       string `json:"rdfGroupNumber"`
+	// States are the pair states of the storage group
+	States  []string            `json:"states"`
+	Volumes []MetroVolumeReport `json:"volumes"`
+	// Consistent is set if all the pairs of the storage group are ActiveActive or ActiveBias and the
+	// volumes are in the storage groups of both arrays
+	Consistent bool `json:"consistent"`
+}
+
+// RemoteSnapshotCopyParam describes the copy of a storage group snapshot to a remote array made by CopySnapshotToRemoteArray
+type RemoteSnapshotCopyParam struct {
+	// StorageGroupID, SnapshotID and SnapID select the snapshot to copy
+	StorageGroupID string `json:"storageGroupId"`
+	SnapshotID     string `json:"snapshotId"`
+	SnapID         string `json:"snapId"`
+	// StagingStorageGroupID is the local storage group the snapshot is linked to, created if it does not exist
+	StagingStorageGroupID string `json:"stagingStorageGroupId"`
+	RemoteSymmetrixID     string `json:"remoteSymmetrixId"`
+	// RemoteStorageGroupID is the storage group created on the remote array to hold the copy
+	RemoteStorageGroupID string `json:"remoteStorageGroupId"`
+	RemoteServiceLevel   string `json:"remoteServiceLevel"`
+	RDFGroupNumber       string `json:"rdfGroupNumber"`
+	// RDFMode is the mode used to synchronize the copy, ASYNC or SYNC
+	RDFMode string `json:"rdfMode"`
+}
@@ -0,0 +1,50 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v100
+
+// Log bundle states
+const (
+	LogBundleStatusRunning   = "Running"
+	LogBundleStatusSucceeded = "Succeeded"
+	LogBundleStatusFailed    = "Failed"
+)
+
+// CreateLogBundleParam : parameters to collect a support log bundle of an array
+type CreateLogBundleParam struct {
+	Description string `json:"description,omitempty"`
+	// StartDate and EndDate, in milliseconds since the epoch, limit the logs collected to a time range
+	StartDate int64 `json:"start_date,omitempty"`
+	EndDate   int64 `json:"end_date,omitempty"`
+	// IncludePerformanceData adds the performance data of the time range to the bundle
+	IncludePerformanceData bool `json:"include_performance_data,omitempty"`
+}
+
+// LogBundle : a support log bundle collected on an array, downloadable once Succeeded
+type LogBundle struct {
+	LogBundleID string `json:"id"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	// Progress is the percentage of the collection done
+	Progress    int    `json:"progress"`
+	SizeBytes   int64  `json:"size_bytes"`
+	FileName    string `json:"file_name"`
+	CreatedDate int64  `json:"created_date"`
+	Message     string `json:"message"`
+}
+
+// LogBundleList : the ids of the log bundles of an array
+type LogBundleList struct {
+	LogBundleIDs []string `json:"id"`
+}
@@ -14,6 +14,8 @@
 
 package v100
 
+import "time"
+
 // QueryParams is a map of key value pairs that can be
 // appended to any url as query parameters.
 type QueryParams map[string]interface{}
@@ -53,6 +55,35 @@ type CreateVolumesSnapshot struct {
 	ExecutionOption  string       `json:"executionOption"`
 }
 
+// CreateSnapshotOptions are the optional settings of a volume snapshot at its creation
+type CreateSnapshotOptions struct {
+	// TimeInHours makes the time to live, and the secure time to live, a number of hours instead of days
+	TimeInHours bool
+	// SecureTTL secures the snapshot, which then cannot be terminated, for this number of days or hours
+	SecureTTL int64
+}
+
+// LinkSnapshotOptions are the optional settings of the Link and Relink actions on a volume snapshot
+type LinkSnapshotOptions struct {
+	// Exact pairs the source and target volumes in their ordinal positions instead of by best match
+	Exact bool
+	// FullCopy makes a Relink unlink the targets and link them again in copy mode, so that all their tracks are
+	// copied, instead of only the tracks changed since the previous link
+	FullCopy bool
+}
+
+// SnapshotDateLayout is the layout of the dates, e.g. the expiry dates, in the snapshot responses
+const SnapshotDateLayout = "Mon Jan 02 15:04:05 2006"
+
+// ParseSnapshotDate parses a date of a snapshot response, returning false for the dates not set, e.g. "N/A"
+func ParseSnapshotDate(date string) (time.Time, bool) {
+	t, err := time.Parse(SnapshotDateLayout, date)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
 // ModifyVolumeSnapshot contains input parameters to modify the snapshot
 type ModifyVolumeSnapshot struct {
 	VolumeNameListSource []VolumeList `json:"deviceNameListSource"`
@@ -96,10 +127,64 @@ type VolumeSnapshotSource struct {
 	Secured              bool            `json:"secured"`
 	IsRestored           bool            `json:"isRestored"`
 	TTL                  int64           `json:"ttl"`
+	TimeToLiveExpiryDate string          `json:"timeToLiveExpiryDate,omitempty"`
+	SecureExpiryDate     string          `json:"secureExpiryDate,omitempty"`
 	Expired              bool            `json:"expired"`
 	LinkedVolumes        []LinkedVolumes `json:"linkedDevices"`
 }
 
+// TimeToLiveExpiry returns when the snapshot is terminated automatically, false if it has no time to live
+func (s *VolumeSnapshotSource) TimeToLiveExpiry() (time.Time, bool) {
+	return ParseSnapshotDate(s.TimeToLiveExpiryDate)
+}
+
+// SecureExpiry returns when the snapshot stops being secured, false if it is not secure
+func (s *VolumeSnapshotSource) SecureExpiry() (time.Time, bool) {
+	return ParseSnapshotDate(s.SecureExpiryDate)
+}
+
+// Linked returns true if a target volume is linked to the snapshot generation
+func (s *VolumeSnapshotSource) Linked() bool {
+	for _, link := range s.LinkedVolumes {
+		if link.Linked {
+			return true
+		}
+	}
+	return false
+}
+
+// SnapshotGenerationFilter : filters and ordering of a snapshot generation listing
+type SnapshotGenerationFilter struct {
+	// Linked selects the generations with a linked target if true, or without if false
+	Linked *bool
+	// Expired selects the expired generations if true, or the unexpired ones if false
+	Expired *bool
+	// CreatedAfter and CreatedBefore select the generations taken in this time range. Zero bounds are
+	// ignored, and the generations without a valid timestamp are excluded when a bound is set.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// NewestFirst orders the generations from the newest, instead of from the oldest
+	NewestFirst bool
+}
+
+// Matches returns true if the snapshot generation is selected by the filter
+func (f *SnapshotGenerationFilter) Matches(s *VolumeSnapshotSource) bool {
+	if f.Linked != nil && s.Linked() != *f.Linked {
+		return false
+	}
+	if f.Expired != nil && s.Expired != *f.Expired {
+		return false
+	}
+	if f.CreatedAfter.IsZero() && f.CreatedBefore.IsZero() {
+		return true
+	}
+	created, ok := ParseSnapshotDate(s.TimeStamp)
+	if !ok {
+		return false
+	}
+	return (f.CreatedAfter.IsZero() || !created.Before(f.CreatedAfter)) && (f.CreatedBefore.IsZero() || created.Before(f.CreatedBefore))
+}
+
 // LinkedVolumes contains information about linked volumes of the snapshot
 type LinkedVolumes struct {
 	TargetDevice     string `json:"targetDevice"`
@@ -138,6 +223,61 @@ type VolumeSnapshotLink struct {
 	Generation       int64  `json:"generation"`
 }
 
+// RemainingTracks returns the number of tracks still to copy to the target, while the link is defining or copying
+func (l *VolumeSnapshotLink) RemainingTracks() int64 {
+	if (l.Defined && !l.Copy) || l.PercentageCopied >= 100 {
+		return 0
+	}
+	return l.Tracks * (100 - l.PercentageCopied) / 100
+}
+
+// Progress returns the progress of the copy to the target, as observed at the given time
+func (l *VolumeSnapshotLink) Progress(observedAt time.Time) SnapshotLinkProgress {
+	return SnapshotLinkProgress{
+		TargetDevice:     l.TargetDevice,
+		State:            l.State,
+		Defined:          l.Defined,
+		Copy:             l.Copy,
+		TrackSize:        l.TrackSize,
+		Tracks:           l.Tracks,
+		RemainingTracks:  l.RemainingTracks(),
+		PercentageCopied: l.PercentageCopied,
+		ObservedAt:       observedAt,
+	}
+}
+
+// SnapshotLinkProgress : the progress of the copy of a snapshot generation to a linked target
+type SnapshotLinkProgress struct {
+	TargetDevice     string    `json:"targetDevice"`
+	State            string    `json:"state"`
+	Defined          bool      `json:"defined"`
+	Copy             bool      `json:"copy"`
+	TrackSize        int64     `json:"trackSize"`
+	Tracks           int64     `json:"tracks"`
+	RemainingTracks  int64     `json:"remainingTracks"`
+	PercentageCopied int64     `json:"percentageCopied"`
+	ObservedAt       time.Time `json:"observedAt"`
+}
+
+// RemainingBytes returns the size of the tracks still to copy
+func (p *SnapshotLinkProgress) RemainingBytes() int64 {
+	return p.RemainingTracks * p.TrackSize
+}
+
+// ETA returns the time remaining until the copy completes, at the rate observed since a previous progress of the
+// same target. false is returned if the rate cannot be known, e.g. if no track was copied in between.
+func (p *SnapshotLinkProgress) ETA(previous SnapshotLinkProgress) (time.Duration, bool) {
+	if p.RemainingTracks == 0 {
+		return 0, true
+	}
+	copied := previous.RemainingTracks - p.RemainingTracks
+	elapsed := p.ObservedAt.Sub(previous.ObservedAt)
+	if previous.TargetDevice != p.TargetDevice || copied <= 0 || elapsed <= 0 {
+		return 0, false
+	}
+	return time.Duration(float64(elapsed) * float64(p.RemainingTracks) / float64(copied)), true
+}
+
 // VolumeSnapshot contains list of volume snapshots
 type VolumeSnapshot struct {
 	DeviceName           string                 `json:"deviceName"`
@@ -162,6 +302,27 @@ type VolumeSnapshotGeneration struct {
 	VolumeSnapshotLink   []VolumeSnapshotLink `json:"snapshotLnk,omitempty"`
 }
 
+// CompareWithSource is the CompareSnapID of a SnapshotDelta comparing a snapshot with its source volumes
+const CompareWithSource = -1
+
+// SnapshotDelta contains the number of tracks changed between a generation of a storage group snapshot
+// and its source volumes, or another generation of the snapshot
+type SnapshotDelta struct {
+	StorageGroupID string `json:"storageGroupId"`
+	SnapshotName   string `json:"snapshotName"`
+	SnapID         int64  `json:"snapid"`
+	// CompareSnapID is the snapid of the generation compared with, or CompareWithSource
+	CompareSnapID int64 `json:"compareSnapid"`
+	ChangedTracks int64 `json:"changedTracks"`
+	// TrackSize is the size of a track in bytes
+	TrackSize int64 `json:"trackSize"`
+}
+
+// ChangedBytes returns the size of the changed tracks
+func (d *SnapshotDelta) ChangedBytes() int64 {
+	return d.ChangedTracks * d.TrackSize
+}
+
 // VolumeSnapshotGenerations contains list of volume snapshot generations
 type VolumeSnapshotGenerations struct {
 	DeviceName           string                 `json:"deviceName"`
@@ -208,6 +369,73 @@ type StorageGroupSnap struct {
 	LinkedStorageGroups     []LinkedStorageGroup `json:"linked_storage_group"`
 }
 
+// TimeToLiveExpiry returns when the snapshot is terminated automatically, false if it has no time to live
+func (s *StorageGroupSnap) TimeToLiveExpiry() (time.Time, bool) {
+	return ParseSnapshotDate(s.TimeToLiveExpiryDate)
+}
+
+// SecureExpiry returns when the snapshot stops being secured, false if it is not secure
+func (s *StorageGroupSnap) SecureExpiry() (time.Time, bool) {
+	return ParseSnapshotDate(s.SecureExpiryDate)
+}
+
+// SnapshotGenerationUsage is the space accounting of one generation of a storage group snapshot.
+// ModifiedTracks are the source tracks changed since the snapshot was taken, NonSharedTracks are
+// the tracks held only by this generation, which are freed when it is terminated.
+type SnapshotGenerationUsage struct {
+	SnapID          int64  `json:"snapid"`
+	Generation      int64  `json:"generation"`
+	Timestamp       string `json:"timestamp"`
+	ModifiedTracks  int64  `json:"modified_tracks"`
+	NonSharedTracks int64  `json:"non_shared_tracks"`
+	Linked          bool   `json:"linked"`
+	Expired         bool   `json:"expired"`
+}
+
+// SnapshotNameUsage is the space accounting of all the generations of a storage group snapshot name
+type SnapshotNameUsage struct {
+	SnapshotName    string                    `json:"snapshot_name"`
+	SnapshotCount   int                       `json:"snapshot_count"`
+	ModifiedTracks  int64                     `json:"modified_tracks"`
+	NonSharedTracks int64                     `json:"non_shared_tracks"`
+	NonSharedGB     float64                   `json:"non_shared_gb"`
+	Generations     []SnapshotGenerationUsage `json:"generations"`
+}
+
+// SnapshotCapacityUsage is the space accounting of all the snapshots of a storage group
+type SnapshotCapacityUsage struct {
+	StorageGroupID  string              `json:"storage_group_id"`
+	SnapshotCount   int                 `json:"snapshot_count"`
+	ModifiedTracks  int64               `json:"modified_tracks"`
+	NonSharedTracks int64               `json:"non_shared_tracks"`
+	NonSharedGB     float64             `json:"non_shared_gb"`
+	Snapshots       []SnapshotNameUsage `json:"snapshots"`
+}
+
+// PolicySnapshot is a generation of a storage group snapshot created by a snapshot policy
+type PolicySnapshot struct {
+	SnapshotPolicyName   string `json:"snapshot_policy_name"`
+	SnapshotName         string `json:"snapshot_name"`
+	SnapID               int64  `json:"snapid"`
+	Generation           int64  `json:"generation"`
+	Timestamp            string `json:"timestamp"`
+	TimestampUtc         int64  `json:"timestamp_utc"`
+	TimeToLiveExpiryDate string `json:"time_to_live_expiry_date"`
+	SecureExpiryDate     string `json:"secure_expiry_date"`
+	Secure               bool   `json:"secure"`
+	Expired              bool   `json:"expired"`
+	Linked               bool   `json:"linked"`
+	Persistent           bool   `json:"persistent"`
+}
+
+// StorageGroupPolicySnapshots lists the snapshots of a storage group created by snapshot policies,
+// and the names of the other, manual, snapshots
+type StorageGroupPolicySnapshots struct {
+	StorageGroupID      string           `json:"storage_group_id"`
+	PolicySnapshots     []PolicySnapshot `json:"policy_snapshots"`
+	ManualSnapshotNames []string         `json:"manual_snapshot_names"`
+}
+
 // LinkedStorageGroup linked storage group
 type LinkedStorageGroup struct {
 	Name                       string `json:"name"`
@@ -233,7 +461,7 @@ type CreateStorageGroupSnapshot struct {
 	ExecutionOption string `json:"executionOption"`
 	TimeToLive      int32  `json:"timeToLive,omitempty"`
 	Secure          int32  `json:"secure,omitempty"`
-	TimeInHours     bool   `json:"force,omitempty"`
+	TimeInHours     bool   `json:"timeInHours,omitempty"`
 	Star            bool   `json:"start,omitempty"`
 	Bothsides       bool   `json:"bothsides,omitempty"`
 }
@@ -16,6 +16,8 @@ limitations under the License.
 
 package v100
 
+import "time"
+
 // SnapshotPolicy holds all the fields of a Snapshot Policy
 type SnapshotPolicy struct {
 	// The System where the snapshot policy is located
@@ -48,6 +50,32 @@ type SnapshotPolicy struct {
 	Type string `json:"type"`
 }
 
+// NextRunTimes returns the next count times, strictly after the given time, at which the policy will run.
+// The policy runs every IntervalMinutes starting OffsetMinutes after 00:00 on Monday, in the location of after.
+// No times are returned for a suspended policy or a policy without an interval.
+func (p *SnapshotPolicy) NextRunTimes(after time.Time, count int) []time.Time {
+	if p.Suspended || p.IntervalMinutes <= 0 || count <= 0 {
+		return nil
+	}
+	interval := time.Duration(p.IntervalMinutes) * time.Minute
+	year, month, day := after.Date()
+	midnight := time.Date(year, month, day, 0, 0, 0, 0, after.Location())
+	daysSinceMonday := (int(midnight.Weekday()) + 6) % 7
+	start := midnight.AddDate(0, 0, -daysSinceMonday).Add(time.Duration(p.OffsetMinutes) * time.Minute)
+
+	// first run strictly after the given time, moving back a week if the offset is later than it
+	for !start.Before(after) {
+		start = start.AddDate(0, 0, -7)
+	}
+	next := start.Add((after.Sub(start)/interval + 1) * interval)
+
+	runs := make([]time.Time, count)
+	for i := range runs {
+		runs[i] = next.Add(time.Duration(i) * interval)
+	}
+	return runs
+}
+
 // CreateSnapshotPolicyParam Parameters for creating a new snapshot policy
 type CreateSnapshotPolicyParam struct {
 	// The name of the new snapshot policy.
@@ -131,3 +159,40 @@ type UpdateSnapshotPolicyParam struct {
 type SnapshotPolicyList struct {
 	SnapshotPolicyIDs []string `json:"name"`
 }
+
+// SnapshotPolicyStorageGroupList contains the names of the storage groups associated with a snapshot policy
+type SnapshotPolicyStorageGroupList struct {
+	StorageGroupIDs []string `json:"name"`
+}
+
+// SnapshotPolicyModification : the outcome of the modification of one snapshot policy by a bulk modification
+type SnapshotPolicyModification struct {
+	SnapshotPolicyID string `json:"snapshotPolicyId"`
+	// SnapshotPolicy is the policy as modified, nil if the modification failed
+	SnapshotPolicy *SnapshotPolicy `json:"snapshotPolicy,omitempty"`
+	// StorageGroupIDs are the storage groups associated with the policy, which are affected by the modification
+	StorageGroupIDs []string `json:"storageGroupIds,omitempty"`
+	// Error is set if the policy, or its storage groups, could not be modified or read
+	Error string `json:"error,omitempty"`
+}
+
+// SnapshotPolicySummary : a snapshot policy with its schedule and the compliance of its storage groups, for reporting
+type SnapshotPolicySummary struct {
+	SnapshotPolicy SnapshotPolicy `json:"snapshotPolicy"`
+	// NextRun is the next time the policy runs, zero for a suspended policy
+	NextRun time.Time `json:"nextRun,omitempty"`
+	// StorageGroups are the compliance of the policy on each of its storage groups
+	StorageGroups []StorageGroupSnapshotPolicy `json:"storageGroups,omitempty"`
+	// Compliance is the number of storage groups per compliance of the policy, e.g. "GREEN"
+	Compliance map[string]int `json:"compliance,omitempty"`
+	// Error is set if part of the summary could not be read, e.g. because the user is not allowed to,
+	// the rest of the summary is still returned
+	Error string `json:"error,omitempty"`
+}
+
+// SnapshotPolicySummaryReport : the summary of all the snapshot policies of an array
+type SnapshotPolicySummaryReport struct {
+	SymmetrixID string                  `json:"symmetrixId"`
+	GeneratedAt time.Time               `json:"generatedAt"`
+	Policies    []SnapshotPolicySummary `json:"policies"`
+}
@@ -14,6 +14,8 @@
 
 package v100
 
+import "time"
+
 // StorageGroupIDList : list of sg's
 type StorageGroupIDList struct {
 	StorageGroupIDs []string `json:"storageGroupId"`
@@ -50,6 +52,16 @@ type StorageGroup struct {
 	Tags                  string                `json:"tags"`
 	UUID                  string                `json:"uuid"`
 	UnreducibleDataGB     float64               `json:"unreducible_data_gb"`
+	// Volumes are the details of the volumes of the storage group, only set by GetStorageGroup with
+	// GetStorageGroupOptions.IncludeVolumes
+	Volumes []VolumeDetail `json:"volumes,omitempty"`
+}
+
+// GetStorageGroupOptions : options of GetStorageGroup
+type GetStorageGroupOptions struct {
+	// IncludeVolumes returns the details of the volumes of the storage group, e.g. their capacities, with the
+	// storage group, with a single detailed listing where the array supports it or else in parallel
+	IncludeVolumes bool
 }
 
 // StorageGroupResult holds result of an operation
@@ -67,6 +79,15 @@ type CreateStorageGroupParam struct {
 	SRPID                     string                      `json:"srpId,omitempty"`
 	SLOBasedStorageGroupParam []SLOBasedStorageGroupParam `json:"sloBasedStorageGroupParam,omitempty"`
 	Emulation                 string                      `json:"emulation,omitempty"`
+	ThinPool                  string                      `json:"thinPool,omitempty"`
+	FastPolicy                string                      `json:"fastPolicy,omitempty"`
+}
+
+// ThinPoolSettings holds the thin pool and FAST policy to provision from on an array older than PowerMaxOS 5977,
+// which has no storage resource pool
+type ThinPoolSettings struct {
+	ThinPool   string `json:"thinPool"`
+	FastPolicy string `json:"fastPolicy,omitempty"`
 }
 
 // MergeStorageGroupParam : Payloads for updating Storage Group
@@ -251,6 +272,7 @@ type EditStorageGroupActionParam struct {
 	RemoveStorageGroupParam       *RemoveStorageGroupParam       `json:"removeStorageGroupParam,omitempty"`
 	RenameStorageGroupParam       *RenameStorageGroupParam       `json:"renameStorageGroupParam,omitempty"`
 	EditSnapshotPoliciesParam     *EditSnapshotPoliciesParam     `json:"edit_snapshot_policies_param,omitempty"`
+	TagManagementParam            *TagManagementParam            `json:"tagManagementParam,omitempty"`
 }
 
 // ExecutionOptionSynchronous : execute tasks synchronously
@@ -259,6 +281,40 @@ const ExecutionOptionSynchronous = "SYNCHRONOUS"
 // ExecutionOptionAsynchronous : execute tasks asynchronously
 const ExecutionOptionAsynchronous = "ASYNCHRONOUS"
 
+// DeleteStorageGroupCascadeOptions holds the cleanup steps to be run before a storage group is deleted
+type DeleteStorageGroupCascadeOptions struct {
+	// RemoveVolumes removes all volumes from the storage group
+	RemoveVolumes bool `json:"removeVolumes"`
+	// DeleteSnapshots deletes all snapshots of the storage group that are not linked
+	DeleteSnapshots bool `json:"deleteSnapshots"`
+	// RemoveFromParents removes the storage group from all of its parent storage groups
+	RemoveFromParents bool `json:"removeFromParents"`
+	// DryRun only reports what would be done, without modifying the array
+	DryRun bool `json:"dryRun"`
+	// MaxRetries is the number of times the final delete is retried when the array reports an error
+	MaxRetries int `json:"maxRetries"`
+	// RetryBackoff is the initial wait between retries, one second if not set; it doubles on every retry
+	RetryBackoff time.Duration `json:"retryBackoff"`
+}
+
+// StorageGroupSnapshotRef identifies a single snapshot generation of a storage group
+type StorageGroupSnapshotRef struct {
+	SnapshotName string `json:"snapshotName"`
+	SnapID       string `json:"snapId"`
+}
+
+// DeleteStorageGroupCascadeReport holds the actions taken (or, in dry-run mode, planned) by a cascading delete
+type DeleteStorageGroupCascadeReport struct {
+	StorageGroupID      string                    `json:"storageGroupId"`
+	DryRun              bool                      `json:"dryRun"`
+	RemovedVolumes      []string                  `json:"removedVolumes"`
+	DeletedSnapshots    []StorageGroupSnapshotRef `json:"deletedSnapshots"`
+	SkippedSnapshots    []StorageGroupSnapshotRef `json:"skippedSnapshots"`
+	RemovedFromParents  []string                  `json:"removedFromParents"`
+	StorageGroupDeleted bool                      `json:"storageGroupDeleted"`
+	DeleteAttempts      int                       `json:"deleteAttempts"`
+}
+
 // UpdateStorageGroupPayload : updates SG rest paylod
 type UpdateStorageGroupPayload struct {
 	EditStorageGroupActionParam EditStorageGroupActionParam `json:"editStorageGroupActionParam"`
@@ -0,0 +1,189 @@
+/*
+ Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v100
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// The large listings, e.g. the volumes of an array, are decoded by walking the JSON tokens and decoding
+// the elements of their result one at a time, so that the decoder only buffers one element instead of
+// the whole listing. The client uses the DecodeStream methods when decoding a response.
+
+// decodeObject walks the JSON object read by dec, calling field with the decoder positioned on the value of
+// every key. The values of the keys for which field returns false are skipped. A null object is ignored.
+func decodeObject(dec *json.Decoder, field func(key string) (bool, error)) error {
+	tok, err := dec.Token()
+	if err != nil || tok == nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected a JSON object, got %v", tok)
+	}
+	for dec.More() {
+		if tok, err = dec.Token(); err != nil {
+			return unexpectedEOF(err)
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("expected an object key, got %v", tok)
+		}
+		known, err := field(key)
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		if !known {
+			if err = skipValue(dec); err != nil {
+				return unexpectedEOF(err)
+			}
+		}
+	}
+	_, err = dec.Token()
+	return unexpectedEOF(err)
+}
+
+// decodeList appends the elements of the JSON array read by dec to list, decoding them one at a time.
+// A null array leaves list unchanged.
+func decodeList[T any](dec *json.Decoder, list []T) ([]T, error) {
+	tok, err := dec.Token()
+	if err != nil || tok == nil {
+		return list, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return list, fmt.Errorf("expected a JSON array, got %v", tok)
+	}
+	for dec.More() {
+		var elem T
+		if err = dec.Decode(&elem); err != nil {
+			return list, unexpectedEOF(err)
+		}
+		list = append(list, elem)
+	}
+	_, err = dec.Token()
+	return list, unexpectedEOF(err)
+}
+
+// skipValue reads the next JSON value from dec without decoding it
+func skipValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if delim, ok := tok.(json.Delim); ok {
+			if delim == '{' || delim == '[' {
+				depth++
+			} else {
+				depth--
+			}
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// unexpectedEOF reports the end of the stream inside a value as an unexpected one
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// DecodeStream decodes a VolumeResultList from dec, one volume at a time
+func (l *VolumeResultList) DecodeStream(dec *json.Decoder) error {
+	return decodeObject(dec, func(key string) (bool, error) {
+		var err error
+		switch key {
+		case "result":
+			l.VolumeList, err = decodeList(dec, l.VolumeList[:0])
+		case "from":
+			err = dec.Decode(&l.From)
+		case "to":
+			err = dec.Decode(&l.To)
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+}
+
+// DecodeStream decodes a VolumeIterator from dec, one volume at a time
+func (it *VolumeIterator) DecodeStream(dec *json.Decoder) error {
+	return decodeObject(dec, func(key string) (bool, error) {
+		var err error
+		switch key {
+		case "resultList":
+			err = it.ResultList.DecodeStream(dec)
+		case "id":
+			err = dec.Decode(&it.ID)
+		case "count":
+			err = dec.Decode(&it.Count)
+		case "expirationTime":
+			err = dec.Decode(&it.ExpirationTime)
+		case "maxPageSize":
+			err = dec.Decode(&it.MaxPageSize)
+		case "warningMessage":
+			err = dec.Decode(&it.WarningMessage)
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+}
+
+// DecodeStream decodes a VolumeDetailResultList from dec, one volume at a time
+func (l *VolumeDetailResultList) DecodeStream(dec *json.Decoder) error {
+	return decodeObject(dec, func(key string) (bool, error) {
+		var err error
+		switch key {
+		case "result":
+			l.VolumeList, err = decodeList(dec, l.VolumeList[:0])
+		case "from":
+			err = dec.Decode(&l.From)
+		case "to":
+			err = dec.Decode(&l.To)
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+}
+
+// DecodeStream decodes a VolumeDetailIterator from dec, one volume at a time
+func (it *VolumeDetailIterator) DecodeStream(dec *json.Decoder) error {
+	return decodeObject(dec, func(key string) (bool, error) {
+		var err error
+		switch key {
+		case "resultList":
+			err = it.ResultList.DecodeStream(dec)
+		case "id":
+			err = dec.Decode(&it.ID)
+		case "count":
+			err = dec.Decode(&it.Count)
+		case "expirationTime":
+			err = dec.Decode(&it.ExpirationTime)
+		case "maxPageSize":
+			err = dec.Decode(&it.MaxPageSize)
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+}
@@ -0,0 +1,123 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v100
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// largeVolumeListing returns a detailed listing of count volumes, as sent by Unisphere
+func largeVolumeListing(count int) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `{"id":"iterator-id","count":%d,"expirationTime":1700000000000,"maxPageSize":%d,"resultList":{"from":1,"to":%d,"result":[`, count, count, count)
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, `{"volumeId":"%05X","type":"TDEV","emulation":"FBA","cap_gb":"10.0","cap_mb":10240.0,"cap_cyl":5462,`+
+			`"volume_identifier":"vol_%d","wwn":"60000970000197900000533030%06X","status":"Ready","storageGroupId":["sg-%d"],"unknown":{"nested":[1,2]}}`,
+			i, i, i, i%100)
+	}
+	buf.WriteString(`]},"warningMessage":"none"}`)
+	return buf.Bytes()
+}
+
+func TestDecodeStream(t *testing.T) {
+	listing := largeVolumeListing(3)
+
+	expected := &VolumeDetailIterator{}
+	if err := json.Unmarshal(listing, expected); err != nil {
+		t.Fatal(err)
+	}
+	iter := &VolumeDetailIterator{}
+	if err := iter.DecodeStream(json.NewDecoder(bytes.NewReader(listing))); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expected, iter) {
+		t.Fatalf("expected %+v, got %+v", expected, iter)
+	}
+
+	expectedIDs := &VolumeIterator{}
+	if err := json.Unmarshal(listing, expectedIDs); err != nil {
+		t.Fatal(err)
+	}
+	ids := &VolumeIterator{}
+	if err := ids.DecodeStream(json.NewDecoder(bytes.NewReader(listing))); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expectedIDs, ids) || ids.WarningMessage != "none" {
+		t.Fatalf("expected %+v, got %+v", expectedIDs, ids)
+	}
+
+	page := &VolumeResultList{}
+	if err := page.DecodeStream(json.NewDecoder(strings.NewReader(`{"from":1,"to":2,"result":null}`))); err != nil {
+		t.Fatal(err)
+	}
+	if page.To != 2 || page.VolumeList != nil {
+		t.Fatalf("unexpected page %+v", page)
+	}
+
+	err := (&VolumeDetailIterator{}).DecodeStream(json.NewDecoder(bytes.NewReader(listing[:len(listing)/2])))
+	if err != io.ErrUnexpectedEOF {
+		t.Fatalf("expected an unexpected EOF for a truncated listing, got %v", err)
+	}
+	if err = (&VolumeDetailResultList{}).DecodeStream(json.NewDecoder(strings.NewReader(`[]`))); err == nil {
+		t.Fatal("expected an error for an array")
+	}
+}
+
+func BenchmarkVolumeListingUnmarshal(b *testing.B) {
+	listing := largeVolumeListing(50000)
+	b.SetBytes(int64(len(listing)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		iter := &VolumeDetailIterator{}
+		if err := json.NewDecoder(bytes.NewReader(listing)).Decode(iter); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVolumeListingDecodeStream(b *testing.B) {
+	listing := largeVolumeListing(50000)
+	b.SetBytes(int64(len(listing)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		iter := &VolumeDetailIterator{}
+		if err := iter.DecodeStream(json.NewDecoder(bytes.NewReader(listing))); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVolumeIDListingDecodeStream(b *testing.B) {
+	listing := largeVolumeListing(50000)
+	b.SetBytes(int64(len(listing)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		iter := &VolumeIterator{}
+		if err := iter.DecodeStream(json.NewDecoder(bytes.NewReader(listing))); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -15,7 +15,10 @@
 package v100
 
 import (
+	"fmt"
+	"slices"
 	"strings"
+	"time"
 )
 
 // Error : contains fields to report rest interface errors
@@ -23,12 +26,23 @@ type Error struct {
 	Message        string `json:"message"`
 	HTTPStatusCode int    `json:"httpStatusCode"`
 	ErrorCode      int    `json:"errorCode"`
+	// RequestID is the request or correlation ID returned in the response headers, if any
+	RequestID string `json:"-"`
+	// RawBody is the unparsed body of the error response
+	RawBody string `json:"-"`
 }
 
 func (e Error) Error() string {
 	return e.Message
 }
 
+// Detail returns the error message along with the status code, Unisphere error code,
+// request ID and raw response body, for use in logs and support tickets
+func (e Error) Detail() string {
+	return fmt.Sprintf("%s (httpStatusCode: %d, errorCode: %d, requestID: %s, body: %s)",
+		e.Message, e.HTTPStatusCode, e.ErrorCode, e.RequestID, e.RawBody)
+}
+
 // Version : /unixmax/restapi/system/version
 type Version struct {
 	Version string `json:"version"`
@@ -39,6 +53,27 @@ type SymmetrixIDList struct {
 	SymmetrixIDs []string `json:"symmetrixId"`
 }
 
+// DiscoveredArrays : the arrays managed by Unisphere, as found by DiscoverArrays
+type DiscoveredArrays struct {
+	// Local are the arrays attached to the Unisphere server
+	Local []string `json:"local"`
+	// Remote are the arrays reached through the SRDF links of the local arrays
+	Remote       []string  `json:"remote"`
+	DiscoveredAt time.Time `json:"discoveredAt"`
+}
+
+// Contains returns true if the array, local or remote, was discovered
+func (a *DiscoveredArrays) Contains(symID string) bool {
+	return slices.Contains(a.Local, symID) || slices.Contains(a.Remote, symID)
+}
+
+// SymmetrixIDs returns the IDs of the local and remote arrays, sorted
+func (a *DiscoveredArrays) SymmetrixIDs() []string {
+	ids := slices.Concat(a.Local, a.Remote)
+	slices.Sort(ids)
+	return ids
+}
+
 // Symmetrix : information about a Symmetrix system
 type Symmetrix struct {
 	SymmetrixID          string                `json:"symmetrixId"`
@@ -64,6 +99,30 @@ type Symmetrix struct {
 	SystemSizedProperty  []SystemSizedProperty `json:"system_sized_property"`
 }
 
+// ArraySummary : identity, registration and capacity of an array, as reported by inventory systems
+type ArraySummary struct {
+	SymmetrixID    string               `json:"symmetrixId"`
+	DisplayName    string               `json:"display_name,omitempty"`
+	DellServiceTag string               `json:"dell_service_tag,omitempty"`
+	Model          string               `json:"model"`
+	Ucode          string               `json:"ucode"`
+	UcodeDate      string               `json:"ucode_date,omitempty"`
+	AllFlash       bool                 `json:"all_flash"`
+	Local          bool                 `json:"local"`
+	DeviceCount    int                  `json:"device_count"`
+	StoragePools   []StoragePoolSummary `json:"storage_pools"`
+}
+
+// StoragePoolSummary : capacity of a storage pool in an ArraySummary
+type StoragePoolSummary struct {
+	StoragePoolID                string   `json:"srpId"`
+	UsableTotalTB                float64  `json:"usable_total_tb"`
+	UsableUsedTB                 float64  `json:"usable_used_tb"`
+	SubscribedTotalTB            float64  `json:"subscribed_total_tb"`
+	EffectiveUsedCapacityPercent int      `json:"effective_used_capacity_percent"`
+	ServiceLevels                []string `json:"service_levels,omitempty"`
+}
+
 // SystemSizedProperty contains information about size data
 type SystemSizedProperty struct {
 	SRPName                    string `json:"srp_name"`
@@ -101,6 +160,179 @@ type StoragePool struct {
 	ServiceLevels        []string       `json:"service_levels"`
 }
 
+// ServiceLevelList : list of the service levels of an array
+type ServiceLevelList struct {
+	ServiceLevelIDs []string `json:"sloId"`
+}
+
+// LatencyBand : the expected average response time of a service level, in [MinResponseTime, MaxResponseTime]
+type LatencyBand struct {
+	MinResponseTime time.Duration `json:"minResponseTime"`
+	MaxResponseTime time.Duration `json:"maxResponseTime"`
+}
+
+// ServiceLevel : information about a service level of an array
+type ServiceLevel struct {
+	ServiceLevelID string `json:"sloId"`
+	// BaseServiceLevelID is the service level it is derived from, e.g. Diamond for a renamed Diamond service level
+	BaseServiceLevelID string   `json:"sloBaseId"`
+	NumStorageGroups   int      `json:"num_of_storage_groups"`
+	StorageGroupIDs    []string `json:"storageGroupId"`
+	// LatencyBand is set by the client from the base service level, it is nil for Optimized
+	LatencyBand *LatencyBand `json:"latencyBand,omitempty"`
+}
+
+// ModifyStoragePoolParam : the storage pool properties to change, nil fields are left unchanged
+type ModifyStoragePoolParam struct {
+	ReservedCapPercent *int    `json:"reserved_cap_percent,omitempty"`
+	RdfaDse            *bool   `json:"rdfa_dse,omitempty"`
+	Description        *string `json:"description,omitempty"`
+}
+
+// EditStoragePoolActionParam : actions on a storage pool
+type EditStoragePoolActionParam struct {
+	ModifyStoragePoolParam *ModifyStoragePoolParam `json:"modifySrpParam,omitempty"`
+}
+
+// UpdateStoragePoolParam : payload to update a storage pool
+type UpdateStoragePoolParam struct {
+	EditStoragePoolActionParam EditStoragePoolActionParam `json:"editSrpActionParam"`
+	ExecutionOption            string                     `json:"executionOption"`
+}
+
+// CapacityThresholds : capacity alert thresholds, in percent of the usable capacity, of an array or of one of its storage pools
+type CapacityThresholds struct {
+	SymmetrixID     string `json:"symmetrixId,omitempty"`
+	StoragePoolID   string `json:"srpId,omitempty"`
+	Enabled         bool   `json:"enabled"`
+	WarningPercent  int    `json:"warning_threshold_percent"`
+	CriticalPercent int    `json:"critical_threshold_percent"`
+	FatalPercent    int    `json:"fatal_threshold_percent,omitempty"`
+}
+
+// AlertNotificationPolicyList : list of alert notification policies
+type AlertNotificationPolicyList struct {
+	AlertNotificationPolicies []AlertNotificationPolicy `json:"alert_policy"`
+}
+
+// AlertNotificationPolicy : how the alerts of a given type are notified
+type AlertNotificationPolicy struct {
+	PolicyName     string   `json:"alert_policy_name"`
+	Enabled        bool     `json:"enabled"`
+	Severities     []string `json:"severities,omitempty"`
+	EmailAddresses []string `json:"email_addresses,omitempty"`
+	SNMP           bool     `json:"snmp"`
+	Syslog         bool     `json:"syslog"`
+}
+
+// Data at rest encryption states and key managers
+const (
+	EncryptionEnabled     = "Enabled"
+	EncryptionDisabled    = "Disabled"
+	KeyManagerInternal    = "Internal"
+	KeyManagerExternal    = "External"
+	KeyServerConnected    = "Connected"
+	KeyServerDisconnected = "Disconnected"
+)
+
+// EncryptionStatus : data at rest encryption (D@RE) capability and status of an array
+type EncryptionStatus struct {
+	SymmetrixID        string `json:"symmetrixId"`
+	Capable            bool   `json:"dare_capable"`
+	DataEncryption     string `json:"data_encryption"`
+	KeyManager         string `json:"key_manager"`
+	EncryptedDrives    int    `json:"num_of_encrypted_drives"`
+	NonEncryptedDrives int    `json:"num_of_non_encrypted_drives"`
+	LastKeyRotation    string `json:"last_key_rotation,omitempty"`
+}
+
+// Active returns true if data at rest encryption is enabled and every drive is encrypted
+func (s *EncryptionStatus) Active() bool {
+	return s.DataEncryption == EncryptionEnabled && s.NonEncryptedDrives == 0
+}
+
+// KeyServer : an external key manager server, accessed with KMIP
+type KeyServer struct {
+	Name              string `json:"name"`
+	Hostname          string `json:"hostname"`
+	Port              int    `json:"port"`
+	Status            string `json:"status"`
+	CertificateExpiry string `json:"certificate_expiry_date,omitempty"`
+}
+
+// KeyManagerConfig : key manager configuration of an array, the servers are only set with an external key manager
+type KeyManagerConfig struct {
+	SymmetrixID string      `json:"symmetrixId"`
+	KeyManager  string      `json:"key_manager"`
+	KeyServers  []KeyServer `json:"key_servers"`
+}
+
+// HealthScoreMetric : a health score of an array, between 0 and 100
+type HealthScoreMetric struct {
+	Metric      string  `json:"metric"`
+	HealthScore float64 `json:"health_score"`
+	DataDate    int64   `json:"data_date"`
+	Expired     bool    `json:"expired"`
+}
+
+// SystemHealth : the health scores of an array
+type SystemHealth struct {
+	HealthScoreMetrics []HealthScoreMetric `json:"health_score_metric"`
+	NumFailedDisks     int                 `json:"num_failed_disks"`
+}
+
+// HealthCheckList : the ids of the health checks run on an array
+type HealthCheckList struct {
+	HealthCheckIDs []string `json:"health_check_id"`
+}
+
+// HealthCheckTest : the result of a test of a health check
+type HealthCheckTest struct {
+	ItemName string `json:"item_name"`
+	Result   bool   `json:"result"`
+}
+
+// HealthCheck : a health check run on an array
+type HealthCheck struct {
+	JobID      string            `json:"jobId"`
+	Date       int64             `json:"date"`
+	TestResult []HealthCheckTest `json:"testResult"`
+}
+
+// UpgradeCheck : a check done before a PowerMaxOS upgrade
+type UpgradeCheck struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// UpgradeReadiness : whether an array can be upgraded to a PowerMaxOS version
+type UpgradeReadiness struct {
+	SymmetrixID  string `json:"symmetrixId"`
+	CurrentUcode string `json:"currentUcode"`
+	TargetUcode  string `json:"targetUcode"`
+	// NewerVersion is set if the target version is more recent than the current one, whatever the health of the array
+	NewerVersion bool `json:"newerVersion"`
+	// Ready is set if the target version is more recent and all the checks passed
+	Ready  bool           `json:"ready"`
+	Checks []UpgradeCheck `json:"checks"`
+}
+
+// ServerLoad : the load of the Unisphere management server
+type ServerLoad struct {
+	CPUPercent     float64 `json:"cpu_utilization_percent"`
+	MemoryPercent  float64 `json:"memory_utilization_percent"`
+	ActiveRequests int     `json:"active_requests"`
+	QueuedRequests int     `json:"queued_requests"`
+	Status         string  `json:"status"`
+}
+
+// Utilization returns the CPU utilization, in percent. The memory utilization does not measure the request load,
+// and is left out.
+func (l *ServerLoad) Utilization() float64 {
+	return l.CPUPercent
+}
+
 // FbaCap FBA storage pool capacity
 type FbaCap struct {
 	Provisioned *Provisioned `json:"provisioned"`
@@ -197,6 +429,31 @@ type Job struct {
 	Links                        []Link `json:"links"`
 }
 
+// AlertIDList : list of alerts
+type AlertIDList struct {
+	AlertIDs []string `json:"alertId"`
+}
+
+// AuditLogRecord : an entry of the audit log of an array
+type AuditLogRecord struct {
+	RecordNumber          int64  `json:"record_number"`
+	EntryDate             string `json:"entry_date"`
+	EntryDateMilliseconds int64  `json:"entry_date_milliseconds"`
+	Username              string `json:"username"`
+	HostName              string `json:"host_name"`
+	ApplicationID         string `json:"application_id"`
+	ActivityID            string `json:"activity_id"`
+	Function              string `json:"function_class"`
+	Action                string `json:"action_code"`
+	Message               string `json:"message"`
+}
+
+// AuditLogRecordList : audit log records matching a query
+type AuditLogRecordList struct {
+	Count           int              `json:"count"`
+	AuditLogRecords []AuditLogRecord `json:"audit_log_record"`
+}
+
 // GetJobResource parses the Resource link and returns three things:
 // The 1) the symmetrixID, 2) the resource type (e.g.) volume, and 3) the resourceID
 // If the Resource Link cannot be parsed, empty strings are returned.
@@ -235,6 +492,39 @@ type PortGroup struct {
 	PortGroupProtocol  string    `json:"port_group_protocol"`
 }
 
+// DirectorPortMembership : port groups, and masking views using them, which contain a director port
+type DirectorPortMembership struct {
+	SymmetrixID  string   `json:"symmetrixId"`
+	DirectorID   string   `json:"directorId"`
+	PortID       string   `json:"portId"`
+	PortGroups   []string `json:"portGroupId"`
+	MaskingViews []string `json:"maskingview"`
+}
+
+// PortCandidate : a front-end director port ranked by a port group recommendation
+type PortCandidate struct {
+	DirectorID string `json:"directorId"`
+	PortID     string `json:"portId"`
+	Identifier string `json:"identifier,omitempty"`
+	// PercentBusy is the average utilization of the port over the last hour of performance data, -1 if not available
+	PercentBusy   float64 `json:"percentBusy"`
+	PortGroups    int64   `json:"numOfPortGroups"`
+	MaskingViews  int64   `json:"numOfMaskingViews"`
+	MappedVolumes int64   `json:"numOfMappedVolumes"`
+}
+
+// PortGroupRecommendation : the ports suggested for a new port group, and all the candidate ports from the least
+// to the most loaded
+type PortGroupRecommendation struct {
+	SymmetrixID string          `json:"symmetrixId"`
+	Transport   string          `json:"transport"`
+	Ports       []PortKey       `json:"symmetrixPortKey"`
+	Candidates  []PortCandidate `json:"candidates"`
+	// MetricsAvailable is false when the array is not registered for performance metrics, the ports are then
+	// only ranked on their port group, masking view and mapped volume counts
+	MetricsAvailable bool `json:"metricsAvailable"`
+}
+
 // CreatePortGroupParams - Input params for creating port groups
 type CreatePortGroupParams struct {
 	PortGroupID       string    `json:"portGroupId"`
@@ -276,11 +566,68 @@ type Initiator struct {
 	HostID               string    `json:"host_id"`
 }
 
+// EditInitiatorParams holds the initiator settings to modify
+type EditInitiatorParams struct {
+	SetHostFlags *SetHostFlags `json:"setHostFlagsParam,omitempty"`
+}
+
+// UpdateInitiatorParam contains the action and option to modify an initiator
+type UpdateInitiatorParam struct {
+	EditInitiatorAction *EditInitiatorParams `json:"editInitiatorActionParam"`
+	ExecutionOption     string               `json:"executionOption"`
+}
+
+// InitiatorFlags : the port flags set on an initiator, overriding the flags of its host on the port of the
+// initiator when PortFlagsOverride is set, and the flags in effect for the initiator
+type InitiatorFlags struct {
+	InitiatorID       string   `json:"initiatorId"`
+	HostID            string   `json:"hostId,omitempty"`
+	PortFlagsOverride bool     `json:"portFlagsOverride"`
+	EnabledFlags      []string `json:"enabledFlags,omitempty"`
+	DisabledFlags     []string `json:"disabledFlags,omitempty"`
+	// EffectiveFlags are the flags enabled for the initiator, as reported by the array or, on the arrays
+	// not reporting them, the flags of its host overridden by the flags of the initiator
+	EffectiveFlags []string `json:"effectiveFlags,omitempty"`
+}
+
+// InitiatorHosts : the hosts and masking views an initiator WWN, IQN or NQN belongs to
+type InitiatorHosts struct {
+	Initiator string `json:"initiator"`
+	// InitiatorIDs are the director:port:initiator ids of the initiator, one per port it logged in to
+	InitiatorIDs   []string `json:"initiatorIds"`
+	HostIDs        []string `json:"hostIds"`
+	MaskingViewIDs []string `json:"maskingViewIds"`
+}
+
 // HostList : list of hosts
 type HostList struct {
 	HostIDs []string `json:"hostId"`
 }
 
+// Actions on the initiators of a host reported in a HostInitiatorResult
+const (
+	HostInitiatorActionAdd    = "add"
+	HostInitiatorActionRemove = "remove"
+)
+
+// HostInitiatorResult : the outcome of the addition or the removal of an initiator of a host
+type HostInitiatorResult struct {
+	InitiatorID string `json:"initiatorId"`
+	Action      string `json:"action"`
+	// Error is set if the initiator could not be added or removed
+	Error string `json:"error,omitempty"`
+}
+
+// HostInitiatorsUpdate : the outcome of the update of the initiators of a host by chunks
+type HostInitiatorsUpdate struct {
+	HostID string `json:"hostId"`
+	// Host is the host once updated
+	Host *Host `json:"host"`
+	// Results are the initiators added or removed, the initiators left unchanged are not listed
+	Results []HostInitiatorResult `json:"results"`
+	Failed  int                   `json:"failed"`
+}
+
 // Host : Information about a host
 type Host struct {
 	HostID             string   `json:"hostId"`
@@ -299,6 +646,49 @@ type Host struct {
 	BWLimit            int      `json:"bw_limit"`
 }
 
+// DeleteHostOptions : checks made by DeleteHost before deleting a host
+type DeleteHostOptions struct {
+	// CheckMaskingViews fails the deletion with a HostInUseError if masking views reference the host
+	CheckMaskingViews bool
+}
+
+// HostFlagPolicy : the host flags a host has to comply with, named as in the enabled_flags of a host,
+// e.g. SPC2_Protocol_Version
+type HostFlagPolicy struct {
+	// Name identifies the policy in the drift report, e.g. the operating system it applies to
+	Name     string   `json:"name"`
+	Enabled  []string `json:"enabled"`
+	Disabled []string `json:"disabled"`
+}
+
+// HostFlagDrift : the difference between the flags of a host and its policy
+type HostFlagDrift struct {
+	HostID string `json:"hostId"`
+	Policy string `json:"policy,omitempty"`
+	// MissingFlags are required enabled and not enabled on the host
+	MissingFlags []string `json:"missingFlags,omitempty"`
+	// UnexpectedFlags are required disabled and enabled on the host
+	UnexpectedFlags []string `json:"unexpectedFlags,omitempty"`
+	// Error is set if the host cannot be read
+	Error string `json:"error,omitempty"`
+}
+
+// HostFlagDriftReport : the result of a host flag audit of an array
+type HostFlagDriftReport struct {
+	SymmetrixID  string `json:"symmetrixId"`
+	HostsAudited int    `json:"hostsAudited"`
+	// HostsSkipped have no policy
+	HostsSkipped int             `json:"hostsSkipped"`
+	Drifts       []HostFlagDrift `json:"drifts"`
+}
+
+// Transport types of hosts and initiators
+const (
+	HostTypeFibre   = "Fibre"
+	HostTypeISCSI   = "iSCSI"
+	HostTypeNVMeTCP = "NVMETCP"
+)
+
 // DirectorIDList : list of directors
 type DirectorIDList struct {
 	DirectorIDs []string `json:"directorId"`
@@ -378,3 +768,33 @@ type Port struct {
 	ExecutionOption string            `json:"executionOption,omitempty"`
 	SymmetrixPort   SymmetrixPortType `json:"symmetrixPort"`
 }
+
+// PortAttributes : attributes of a port to be set
+type PortAttributes struct {
+	Aclx *bool `json:"aclx,omitempty"`
+}
+
+// SetPortAttributesActionParam : sets the attributes of a port
+type SetPortAttributesActionParam struct {
+	PortAttributes PortAttributes `json:"port_attributes"`
+}
+
+// EditPortActionParam : action information to edit a port
+type EditPortActionParam struct {
+	SetPortAttributesActionParam *SetPortAttributesActionParam `json:"setPortAttributesActionParam,omitempty"`
+}
+
+// EditPortParam : payload to edit a port
+type EditPortParam struct {
+	EditPortActionParam EditPortActionParam `json:"editPortActionParam"`
+	ExecutionOption     string              `json:"executionOption"`
+}
+
+// ModifiedIDList lists the IDs of the objects of an array modified since a given time
+type ModifiedIDList struct {
+	IDs []string
+	// Full is true when the array cannot filter on the modification time, IDs then lists all the objects
+	Full bool
+	// ListedAt is the time of the listing, to pass as the since time of the next listing
+	ListedAt time.Time
+}
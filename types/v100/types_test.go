@@ -16,7 +16,10 @@ limitations under the License.
 
 package v100
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestGetJobResource(t *testing.T) {
 	tests := []struct {
@@ -101,3 +104,121 @@ func TestError(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorDetail(t *testing.T) {
+	err := &Error{
+		Message:        "not found",
+		HTTPStatusCode: 404,
+		ErrorCode:      1234,
+		RequestID:      "abc-123",
+		RawBody:        `{"message":"not found"}`,
+	}
+	expected := `not found (httpStatusCode: 404, errorCode: 1234, requestID: abc-123, body: {"message":"not found"})`
+	if err.Detail() != expected {
+		t.Errorf("expected %s, got %s", expected, err.Detail())
+	}
+}
+
+func TestSnapshotPolicyNextRunTimes(t *testing.T) {
+	// Wednesday
+	after := time.Date(2024, time.January, 3, 10, 5, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		policy   SnapshotPolicy
+		count    int
+		expected []time.Time
+	}{
+		{
+			name:   "hourly with offset",
+			policy: SnapshotPolicy{IntervalMinutes: 60, OffsetMinutes: 15},
+			count:  2,
+			expected: []time.Time{
+				time.Date(2024, time.January, 3, 10, 15, 0, 0, time.UTC),
+				time.Date(2024, time.January, 3, 11, 15, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "weekly offset later in the week",
+			policy:   SnapshotPolicy{IntervalMinutes: 7 * 24 * 60, OffsetMinutes: 4*24*60 + 60},
+			count:    1,
+			expected: []time.Time{time.Date(2024, time.January, 5, 1, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "weekly offset earlier in the week",
+			policy:   SnapshotPolicy{IntervalMinutes: 7 * 24 * 60},
+			count:    1,
+			expected: []time.Time{time.Date(2024, time.January, 8, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "run at the given time is excluded",
+			policy:   SnapshotPolicy{IntervalMinutes: 5, OffsetMinutes: 0},
+			count:    1,
+			expected: []time.Time{time.Date(2024, time.January, 3, 10, 10, 0, 0, time.UTC)},
+		},
+		{
+			name:   "suspended",
+			policy: SnapshotPolicy{IntervalMinutes: 60, Suspended: true},
+			count:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs := tt.policy.NextRunTimes(after, tt.count)
+			if len(runs) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, runs)
+			}
+			for i := range runs {
+				if !runs[i].Equal(tt.expected[i]) {
+					t.Errorf("expected %v, got %v", tt.expected, runs)
+				}
+			}
+		})
+	}
+}
+
+func TestParseSnapshotDate(t *testing.T) {
+	expiry, ok := ParseSnapshotDate("Mon Jan 01 10:00:00 2024")
+	if !ok || !expiry.Equal(time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected date %v", expiry)
+	}
+	snap := StorageGroupSnap{TimeToLiveExpiryDate: "N/A", SecureExpiryDate: "Tue Jan 02 10:00:00 2024"}
+	if _, ok = snap.TimeToLiveExpiry(); ok {
+		t.Error("expected no time to live expiry")
+	}
+	if expiry, ok = snap.SecureExpiry(); !ok || expiry.Day() != 2 {
+		t.Errorf("unexpected secure expiry %v", expiry)
+	}
+}
+
+func TestSnapshotLinkProgress(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC)
+	link := VolumeSnapshotLink{TargetDevice: "00002", Tracks: 1000, PercentageCopied: 20, Copy: true, Defined: true}
+	previous := link.Progress(start)
+	link.PercentageCopied = 60
+	current := link.Progress(start.Add(time.Minute))
+	if previous.RemainingTracks != 800 || current.RemainingTracks != 400 {
+		t.Fatalf("unexpected remaining tracks %d, %d", previous.RemainingTracks, current.RemainingTracks)
+	}
+	if eta, ok := current.ETA(previous); !ok || eta != time.Minute {
+		t.Errorf("expected one minute remaining, got %s, %v", eta, ok)
+	}
+	if _, ok := current.ETA(current); ok {
+		t.Error("expected no ETA without progress")
+	}
+
+	// a link without copy is done once defined, but not while defining
+	link = VolumeSnapshotLink{TargetDevice: "00003", Tracks: 1000, Defined: true}
+	if link.RemainingTracks() != 0 {
+		t.Errorf("expected no track to copy, got %d", link.RemainingTracks())
+	}
+	link.Defined = false
+	if link.RemainingTracks() != 1000 {
+		t.Errorf("expected all the tracks to define, got %d", link.RemainingTracks())
+	}
+	done := link.Progress(start)
+	done.RemainingTracks = 0
+	if eta, ok := done.ETA(previous); !ok || eta != 0 {
+		t.Errorf("expected a completed copy, got %s, %v", eta, ok)
+	}
+}
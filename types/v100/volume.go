@@ -73,6 +73,133 @@ type Volume struct {
 	NGUID                 string                 `json:"nguid"`
 }
 
+// Kinds of VolumeBlocker
+const (
+	VolumeBlockerSnapshot     = "SnapVXSession"
+	VolumeBlockerLinkedTarget = "SnapVXLinkedTarget"
+	VolumeBlockerRDFPair      = "RDFPair"
+	VolumeBlockerMaskingView  = "MaskingView"
+	VolumeBlockerStorageGroup = "StorageGroup"
+	VolumeBlockerGatekeeper   = "Gatekeeper"
+)
+
+// VolumeBlocker : something preventing a volume from being deleted. Removable blockers,
+// snapshots without linked targets and storage groups not in a masking view, can be
+// cleaned up by DeleteVolume when DeleteVolumeOptions.RemoveBlockers is set.
+type VolumeBlocker struct {
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Generation int64  `json:"generation,omitempty"`
+	Removable  bool   `json:"removable"`
+}
+
+// DeleteVolumeOptions : checks made by DeleteVolume before deleting a volume
+type DeleteVolumeOptions struct {
+	// CheckBlockers looks for snapshots, RDF pairs, masking views and storage groups using the volume
+	CheckBlockers bool
+	// RemoveBlockers terminates the snapshots and removes the volume from the storage groups that can be
+	// cleaned up, instead of failing. It implies CheckBlockers.
+	RemoveBlockers bool
+	// AllowGatekeeper allows volumes which are sized as gatekeepers to be deleted
+	AllowGatekeeper bool
+}
+
+// VolumePath : a masking view exposing a volume to a host, through a storage group holding the volume
+type VolumePath struct {
+	StorageGroupID string `json:"storageGroupId"`
+	// ParentStorageGroupID is the storage group of the masking view when it is a parent of StorageGroupID
+	ParentStorageGroupID string `json:"parentStorageGroupId,omitempty"`
+	MaskingViewID        string `json:"maskingViewId"`
+	HostID               string `json:"hostId,omitempty"`
+	HostGroupID          string `json:"hostGroupId,omitempty"`
+	PortGroupID          string `json:"portGroupId"`
+}
+
+// UnmapVolumeOptions : the options of UnmapVolume
+type UnmapVolumeOptions struct {
+	// DryRun lists the storage groups the volume would be removed from, without removing it
+	DryRun bool
+}
+
+// VolumeUnmap : the removal of a volume from a storage group exposing it, with the paths removed with it
+type VolumeUnmap struct {
+	StorageGroupID string       `json:"storageGroupId"`
+	Paths          []VolumePath `json:"paths"`
+	// Removed is set once the volume is removed from the storage group, it is never set in a dry run
+	Removed bool `json:"removed"`
+	// Blocked is why the volume cannot be removed, e.g. as it is the last volume of a storage group in a masking view
+	Blocked string `json:"blocked,omitempty"`
+	// Error is set if the removal failed
+	Error string `json:"error,omitempty"`
+}
+
+// VolumeDetail : details of a volume returned by a bulk volume listing
+type VolumeDetail struct {
+	Volume
+	// Error is set when the details of this volume could not be fetched
+	Error string `json:"error,omitempty"`
+}
+
+// VolumeQueryParam : payload of a bulk lookup of volumes by ID
+type VolumeQueryParam struct {
+	VolumeIDs []string `json:"volumeIds"`
+}
+
+// DeallocationProgress : progress of the deallocation of the tracks of a volume before its deletion
+type DeallocationProgress struct {
+	VolumeID         string `json:"volumeId"`
+	JobID            string `json:"jobId"`
+	JobStatus        string `json:"jobStatus"`
+	AllocatedPercent int    `json:"allocated_percent"`
+}
+
+// VolumeListOptions : filters and ordering of a volume listing
+type VolumeListOptions struct {
+	// ExcludeGatekeepers excludes the gatekeeper devices, of at most 10 cylinders
+	ExcludeGatekeepers bool
+	// Emulation selects the volumes with this emulation, e.g. FBA
+	Emulation string
+	// SortByCapacity orders the volumes by capacity, smallest first unless Descending is set,
+	// and by volume id otherwise
+	SortByCapacity bool
+	Descending     bool
+}
+
+// NumberedVolume : a volume whose identifier is a prefix followed by a number, as named by the
+// identifier_name_plus_append_number option
+type NumberedVolume struct {
+	Number           int    `json:"number"`
+	VolumeID         string `json:"volumeId"`
+	VolumeIdentifier string `json:"volume_identifier"`
+}
+
+// VolumeRelabel : the new identifier of a volume relabeled by RelabelStorageGroupVolumes
+type VolumeRelabel struct {
+	VolumeID         string `json:"volumeId"`
+	OldIdentifier    string `json:"oldIdentifier"`
+	VolumeIdentifier string `json:"volume_identifier"`
+	// AlreadyIdentified is set if the volume already had the identifier and was not renamed
+	AlreadyIdentified bool `json:"alreadyIdentified"`
+	// Error is set if the volume could not be renamed
+	Error string `json:"error,omitempty"`
+}
+
+// VolumeDetailResultList : page of a detailed volume listing
+type VolumeDetailResultList struct {
+	VolumeList []VolumeDetail `json:"result"`
+	From       int            `json:"from"`
+	To         int            `json:"to"`
+}
+
+// VolumeDetailIterator : holds the iterator of a detailed volume listing
+type VolumeDetailIterator struct {
+	ResultList     VolumeDetailResultList `json:"resultList"`
+	ID             string                 `json:"id"`
+	Count          int                    `json:"count"`
+	ExpirationTime int64                  `json:"expirationTime"`
+	MaxPageSize    int                    `json:"maxPageSize"`
+}
+
 // StorageGroupName holds group name in which volume exists
 type StorageGroupName struct {
 	StorageGroupName       string `json:"storage_group_name"`
@@ -106,12 +233,58 @@ type EnableMobilityIDParam struct {
 	EnableMobilityID bool `json:"enable_mobility_id"`
 }
 
+// Device ready states that can be set on a volume
+const (
+	VolumeReady        = "Ready"
+	VolumeNotReady     = "Not_Ready"
+	VolumeUserNotReady = "User_Not_Ready"
+)
+
+// SetVolumeReadyStateParam : ready state to be set on a volume
+type SetVolumeReadyStateParam struct {
+	ReadyState string `json:"ready_state"`
+	SymForce   bool   `json:"_symforce,omitempty"`
+}
+
+// VolumeActionOutcome : outcome of an action on one of the volumes it is applied to
+type VolumeActionOutcome struct {
+	VolumeID string `json:"volumeId"`
+	// Error is set if the action failed on this volume
+	Error string `json:"error,omitempty"`
+}
+
 // EditVolumeActionParam : action information to edit volume
 type EditVolumeActionParam struct {
 	EnableMobilityIDParam       *EnableMobilityIDParam       `json:"enable_mobility_id_param"`
 	FreeVolumeParam             *FreeVolumeParam             `json:"freeVolumeParam,omitempty"`
 	ExpandVolumeParam           *ExpandVolumeParam           `json:"expandVolumeParam,omitempty"`
 	ModifyVolumeIdentifierParam *ModifyVolumeIdentifierParam `json:"modifyVolumeIdentifierParam,omitempty"`
+	SetVolumeReadyStateParam    *SetVolumeReadyStateParam    `json:"setVolumeReadyStateParam,omitempty"`
+	MigrateVolumeParam          *MigrateVolumeParam          `json:"migrateVolumeParam,omitempty"`
+	FormatVolumeParam           *FormatVolumeParam           `json:"formatVolumeParam,omitempty"`
+}
+
+// FormatVolumeParam : formats a volume, erasing its data
+type FormatVolumeParam struct {
+	Format   bool `json:"format"`
+	SymForce bool `json:"_symforce,omitempty"`
+}
+
+// MigrateVolumeParam : moves the data of a volume to another storage resource pool
+type MigrateVolumeParam struct {
+	SRPID string `json:"srpId"`
+}
+
+// SRPMigrationProgress : progress of the migration of volumes between storage resource pools
+type SRPMigrationProgress struct {
+	TargetSRP       string            `json:"targetSrp"`
+	Jobs            map[string]string `json:"jobs"`
+	Succeeded       int               `json:"succeeded"`
+	Failed          int               `json:"failed"`
+	Running         int               `json:"running"`
+	PercentComplete int               `json:"percentComplete"`
+	Done            bool              `json:"done"`
+	Errors          []string          `json:"errors,omitempty"`
 }
 
 // EditVolumeParam : parameters required to edit volume information
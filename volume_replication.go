@@ -17,11 +17,17 @@ package pmax
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/dell/gopowermax/v2/api"
+	"github.com/dell/gopowermax/v2/payload"
 	types "github.com/dell/gopowermax/v2/types/v100"
 	log "github.com/sirupsen/logrus"
 )
@@ -32,14 +38,17 @@ const (
 	XFREERDFG      = "/rdf_group_numbers_free"
 	XRDFDIR        = "/rdf_director/"
 	XRDFONLINEDIR  = "/rdf_director?online=true"
+	XRDFDIRS       = "/rdf_director"
 	XRDFPORT       = "/port/"
 	XRDFPORTONLINE = "/port?online=true"
+	XRDFPORTS      = "/port"
 	XREMOTEPORT    = "/remote_port"
 	ASYNC          = "ASYNC"
 	METRO          = "METRO"
 	SYNC           = "SYNC"
 	XMigration     = "migration/"
 	XRemoteSymID   = "?remote_symmetrix_id="
+	XRemoteSymm    = "/remote_symmetrix/"
 )
 
 // GetFreeLocalAndRemoteRDFg  gets the next free RDFg available
@@ -205,6 +214,252 @@ func (c *Client) GetLocalRDFPortDetails(ctx context.Context, localSymID string,
 	return LocalRDFPortDetails, nil
 }
 
+// GetRDFDirList gets all the RDF directors of the array, online or not
+func (c *Client) GetRDFDirList(ctx context.Context, symID string) (*types.RDFDirList, error) {
+	defer c.TimeSpent("GetRDFDirList", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	URL := c.urlPrefix() + ReplicationX + SymmetrixX + symID + XRDFDIRS
+	rdfDirList := new(types.RDFDirList)
+	if err := c.getWithTimeout(ctx, URL, rdfDirList); err != nil {
+		log.Error("GetRDFDirList failed: " + err.Error())
+		return nil, err
+	}
+	return rdfDirList, nil
+}
+
+// GetRDFDirDetails gets the details of an RDF director, including whether it is an FC or a GigE (SRDF over IP) director
+func (c *Client) GetRDFDirDetails(ctx context.Context, symID, rdfDir string) (*types.RDFDirDetails, error) {
+	defer c.TimeSpent("GetRDFDirDetails", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	URL := c.urlPrefix() + ReplicationX + SymmetrixX + symID + XRDFDIR + rdfDir
+	rdfDirDetails := new(types.RDFDirDetails)
+	if err := c.getWithTimeout(ctx, URL, rdfDirDetails); err != nil {
+		log.Error("GetRDFDirDetails failed: " + err.Error())
+		return nil, err
+	}
+	return rdfDirDetails, nil
+}
+
+// GetRDFPortList gets all the ports of an RDF director, online or not
+func (c *Client) GetRDFPortList(ctx context.Context, symID, rdfDir string) (*types.RDFPortList, error) {
+	defer c.TimeSpent("GetRDFPortList", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	URL := c.urlPrefix() + ReplicationX + SymmetrixX + symID + XRDFDIR + rdfDir + XRDFPORTS
+	rdfPortList := new(types.RDFPortList)
+	if err := c.getWithTimeout(ctx, URL, rdfPortList); err != nil {
+		log.Error("GetRDFPortList failed: " + err.Error())
+		return nil, err
+	}
+	return rdfPortList, nil
+}
+
+// GetRDFPort gets the details of an RDF port, including the IP addresses and the speed of the GigE ports
+func (c *Client) GetRDFPort(ctx context.Context, symID, rdfDir string, rdfPort int) (*types.RDFPort, error) {
+	defer c.TimeSpent("GetRDFPort", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	URL := c.urlPrefix() + ReplicationX + SymmetrixX + symID + XRDFDIR + rdfDir + XRDFPORT + strconv.Itoa(rdfPort)
+	port := new(types.RDFPort)
+	if err := c.getWithTimeout(ctx, URL, port); err != nil {
+		log.Error("GetRDFPort failed: " + err.Error())
+		return nil, err
+	}
+	return port, nil
+}
+
+// GetRDFPortInventory lists all the RDF directors of the array and all their ports, online or not, with
+// the protocol of each port (FC or GigE) and the IP addresses of the SRDF over IP ports. The online ports
+// it returns can be used as the local or remote ports of CreateRDFGroup.
+func (c *Client) GetRDFPortInventory(ctx context.Context, symID string) (*types.RDFPortInventory, error) {
+	defer c.TimeSpent("GetRDFPortInventory", time.Now())
+	rdfDirs, err := c.GetRDFDirList(ctx, symID)
+	if err != nil {
+		return nil, err
+	}
+	inventory := &types.RDFPortInventory{SymmetrixID: symID}
+	for _, rdfDir := range rdfDirs.RdfDirs {
+		dirDetails, err := c.GetRDFDirDetails(ctx, symID, rdfDir)
+		if err != nil {
+			return nil, err
+		}
+		director := types.RDFDirector{RDFDirDetails: *dirDetails}
+		rdfPorts, err := c.GetRDFPortList(ctx, symID, rdfDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, rdfPort := range rdfPorts.RdfPorts {
+			portNum, err := strconv.Atoi(rdfPort)
+			if err != nil {
+				return nil, fmt.Errorf("invalid port %s of RDF director %s: %s", rdfPort, rdfDir, err.Error())
+			}
+			port, err := c.GetRDFPort(ctx, symID, rdfDir, portNum)
+			if err != nil {
+				return nil, err
+			}
+			port.Protocol = director.Protocol()
+			director.Ports = append(director.Ports, *port)
+		}
+		inventory.Directors = append(inventory.Directors, director)
+	}
+	log.Info(fmt.Sprintf("Found %d RDF directors on array %s", len(inventory.Directors), symID))
+	return inventory, nil
+}
+
+// GetTargetRDFPorts returns the online RDF ports of the array with their negotiated speed and protocol, and the
+// remote ports and arrays reachable through each of them, to validate the SRDF fabric before creating RDF groups.
+// The ports whose details or remote ports cannot be read are reported with their error.
+func (c *Client) GetTargetRDFPorts(ctx context.Context, symID string) (*types.RDFConnections, error) {
+	defer c.TimeSpent("GetTargetRDFPorts", time.Now())
+	rdfDirs, err := c.GetLocalOnlineRDFDirs(ctx, symID)
+	if err != nil {
+		return nil, err
+	}
+	connections := &types.RDFConnections{SymmetrixID: symID}
+	for _, rdfDir := range rdfDirs.RdfDirs {
+		dirDetails, err := c.GetRDFDirDetails(ctx, symID, rdfDir)
+		if err != nil {
+			return nil, err
+		}
+		director := types.RDFDirector{RDFDirDetails: *dirDetails}
+		rdfPorts, err := c.GetLocalOnlineRDFPorts(ctx, rdfDir, symID)
+		if err != nil {
+			return nil, err
+		}
+		for _, rdfPort := range rdfPorts.RdfPorts {
+			portNum, err := strconv.Atoi(rdfPort)
+			if err != nil {
+				return nil, fmt.Errorf("invalid port %s of RDF director %s: %s", rdfPort, rdfDir, err.Error())
+			}
+			connections.Ports = append(connections.Ports, c.getRDFPortConnection(ctx, symID, rdfDir, portNum, director.Protocol()))
+		}
+	}
+	log.Info(fmt.Sprintf("Found %d online RDF ports on array %s", len(connections.Ports), symID))
+	return connections, nil
+}
+
+// getRDFPortConnection returns an RDF port with the remote ports reachable through it, or the error reading them
+func (c *Client) getRDFPortConnection(ctx context.Context, symID, rdfDir string, portNum int, protocol string) types.RDFPortConnection {
+	connection := types.RDFPortConnection{DirectorID: rdfDir, PortNumber: portNum, Protocol: protocol}
+	port, err := c.GetRDFPort(ctx, symID, rdfDir, portNum)
+	if err != nil {
+		connection.Error = err.Error()
+		return connection
+	}
+	connection.PortSpeed, connection.NegotiatedSpeed = port.PortSpeed, port.NegotiatedSpeed
+	remotePorts, err := c.GetRemoteRDFPortOnSAN(ctx, symID, rdfDir, strconv.Itoa(portNum))
+	if err != nil {
+		connection.Error = err.Error()
+		return connection
+	}
+	connection.RemotePorts = remotePorts.RemotePorts
+	for _, remotePort := range remotePorts.RemotePorts {
+		if !slices.Contains(connection.RemoteSymmetrixIDs, remotePort.SymmID) {
+			connection.RemoteSymmetrixIDs = append(connection.RemoteSymmetrixIDs, remotePort.SymmID)
+		}
+	}
+	slices.Sort(connection.RemoteSymmetrixIDs)
+	return connection
+}
+
+// VerifyRemoteArrayConnectivity checks that the online RDF ports of the local array are zoned to online
+// RDF ports of the remote array, and reports the state of the existing RDF groups between the two arrays,
+// so that replication setup can fail early with a clear reason
+func (c *Client) VerifyRemoteArrayConnectivity(ctx context.Context, localSymID, remoteSymID string) (*types.RemoteArrayConnectivity, error) {
+	defer c.TimeSpent("VerifyRemoteArrayConnectivity", time.Now())
+	if _, err := c.IsAllowedArray(localSymID); err != nil {
+		return nil, err
+	}
+	report := &types.RemoteArrayConnectivity{
+		LocalSymmetrixID:  localSymID,
+		RemoteSymmetrixID: remoteSymID,
+	}
+
+	rdfDirs, err := c.GetLocalOnlineRDFDirs(ctx, localSymID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rdfDirs.RdfDirs) == 0 {
+		report.Reasons = append(report.Reasons, fmt.Sprintf("no online RDF directors on array %s", localSymID))
+	}
+	onlinePorts := 0
+	for _, rdfDir := range rdfDirs.RdfDirs {
+		rdfPorts, err := c.GetLocalOnlineRDFPorts(ctx, rdfDir, localSymID)
+		if err != nil {
+			return nil, err
+		}
+		for _, rdfPort := range rdfPorts.RdfPorts {
+			onlinePorts++
+			remotePorts, err := c.GetRemoteRDFPortOnSAN(ctx, localSymID, rdfDir, rdfPort)
+			if err != nil {
+				return nil, err
+			}
+			localPort, _ := strconv.Atoi(rdfPort)
+			for _, remotePort := range remotePorts.RemotePorts {
+				if remotePort.SymmID != remoteSymID || !remotePort.PortOnline {
+					continue
+				}
+				report.Links = append(report.Links, types.RDFLink{
+					LocalDirectorID:  rdfDir,
+					LocalPort:        localPort,
+					RemoteDirectorID: remotePort.DirID,
+					RemotePort:       remotePort.PortNum,
+					RemotePortWWN:    remotePort.PortWWN,
+				})
+			}
+		}
+	}
+	if len(rdfDirs.RdfDirs) > 0 && onlinePorts == 0 {
+		report.Reasons = append(report.Reasons, fmt.Sprintf("no online RDF ports on array %s", localSymID))
+	}
+	if onlinePorts > 0 && len(report.Links) == 0 {
+		report.Reasons = append(report.Reasons, fmt.Sprintf("no online RDF ports of array %s are zoned to array %s", remoteSymID, localSymID))
+	}
+
+	rdfGroups, err := c.GetRDFGroupList(ctx, localSymID, types.QueryParams{"remote_symmetrix_id": remoteSymID})
+	if err != nil {
+		return nil, err
+	}
+	for _, rdfGroupID := range rdfGroups.RDFGroupIDs {
+		rdfGroup, err := c.GetRDFGroupByID(ctx, localSymID, strconv.Itoa(rdfGroupID.RDFGNumber))
+		if err != nil {
+			return nil, err
+		}
+		if rdfGroup.RemoteSymmetrix != remoteSymID {
+			continue
+		}
+		group := types.RDFGroupConnectivity{
+			RdfgNumber:        rdfGroup.RdfgNumber,
+			Label:             rdfGroup.Label,
+			Offline:           rdfGroup.Offline,
+			LocalOnlinePorts:  rdfGroup.LocalOnlinePorts,
+			RemoteOnlinePorts: rdfGroup.RemoteOnlinePorts,
+			WitnessConfigured: rdfGroup.Witness || rdfGroup.WitnessConfigured,
+			WitnessEffective:  rdfGroup.WitnessEffective,
+		}
+		switch {
+		case rdfGroup.Offline:
+			report.Reasons = append(report.Reasons, fmt.Sprintf("RDF group %d (%s) is offline", rdfGroup.RdfgNumber, rdfGroup.Label))
+		case len(rdfGroup.LocalOnlinePorts) == 0 || len(rdfGroup.RemoteOnlinePorts) == 0:
+			report.Reasons = append(report.Reasons, fmt.Sprintf("RDF group %d (%s) has no online ports on one of the arrays", rdfGroup.RdfgNumber, rdfGroup.Label))
+		case group.WitnessConfigured && !group.WitnessEffective:
+			report.Reasons = append(report.Reasons, fmt.Sprintf("the witness of RDF group %d (%s) is not effective", rdfGroup.RdfgNumber, rdfGroup.Label))
+		}
+		report.RDFGroups = append(report.RDFGroups, group)
+	}
+
+	report.Reachable = len(report.Links) > 0
+	log.Info(fmt.Sprintf("Array %s reachable from %s: %t, %d RDF links, %d RDF groups",
+		remoteSymID, localSymID, report.Reachable, len(report.Links), len(report.RDFGroups)))
+	return report, nil
+}
+
 // GetRDFGroupByID returns RDF group information given the RDF group number
 func (c *Client) GetRDFGroupByID(ctx context.Context, symID, rdfGroupNo string) (*types.RDFGroup, error) {
 	defer c.TimeSpent("GetRdfGroup", time.Now())
@@ -235,6 +490,64 @@ func (c *Client) GetRDFGroupByID(ctx context.Context, symID, rdfGroupNo string)
 	return rdfGrpInfo, nil
 }
 
+// GetSRDFAAttributes returns the SRDF/A session attributes of an RDF group, e.g. its minimum cycle time
+func (c *Client) GetSRDFAAttributes(ctx context.Context, symID, rdfGroupNo string) (*types.SRDFAAttributes, error) {
+	defer c.TimeSpent("GetSRDFAAttributes", time.Now())
+	rdfGroup, err := c.GetRDFGroupByID(ctx, symID, rdfGroupNo)
+	if err != nil {
+		return nil, err
+	}
+	if !rdfGroup.Async {
+		return nil, fmt.Errorf("RDF group %s of array %s is not in asynchronous mode", rdfGroupNo, symID)
+	}
+	return &types.SRDFAAttributes{
+		RDFGroupNumber:   rdfGroup.RdfgNumber,
+		MinimumCycleTime: rdfGroup.MinimumCycleTime,
+		TransmitIdle:     rdfGroup.TransmitIdle,
+		DSEThreshold:     rdfGroup.DSEThreshold,
+	}, nil
+}
+
+// SetSRDFAAttributes changes the SRDF/A session attributes of an RDF group set in param, and returns the attributes
+// as changed. The minimum cycle time and the DSE threshold are checked against their ranges before the group is changed.
+func (c *Client) SetSRDFAAttributes(ctx context.Context, symID, rdfGroupNo string, param types.SetSRDFAAttributesParam) (*types.SRDFAAttributes, error) {
+	defer c.TimeSpent("SetSRDFAAttributes", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	if param.MinimumCycleTime == nil && param.TransmitIdle == nil && param.DSEThreshold == nil {
+		return nil, fmt.Errorf("no SRDF/A attribute given for RDF group %s", rdfGroupNo)
+	}
+	if cycleTime := param.MinimumCycleTime; cycleTime != nil && (*cycleTime < types.MinSRDFAMinimumCycleTime || *cycleTime > types.MaxSRDFAMinimumCycleTime) {
+		return nil, fmt.Errorf("the SRDF/A minimum cycle time must be between %d and %d seconds, got %d",
+			types.MinSRDFAMinimumCycleTime, types.MaxSRDFAMinimumCycleTime, *cycleTime)
+	}
+	if threshold := param.DSEThreshold; threshold != nil && (*threshold < types.MinSRDFADSEThreshold || *threshold > types.MaxSRDFADSEThreshold) {
+		return nil, fmt.Errorf("the SRDF/A DSE threshold must be between %d and %d percent, got %d",
+			types.MinSRDFADSEThreshold, types.MaxSRDFADSEThreshold, *threshold)
+	}
+	payload := &types.ModifyRDFGroup{
+		Action:             types.RDFGroupActionSetSRDFAAttributes,
+		SetSRDFAAttributes: &param,
+		ExecutionOption:    types.ExecutionOptionSynchronous,
+	}
+	ifDebugLogPayload(payload)
+	URL := c.urlPrefix() + ReplicationX + SymmetrixX + symID + XRDFGroup + "/" + rdfGroupNo
+	fields := map[string]interface{}{
+		http.MethodPut: URL,
+		"RDFGroupNo":   rdfGroupNo,
+		"Action":       payload.Action,
+	}
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	if err := c.api.Put(ctx, URL, c.getDefaultHeaders(), payload, nil); err != nil {
+		log.WithFields(fields).Error("Error in SetSRDFAAttributes: " + err.Error())
+		return nil, err
+	}
+	log.Info(fmt.Sprintf("Successfully set the SRDF/A attributes of RDF group %s", rdfGroupNo))
+	return c.GetSRDFAAttributes(ctx, symID, rdfGroupNo)
+}
+
 // GetRDFGroupList fetches all RDF group
 func (c *Client) GetRDFGroupList(ctx context.Context, symID string, queryParams types.QueryParams) (*types.RDFGroupList, error) {
 	defer c.TimeSpent("GetRdfGroupList", time.Now())
@@ -278,6 +591,58 @@ func (c *Client) GetRDFGroupList(ctx context.Context, symID string, queryParams
 	return rdfGrpList, nil
 }
 
+// GetFilteredRDFGroupList returns the RDF groups of the array selected by the filter. The filters are sent to the array,
+// using the remote_symmetrix_id, group_type and volume_count query parameters, and the remote array and group type are
+// checked again on the returned groups. As the array takes a single volume_count condition, a volume count range sends
+// the minimum and checks the maximum on the details of the returned groups.
+func (c *Client) GetFilteredRDFGroupList(ctx context.Context, symID string, filter types.RDFGroupFilter) (*types.RDFGroupList, error) {
+	defer c.TimeSpent("GetFilteredRDFGroupList", time.Now())
+	if filter.MaxVolumeCount > 0 && filter.MaxVolumeCount < filter.MinVolumeCount {
+		return nil, fmt.Errorf("the maximum volume count %d is lower than the minimum %d", filter.MaxVolumeCount, filter.MinVolumeCount)
+	}
+	queryParams := types.QueryParams{}
+	if filter.RemoteSymmetrixID != "" {
+		queryParams["remote_symmetrix_id"] = url.QueryEscape(filter.RemoteSymmetrixID)
+	}
+	if filter.GroupType != "" {
+		queryParams["group_type"] = url.QueryEscape(filter.GroupType)
+	}
+	checkMax := false
+	switch {
+	case filter.MinVolumeCount > 0:
+		queryParams["volume_count"] = url.QueryEscape(fmt.Sprintf(">%d", filter.MinVolumeCount-1))
+		checkMax = filter.MaxVolumeCount > 0
+	case filter.MaxVolumeCount > 0:
+		queryParams["volume_count"] = url.QueryEscape(fmt.Sprintf("<%d", filter.MaxVolumeCount+1))
+	}
+	rdfGroups, err := c.GetRDFGroupList(ctx, symID, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := &types.RDFGroupList{RDFGroupIDs: []types.RDFGroupIDL{}}
+	for _, rdfGroup := range rdfGroups.RDFGroupIDs {
+		if filter.RemoteSymmetrixID != "" && rdfGroup.RemoteSymID != "" && rdfGroup.RemoteSymID != filter.RemoteSymmetrixID {
+			continue
+		}
+		if filter.GroupType != "" && rdfGroup.GroupType != "" && !strings.EqualFold(rdfGroup.GroupType, filter.GroupType) {
+			continue
+		}
+		if checkMax {
+			details, err := c.GetRDFGroupByID(ctx, symID, strconv.Itoa(rdfGroup.RDFGNumber))
+			if err != nil {
+				return nil, err
+			}
+			if details.NumDevices < filter.MinVolumeCount || details.NumDevices > filter.MaxVolumeCount {
+				continue
+			}
+		}
+		filtered.RDFGroupIDs = append(filtered.RDFGroupIDs, rdfGroup)
+	}
+	filtered.RDFGroupCount = len(filtered.RDFGroupIDs)
+	return filtered, nil
+}
+
 // GetProtectedStorageGroup returns protected storage group given the storage group ID
 func (c *Client) GetProtectedStorageGroup(ctx context.Context, symID, storageGroup string) (*types.RDFStorageGroup, error) {
 	defer c.TimeSpent("GetProtectedStorageGroup", time.Now())
@@ -426,13 +791,35 @@ func (c *Client) ExecuteReplicationActionOnSG(ctx context.Context, symID, action
 			Action:          action,
 			ExecutionOption: types.ExecutionOptionSynchronous,
 		}
+	case "Split":
+		actionParam := &types.Split{
+			Force:    force,
+			SymForce: false,
+			Star:     false,
+			Hop2:     false,
+			Bypass:   false,
+		}
+		modifyParam = &types.ModifySGRDFGroup{
+			Split:           actionParam,
+			Action:          action,
+			ExecutionOption: types.ExecutionOptionSynchronous,
+		}
 	default:
 		return fmt.Errorf("not a supported action on a protected storage group")
 	}
+	if c.opts.validateRDFActions {
+		if err := c.ValidateReplicationActionOnSG(ctx, symID, action, storageGroup, rdfGroup); err != nil {
+			log.Error("ExecuteReplicationActionOnSG failed: " + err.Error())
+			return err
+		}
+	}
 	URL := c.urlPrefix() + ReplicationX + SymmetrixX + symID + XStorageGroup + "/" + storageGroup + XRDFGroup + "/" + rdfGroup
 	fields := map[string]interface{}{
 		http.MethodPut: URL,
 	}
+	if action == "Failover" || action == "Swap" {
+		ctx = api.WithDestructiveOperation(ctx, action)
+	}
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
 	err := c.api.Put(
@@ -447,41 +834,7 @@ func (c *Client) ExecuteReplicationActionOnSG(ctx context.Context, symID, action
 
 // GetCreateSGReplicaPayload returns a payload to create a storage group on remote array from local array and protect it with rdfgNo
 func (c *Client) GetCreateSGReplicaPayload(remoteSymID string, rdfMode string, rdfgNo int, remoteSGName string, remoteServiceLevel string, establish, bias bool) *types.CreateSGSRDF {
-	var payload *types.CreateSGSRDF
-	switch rdfMode {
-	case ASYNC:
-		payload = &types.CreateSGSRDF{
-			ReplicationMode:        "Asynchronous",
-			RemoteSLO:              remoteServiceLevel,
-			RemoteSymmID:           remoteSymID,
-			RdfgNumber:             rdfgNo,
-			RemoteStorageGroupName: remoteSGName,
-			Establish:              establish,
-			ExecutionOption:        types.ExecutionOptionSynchronous,
-		}
-	case SYNC:
-		payload = &types.CreateSGSRDF{
-			ReplicationMode:        "Synchronous",
-			RemoteSLO:              remoteServiceLevel,
-			RemoteSymmID:           remoteSymID,
-			RdfgNumber:             rdfgNo,
-			RemoteStorageGroupName: remoteSGName,
-			Establish:              establish,
-			ExecutionOption:        types.ExecutionOptionSynchronous,
-		}
-	case METRO:
-		payload = &types.CreateSGSRDF{
-			ReplicationMode:        "Active",
-			RemoteSLO:              remoteServiceLevel,
-			RemoteSymmID:           remoteSymID,
-			RdfgNumber:             rdfgNo,
-			RemoteStorageGroupName: remoteSGName,
-			Establish:              establish,
-			MetroBias:              bias,
-			ExecutionOption:        types.ExecutionOptionSynchronous,
-		}
-	}
-	return payload
+	return payload.CreateStorageGroupReplica(remoteSymID, rdfMode, rdfgNo, remoteSGName, remoteServiceLevel, establish, bias)
 }
 
 // CreateSGReplica creates a storage group on remote array and protect them with given RDF Mode and a given source storage group
@@ -492,6 +845,10 @@ func (c *Client) CreateSGReplica(ctx context.Context, symID, remoteSymID, rdfMod
 	}
 	rdfgNo, _ := strconv.Atoi(rdfGroupNo)
 	createSGReplicaPayload := c.GetCreateSGReplicaPayload(remoteSymID, rdfMode, rdfgNo, remoteSGName, remoteServiceLevel, true, bias)
+	if err := c.setReplicaThinPool(ctx, remoteSymID, createSGReplicaPayload); err != nil {
+		log.Error("CreateSGReplica failed: " + err.Error())
+		return nil, err
+	}
 	Debug = true
 	ifDebugLogPayload(createSGReplicaPayload)
 	URL := c.urlPrefix() + ReplicationX + SymmetrixX + symID + XStorageGroup + "/" + sourceSG + XRDFGroup
@@ -519,38 +876,82 @@ func (c *Client) CreateSGReplica(ctx context.Context, symID, remoteSymID, rdfMod
 
 // GetCreateRDFPairPayload returns payload for adding a replication pair based on replication mode
 func (c *Client) GetCreateRDFPairPayload(devList types.LocalDeviceListCriteria, rdfMode, rdfType string, establish, exemptConsistency bool) *types.CreateRDFPair {
-	var payload *types.CreateRDFPair
-	switch rdfMode {
-	case ASYNC:
-		payload = &types.CreateRDFPair{
-			RdfMode:                 "Asynchronous",
-			RdfType:                 rdfType,
-			Establish:               establish,
-			Exempt:                  exemptConsistency,
-			LocalDeviceListCriteria: &devList,
-			ExecutionOption:         types.ExecutionOptionSynchronous,
+	return payload.CreateRDFPair(devList, rdfMode, rdfType, establish, exemptConsistency)
+}
+
+// SuggestRDFPairs proposes a remote device on remoteSymID for each of the local devices, matching on emulation and
+// exact size in cylinders. The remote candidates are taken from remoteCandidateIDs or, if empty, from the unmapped
+// volumes of the remote array; candidates which are already RDF protected are never proposed.
+// The returned suggestion contains ready to submit CreateRDFPair payloads for the matched devices and for the devices
+// with no match, for which the array will create the remote devices.
+func (c *Client) SuggestRDFPairs(ctx context.Context, localSymID, remoteSymID string, localVolumeIDs, remoteCandidateIDs []string, rdfMode, rdfType string, establish, exemptConsistency bool) (*types.RDFPairSuggestion, error) {
+	defer c.TimeSpent("SuggestRDFPairs", time.Now())
+	if _, err := c.IsAllowedArray(localSymID); err != nil {
+		return nil, err
+	}
+	if len(localVolumeIDs) == 0 {
+		return nil, fmt.Errorf("at least one local volume id has to be specified")
+	}
+	if len(remoteCandidateIDs) == 0 {
+		var err error
+		remoteCandidateIDs, err = c.GetVolumeIDListWithParams(ctx, remoteSymID, map[string]string{"mapped": "false"})
+		if err != nil {
+			return nil, err
 		}
-	case SYNC:
-		payload = &types.CreateRDFPair{
-			RdfMode:                 "Synchronous",
-			RdfType:                 rdfType,
-			Establish:               establish,
-			Exempt:                  exemptConsistency,
-			LocalDeviceListCriteria: &devList,
-			ExecutionOption:         types.ExecutionOptionSynchronous,
+	}
+	candidates := make([]*types.Volume, 0, len(remoteCandidateIDs))
+	for _, volumeID := range remoteCandidateIDs {
+		vol, err := c.GetVolumeByID(ctx, remoteSymID, volumeID)
+		if err != nil {
+			return nil, err
 		}
-	case METRO:
-		payload = &types.CreateRDFPair{
-			RdfMode:                 "Active",
-			RdfType:                 "RDF1",
-			Bias:                    true,
-			Establish:               establish,
-			Exempt:                  exemptConsistency,
-			LocalDeviceListCriteria: &devList,
-			ExecutionOption:         types.ExecutionOptionSynchronous,
+		if len(vol.RDFGroupIDList) == 0 {
+			candidates = append(candidates, vol)
 		}
 	}
-	return payload
+
+	suggestion := &types.RDFPairSuggestion{
+		LocalSymmetrixID:  localSymID,
+		RemoteSymmetrixID: remoteSymID,
+	}
+	var matched types.LocalDeviceListCriteria
+	for _, volumeID := range localVolumeIDs {
+		vol, err := c.GetVolumeByID(ctx, localSymID, volumeID)
+		if err != nil {
+			return nil, err
+		}
+		found := false
+		for i, candidate := range candidates {
+			if candidate == nil || candidate.Emulation != vol.Emulation || candidate.CapacityCYL != vol.CapacityCYL {
+				continue
+			}
+			suggestion.Pairs = append(suggestion.Pairs, types.SuggestedRDFPair{
+				LocalVolumeID:  volumeID,
+				RemoteVolumeID: candidate.VolumeID,
+				Emulation:      vol.Emulation,
+				CapacityCYL:    vol.CapacityCYL,
+			})
+			matched.LocalDeviceList = append(matched.LocalDeviceList, volumeID)
+			matched.RemoteDeviceList = append(matched.RemoteDeviceList, candidate.VolumeID)
+			// a remote device can only be proposed once
+			candidates[i] = nil
+			found = true
+			break
+		}
+		if !found {
+			suggestion.Unmatched = append(suggestion.Unmatched, volumeID)
+		}
+	}
+	if len(matched.LocalDeviceList) > 0 {
+		suggestion.Payload = c.GetCreateRDFPairPayload(matched, rdfMode, rdfType, establish, exemptConsistency)
+	}
+	if len(suggestion.Unmatched) > 0 {
+		unmatched := types.LocalDeviceListCriteria{LocalDeviceList: suggestion.Unmatched}
+		suggestion.AutoCreatePayload = c.GetCreateRDFPairPayload(unmatched, rdfMode, rdfType, establish, exemptConsistency)
+	}
+	log.Info(fmt.Sprintf("Suggested %d RDF pairs between %s and %s, %d devices unmatched",
+		len(suggestion.Pairs), localSymID, remoteSymID, len(suggestion.Unmatched)))
+	return suggestion, nil
 }
 
 // CreateRDFPair creates an RDF device pair in the given RDF group
@@ -621,6 +1022,494 @@ func (c *Client) GetRDFDevicePairInfo(ctx context.Context, symID, rdfGroup, volu
 	return rdfDevPairInfo, nil
 }
 
+// SetR2ReadOnly write disables the R2 devices of the RDF pairs of the volumes in rdfGroupNo, making them read-only to
+// their hosts, or read/write enables them if readOnly is false, e.g. to use the R2 devices during a DR drill.
+// The pairs usually have to be suspended or split for their R2 devices to be read/write enabled.
+func (c *Client) SetR2ReadOnly(ctx context.Context, symID, rdfGroupNo string, readOnly bool, volumeIDs ...string) error {
+	defer c.TimeSpent("SetR2ReadOnly", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return err
+	}
+	if len(volumeIDs) == 0 {
+		return fmt.Errorf("at least one volume ID must be supplied")
+	}
+	payload := &types.ModifyRDFDevicePair{
+		Action:          types.RDFActionRWEnable,
+		RWEnable:        &types.RDFSide{Side: types.RDFSideR2},
+		ExecutionOption: types.ExecutionOptionSynchronous,
+	}
+	if readOnly {
+		payload = &types.ModifyRDFDevicePair{
+			Action:          types.RDFActionWriteDisable,
+			WriteDisable:    &types.RDFSide{Side: types.RDFSideR2},
+			ExecutionOption: types.ExecutionOptionSynchronous,
+		}
+	}
+	ifDebugLogPayload(payload)
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	for _, volumeID := range volumeIDs {
+		URL := c.urlPrefix() + ReplicationX + SymmetrixX + symID + XRDFGroup + "/" + rdfGroupNo + XVolume + "/" + volumeID
+		fields := map[string]interface{}{
+			http.MethodPut: URL,
+			"VolumeID":     volumeID,
+			"Action":       payload.Action,
+		}
+		if err := c.api.Put(ctx, URL, c.getDefaultHeaders(), payload, nil); err != nil {
+			log.WithFields(fields).Error("Error in SetR2ReadOnly: " + err.Error())
+			return err
+		}
+	}
+	log.Info(fmt.Sprintf("Action (%s) on the R2 devices of volumes %v in RDF group (%s) is successful", payload.Action, volumeIDs, rdfGroupNo))
+	return nil
+}
+
+// GetRemoteRDFGroup returns the remote array's view of its RDF group remoteRDFGroupNo, through the remote_symmetrix
+// endpoints of the Unisphere managing symID, for the arrays connected with SRDF to symID
+func (c *Client) GetRemoteRDFGroup(ctx context.Context, symID, remoteSymID, remoteRDFGroupNo string) (*types.RDFGroup, error) {
+	defer c.TimeSpent("GetRemoteRDFGroup", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	URL := c.urlPrefix() + ReplicationX + SymmetrixX + symID + XRemoteSymm + remoteSymID + XRDFGroup + "/" + remoteRDFGroupNo
+	rdfGroup := &types.RDFGroup{}
+	if err := c.getWithTimeout(ctx, URL, rdfGroup); err != nil {
+		log.Error("GetRemoteRDFGroup failed: " + err.Error())
+		return nil, err
+	}
+	return rdfGroup, nil
+}
+
+// GetRemoteRDFDevicePairInfo returns the remote array's view of the RDF pair of its volume remoteVolumeID in its RDF
+// group remoteRDFGroupNo, through the remote_symmetrix endpoints of the Unisphere managing symID
+func (c *Client) GetRemoteRDFDevicePairInfo(ctx context.Context, symID, remoteSymID, remoteRDFGroupNo, remoteVolumeID string) (*types.RDFDevicePair, error) {
+	defer c.TimeSpent("GetRemoteRDFDevicePairInfo", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	URL := c.urlPrefix() + ReplicationX + SymmetrixX + symID + XRemoteSymm + remoteSymID + XRDFGroup + "/" + remoteRDFGroupNo + XVolume + "/" + remoteVolumeID
+	pair := &types.RDFDevicePair{}
+	if err := c.getWithTimeout(ctx, URL, pair); err != nil {
+		log.Error("GetRemoteRDFDevicePairInfo failed: " + err.Error())
+		return nil, err
+	}
+	return pair, nil
+}
+
+// remoteViewError returns the message of err if it is an error returned by Unisphere for a remote_symmetrix
+// endpoint, e.g. because the remote array is not reachable or the endpoints are not supported, and err otherwise
+func remoteViewError(err error) (string, error) {
+	var apiErr *types.Error
+	if errors.As(err, &apiErr) {
+		return err.Error(), nil
+	}
+	return "", err
+}
+
+// GetRDFInfoForVolume returns the RDF pairs of the volume volumeID of symID in all the RDF groups it is in,
+// with their mode, state and remote volume, without the caller having to find the RDF groups of the volume first
+func (c *Client) GetRDFInfoForVolume(ctx context.Context, symID, volumeID string) (*types.VolumeRDFInfo, error) {
+	defer c.TimeSpent("GetRDFInfoForVolume", time.Now())
+	vol, err := c.GetVolumeByID(ctx, symID, volumeID)
+	if err != nil {
+		return nil, err
+	}
+	info := &types.VolumeRDFInfo{SymmetrixID: symID, VolumeID: volumeID, Pairs: []types.RDFDevicePair{}}
+	for _, rdfGroup := range vol.RDFGroupIDList {
+		pair, err := c.GetRDFDevicePairInfo(ctx, symID, strconv.Itoa(rdfGroup.RDFGroupNumber), volumeID)
+		if err != nil {
+			return nil, err
+		}
+		info.Pairs = append(info.Pairs, *pair)
+	}
+	slices.SortFunc(info.Pairs, func(a, b types.RDFDevicePair) int {
+		return a.LocalRdfGroupNumber - b.LocalRdfGroupNumber
+	})
+	return info, nil
+}
+
+// GetRDFGroupSides returns the RDF group rdfGroupNo of symID and, when the remote array can be reached through
+// the remote_symmetrix endpoints, the paired RDF group as seen by the remote array
+func (c *Client) GetRDFGroupSides(ctx context.Context, symID, rdfGroupNo string) (*types.RDFGroupSides, error) {
+	defer c.TimeSpent("GetRDFGroupSides", time.Now())
+	local, err := c.GetRDFGroupByID(ctx, symID, rdfGroupNo)
+	if err != nil {
+		return nil, err
+	}
+	sides := &types.RDFGroupSides{Local: local}
+	remote, err := c.GetRemoteRDFGroup(ctx, symID, local.RemoteSymmetrix, strconv.Itoa(local.RemoteRdfgNumber))
+	if err != nil {
+		if sides.RemoteError, err = remoteViewError(err); err != nil {
+			return nil, err
+		}
+		return sides, nil
+	}
+	sides.Remote = remote
+	sides.RemoteReachable = true
+	return sides, nil
+}
+
+// GetRDFDevicePairSides returns the RDF pair of the volume volumeID of symID in the RDF group rdfGroupNo and,
+// when the remote array can be reached through the remote_symmetrix endpoints, the pair as seen by the remote array
+func (c *Client) GetRDFDevicePairSides(ctx context.Context, symID, rdfGroupNo, volumeID string) (*types.RDFDevicePairSides, error) {
+	defer c.TimeSpent("GetRDFDevicePairSides", time.Now())
+	local, err := c.GetRDFDevicePairInfo(ctx, symID, rdfGroupNo, volumeID)
+	if err != nil {
+		return nil, err
+	}
+	sides := &types.RDFDevicePairSides{Local: local}
+	remote, err := c.GetRemoteRDFDevicePairInfo(ctx, symID, local.RemoteSymmID, strconv.Itoa(local.RemoteRdfGroupNumber), local.RemoteVolumeName)
+	if err != nil {
+		if sides.RemoteError, err = remoteViewError(err); err != nil {
+			return nil, err
+		}
+		return sides, nil
+	}
+	sides.Remote = remote
+	sides.RemoteReachable = true
+	return sides, nil
+}
+
+// ResizeRDFPair expands both sides of the SRDF/S or SRDF/A pair of the R1 volume volumeID to volumeSize in capUnit
+// (CYL, MB, GB or TB) when the online expansion is not possible. The storage group protected by rdfGroup is suspended
+// with consistency exempt, the R2 and then the R1 volume are expanded if smaller than volumeSize, and the storage group
+// is resumed, unless it was already suspended. SRDF/Metro pairs are rejected, as suspending them makes the R2 side
+// unavailable to the hosts; they are expanded online with ExpandVolume and the RDF group number.
+// If an expansion fails, the storage group is left suspended for the sizes to be fixed before it is resumed.
+func (c *Client) ResizeRDFPair(ctx context.Context, symID, storageGroup, rdfGroup, volumeID string, volumeSize int, capUnit string) (*types.RDFDevicePair, error) {
+	defer c.TimeSpent("ResizeRDFPair", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	pair, err := c.GetRDFDevicePairInfo(ctx, symID, rdfGroup, volumeID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(pair.VolumeConfig, "RDF1") {
+		return nil, fmt.Errorf("volume %s is not an R1 volume (%s), the pair has to be resized from the R1 side", volumeID, pair.VolumeConfig)
+	}
+	switch pair.RdfMode {
+	case "Synchronous", "Asynchronous":
+	case "Active":
+		return nil, fmt.Errorf("volume %s is in an SRDF/Metro pair, which has to be expanded online with ExpandVolume", volumeID)
+	default:
+		return nil, fmt.Errorf("unsupported RDF mode %s for volume %s", pair.RdfMode, volumeID)
+	}
+	var suspended bool
+	switch pair.RdfpairState {
+	case "Synchronized", "Consistent":
+	case "Suspended":
+		suspended = true
+	default:
+		return nil, fmt.Errorf("RDF pair of volume %s is %s, it has to be Synchronized, Consistent or Suspended to be resized", volumeID, pair.RdfpairState)
+	}
+
+	localVol, err := c.GetVolumeByID(ctx, symID, volumeID)
+	if err != nil {
+		return nil, err
+	}
+	remoteVol, err := c.GetVolumeByID(ctx, pair.RemoteSymmID, pair.RemoteVolumeName)
+	if err != nil {
+		return nil, err
+	}
+	localSize, err := volumeSizeIn(localVol, capUnit)
+	if err != nil {
+		return nil, err
+	}
+	remoteSize, err := volumeSizeIn(remoteVol, capUnit)
+	if err != nil {
+		return nil, err
+	}
+	if float64(volumeSize) < localSize || float64(volumeSize) < remoteSize {
+		return nil, fmt.Errorf("volume %s cannot be shrunk to %d %s", volumeID, volumeSize, capUnit)
+	}
+	if float64(volumeSize) == localSize && float64(volumeSize) == remoteSize {
+		return pair, nil
+	}
+
+	if !suspended {
+		err = RunStep(ctx, "ResizeRDFPair", "Suspend storage group "+storageGroup, func() error {
+			return c.ExecuteReplicationActionOnSG(ctx, symID, "Suspend", storageGroup, rdfGroup, false, true, false)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if float64(volumeSize) > remoteSize {
+		err = RunStep(ctx, "ResizeRDFPair", "Expand R2 volume "+pair.RemoteVolumeName, func() error {
+			_, err := c.ExpandVolume(ctx, pair.RemoteSymmID, pair.RemoteVolumeName, 0, volumeSize, capUnit)
+			return err
+		})
+		if err != nil {
+			log.Error(fmt.Sprintf("Expanding R2 volume %s failed, storage group %s is left suspended: %s", pair.RemoteVolumeName, storageGroup, err.Error()))
+			return nil, err
+		}
+	}
+	if float64(volumeSize) > localSize {
+		err = RunStep(ctx, "ResizeRDFPair", "Expand R1 volume "+volumeID, func() error {
+			_, err := c.ExpandVolume(ctx, symID, volumeID, 0, volumeSize, capUnit)
+			return err
+		})
+		if err != nil {
+			log.Error(fmt.Sprintf("Expanding R1 volume %s failed, storage group %s is left suspended: %s", volumeID, storageGroup, err.Error()))
+			return nil, err
+		}
+	}
+	if !suspended {
+		err = RunStep(ctx, "ResizeRDFPair", "Resume storage group "+storageGroup, func() error {
+			return c.ExecuteReplicationActionOnSG(ctx, symID, "Resume", storageGroup, rdfGroup, false, false, false)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	log.Info(fmt.Sprintf("Successfully resized RDF pair of volume %s to %d %s", volumeID, volumeSize, capUnit))
+	return c.GetRDFDevicePairInfo(ctx, symID, rdfGroup, volumeID)
+}
+
+// AddExistingVolumesToProtectedStorageGroup adds existing, unpaired volumes to a storage group protected by SRDF/S or
+// SRDF/A in rdfGroupNo. A pair is created, without being established and consistency exempt for SRDF/A, for each volume,
+// which is then added to the storage group while its R2 volume is added to remoteStorageGroupID, or to the storage group
+// of the same name if empty, on the remote array. Once the remote storage group is checked to hold all the R2 volumes,
+// the storage group is resumed, unless it was suspended already.
+// It returns the created pairs; on errors the pairs which were created are left for the caller to clean up.
+func (c *Client) AddExistingVolumesToProtectedStorageGroup(ctx context.Context, symID, storageGroupID, rdfGroupNo, remoteStorageGroupID string, volumeIDs ...string) ([]types.RDFDevicePair, error) {
+	defer c.TimeSpent("AddExistingVolumesToProtectedStorageGroup", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	if len(volumeIDs) == 0 {
+		return nil, fmt.Errorf("no volume to add to storage group %s", storageGroupID)
+	}
+	if remoteStorageGroupID == "" {
+		remoteStorageGroupID = storageGroupID
+	}
+	rdfInfo, err := c.GetStorageGroupRDFInfo(ctx, symID, storageGroupID, rdfGroupNo)
+	if err != nil {
+		return nil, err
+	}
+	if len(rdfInfo.Modes) != 1 {
+		return nil, fmt.Errorf("storage group %s has RDF modes %v in RDF group %s, a single mode is expected", storageGroupID, rdfInfo.Modes, rdfGroupNo)
+	}
+	var rdfMode string
+	switch rdfInfo.Modes[0] {
+	case "Synchronous":
+		rdfMode = SYNC
+	case "Asynchronous":
+		rdfMode = ASYNC
+	case "Active":
+		return nil, fmt.Errorf("storage group %s is protected by SRDF/Metro, the volumes have to be added with AddVolumesToMetroStorageGroup", storageGroupID)
+	default:
+		return nil, fmt.Errorf("unsupported RDF mode %s for storage group %s", rdfInfo.Modes[0], storageGroupID)
+	}
+	suspended := len(rdfInfo.States) > 0
+	for _, state := range rdfInfo.States {
+		if state != "Suspended" {
+			suspended = false
+		}
+	}
+	for _, volumeID := range volumeIDs {
+		vol, err := c.GetVolumeByID(ctx, symID, volumeID)
+		if err != nil {
+			return nil, err
+		}
+		if len(vol.RDFGroupIDList) > 0 {
+			return nil, fmt.Errorf("volume %s is already RDF protected in RDF group %d", volumeID, vol.RDFGroupIDList[0].RDFGroupNumber)
+		}
+	}
+
+	exempt := rdfMode == ASYNC
+	var pairs []types.RDFDevicePair
+	for _, volumeID := range volumeIDs {
+		created, err := c.CreateRDFPair(ctx, symID, rdfGroupNo, volumeID, rdfMode, "RDF1", false, exempt)
+		if err != nil {
+			log.Error(fmt.Sprintf("Creating RDF pair of volume %s failed, %d pairs are left created: %s", volumeID, len(pairs), err.Error()))
+			return pairs, err
+		}
+		pairs = append(pairs, created.RDFDevicePair...)
+	}
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("no RDF pair was created in RDF group %s", rdfGroupNo)
+	}
+	if err = c.AddVolumesToStorageGroupS(ctx, symID, storageGroupID, false, volumeIDs...); err != nil {
+		return pairs, err
+	}
+
+	remoteSymID := pairs[0].RemoteSymmID
+	remoteVolumeIDs, err := c.GetVolumeIDListInStorageGroup(ctx, remoteSymID, remoteStorageGroupID)
+	if err != nil {
+		return pairs, err
+	}
+	var missing []string
+	for _, pair := range pairs {
+		if !slices.Contains(remoteVolumeIDs, pair.RemoteVolumeName) {
+			missing = append(missing, pair.RemoteVolumeName)
+		}
+	}
+	if len(missing) > 0 {
+		if err = c.AddVolumesToStorageGroupS(ctx, remoteSymID, remoteStorageGroupID, false, missing...); err != nil {
+			return pairs, err
+		}
+		if remoteVolumeIDs, err = c.GetVolumeIDListInStorageGroup(ctx, remoteSymID, remoteStorageGroupID); err != nil {
+			return pairs, err
+		}
+		for _, volumeID := range missing {
+			if !slices.Contains(remoteVolumeIDs, volumeID) {
+				return pairs, fmt.Errorf("R2 volume %s is not in remote storage group %s on %s", volumeID, remoteStorageGroupID, remoteSymID)
+			}
+		}
+	}
+
+	if !suspended {
+		if err = c.ExecuteReplicationActionOnSG(ctx, symID, "Resume", storageGroupID, rdfGroupNo, false, exempt, false); err != nil {
+			return pairs, err
+		}
+	}
+	log.Info(fmt.Sprintf("Successfully added %d volumes to protected storage group %s", len(volumeIDs), storageGroupID))
+	return pairs, nil
+}
+
+// AddVolumesToMetroStorageGroup adds volumes to a storage group protected by SRDF/Metro in rdfGroupNo, keeping the
+// storage group of the remote array consistent: the R2 volumes are added to remoteStorageGroupID, or to the storage group
+// of the same name if empty, which has to exist on the remote array. The pair states of the storage group are then checked
+// every pollInterval until they are all ActiveActive or ActiveBias, or ctx is done.
+// It returns the state of each volume on both arrays; the report is returned with the error if the pairs do not become active.
+func (c *Client) AddVolumesToMetroStorageGroup(ctx context.Context, symID, storageGroupID, rdfGroupNo, remoteStorageGroupID string, pollInterval time.Duration, volumeIDs ...string) (*types.MetroStorageGroupReport, error) {
+	defer c.TimeSpent("AddVolumesToMetroStorageGroup", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	if len(volumeIDs) == 0 {
+		return nil, fmt.Errorf("no volume to add to storage group %s", storageGroupID)
+	}
+	if remoteStorageGroupID == "" {
+		remoteStorageGroupID = storageGroupID
+	}
+	if pollInterval <= 0 {
+		pollInterval = DefaultRDFSyncPollInterval
+	}
+	rdfInfo, err := c.GetStorageGroupRDFInfo(ctx, symID, storageGroupID, rdfGroupNo)
+	if err != nil {
+		return nil, err
+	}
+	if len(rdfInfo.Modes) != 1 || rdfInfo.Modes[0] != "Active" {
+		return nil, fmt.Errorf("storage group %s has RDF modes %v in RDF group %s, it is not protected by SRDF/Metro", storageGroupID, rdfInfo.Modes, rdfGroupNo)
+	}
+	rdfGroup, err := c.GetRDFGroupByID(ctx, symID, rdfGroupNo)
+	if err != nil {
+		return nil, err
+	}
+	remoteSymID := rdfGroup.RemoteSymmetrix
+	if _, err = c.GetStorageGroup(ctx, remoteSymID, remoteStorageGroupID); err != nil {
+		return nil, fmt.Errorf("remote storage group %s cannot be read on %s: %s", remoteStorageGroupID, remoteSymID, err.Error())
+	}
+
+	err = RunStep(ctx, "AddVolumesToMetroStorageGroup", "Add volumes to storage group "+storageGroupID, func() error {
+		return c.AddVolumesToProtectedStorageGroup(ctx, symID, storageGroupID, remoteSymID, remoteStorageGroupID, false, volumeIDs...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &types.MetroStorageGroupReport{
+		SymmetrixID:          symID,
+		StorageGroupID:       storageGroupID,
+		RemoteSymmetrixID:    remoteSymID,
+		RemoteStorageGroupID: remoteStorageGroupID,
+		RDFGroupNumber:       rdfGroupNo,
+	}
+	var notActive bool
+	err = RunStep(ctx, "AddVolumesToMetroStorageGroup", "Wait for storage group "+storageGroupID+" to be ActiveActive", func() error {
+		for {
+			if rdfInfo, err = c.GetStorageGroupRDFInfo(ctx, symID, storageGroupID, rdfGroupNo); err != nil {
+				return err
+			}
+			report.States = rdfInfo.States
+			active := len(rdfInfo.States) > 0
+			for _, state := range rdfInfo.States {
+				if state != "ActiveActive" && state != "ActiveBias" {
+					active = false
+				}
+			}
+			if active {
+				return nil
+			}
+			log.Debug(fmt.Sprintf("Waiting for storage group %s to be ActiveActive, states: %v", storageGroupID, rdfInfo.States))
+			select {
+			case <-ctx.Done():
+				notActive = true
+				return fmt.Errorf("storage group %s not ActiveActive: %w", storageGroupID, ctx.Err())
+			case <-time.After(pollInterval):
+			}
+		}
+	})
+	if notActive {
+		if reportErr := c.reportMetroVolumes(context.WithoutCancel(ctx), report, volumeIDs); reportErr != nil {
+			return nil, errors.Join(err, reportErr)
+		}
+		return report, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = c.reportMetroVolumes(ctx, report, volumeIDs); err != nil {
+		return nil, err
+	}
+	report.Consistent = true
+	for _, vol := range report.Volumes {
+		if !vol.InStorageGroup || !vol.InRemoteStorageGroup {
+			report.Consistent = false
+		}
+	}
+	if !report.Consistent {
+		return report, fmt.Errorf("the volumes added to storage group %s are not in the storage groups of both arrays", storageGroupID)
+	}
+	log.Info(fmt.Sprintf("Successfully added %d volumes to SRDF/Metro storage group %s", len(volumeIDs), storageGroupID))
+	return report, nil
+}
+
+// reportMetroVolumes fills in the state of the volumes, and of their R2 volume, in the report
+func (c *Client) reportMetroVolumes(ctx context.Context, report *types.MetroStorageGroupReport, volumeIDs []string) error {
+	localVolumeIDs, err := c.GetVolumeIDListInStorageGroup(ctx, report.SymmetrixID, report.StorageGroupID)
+	if err != nil {
+		return err
+	}
+	remoteVolumeIDs, err := c.GetVolumeIDListInStorageGroup(ctx, report.RemoteSymmetrixID, report.RemoteStorageGroupID)
+	if err != nil {
+		return err
+	}
+	report.Volumes = make([]types.MetroVolumeReport, 0, len(volumeIDs))
+	for _, volumeID := range volumeIDs {
+		pair, err := c.GetRDFDevicePairInfo(ctx, report.SymmetrixID, report.RDFGroupNumber, volumeID)
+		if err != nil {
+			return err
+		}
+		report.Volumes = append(report.Volumes, types.MetroVolumeReport{
+			VolumeID:             volumeID,
+			RemoteVolumeID:       pair.RemoteVolumeName,
+			PairState:            pair.RdfpairState,
+			InStorageGroup:       slices.Contains(localVolumeIDs, volumeID),
+			InRemoteStorageGroup: slices.Contains(remoteVolumeIDs, pair.RemoteVolumeName),
+		})
+	}
+	return nil
+}
+
+// volumeSizeIn returns the size of the volume in capUnit
+func volumeSizeIn(vol *types.Volume, capUnit string) (float64, error) {
+	switch capUnit {
+	case "CYL":
+		return float64(vol.CapacityCYL), nil
+	case "MB":
+		return vol.FloatCapacityMB, nil
+	case "GB":
+		return vol.CapacityGB, nil
+	case "TB":
+		return vol.CapacityGB / 1024, nil
+	}
+	return 0, fmt.Errorf("unsupported capacity unit %s", capUnit)
+}
+
 // GetStorageGroupRDFInfo returns the of RDF info of protected storage group
 func (c *Client) GetStorageGroupRDFInfo(ctx context.Context, symID, sgName, rdfGroupNo string) (*types.StorageGroupRDFG, error) {
 	defer c.TimeSpent("GetStorageGroupRDFInfo", time.Now())
@@ -651,3 +1540,91 @@ func (c *Client) GetStorageGroupRDFInfo(ctx context.Context, symID, sgName, rdfG
 	}
 	return sgRdfInfo, nil
 }
+
+// DefaultRDFSyncPollInterval is how often CopySnapshotToRemoteArray checks the synchronization when no interval is given
+const DefaultRDFSyncPollInterval = 10 * time.Second
+
+// CopySnapshotToRemoteArray makes a usable copy of a storage group snapshot on a remote array, e.g. to seed a DR site.
+// The snapshot is linked, in copy mode, to a staging storage group which is then protected with SRDF in the RDF group
+// and mode of the param, creating the remote storage group. Once every pair is synchronized, the pairs are split so that
+// the remote copy can be accessed. The synchronization is checked every pollInterval until ctx is done.
+// The staging storage group stays linked to the snapshot and paired with the remote storage group, so that the copy can
+// be refreshed by relinking the snapshot and resuming the pairs; they are left for the caller to clean up on errors.
+// It returns the RDF information of the staging storage group once split.
+func (c *Client) CopySnapshotToRemoteArray(ctx context.Context, symID string, param types.RemoteSnapshotCopyParam, pollInterval time.Duration) (*types.StorageGroupRDFG, error) {
+	defer c.TimeSpent("CopySnapshotToRemoteArray", time.Now())
+	if _, err := c.IsAllowedArray(symID); err != nil {
+		return nil, err
+	}
+	var syncState string
+	switch param.RDFMode {
+	case SYNC:
+		syncState = "Synchronized"
+	case ASYNC:
+		syncState = "Consistent"
+	default:
+		return nil, fmt.Errorf("RDF mode %s is not supported to copy a snapshot, %s or %s is expected", param.RDFMode, SYNC, ASYNC)
+	}
+	if param.StagingStorageGroupID == "" || param.RemoteStorageGroupID == "" || param.RDFGroupNumber == "" {
+		return nil, fmt.Errorf("the staging storage group, remote storage group and RDF group have to be specified")
+	}
+	if pollInterval <= 0 {
+		pollInterval = DefaultRDFSyncPollInterval
+	}
+
+	err := RunStep(ctx, "CopySnapshotToRemoteArray", "Link snapshot "+param.SnapshotID+" to storage group "+param.StagingStorageGroupID, func() error {
+		if _, err := c.LinkStorageGroupSnapshot(ctx, symID, param.StorageGroupID, param.SnapshotID, param.SnapID, param.StagingStorageGroupID, true); err != nil {
+			return fmt.Errorf("linking snapshot %s of storage group %s to %s failed: %s", param.SnapshotID, param.StorageGroupID, param.StagingStorageGroupID, err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = RunStep(ctx, "CopySnapshotToRemoteArray", "Protect storage group "+param.StagingStorageGroupID, func() error {
+		if _, err := c.CreateSGReplica(ctx, symID, param.RemoteSymmetrixID, param.RDFMode, param.RDFGroupNumber, param.StagingStorageGroupID, param.RemoteStorageGroupID, param.RemoteServiceLevel, false); err != nil {
+			return fmt.Errorf("protecting storage group %s on array %s failed: %s", param.StagingStorageGroupID, param.RemoteSymmetrixID, err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = RunStep(ctx, "CopySnapshotToRemoteArray", "Wait for storage group "+param.StagingStorageGroupID+" to be "+syncState, func() error {
+		for {
+			rdfInfo, err := c.GetStorageGroupRDFInfo(ctx, symID, param.StagingStorageGroupID, param.RDFGroupNumber)
+			if err != nil {
+				return err
+			}
+			synchronized := len(rdfInfo.States) > 0
+			for _, state := range rdfInfo.States {
+				if !strings.EqualFold(state, syncState) {
+					synchronized = false
+				}
+			}
+			if synchronized {
+				return nil
+			}
+			log.Debug(fmt.Sprintf("Waiting for storage group %s to be %s, states: %v", param.StagingStorageGroupID, syncState, rdfInfo.States))
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("storage group %s not %s: %w", param.StagingStorageGroupID, syncState, ctx.Err())
+			case <-time.After(pollInterval):
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = RunStep(ctx, "CopySnapshotToRemoteArray", "Split storage group "+param.StagingStorageGroupID, func() error {
+		return c.ExecuteReplicationActionOnSG(ctx, symID, "Split", param.StagingStorageGroupID, param.RDFGroupNumber, false, false, false)
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Info(fmt.Sprintf("Successfully copied snapshot %s of storage group %s to storage group %s on array %s",
+		param.SnapshotID, param.StorageGroupID, param.RemoteStorageGroupID, param.RemoteSymmetrixID))
+	return c.GetStorageGroupRDFInfo(ctx, symID, param.StagingStorageGroupID, param.RDFGroupNumber)
+}
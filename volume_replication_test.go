@@ -0,0 +1,980 @@
+/*
+Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pmax
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	types "github.com/dell/gopowermax/v2/types/v100"
+)
+
+func TestSuggestRDFPairs(t *testing.T) {
+	volumes := map[string]*types.Volume{
+		"local-sym-id/00001":  {VolumeID: "00001", Emulation: "FBA", CapacityCYL: 547},
+		"local-sym-id/00002":  {VolumeID: "00002", Emulation: "FBA", CapacityCYL: 1093},
+		"remote-sym-id/00101": {VolumeID: "00101", Emulation: "FBA", CapacityCYL: 547, RDFGroupIDList: []types.RDFGroupID{{RDFGroupNumber: 1}}},
+		"remote-sym-id/00102": {VolumeID: "00102", Emulation: "FBA", CapacityCYL: 547},
+		"remote-sym-id/00103": {VolumeID: "00103", Emulation: "CKD-3390", CapacityCYL: 1093},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		path := strings.TrimPrefix(req.URL.Path, urlPrefix+SLOProvisioningX+SymmetrixX)
+		switch {
+		case path == "remote-sym-id"+XVolume:
+			body = &types.VolumeIterator{
+				ResultList: types.VolumeResultList{
+					VolumeList: []types.VolumeIDList{{VolumeIDs: "00101"}, {VolumeIDs: "00102"}, {VolumeIDs: "00103"}},
+					From:       1,
+					To:         3,
+				},
+				Count:       3,
+				MaxPageSize: 1000,
+			}
+		case volumes[strings.Replace(path, XVolume, "", 1)] != nil:
+			body = volumes[strings.Replace(path, XVolume, "", 1)]
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	suggestion, err := client.SuggestRDFPairs(context.TODO(), "local-sym-id", "remote-sym-id", []string{"00001", "00002"}, nil, SYNC, "RDF1", true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedPairs := []types.SuggestedRDFPair{{LocalVolumeID: "00001", RemoteVolumeID: "00102", Emulation: "FBA", CapacityCYL: 547}}
+	if !reflect.DeepEqual(expectedPairs, suggestion.Pairs) {
+		t.Fatalf("expected pairs %+v, got %+v", expectedPairs, suggestion.Pairs)
+	}
+	if !reflect.DeepEqual([]string{"00002"}, suggestion.Unmatched) {
+		t.Fatalf("unexpected unmatched devices %v", suggestion.Unmatched)
+	}
+	if suggestion.Payload == nil || suggestion.Payload.RdfMode != "Synchronous" ||
+		!reflect.DeepEqual([]string{"00102"}, suggestion.Payload.LocalDeviceListCriteria.RemoteDeviceList) {
+		t.Fatalf("unexpected payload %+v", suggestion.Payload)
+	}
+	if suggestion.AutoCreatePayload == nil || len(suggestion.AutoCreatePayload.LocalDeviceListCriteria.RemoteDeviceList) != 0 {
+		t.Fatalf("unexpected auto create payload %+v", suggestion.AutoCreatePayload)
+	}
+}
+
+func TestVerifyRemoteArrayConnectivity(t *testing.T) {
+	remoteOnline := true
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch strings.TrimPrefix(req.RequestURI, urlPrefix+ReplicationX+SymmetrixX+"local-sym-id") {
+		case XRDFONLINEDIR:
+			body = &types.RDFDirList{RdfDirs: []string{"RF-1E"}}
+		case XRDFDIR + "RF-1E" + XRDFPORTONLINE:
+			body = &types.RDFPortList{RdfPorts: []string{"4"}}
+		case XRDFDIR + "RF-1E" + XRDFPORT + "4" + XREMOTEPORT:
+			body = &types.RemoteRDFPortDetails{RemotePorts: []types.RDFPortDetails{
+				{SymmID: "remote-sym-id", DirID: "RF-2E", PortNum: 5, PortOnline: remoteOnline, PortWWN: "50000973b0000001"},
+				{SymmID: "other-sym-id", DirID: "RF-3E", PortNum: 6, PortOnline: true},
+			}}
+		case XRDFGroup + "?remote_symmetrix_id=remote-sym-id":
+			body = &types.RDFGroupList{RDFGroupCount: 3, RDFGroupIDs: []types.RDFGroupIDL{{RDFGNumber: 10}, {RDFGNumber: 11}, {RDFGNumber: 12}}}
+		case XRDFGroup + "/10":
+			body = &types.RDFGroup{RdfgNumber: 10, Label: "metro", RemoteSymmetrix: "remote-sym-id", Metro: true,
+				WitnessConfigured: true, WitnessEffective: true,
+				LocalOnlinePorts: []string{"RF-1E:4"}, RemoteOnlinePorts: []string{"RF-2E:5"}}
+		case XRDFGroup + "/11":
+			body = &types.RDFGroup{RdfgNumber: 11, Label: "down", RemoteSymmetrix: "remote-sym-id", Offline: true}
+		case XRDFGroup + "/12":
+			body = &types.RDFGroup{RdfgNumber: 12, Label: "no-witness", RemoteSymmetrix: "remote-sym-id", Metro: true, Witness: true,
+				LocalOnlinePorts: []string{"RF-1E:4"}, RemoteOnlinePorts: []string{"RF-2E:5"}}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Error(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := client.VerifyRemoteArrayConnectivity(context.TODO(), "local-sym-id", "remote-sym-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedLinks := []types.RDFLink{{LocalDirectorID: "RF-1E", LocalPort: 4, RemoteDirectorID: "RF-2E", RemotePort: 5, RemotePortWWN: "50000973b0000001"}}
+	if !report.Reachable || !reflect.DeepEqual(expectedLinks, report.Links) {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if len(report.RDFGroups) != 3 || !report.RDFGroups[0].WitnessEffective || report.RDFGroups[1].WitnessConfigured {
+		t.Fatalf("unexpected RDF groups %+v", report.RDFGroups)
+	}
+	if len(report.Reasons) != 2 || !strings.Contains(report.Reasons[0], "offline") || !strings.Contains(report.Reasons[1], "witness") {
+		t.Fatalf("unexpected reasons %v", report.Reasons)
+	}
+
+	remoteOnline = false
+	report, err = client.VerifyRemoteArrayConnectivity(context.TODO(), "local-sym-id", "remote-sym-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Reachable || len(report.Reasons) != 3 || !strings.Contains(report.Reasons[0], "zoned") {
+		t.Fatalf("unexpected report %+v", report)
+	}
+}
+
+func TestGetFilteredRDFGroupList(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch strings.TrimPrefix(req.URL.Path, urlPrefix+ReplicationX+SymmetrixX+"local-sym-id") {
+		case XRDFGroup:
+			query = req.URL.Query()
+			// an older array ignoring the filters returns all the groups
+			body = &types.RDFGroupList{RDFGroupCount: 3, RDFGroupIDs: []types.RDFGroupIDL{
+				{RDFGNumber: 10, RemoteSymID: "remote-sym-id", GroupType: "Dynamic"},
+				{RDFGNumber: 11, RemoteSymID: "remote-sym-id", GroupType: "Dynamic"},
+				{RDFGNumber: 12, RemoteSymID: "other-sym-id", GroupType: "Dynamic"},
+			}}
+		case XRDFGroup + "/10":
+			body = &types.RDFGroup{RdfgNumber: 10, NumDevices: 5}
+		case XRDFGroup + "/11":
+			body = &types.RDFGroup{RdfgNumber: 11, NumDevices: 50}
+		default:
+			t.Errorf("unexpected request %s", req.URL)
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Error(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rdfGroups, err := client.GetFilteredRDFGroupList(context.TODO(), "local-sym-id", types.RDFGroupFilter{RemoteSymmetrixID: "remote-sym-id", GroupType: "dynamic"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rdfGroups.RDFGroupCount != 2 || query.Get("remote_symmetrix_id") != "remote-sym-id" || query.Get("group_type") != "dynamic" || query.Has("volume_count") {
+		t.Fatalf("unexpected RDF groups %+v for query %v", rdfGroups, query)
+	}
+
+	rdfGroups, err = client.GetFilteredRDFGroupList(context.TODO(), "local-sym-id", types.RDFGroupFilter{RemoteSymmetrixID: "remote-sym-id", MinVolumeCount: 1, MaxVolumeCount: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rdfGroups.RDFGroupCount != 1 || rdfGroups.RDFGroupIDs[0].RDFGNumber != 10 || query.Get("volume_count") != ">0" {
+		t.Fatalf("unexpected RDF groups %+v for query %v", rdfGroups, query)
+	}
+
+	if _, err = client.GetFilteredRDFGroupList(context.TODO(), "local-sym-id", types.RDFGroupFilter{MaxVolumeCount: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if query.Get("volume_count") != "<11" {
+		t.Fatalf("unexpected query %v", query)
+	}
+	if _, err = client.GetFilteredRDFGroupList(context.TODO(), "local-sym-id", types.RDFGroupFilter{MinVolumeCount: 10, MaxVolumeCount: 1}); err == nil {
+		t.Fatal("expected an error for an empty volume count range")
+	}
+}
+
+func TestGetRDFSides(t *testing.T) {
+	remoteReachable := true
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		remotePrefix := XRemoteSymm + "remote-sym-id"
+		path := strings.TrimPrefix(req.RequestURI, urlPrefix+ReplicationX+SymmetrixX+"local-sym-id")
+		if !remoteReachable && strings.HasPrefix(path, remotePrefix) {
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"Symmetrix remote-sym-id is not remote to local-sym-id","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		switch path {
+		case XRDFGroup + "/10":
+			body = &types.RDFGroup{RdfgNumber: 10, RemoteRdfgNumber: 20, RemoteSymmetrix: "remote-sym-id"}
+		case XRDFGroup + "/10" + XVolume + "/00001":
+			body = &types.RDFDevicePair{LocalSymmID: "local-sym-id", RemoteSymmID: "remote-sym-id", LocalRdfGroupNumber: 10,
+				RemoteRdfGroupNumber: 20, LocalVolumeName: "00001", RemoteVolumeName: "00002", RdfpairState: "Synchronized"}
+		case remotePrefix + XRDFGroup + "/20":
+			body = &types.RDFGroup{RdfgNumber: 20, RemoteRdfgNumber: 10, RemoteSymmetrix: "local-sym-id"}
+		case remotePrefix + XRDFGroup + "/20" + XVolume + "/00002":
+			body = &types.RDFDevicePair{LocalSymmID: "remote-sym-id", RemoteSymmID: "local-sym-id", LocalRdfGroupNumber: 20,
+				RemoteRdfGroupNumber: 10, LocalVolumeName: "00002", RemoteVolumeName: "00001", RdfpairState: "Synchronized"}
+		default:
+			t.Errorf("unexpected request %s", req.RequestURI)
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Error(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	groupSides, err := client.GetRDFGroupSides(context.TODO(), "local-sym-id", "10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !groupSides.RemoteReachable || groupSides.Remote.RdfgNumber != 20 || groupSides.Remote.RemoteSymmetrix != "local-sym-id" {
+		t.Fatalf("unexpected RDF group sides %+v", groupSides)
+	}
+	pairSides, err := client.GetRDFDevicePairSides(context.TODO(), "local-sym-id", "10", "00001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pairSides.RemoteReachable || pairSides.Remote.LocalVolumeName != "00002" || pairSides.Remote.RemoteVolumeName != "00001" {
+		t.Fatalf("unexpected RDF pair sides %+v", pairSides)
+	}
+
+	remoteReachable = false
+	groupSides, err = client.GetRDFGroupSides(context.TODO(), "local-sym-id", "10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if groupSides.RemoteReachable || groupSides.Remote != nil || !strings.Contains(groupSides.RemoteError, "not remote") {
+		t.Fatalf("unexpected RDF group sides %+v", groupSides)
+	}
+	pairSides, err = client.GetRDFDevicePairSides(context.TODO(), "local-sym-id", "10", "00001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pairSides.RemoteReachable || pairSides.Remote != nil || pairSides.Local.RdfpairState != "Synchronized" {
+		t.Fatalf("unexpected RDF pair sides %+v", pairSides)
+	}
+}
+
+func TestGetRDFPortInventory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch strings.TrimPrefix(req.RequestURI, urlPrefix+ReplicationX+SymmetrixX+"sym-id") {
+		case XRDFDIRS:
+			body = &types.RDFDirList{RdfDirs: []string{"RF-1E", "RE-2G"}}
+		case XRDFDIR + "RF-1E":
+			body = &types.RDFDirDetails{DirID: "RF-1E", DirOnline: "Online", DirProtocolFC: true}
+		case XRDFDIR + "RE-2G":
+			body = &types.RDFDirDetails{DirID: "RE-2G", DirOnline: "Online", DirProtocolGigE: true}
+		case XRDFDIR + "RF-1E" + XRDFPORTS:
+			body = &types.RDFPortList{RdfPorts: []string{"4"}}
+		case XRDFDIR + "RE-2G" + XRDFPORTS:
+			body = &types.RDFPortList{RdfPorts: []string{"7", "8"}}
+		case XRDFDIR + "RF-1E" + XRDFPORT + "4":
+			_, _ = resp.Write([]byte(`{"directorId":"RF-1E","portNumber":4,"online":true,"wwn":"50000973b0000001"}`))
+			return
+		case XRDFDIR + "RE-2G" + XRDFPORT + "7":
+			_, _ = resp.Write([]byte(`{"directorId":"RE-2G","portNumber":7,"online":true,"ipv4_address":"10.0.0.7","port_speed":"10 Gb/s"}`))
+			return
+		case XRDFDIR + "RE-2G" + XRDFPORT + "8":
+			_, _ = resp.Write([]byte(`{"directorId":"RE-2G","portNumber":8,"online":false,"ipv6_address":"fd00::8"}`))
+			return
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Error(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	inventory, err := client.GetRDFPortInventory(context.TODO(), "sym-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inventory.SymmetrixID != "sym-id" || len(inventory.Directors) != 2 {
+		t.Fatalf("unexpected inventory %+v", inventory)
+	}
+	expectedFC := []types.RDFPort{{DirID: "RF-1E", PortNum: 4, PortOnline: true, PortWWN: "50000973b0000001", Protocol: types.RDFProtocolFC}}
+	if !reflect.DeepEqual(expectedFC, inventory.Directors[0].Ports) {
+		t.Fatalf("unexpected FC ports %+v", inventory.Directors[0].Ports)
+	}
+	expectedGigE := []types.RDFPort{
+		{DirID: "RE-2G", PortNum: 7, PortOnline: true, IPv4Address: "10.0.0.7", PortSpeed: "10 Gb/s", Protocol: types.RDFProtocolGigE},
+		{DirID: "RE-2G", PortNum: 8, IPv6Address: "fd00::8", Protocol: types.RDFProtocolGigE},
+	}
+	if !reflect.DeepEqual(expectedGigE, inventory.Directors[1].Ports) {
+		t.Fatalf("unexpected GigE ports %+v", inventory.Directors[1].Ports)
+	}
+
+	_, err = client.GetRDFPortInventory(context.TODO(), "other-sym-id")
+	if err == nil {
+		t.Fatal("expected an error for an unknown array")
+	}
+}
+
+func TestGetTargetRDFPorts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch strings.TrimPrefix(req.RequestURI, urlPrefix+ReplicationX+SymmetrixX+"sym-id") {
+		case XRDFONLINEDIR:
+			body = &types.RDFDirList{RdfDirs: []string{"RF-1E", "RE-2G"}}
+		case XRDFDIR + "RF-1E":
+			body = &types.RDFDirDetails{DirID: "RF-1E", DirOnline: "Online", DirProtocolFC: true}
+		case XRDFDIR + "RE-2G":
+			body = &types.RDFDirDetails{DirID: "RE-2G", DirOnline: "Online", DirProtocolGigE: true}
+		case XRDFDIR + "RF-1E" + XRDFPORTONLINE:
+			body = &types.RDFPortList{RdfPorts: []string{"4"}}
+		case XRDFDIR + "RE-2G" + XRDFPORTONLINE:
+			body = &types.RDFPortList{RdfPorts: []string{"7"}}
+		case XRDFDIR + "RF-1E" + XRDFPORT + "4":
+			_, _ = resp.Write([]byte(`{"directorId":"RF-1E","portNumber":4,"online":true,"port_speed":"32 Gb/s","negotiated_speed":"16 Gb/s"}`))
+			return
+		case XRDFDIR + "RF-1E" + XRDFPORT + "4" + XREMOTEPORT:
+			body = &types.RemoteRDFPortDetails{RemotePorts: []types.RDFPortDetails{
+				{SymmID: "remote-2", DirID: "RF-1F", PortNum: 4},
+				{SymmID: "remote-1", DirID: "RF-1E", PortNum: 4},
+				{SymmID: "remote-1", DirID: "RF-2E", PortNum: 4},
+			}}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	connections, err := client.GetTargetRDFPorts(context.TODO(), "sym-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(connections.Ports) != 2 {
+		t.Fatalf("unexpected connections %+v", connections)
+	}
+	fc := connections.Ports[0]
+	if fc.Protocol != types.RDFProtocolFC || fc.PortSpeed != "32 Gb/s" || fc.NegotiatedSpeed != "16 Gb/s" ||
+		!reflect.DeepEqual([]string{"remote-1", "remote-2"}, fc.RemoteSymmetrixIDs) || len(fc.RemotePorts) != 3 || fc.Error != "" {
+		t.Errorf("unexpected FC port %+v", fc)
+	}
+	if gige := connections.Ports[1]; gige.Protocol != types.RDFProtocolGigE || gige.Error == "" {
+		t.Errorf("expected the unreadable GigE port to be reported with its error, got %+v", gige)
+	}
+	if ports := connections.PortsTo("remote-1"); len(ports) != 1 || ports[0].DirectorID != "RF-1E" {
+		t.Errorf("unexpected ports to remote-1 %+v", ports)
+	}
+	if ports := connections.PortsTo("remote-3"); len(ports) != 0 {
+		t.Errorf("unexpected ports to remote-3 %+v", ports)
+	}
+}
+
+func TestResizeRDFPair(t *testing.T) {
+	volumes := map[string]*types.Volume{}
+	pair := &types.RDFDevicePair{}
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch {
+		case strings.HasPrefix(req.URL.Path, urlPrefix+ReplicationX) && strings.Contains(req.URL.Path, XStorageGroup):
+			payload := &types.ModifySGRDFGroup{}
+			if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+				t.Error(err)
+			}
+			if payload.Suspend != nil && !payload.Suspend.ConsExempt {
+				t.Error("expected the storage group to be suspended with consistency exempt")
+			}
+			calls = append(calls, payload.Action)
+		case strings.HasPrefix(req.URL.Path, urlPrefix+ReplicationX):
+			body = pair
+		case req.Method == http.MethodPut:
+			path := strings.TrimPrefix(req.URL.Path, urlPrefix+SLOProvisioningX+SymmetrixX)
+			payload := &types.EditVolumeParam{}
+			if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+				t.Error(err)
+			}
+			volumes[strings.Replace(path, XVolume, "", 1)].CapacityGB = 20
+			calls = append(calls, "Expand "+path)
+		default:
+			path := strings.TrimPrefix(req.URL.Path, urlPrefix+SLOProvisioningX+SymmetrixX)
+			body = volumes[strings.Replace(path, XVolume, "", 1)]
+		}
+		if body == nil {
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+	reset := func(mode, state, config string) {
+		*pair = types.RDFDevicePair{RemoteSymmID: "remote-sym-id", RemoteVolumeName: "00101", VolumeConfig: config, RdfMode: mode, RdfpairState: state}
+		volumes["local-sym-id/00001"] = &types.Volume{VolumeID: "00001", CapacityGB: 10}
+		volumes["remote-sym-id/00101"] = &types.Volume{VolumeID: "00101", CapacityGB: 10}
+		calls = nil
+	}
+
+	reset("Asynchronous", "Consistent", "RDF1+TDEV")
+	if _, err = client.ResizeRDFPair(ctx, "local-sym-id", "sg", "1", "00001", 20, "GB"); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"Suspend", "Expand remote-sym-id/volume/00101", "Expand local-sym-id/volume/00001", "Resume"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected calls %v, got %v", expected, calls)
+	}
+
+	// an already suspended pair is left suspended
+	reset("Synchronous", "Suspended", "RDF1+TDEV")
+	if _, err = client.ResizeRDFPair(ctx, "local-sym-id", "sg", "1", "00001", 20, "GB"); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 2 {
+		t.Errorf("expected only the expansions, got %v", calls)
+	}
+
+	for _, tt := range []struct {
+		mode, state, config string
+		size                int
+		unit                string
+	}{
+		{"Active", "ActiveBias", "RDF1+TDEV", 20, "GB"},
+		{"Asynchronous", "Consistent", "RDF2+TDEV", 20, "GB"},
+		{"Asynchronous", "Partitioned", "RDF1+TDEV", 20, "GB"},
+		{"Asynchronous", "Consistent", "RDF1+TDEV", 5, "GB"},
+		{"Asynchronous", "Consistent", "RDF1+TDEV", 20, "PB"},
+	} {
+		reset(tt.mode, tt.state, tt.config)
+		if _, err = client.ResizeRDFPair(ctx, "local-sym-id", "sg", "1", "00001", tt.size, tt.unit); err == nil {
+			t.Errorf("expected an error for %+v", tt)
+		}
+		if len(calls) != 0 {
+			t.Errorf("expected no change for %+v, got %v", tt, calls)
+		}
+	}
+}
+
+func TestAddExistingVolumesToProtectedStorageGroup(t *testing.T) {
+	sgRDFInfo := &types.StorageGroupRDFG{}
+	volumes := map[string]*types.Volume{}
+	members := map[string][]string{}
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch {
+		case strings.HasPrefix(req.URL.Path, urlPrefix+ReplicationX) && strings.Contains(req.URL.Path, XStorageGroup) && req.Method == http.MethodPut:
+			payload := &types.ModifySGRDFGroup{}
+			if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+				t.Error(err)
+			}
+			calls = append(calls, payload.Action)
+		case strings.HasPrefix(req.URL.Path, urlPrefix+ReplicationX) && strings.Contains(req.URL.Path, XStorageGroup):
+			body = sgRDFInfo
+		case strings.HasPrefix(req.URL.Path, urlPrefix+ReplicationX):
+			payload := &types.CreateRDFPair{}
+			if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+				t.Error(err)
+			}
+			if payload.Establish || payload.Exempt != (payload.RdfMode == "Asynchronous") {
+				t.Errorf("unexpected pair creation payload %+v", payload)
+			}
+			volumeID := payload.LocalDeviceListCriteria.LocalDeviceList[0]
+			calls = append(calls, "Create "+volumeID)
+			body = &types.RDFDevicePairList{RDFDevicePair: []types.RDFDevicePair{
+				{LocalVolumeName: volumeID, RemoteSymmID: "remote-sym-id", RemoteVolumeName: "1" + volumeID[1:]},
+			}}
+		case req.Method == http.MethodPut:
+			path := strings.TrimPrefix(req.URL.Path, urlPrefix+SLOProvisioningX+SymmetrixX)
+			payload := &types.UpdateStorageGroupPayload{}
+			if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+				t.Error(err)
+			}
+			volumeIDs := payload.EditStorageGroupActionParam.ExpandStorageGroupParam.AddSpecificVolumeParam.VolumeIDs
+			members[path] = append(members[path], volumeIDs...)
+			calls = append(calls, "Add "+path)
+		case req.URL.Query().Get("storageGroupId") != "":
+			path := strings.TrimPrefix(req.URL.Path, urlPrefix+SLOProvisioningX+SymmetrixX)
+			sg := strings.Replace(path, XVolume, XStorageGroup+"/"+req.URL.Query().Get("storageGroupId"), 1)
+			list := types.VolumeResultList{From: 1, To: len(members[sg])}
+			for _, volumeID := range members[sg] {
+				list.VolumeList = append(list.VolumeList, types.VolumeIDList{VolumeIDs: volumeID})
+			}
+			body = &types.VolumeIterator{Count: len(members[sg]), MaxPageSize: 1000, ResultList: list}
+		default:
+			path := strings.TrimPrefix(req.URL.Path, urlPrefix+SLOProvisioningX+SymmetrixX)
+			body = volumes[strings.Replace(path, XVolume, "", 1)]
+		}
+		if body == nil {
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+	reset := func(mode, state string) {
+		*sgRDFInfo = types.StorageGroupRDFG{Modes: []string{mode}, States: []string{state}}
+		volumes["local-sym-id/00001"] = &types.Volume{VolumeID: "00001"}
+		volumes["local-sym-id/00002"] = &types.Volume{VolumeID: "00002"}
+		members = map[string][]string{"remote-sym-id/storagegroup/sg-r2": {"10001"}}
+		calls = nil
+	}
+
+	reset("Asynchronous", "Consistent")
+	pairs, err := client.AddExistingVolumesToProtectedStorageGroup(ctx, "local-sym-id", "sg", "1", "sg-r2", "00001", "00002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pairs) != 2 {
+		t.Errorf("expected 2 pairs, got %v", pairs)
+	}
+	expected := []string{"Create 00001", "Create 00002", "Add local-sym-id/storagegroup/sg", "Add remote-sym-id/storagegroup/sg-r2", "Resume"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected calls %v, got %v", expected, calls)
+	}
+	if !reflect.DeepEqual(members["remote-sym-id/storagegroup/sg-r2"], []string{"10001", "10002"}) {
+		t.Errorf("expected both R2 volumes in the remote storage group, got %v", members["remote-sym-id/storagegroup/sg-r2"])
+	}
+
+	// a suspended storage group is left suspended, and the remote storage group defaults to the local name
+	reset("Synchronous", "Suspended")
+	if _, err = client.AddExistingVolumesToProtectedStorageGroup(ctx, "local-sym-id", "sg", "1", "", "00001"); err != nil {
+		t.Fatal(err)
+	}
+	expected = []string{"Create 00001", "Add local-sym-id/storagegroup/sg", "Add remote-sym-id/storagegroup/sg"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected calls %v, got %v", expected, calls)
+	}
+
+	reset("Active", "ActiveBias")
+	if _, err = client.AddExistingVolumesToProtectedStorageGroup(ctx, "local-sym-id", "sg", "1", "", "00001"); err == nil {
+		t.Error("expected an error for an SRDF/Metro storage group")
+	}
+	reset("Synchronous", "Synchronized")
+	volumes["local-sym-id/00001"].RDFGroupIDList = []types.RDFGroupID{{RDFGroupNumber: 2}}
+	if _, err = client.AddExistingVolumesToProtectedStorageGroup(ctx, "local-sym-id", "sg", "1", "", "00001"); err == nil {
+		t.Error("expected an error for an already paired volume")
+	}
+	if len(calls) != 0 {
+		t.Errorf("expected no change, got %v", calls)
+	}
+}
+
+func TestSetR2ReadOnly(t *testing.T) {
+	var payloads []types.ModifyRDFDevicePair
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPut {
+			resp.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		payload := types.ModifyRDFDevicePair{}
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			t.Error(err)
+		}
+		payloads = append(payloads, payload)
+		paths = append(paths, strings.TrimPrefix(req.URL.Path, urlPrefix+ReplicationX+SymmetrixX))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+	if err = client.SetR2ReadOnly(ctx, "local-sym-id", "10", false, "00001", "00002"); err != nil {
+		t.Fatal(err)
+	}
+	expectedPaths := []string{"local-sym-id/rdf_group/10/volume/00001", "local-sym-id/rdf_group/10/volume/00002"}
+	if !reflect.DeepEqual(paths, expectedPaths) {
+		t.Errorf("expected paths %v, got %v", expectedPaths, paths)
+	}
+	if payloads[0].Action != types.RDFActionRWEnable || payloads[0].RWEnable == nil || payloads[0].RWEnable.Side != types.RDFSideR2 || payloads[0].WriteDisable != nil {
+		t.Errorf("unexpected read/write enable payload %+v", payloads[0])
+	}
+
+	payloads = nil
+	if err = client.SetR2ReadOnly(ctx, "local-sym-id", "10", true, "00001"); err != nil {
+		t.Fatal(err)
+	}
+	if payloads[0].Action != types.RDFActionWriteDisable || payloads[0].WriteDisable == nil || payloads[0].WriteDisable.Side != types.RDFSideR2 {
+		t.Errorf("unexpected write disable payload %+v", payloads[0])
+	}
+	if err = client.SetR2ReadOnly(ctx, "local-sym-id", "10", true); err == nil {
+		t.Error("expected an error without volumes")
+	}
+
+	for _, tt := range []struct {
+		pair     types.RDFDevicePair
+		readOnly bool
+	}{
+		{types.RDFDevicePair{VolumeConfig: "RDF1+TDEV", RemoteVolumeState: types.RDFVolumeStateWriteDisabled, LocalVolumeState: "Ready"}, true},
+		{types.RDFDevicePair{VolumeConfig: "RDF1+TDEV", RemoteVolumeState: "Ready", LocalVolumeState: types.RDFVolumeStateWriteDisabled}, false},
+		{types.RDFDevicePair{VolumeConfig: "RDF2+TDEV", LocalVolumeState: types.RDFVolumeStateWriteDisabled, RemoteVolumeState: "Ready"}, true},
+	} {
+		if tt.pair.R2WriteDisabled() != tt.readOnly {
+			t.Errorf("expected R2WriteDisabled %v for %+v", tt.readOnly, tt.pair)
+		}
+	}
+}
+
+func TestCopySnapshotToRemoteArray(t *testing.T) {
+	var calls []string
+	checks := 0
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		sgPrefix := urlPrefix + ReplicationX + SymmetrixX + "sym-id" + XStorageGroup + "/"
+		switch {
+		case req.Method == http.MethodPut && req.URL.Path == sgPrefix+"sg-1"+XSnapshot+"/snap-1"+SnapID+"/1":
+			payload := &types.LinkStorageGroupSnapshot{}
+			if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+				t.Error(err)
+			}
+			calls = append(calls, payload.Action+" "+payload.Link.StorageGroupName)
+			body = &types.StorageGroupSnap{Name: "snap-1", Linked: true}
+		case req.Method == http.MethodPost && req.URL.Path == sgPrefix+"staging-sg"+XRDFGroup:
+			payload := &types.CreateSGSRDF{}
+			if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+				t.Error(err)
+			}
+			calls = append(calls, "Protect "+payload.RemoteStorageGroupName+" "+payload.ReplicationMode)
+			body = &types.SGRDFInfo{StorageGroupName: "staging-sg", RdfGroupNumber: 10}
+		case req.Method == http.MethodGet && req.URL.Path == sgPrefix+"staging-sg"+XRDFGroup+"/10":
+			checks++
+			states := []string{"Synchronized", "SyncInProg"}
+			switch {
+			case len(calls) == 3:
+				states = []string{"Split", "Split"}
+			case checks > 1:
+				states = []string{"Synchronized", "Synchronized"}
+			}
+			body = &types.StorageGroupRDFG{StorageGroupName: "staging-sg", RdfGroupNumber: 10, States: states}
+		case req.Method == http.MethodPut && req.URL.Path == sgPrefix+"staging-sg"+XRDFGroup+"/10":
+			payload := &types.ModifySGRDFGroup{}
+			if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+				t.Error(err)
+			}
+			if payload.Split == nil {
+				t.Errorf("unexpected action %+v", payload)
+			}
+			calls = append(calls, payload.Action)
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL)
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			t.Error(err)
+		}
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	param := types.RemoteSnapshotCopyParam{
+		StorageGroupID:        "sg-1",
+		SnapshotID:            "snap-1",
+		SnapID:                "1",
+		StagingStorageGroupID: "staging-sg",
+		RemoteSymmetrixID:     "remote-sym-id",
+		RemoteStorageGroupID:  "remote-sg",
+		RemoteServiceLevel:    "Diamond",
+		RDFGroupNumber:        "10",
+		RDFMode:               SYNC,
+	}
+	rdfInfo, err := client.CopySnapshotToRemoteArray(context.TODO(), "sym-id", param, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedCalls := []string{"Link staging-sg", "Protect remote-sg Synchronous", "Split"}
+	if !reflect.DeepEqual(expectedCalls, calls) || checks != 3 || rdfInfo.States[0] != "Split" {
+		t.Fatalf("unexpected calls %v after %d checks, states %v", calls, checks, rdfInfo.States)
+	}
+
+	param.RDFMode = METRO
+	if _, err = client.CopySnapshotToRemoteArray(context.TODO(), "sym-id", param, time.Millisecond); err == nil {
+		t.Fatal("expected an error for the Metro mode")
+	}
+}
+
+func TestAddVolumesToMetroStorageGroup(t *testing.T) {
+	replURL := urlPrefix + ReplicationX + SymmetrixX + "local-sym-id"
+	var (
+		mode      string
+		rdfInfo   int
+		remoteSGs []string
+		members   map[string][]string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		path := strings.TrimPrefix(req.URL.Path, urlPrefix+SLOProvisioningX+SymmetrixX)
+		switch {
+		case req.URL.Path == replURL+XStorageGroup+"/sg"+XRDFGroup+"/1":
+			rdfInfo++
+			state := "SyncInProg"
+			if rdfInfo > 2 {
+				state = "ActiveActive"
+			}
+			body = &types.StorageGroupRDFG{Modes: []string{mode}, States: []string{state, state}}
+		case req.URL.Path == replURL+XRDFGroup+"/1":
+			body = &types.RDFGroup{RdfgNumber: 1, RemoteSymmetrix: "remote-sym-id", Metro: true}
+		case strings.HasPrefix(req.URL.Path, replURL+XRDFGroup+"/1"+XVolume+"/"):
+			volumeID := strings.TrimPrefix(req.URL.Path, replURL+XRDFGroup+"/1"+XVolume+"/")
+			body = &types.RDFDevicePair{LocalVolumeName: volumeID, RemoteVolumeName: "1" + volumeID[1:], RdfpairState: "ActiveActive"}
+		case req.Method == http.MethodPut && path == "local-sym-id/storagegroup/sg":
+			payload := &types.UpdateStorageGroupPayload{}
+			if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+				t.Error(err)
+			}
+			add := payload.EditStorageGroupActionParam.ExpandStorageGroupParam.AddSpecificVolumeParam
+			if add.RemoteSymmetrixSGInfo.RemoteSymmetrix1ID != "remote-sym-id" {
+				t.Errorf("unexpected remote storage group %+v", add.RemoteSymmetrixSGInfo)
+			}
+			for _, volumeID := range add.VolumeIDs {
+				members["local-sym-id/storagegroup/sg"] = append(members["local-sym-id/storagegroup/sg"], volumeID)
+				for _, sg := range add.RemoteSymmetrixSGInfo.RemoteSymmetrix1SGs {
+					members["remote-sym-id/storagegroup/"+sg] = append(members["remote-sym-id/storagegroup/"+sg], "1"+volumeID[1:])
+				}
+			}
+		case req.URL.Query().Get("storageGroupId") != "":
+			sg := strings.Replace(path, XVolume, XStorageGroup+"/"+req.URL.Query().Get("storageGroupId"), 1)
+			list := types.VolumeResultList{From: 1, To: len(members[sg])}
+			for _, volumeID := range members[sg] {
+				list.VolumeList = append(list.VolumeList, types.VolumeIDList{VolumeIDs: volumeID})
+			}
+			body = &types.VolumeIterator{Count: len(members[sg]), MaxPageSize: 1000, ResultList: list}
+		case strings.HasPrefix(path, "remote-sym-id/storagegroup/") && slices.Contains(remoteSGs, strings.TrimPrefix(path, "remote-sym-id/storagegroup/")):
+			body = &types.StorageGroup{StorageGroupID: strings.TrimPrefix(path, "remote-sym-id/storagegroup/")}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		if body == nil {
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+	reset := func(rdfMode string, sgs ...string) {
+		mode, rdfInfo, remoteSGs = rdfMode, 0, sgs
+		members = map[string][]string{"local-sym-id/storagegroup/sg": {"00003"}, "remote-sym-id/storagegroup/sg": {"10003"}}
+	}
+
+	reset("Active", "sg")
+	report, err := client.AddVolumesToMetroStorageGroup(ctx, "local-sym-id", "sg", "1", "", time.Millisecond, "00001", "00002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Consistent || report.RemoteSymmetrixID != "remote-sym-id" || report.RemoteStorageGroupID != "sg" || len(report.Volumes) != 2 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if vol := report.Volumes[1]; vol.RemoteVolumeID != "10002" || !vol.InStorageGroup || !vol.InRemoteStorageGroup || vol.PairState != "ActiveActive" {
+		t.Errorf("unexpected volume report %+v", vol)
+	}
+	if rdfInfo != 3 {
+		t.Errorf("expected the pair states to be polled until ActiveActive, got %d calls", rdfInfo)
+	}
+
+	// the pairs do not become active
+	reset("Active", "sg")
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	report, err = client.AddVolumesToMetroStorageGroup(timeout, "local-sym-id", "sg", "1", "", time.Hour, "00001")
+	if err == nil || report == nil || report.Consistent || len(report.Volumes) != 1 || report.States[0] != "SyncInProg" {
+		t.Errorf("expected a report of pairs not ActiveActive, got %+v, %v", report, err)
+	}
+
+	// the remote storage group has to exist
+	reset("Active", "sg")
+	if _, err = client.AddVolumesToMetroStorageGroup(ctx, "local-sym-id", "sg", "1", "sg-r2", time.Millisecond, "00001"); err == nil {
+		t.Error("expected an error for a missing remote storage group")
+	}
+	// and the storage group to be protected by SRDF/Metro
+	reset("Synchronous", "sg")
+	if _, err = client.AddVolumesToMetroStorageGroup(ctx, "local-sym-id", "sg", "1", "", time.Millisecond, "00001"); err == nil {
+		t.Error("expected an error for an SRDF/S storage group")
+	}
+	if len(members["local-sym-id/storagegroup/sg"]) != 1 {
+		t.Errorf("expected no volume to be added, got %v", members)
+	}
+}
+
+func TestGetRDFInfoForVolume(t *testing.T) {
+	volURL := urlPrefix + SLOProvisioningX + SymmetrixX + "mock-sym-id" + XVolume + "/"
+	rdfURL := urlPrefix + ReplicationX + SymmetrixX + "mock-sym-id" + XRDFGroup + "/"
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.URL.Path {
+		case volURL + "00001":
+			body = &types.Volume{VolumeID: "00001", RDFGroupIDList: []types.RDFGroupID{{RDFGroupNumber: 20, Label: "async"}, {RDFGroupNumber: 10, Label: "metro"}}}
+		case volURL + "00002":
+			body = &types.Volume{VolumeID: "00002"}
+		case rdfURL + "10" + XVolume + "/00001":
+			body = &types.RDFDevicePair{LocalRdfGroupNumber: 10, RemoteSymmID: "metro-sym-id", RemoteVolumeName: "00011", RdfMode: "Active", RdfpairState: "ActiveActive"}
+		case rdfURL + "20" + XVolume + "/00001":
+			body = &types.RDFDevicePair{LocalRdfGroupNumber: 20, RemoteSymmID: "async-sym-id", RemoteVolumeName: "00021", RdfMode: "Asynchronous", RdfpairState: "Consistent"}
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		content, _ := json.Marshal(body)
+		_, _ = resp.Write(content)
+	}))
+	defer server.Close()
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+
+	info, err := client.GetRDFInfoForVolume(ctx, "mock-sym-id", "00001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.VolumeID != "00001" || len(info.Pairs) != 2 {
+		t.Fatalf("unexpected RDF info %+v", info)
+	}
+	if pair := info.Pairs[0]; pair.LocalRdfGroupNumber != 10 || pair.RdfMode != "Active" || pair.RemoteSymmID != "metro-sym-id" || pair.RemoteVolumeName != "00011" {
+		t.Errorf("unexpected first pair %+v", pair)
+	}
+	if pair := info.Pairs[1]; pair.LocalRdfGroupNumber != 20 || pair.RdfpairState != "Consistent" || pair.RemoteSymmID != "async-sym-id" {
+		t.Errorf("unexpected second pair %+v", pair)
+	}
+
+	// a volume which is not replicated has no pair
+	info, err = client.GetRDFInfoForVolume(ctx, "mock-sym-id", "00002")
+	if err != nil || len(info.Pairs) != 0 {
+		t.Errorf("expected no RDF pair, got %+v, %v", info, err)
+	}
+	if _, err = client.GetRDFInfoForVolume(ctx, "mock-sym-id", "00003"); err == nil {
+		t.Error("expected an error for a missing volume")
+	}
+}
+
+func TestSRDFAAttributes(t *testing.T) {
+	rdfURL := urlPrefix + ReplicationX + SymmetrixX + "mock-sym-id" + XRDFGroup
+	group := &types.RDFGroup{RdfgNumber: 20, Async: true, MinimumCycleTime: 15, DSEThreshold: 50}
+	puts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.URL.Path == rdfURL+"/20" && req.Method == http.MethodGet:
+			content, _ := json.Marshal(group)
+			_, _ = resp.Write(content)
+		case req.URL.Path == rdfURL+"/20" && req.Method == http.MethodPut:
+			puts++
+			payload := &types.ModifyRDFGroup{}
+			if err := json.NewDecoder(req.Body).Decode(payload); err != nil || payload.Action != types.RDFGroupActionSetSRDFAAttributes {
+				t.Errorf("unexpected payload %+v, %v", payload, err)
+			}
+			if param := payload.SetSRDFAAttributes; param.DSEThreshold != nil {
+				t.Errorf("unexpected DSE threshold %d", *param.DSEThreshold)
+			} else {
+				group.MinimumCycleTime, group.TransmitIdle = *param.MinimumCycleTime, *param.TransmitIdle
+			}
+		case req.URL.Path == rdfURL+"/10":
+			content, _ := json.Marshal(&types.RDFGroup{RdfgNumber: 10, Metro: true})
+			_, _ = resp.Write(content)
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+		}
+	}))
+	defer server.Close()
+	client, err := NewClientWithArgs(server.URL, "", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+
+	attributes, err := client.GetSRDFAAttributes(ctx, "mock-sym-id", "20")
+	if err != nil || *attributes != (types.SRDFAAttributes{RDFGroupNumber: 20, MinimumCycleTime: 15, DSEThreshold: 50}) {
+		t.Errorf("unexpected attributes %+v, %v", attributes, err)
+	}
+	cycleTime, transmitIdle := 30, true
+	attributes, err = client.SetSRDFAAttributes(ctx, "mock-sym-id", "20", types.SetSRDFAAttributesParam{MinimumCycleTime: &cycleTime, TransmitIdle: &transmitIdle})
+	if err != nil || attributes.MinimumCycleTime != 30 || !attributes.TransmitIdle || attributes.DSEThreshold != 50 {
+		t.Errorf("unexpected attributes %+v, %v", attributes, err)
+	}
+
+	// the values out of range are not sent
+	cycleTime, threshold := 60, 10
+	if _, err = client.SetSRDFAAttributes(ctx, "mock-sym-id", "20", types.SetSRDFAAttributesParam{MinimumCycleTime: &cycleTime}); err == nil {
+		t.Error("expected an error for a cycle time out of range")
+	}
+	if _, err = client.SetSRDFAAttributes(ctx, "mock-sym-id", "20", types.SetSRDFAAttributesParam{DSEThreshold: &threshold}); err == nil {
+		t.Error("expected an error for a DSE threshold out of range")
+	}
+	if _, err = client.SetSRDFAAttributes(ctx, "mock-sym-id", "20", types.SetSRDFAAttributesParam{}); err == nil || puts != 1 {
+		t.Errorf("expected an error without attributes and a single update, got %d updates, %v", puts, err)
+	}
+	if _, err = client.GetSRDFAAttributes(ctx, "mock-sym-id", "10"); err == nil {
+		t.Error("expected an error for an SRDF/Metro group")
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -149,7 +150,8 @@ func (c *Client) GetSnapshotInfo(ctx context.Context, symID, volumeID, snapID st
 //  Star flag is used if the source device is participating in SRDF star mode
 //  Use the Force flag to automate some scenarios to succeed
 //  TimeToLive value ins hour is set on the snapshot to automatically delete the snapshot after target is unlinked
-func (c *Client) CreateSnapshot(ctx context.Context, symID string, snapID string, sourceVolumeList []types.VolumeList, ttl int64) error {
+// The options can give the TimeToLive in hours instead of days and secure the snapshot for a time
+func (c *Client) CreateSnapshot(ctx context.Context, symID string, snapID string, sourceVolumeList []types.VolumeList, ttl int64, opts ...types.CreateSnapshotOptions) error {
 	defer c.TimeSpent("CreateSnapshot", time.Now())
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return err
@@ -162,6 +164,12 @@ func (c *Client) CreateSnapshot(ctx context.Context, symID string, snapID string
 		TimeToLive:       ttl,
 		ExecutionOption:  types.ExecutionOptionSynchronous,
 	}
+	for _, opt := range opts {
+		snapParam.TimeInHours = snapParam.TimeInHours || opt.TimeInHours
+		if opt.SecureTTL > 0 {
+			snapParam.Securettl = opt.SecureTTL
+		}
+	}
 	ifDebugLogPayload(snapParam)
 	URL := c.privURLPrefix() + ReplicationX + SymmetrixX + symID + XSnapshot + "/" + snapID
 	ctx, cancel := c.GetTimeoutContext(ctx)
@@ -262,53 +270,26 @@ func (c *Client) DeleteSnapshotS(ctx context.Context, symID, snapID string, sour
 // NewSnapshotName specifies the new snapshot name to which the old snapshot will be renamed
 // ExecutionOption tells the Unisphere to perform the operation either in Synchronous mode or Asynchronous mode
 // Action defined the operation which will be performed on the given snapshot
+// The options of the Link and Relink actions pair the volumes exactly, and can make a Relink copy all the tracks
+// instead of only the tracks changed since the previous link
 func (c *Client) ModifySnapshot(ctx context.Context, symID string, sourceVol []types.VolumeList,
 	targetVol []types.VolumeList, snapID string, action string,
-	newSnapID string, generation int64, isCopy bool,
+	newSnapID string, generation int64, isCopy bool, opts ...types.LinkSnapshotOptions,
 ) error {
 	defer c.TimeSpent("ModifySnapshot", time.Now())
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return err
 	}
-
-	snapParam := &types.ModifyVolumeSnapshot{}
-
-	switch action {
-	case "Link":
-		snapParam = &types.ModifyVolumeSnapshot{
-			VolumeNameListSource: sourceVol,
-			VolumeNameListTarget: targetVol,
-			Force:                false,
-			Star:                 false,
-			Exact:                false,
-			Copy:                 isCopy,
-			Remote:               false,
-			Symforce:             false,
-			Action:               action,
-			Generation:           generation,
-			ExecutionOption:      types.ExecutionOptionAsynchronous,
-		}
-	case "Unlink":
-		snapParam = &types.ModifyVolumeSnapshot{
-			VolumeNameListSource: sourceVol,
-			VolumeNameListTarget: targetVol,
-			Force:                false,
-			Star:                 false,
-			Symforce:             false,
-			Action:               action,
-			Generation:           generation,
-			ExecutionOption:      types.ExecutionOptionAsynchronous,
+	if action == "Relink" && linkSnapshotOptions(opts).FullCopy {
+		if err := c.ModifySnapshot(ctx, symID, sourceVol, targetVol, snapID, "Unlink", "", generation, false); err != nil {
+			return err
 		}
-	case "Rename":
-		snapParam = &types.ModifyVolumeSnapshot{
-			VolumeNameListSource: sourceVol,
-			VolumeNameListTarget: targetVol,
-			NewSnapshotName:      newSnapID,
-			Action:               action,
-			ExecutionOption:      types.ExecutionOptionAsynchronous,
-		}
-	default:
-		return fmt.Errorf("not a supported action on Snapshots")
+		return c.ModifySnapshot(ctx, symID, sourceVol, targetVol, snapID, "Link", "", generation, true, opts...)
+	}
+
+	snapParam, err := modifySnapshotParam(sourceVol, targetVol, action, newSnapID, generation, isCopy, types.ExecutionOptionAsynchronous, opts)
+	if err != nil {
+		return err
 	}
 	URL := c.privURLPrefix() + ReplicationX + SymmetrixX + symID + XSnapshot + "/" + snapID
 	job := &types.Job{}
@@ -317,7 +298,7 @@ func (c *Client) ModifySnapshot(ctx context.Context, symID string, sourceVol []t
 	}
 	ctx, cancel := c.GetTimeoutContext(ctx)
 	defer cancel()
-	err := c.api.Put(
+	err = c.api.Put(
 		ctx, URL, c.getDefaultHeaders(), snapParam, job)
 	if err != nil {
 		log.WithFields(fields).Error("Error in ModifySnapshot: " + err.Error())
@@ -337,33 +318,71 @@ func (c *Client) ModifySnapshot(ctx context.Context, symID string, sourceVol []t
 // ModifySnapshotS executes actions on snapshots synchronously
 func (c *Client) ModifySnapshotS(ctx context.Context, symID string, sourceVol []types.VolumeList,
 	targetVol []types.VolumeList, snapID string, action string,
-	newSnapID string, generation int64, isCopy bool,
+	newSnapID string, generation int64, isCopy bool, opts ...types.LinkSnapshotOptions,
 ) error {
 	defer c.TimeSpent("ModifySnapshotS", time.Now())
 
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return err
 	}
+	if action == "Relink" && linkSnapshotOptions(opts).FullCopy {
+		if err := c.ModifySnapshotS(ctx, symID, sourceVol, targetVol, snapID, "Unlink", "", generation, false); err != nil {
+			return err
+		}
+		return c.ModifySnapshotS(ctx, symID, sourceVol, targetVol, snapID, "Link", "", generation, true, opts...)
+	}
 
-	snapParam := &types.ModifyVolumeSnapshot{}
+	snapParam, err := modifySnapshotParam(sourceVol, targetVol, action, newSnapID, generation, isCopy, types.ExecutionOptionSynchronous, opts)
+	if err != nil {
+		return err
+	}
+	URL := c.privURLPrefix() + ReplicationX + SymmetrixX + symID + XSnapshot + "/" + snapID
+	fields := map[string]interface{}{
+		http.MethodPut: URL,
+	}
+	ctx, cancel := c.GetTimeoutContext(ctx)
+	defer cancel()
+	err = c.api.Put(ctx, URL, c.getDefaultHeaders(), snapParam, nil)
+	if err != nil {
+		log.WithFields(fields).Error("Error in ModifySnapshotS: " + err.Error())
+		return err
+	}
+	log.Info(fmt.Sprintf("Action (%s) on Snapshot (%s) is successful", action, snapID))
+	return nil
+}
 
+// linkSnapshotOptions merges the options of a Link or Relink action
+func linkSnapshotOptions(opts []types.LinkSnapshotOptions) types.LinkSnapshotOptions {
+	merged := types.LinkSnapshotOptions{}
+	for _, opt := range opts {
+		merged.Exact = merged.Exact || opt.Exact
+		merged.FullCopy = merged.FullCopy || opt.FullCopy
+	}
+	return merged
+}
+
+// modifySnapshotParam returns the payload of a ModifySnapshot action. A Relink is differential, only the tracks
+// changed since the previous link are copied to the targets.
+func modifySnapshotParam(sourceVol []types.VolumeList, targetVol []types.VolumeList, action string,
+	newSnapID string, generation int64, isCopy bool, executionOption string, opts []types.LinkSnapshotOptions,
+) (*types.ModifyVolumeSnapshot, error) {
 	switch action {
-	case "Link":
-		snapParam = &types.ModifyVolumeSnapshot{
+	case "Link", "Relink":
+		return &types.ModifyVolumeSnapshot{
 			VolumeNameListSource: sourceVol,
 			VolumeNameListTarget: targetVol,
 			Force:                false,
 			Star:                 false,
-			Exact:                false,
+			Exact:                linkSnapshotOptions(opts).Exact,
 			Copy:                 isCopy,
 			Remote:               false,
 			Symforce:             false,
 			Action:               action,
 			Generation:           generation,
-			ExecutionOption:      types.ExecutionOptionSynchronous,
-		}
+			ExecutionOption:      executionOption,
+		}, nil
 	case "Unlink":
-		snapParam = &types.ModifyVolumeSnapshot{
+		return &types.ModifyVolumeSnapshot{
 			VolumeNameListSource: sourceVol,
 			VolumeNameListTarget: targetVol,
 			Force:                false,
@@ -371,32 +390,19 @@ func (c *Client) ModifySnapshotS(ctx context.Context, symID string, sourceVol []
 			Symforce:             false,
 			Action:               action,
 			Generation:           generation,
-			ExecutionOption:      types.ExecutionOptionSynchronous,
-		}
+			ExecutionOption:      executionOption,
+		}, nil
 	case "Rename":
-		snapParam = &types.ModifyVolumeSnapshot{
+		return &types.ModifyVolumeSnapshot{
 			VolumeNameListSource: sourceVol,
 			VolumeNameListTarget: targetVol,
 			NewSnapshotName:      newSnapID,
 			Action:               action,
-			ExecutionOption:      types.ExecutionOptionSynchronous,
-		}
+			ExecutionOption:      executionOption,
+		}, nil
 	default:
-		return fmt.Errorf("not a supported action on Snapshots")
-	}
-	URL := c.privURLPrefix() + ReplicationX + SymmetrixX + symID + XSnapshot + "/" + snapID
-	fields := map[string]interface{}{
-		http.MethodPut: URL,
+		return nil, fmt.Errorf("not a supported action on Snapshots")
 	}
-	ctx, cancel := c.GetTimeoutContext(ctx)
-	defer cancel()
-	err := c.api.Put(ctx, URL, c.getDefaultHeaders(), snapParam, nil)
-	if err != nil {
-		log.WithFields(fields).Error("Error in ModifySnapshotS: " + err.Error())
-		return err
-	}
-	log.Info(fmt.Sprintf("Action (%s) on Snapshot (%s) is successful", action, snapID))
-	return nil
 }
 
 // GetPrivVolumeByID returns a Volume structure given the symmetrix and volume ID
@@ -441,8 +447,9 @@ func (c *Client) GetPrivVolumeByID(ctx context.Context, symID string, volumeID s
 	return &privateVolumeIterator.ResultList.PrivVolumeList[0], nil
 }
 
-// GetSnapshotGenerations returns a list of all the snapshot generation on a specific snapshot
-func (c *Client) GetSnapshotGenerations(ctx context.Context, symID, volumeID, snapID string) (*types.VolumeSnapshotGenerations, error) {
+// GetSnapshotGenerations returns a list of all the snapshot generation on a specific snapshot.
+// If a filter is given, only the generations it selects are returned, ordered by their timestamp.
+func (c *Client) GetSnapshotGenerations(ctx context.Context, symID, volumeID, snapID string, filter ...types.SnapshotGenerationFilter) (*types.VolumeSnapshotGenerations, error) {
 	defer c.TimeSpent("GetSnapshotGenerations", time.Now())
 	if _, err := c.IsAllowedArray(symID); err != nil {
 		return nil, err
@@ -455,9 +462,41 @@ func (c *Client) GetSnapshotGenerations(ctx context.Context, symID, volumeID, sn
 	if err != nil {
 		return nil, err
 	}
+	if len(filter) > 0 {
+		filterSnapshotGenerations(volumeSnapshotGenerations, &filter[0])
+	}
 	return volumeSnapshotGenerations, nil
 }
 
+// filterSnapshotGenerations keeps the generations selected by the filter, ordered by their timestamp, the oldest
+// first unless NewestFirst is set. The generations are ordered by number, the higher the older, when their
+// timestamps are equal or invalid.
+func filterSnapshotGenerations(generations *types.VolumeSnapshotGenerations, filter *types.SnapshotGenerationFilter) {
+	sources := make([]types.VolumeSnapshotSource, 0, len(generations.VolumeSnapshotSource))
+	for i := range generations.VolumeSnapshotSource {
+		if filter.Matches(&generations.VolumeSnapshotSource[i]) {
+			sources = append(sources, generations.VolumeSnapshotSource[i])
+		}
+	}
+	sort.SliceStable(sources, func(i, j int) bool {
+		a, b := &sources[i], &sources[j]
+		if filter.NewestFirst {
+			a, b = b, a
+		}
+		createdA, okA := types.ParseSnapshotDate(a.TimeStamp)
+		createdB, okB := types.ParseSnapshotDate(b.TimeStamp)
+		if okA && okB && !createdA.Equal(createdB) {
+			return createdA.Before(createdB)
+		}
+		return a.Generation > b.Generation
+	})
+	generations.VolumeSnapshotSource = sources
+	generations.Generation = make([]int64, len(sources))
+	for i := range sources {
+		generations.Generation[i] = sources[i].Generation
+	}
+}
+
 // GetSnapshotGenerationInfo returns the specific generation info related to a snapshot
 func (c *Client) GetSnapshotGenerationInfo(ctx context.Context, symID, volumeID, snapID string, generation int64) (*types.VolumeSnapshotGeneration, error) {
 	defer c.TimeSpent("GetSnapshotGenerationInfo", time.Now())
@@ -475,6 +514,24 @@ func (c *Client) GetSnapshotGenerationInfo(ctx context.Context, symID, volumeID,
 	return volumeSnapshotGeneration, nil
 }
 
+// GetSnapshotLinkProgress returns the progress of the copy of a snapshot generation to each of its linked targets,
+// with the tracks remaining to copy while a link is defining or copying. Two observations of the same target give
+// the time remaining with SnapshotLinkProgress.ETA.
+func (c *Client) GetSnapshotLinkProgress(ctx context.Context, symID, volumeID, snapID string, generation int64) ([]types.SnapshotLinkProgress, error) {
+	defer c.TimeSpent("GetSnapshotLinkProgress", time.Now())
+	info, err := c.GetSnapshotGenerationInfo(ctx, symID, volumeID, snapID, generation)
+	if err != nil {
+		log.Error("GetSnapshotLinkProgress failed: " + err.Error())
+		return nil, err
+	}
+	observedAt := time.Now()
+	progress := make([]types.SnapshotLinkProgress, len(info.VolumeSnapshotLink))
+	for i := range info.VolumeSnapshotLink {
+		progress[i] = info.VolumeSnapshotLink[i].Progress(observedAt)
+	}
+	return progress, nil
+}
+
 // GetReplicationCapabilities returns details about SnapVX and SRDF
 // execution capabilities on the Symmetrix array
 func (c *Client) GetReplicationCapabilities(ctx context.Context) (*types.SymReplicationCapabilities, error) {